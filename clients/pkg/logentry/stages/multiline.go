@@ -78,6 +78,7 @@ type multilineState struct {
 	buffer         *bytes.Buffer // The lines of the current multiline block.
 	startLineEntry Entry         // The entry of the start line of a multiline block.
 	currentLines   uint64        // The number of lines of the current multiline block.
+	position       api.Position  // The position of the last line of the current multiline block.
 }
 
 // newMulitlineStage creates a MulitlineStage from config
@@ -189,6 +190,7 @@ func (m *multilineStage) runMultiline(in chan Entry, out chan Entry, wg *sync.Wa
 			}
 			state.buffer.WriteString(e.Line)
 			state.currentLines++
+			state.position = e.Position
 
 			if state.currentLines == *m.cfg.MaxLines {
 				m.flush(out, state)
@@ -217,6 +219,7 @@ func (m *multilineStage) flush(out chan Entry, s *multilineState) {
 				Timestamp: s.startLineEntry.Entry.Entry.Timestamp,
 				Line:      s.buffer.String(),
 			},
+			Position: s.position,
 		},
 	}
 	s.buffer.Reset()
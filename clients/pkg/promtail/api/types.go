@@ -13,6 +13,17 @@ import (
 type Entry struct {
 	Labels model.LabelSet
 	logproto.Entry
+
+	// Position is where the entry ends in the file it has been read from. It is
+	// only set by file tailers when the positions of files are confirmed by the
+	// clients once the entries are durable, see positions.Positions.FromEntries.
+	Position Position
+}
+
+// Position is an offset in a file.
+type Position struct {
+	Path   string
+	Offset int64
 }
 
 type InstrumentedEntryHandler interface {
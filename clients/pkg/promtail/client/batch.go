@@ -23,6 +23,9 @@ type batch struct {
 	streams   map[string]*logproto.Stream
 	bytes     int
 	createdAt time.Time
+
+	// records are the WAL records the entries of the batch have been read from.
+	records []walRecord
 }
 
 func newBatch(entries ...api.Entry) *batch {
@@ -58,6 +61,15 @@ func (b *batch) add(entry api.Entry) {
 	}
 }
 
+// trackRecord records that the last entry added has been read from the given
+// WAL record.
+func (b *batch) trackRecord(record *walRecord) {
+	if record == nil {
+		return
+	}
+	b.records = append(b.records, *record)
+}
+
 func labelsMapToString(ls model.LabelSet, without ...model.LabelName) string {
 	lstrs := make([]string, 0, len(ls))
 Outer:
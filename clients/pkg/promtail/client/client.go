@@ -52,6 +52,10 @@ type Metrics struct {
 	batchRetries     *prometheus.CounterVec
 	countersWithHost []*prometheus.CounterVec
 	streamLag        *prometheus.GaugeVec
+
+	walSizeBytes       *prometheus.GaugeVec
+	walSegments        *prometheus.GaugeVec
+	walDroppedSegments *prometheus.CounterVec
 }
 
 func NewMetrics(reg prometheus.Registerer, streamLagLabels []string) *Metrics {
@@ -93,6 +97,22 @@ func NewMetrics(reg prometheus.Registerer, streamLagLabels []string) *Metrics {
 		Help:      "Number of times batches has had to be retried.",
 	}, []string{HostLabel})
 
+	m.walSizeBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "promtail",
+		Name:      "wal_size_bytes",
+		Help:      "Size in bytes of the entries buffered in the client WAL.",
+	}, []string{HostLabel})
+	m.walSegments = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "promtail",
+		Name:      "wal_segments",
+		Help:      "Number of segments in the client WAL.",
+	}, []string{HostLabel})
+	m.walDroppedSegments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "wal_dropped_segments_total",
+		Help:      "Number of client WAL segments dropped before being sent because the WAL reached its size or age limit.",
+	}, []string{HostLabel, "reason"})
+
 	m.countersWithHost = []*prometheus.CounterVec{
 		m.encodedBytes, m.sentBytes, m.droppedBytes, m.sentEntries, m.droppedEntries,
 	}
@@ -114,6 +134,9 @@ func NewMetrics(reg prometheus.Registerer, streamLagLabels []string) *Metrics {
 		m.requestDuration = mustRegisterOrGet(reg, m.requestDuration).(*prometheus.HistogramVec)
 		m.batchRetries = mustRegisterOrGet(reg, m.batchRetries).(*prometheus.CounterVec)
 		m.streamLag = mustRegisterOrGet(reg, m.streamLag).(*prometheus.GaugeVec)
+		m.walSizeBytes = mustRegisterOrGet(reg, m.walSizeBytes).(*prometheus.GaugeVec)
+		m.walSegments = mustRegisterOrGet(reg, m.walSegments).(*prometheus.GaugeVec)
+		m.walDroppedSegments = mustRegisterOrGet(reg, m.walDroppedSegments).(*prometheus.CounterVec)
 	}
 
 	return &m
@@ -137,6 +160,20 @@ type Client interface {
 	Name() string
 }
 
+// Syncer is implemented by clients which can buffer entries on disk.
+type Syncer interface {
+	// Sync makes the entries received so far durable.
+	Sync() error
+}
+
+// PositionsSyncer is implemented by clients which can tell up to which
+// positions the entries read from files are durable.
+type PositionsSyncer interface {
+	// SyncPositions makes the entries received so far durable and returns the
+	// positions of the files they were read up to, see api.Entry.Position.
+	SyncPositions() (map[string]int64, error)
+}
+
 // Client for pushing logs in snappy-compressed protos over HTTP.
 type client struct {
 	name            string
@@ -152,6 +189,12 @@ type client struct {
 
 	externalLabels model.LabelSet
 
+	// wal buffers the received entries on disk when enabled, the batches are
+	// then built from the entries read back from it.
+	wal     *wal
+	syncs   chan chan error
+	walDone chan struct{}
+
 	// ctx is used in any upstream calls from the `client`.
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -210,6 +253,18 @@ func newClient(metrics *Metrics, cfg Config, streamLagLabels []string, logger lo
 		counter.WithLabelValues(c.cfg.URL.Host).Add(0)
 	}
 
+	if cfg.WAL.Enabled {
+		c.wal, err = newWAL(cfg.WAL, metrics, cfg.URL.Host, c.logger)
+		if err != nil {
+			return nil, err
+		}
+		c.syncs = make(chan chan error)
+		c.walDone = make(chan struct{})
+
+		c.wg.Add(1)
+		go c.runWALWriter()
+	}
+
 	c.wg.Add(1)
 	go c.run()
 	return c, nil
@@ -246,6 +301,18 @@ func (c *client) run() {
 
 	maxWaitCheck := time.NewTicker(maxWaitCheckFrequency)
 
+	// Entries are either received directly or read back from the WAL.
+	var (
+		entries    <-chan api.Entry
+		walEntries chan walEntry
+	)
+	if c.wal != nil {
+		walEntries = make(chan walEntry)
+		go c.readWAL(walEntries)
+	} else {
+		entries = c.entries
+	}
+
 	defer func() {
 		maxWaitCheck.Stop()
 		// Send all pending batches
@@ -258,30 +325,17 @@ func (c *client) run() {
 
 	for {
 		select {
-		case e, ok := <-c.entries:
+		case e, ok := <-entries:
 			if !ok {
 				return
 			}
-			e, tenantID := c.processEntry(e)
-			batch, ok := batches[tenantID]
+			c.addToBatch(batches, e, nil)
 
-			// If the batch doesn't exist yet, we create a new one with the entry
+		case e, ok := <-walEntries:
 			if !ok {
-				batches[tenantID] = newBatch(e)
-				break
-			}
-
-			// If adding the entry to the batch will increase the size over the max
-			// size allowed, we do send the current batch and then create a new one
-			if batch.sizeBytesAfter(e) > c.cfg.BatchSize {
-				c.sendBatch(tenantID, batch)
-
-				batches[tenantID] = newBatch(e)
-				break
+				return
 			}
-
-			// The max size of the batch isn't reached, so we can add the entry
-			batch.add(e)
+			c.addToBatch(batches, e.Entry, &e.record)
 
 		case <-maxWaitCheck.C:
 			// Send all batches whose max wait time has been reached
@@ -297,6 +351,115 @@ func (c *client) run() {
 	}
 }
 
+// addToBatch adds the entry to the batch of its tenant, sending the batch
+// first if it would go over the max size. record is the WAL record the entry
+// has been read from, if any.
+func (c *client) addToBatch(batches map[string]*batch, e api.Entry, record *walRecord) {
+	e, tenantID := c.processEntry(e)
+	batch, ok := batches[tenantID]
+
+	switch {
+	// If the batch doesn't exist yet, we create a new one with the entry
+	case !ok:
+		batch = newBatch(e)
+		batches[tenantID] = batch
+
+	// If adding the entry to the batch will increase the size over the max
+	// size allowed, we do send the current batch and then create a new one
+	case batch.sizeBytesAfter(e) > c.cfg.BatchSize:
+		c.sendBatch(tenantID, batch)
+
+		batch = newBatch(e)
+		batches[tenantID] = batch
+
+	// The max size of the batch isn't reached, so we can add the entry
+	default:
+		batch.add(e)
+	}
+	batch.trackRecord(record)
+}
+
+// runWALWriter appends the received entries to the WAL until the client is
+// stopped.
+func (c *client) runWALWriter() {
+	enforceLimits := time.NewTicker(time.Minute)
+
+	defer func() {
+		enforceLimits.Stop()
+		if err := c.wal.close(); err != nil {
+			level.Error(c.logger).Log("msg", "error closing wal", "error", err)
+		}
+		close(c.walDone)
+		// The entries not sent yet are kept in the WAL and sent on the next
+		// start, so there is no need to keep retrying them.
+		c.cancel()
+		c.wg.Done()
+	}()
+
+	for {
+		select {
+		case e, ok := <-c.entries:
+			if !ok {
+				return
+			}
+			if err := c.wal.append(e); err != nil {
+				level.Error(c.logger).Log("msg", "error writing entry to the wal, dropping it", "error", err)
+				c.metrics.droppedBytes.WithLabelValues(c.cfg.URL.Host).Add(float64(len(e.Line)))
+				c.metrics.droppedEntries.WithLabelValues(c.cfg.URL.Host).Inc()
+			}
+		case errc := <-c.syncs:
+			errc <- c.wal.sync()
+		case now := <-enforceLimits.C:
+			c.wal.enforceLimits(now)
+		}
+	}
+}
+
+// readWAL reads the entries back from the WAL until the client is stopped.
+func (c *client) readWAL(out chan<- walEntry) {
+	defer close(out)
+
+	r := c.wal.newReader()
+	defer r.close()
+
+	for {
+		e, err := r.next(c.ctx)
+		if err != nil {
+			if c.ctx.Err() != nil {
+				return
+			}
+			level.Error(c.logger).Log("msg", "error reading wal", "error", err)
+			select {
+			case <-time.After(time.Second):
+				continue
+			case <-c.ctx.Done():
+				return
+			}
+		}
+		select {
+		case out <- e:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// Sync implements Syncer. It makes the entries received so far durable when
+// the WAL is enabled.
+func (c *client) Sync() error {
+	if c.wal == nil {
+		return nil
+	}
+	errc := make(chan error, 1)
+	select {
+	case c.syncs <- errc:
+		return <-errc
+	case <-c.walDone:
+		// The WAL has been synced when it was closed.
+		return nil
+	}
+}
+
 func (c *client) Chan() chan<- api.Entry {
 	return c.entries
 }
@@ -313,6 +476,7 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 	buf, entriesCount, err := batch.encode()
 	if err != nil {
 		level.Error(c.logger).Log("msg", "error encoding batch", "error", err)
+		c.ackBatch(batch)
 		return
 	}
 	bufBytes := float64(len(buf))
@@ -330,6 +494,7 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 		if err == nil {
 			c.metrics.sentBytes.WithLabelValues(c.cfg.URL.Host).Add(bufBytes)
 			c.metrics.sentEntries.WithLabelValues(c.cfg.URL.Host).Add(float64(entriesCount))
+			c.ackBatch(batch)
 			for _, s := range batch.streams {
 				lbls, err := parser.ParseMetric(s.Labels)
 				if err != nil {
@@ -376,10 +541,28 @@ func (c *client) sendBatch(tenantID string, batch *batch) {
 		}
 	}
 
+	// With the WAL enabled, batches which could be sent later are requeued in
+	// it rather than retried forever, which would hold back the batches of
+	// every other tenant. Their entries are read again after the max backoff.
+	if err != nil && c.wal != nil && (status <= 0 || status == 429 || status/100 == 5) {
+		level.Warn(c.logger).Log("msg", "error sending batch, it will be sent again from the wal", "status", status, "error", err)
+		c.wal.requeue(batch.records, time.Now().Add(c.cfg.BackoffConfig.MaxBackoff))
+		return
+	}
+
 	if err != nil {
 		level.Error(c.logger).Log("msg", "final error sending batch", "status", status, "error", err)
 		c.metrics.droppedBytes.WithLabelValues(c.cfg.URL.Host).Add(bufBytes)
 		c.metrics.droppedEntries.WithLabelValues(c.cfg.URL.Host).Add(float64(entriesCount))
+		c.ackBatch(batch)
+	}
+}
+
+// ackBatch removes the entries of the batch from the WAL, once they have been
+// sent or can't ever be sent.
+func (c *client) ackBatch(batch *batch) {
+	if c.wal != nil {
+		c.wal.ack(batch.records)
 	}
 }
 
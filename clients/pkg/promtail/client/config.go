@@ -19,6 +19,9 @@ const (
 	MaxBackoff     = 5 * time.Minute
 	MaxRetries int = 10
 	Timeout        = 10 * time.Second
+
+	WALMaxSize = 1 << 30 // 1GB
+	WALMaxAge  = 24 * time.Hour
 )
 
 // Config describes configuration for an HTTP pusher client.
@@ -41,6 +44,23 @@ type Config struct {
 
 	// deprecated use StreamLagLabels from config.Config instead
 	StreamLagLabels flagext.StringSliceCSV `yaml:"stream_lag_labels"`
+
+	// WAL buffers entries on disk until they are sent to Loki.
+	WAL WALConfig `yaml:"wal"`
+}
+
+// WALConfig describes the on-disk write-ahead log of a client.
+type WALConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Dir is the directory the WAL segments are stored in. Each client needs
+	// its own directory.
+	Dir string `yaml:"dir"`
+	// MaxSize is the maximum size of the WAL on disk. When it is reached the
+	// oldest segments are dropped, even if they haven't been sent yet.
+	MaxSize lokiflag.ByteSize `yaml:"max_size"`
+	// MaxAge is the maximum age of a WAL segment. Older segments are dropped,
+	// even if they haven't been sent yet.
+	MaxAge time.Duration `yaml:"max_age"`
 }
 
 // RegisterFlags with prefix registers flags where every name is prefixed by
@@ -82,6 +102,10 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			BatchSize: BatchSize,
 			BatchWait: BatchWait,
 			Timeout:   Timeout,
+			WAL: WALConfig{
+				MaxSize: WALMaxSize,
+				MaxAge:  WALMaxAge,
+			},
 		}
 	}
 
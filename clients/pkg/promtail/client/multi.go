@@ -15,8 +15,14 @@ import (
 type MultiClient struct {
 	clients []Client
 	entries chan api.Entry
+	syncs   chan chan syncResult
+	done    chan struct{}
 	wg      sync.WaitGroup
 
+	// positions holds the positions of the files the forwarded entries were
+	// read up to, until they are synced.
+	positions map[string]int64
+
 	once sync.Once
 }
 
@@ -52,17 +58,77 @@ func NewMulti(metrics *Metrics, streamLagLabels []string, logger log.Logger, cfg
 }
 
 func (m *MultiClient) start() {
+	m.syncs = make(chan chan syncResult)
+	m.positions = map[string]int64{}
+	m.done = make(chan struct{})
 	m.wg.Add(1)
 	go func() {
-		defer m.wg.Done()
-		for e := range m.entries {
-			for _, c := range m.clients {
-				c.Chan() <- e
+		defer func() {
+			close(m.done)
+			m.wg.Done()
+		}()
+		for {
+			select {
+			case e, ok := <-m.entries:
+				if !ok {
+					return
+				}
+				for _, c := range m.clients {
+					c.Chan() <- e
+				}
+				if e.Position.Path != "" {
+					m.positions[e.Position.Path] = e.Position.Offset
+				}
+			case resc := <-m.syncs:
+				resc <- m.syncPositions()
 			}
 		}
 	}()
 }
 
+type syncResult struct {
+	positions map[string]int64
+	err       error
+}
+
+// SyncPositions implements PositionsSyncer. Syncs are handled in between
+// forwarded entries, so that every entry received before is synced by all
+// clients when the positions are returned.
+func (m *MultiClient) SyncPositions() (map[string]int64, error) {
+	resc := make(chan syncResult, 1)
+	select {
+	case m.syncs <- resc:
+		res := <-resc
+		return res.positions, res.err
+	case <-m.done:
+		// No more entries are forwarded.
+		res := m.syncPositions()
+		return res.positions, res.err
+	}
+}
+
+// syncPositions syncs the clients and hands over the recorded positions. They
+// are kept for the next sync if a client fails to sync.
+func (m *MultiClient) syncPositions() syncResult {
+	if err := m.syncClients(); err != nil {
+		return syncResult{err: err}
+	}
+	positions := m.positions
+	m.positions = map[string]int64{}
+	return syncResult{positions: positions}
+}
+
+func (m *MultiClient) syncClients() error {
+	for _, c := range m.clients {
+		if s, ok := c.(Syncer); ok {
+			if err := s.Sync(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (m *MultiClient) Chan() chan<- api.Entry {
 	return m.entries
 }
@@ -163,3 +163,33 @@ func TestMultiClient_Handle_Race(t *testing.T) {
 
 	m.Stop()
 }
+
+func TestMultiClient_SyncPositions(t *testing.T) {
+	f := fake.New(func() {})
+	m := &MultiClient{
+		clients: []Client{f, f},
+		entries: make(chan api.Entry),
+	}
+	m.start()
+
+	m.Chan() <- api.Entry{Entry: logproto.Entry{Line: "foo"}, Position: api.Position{Path: "/a.log", Offset: 4}}
+	m.Chan() <- api.Entry{Entry: logproto.Entry{Line: "bar"}, Position: api.Position{Path: "/a.log", Offset: 8}}
+	m.Chan() <- api.Entry{Entry: logproto.Entry{Line: "baz"}, Position: api.Position{Path: "/b.log", Offset: 4}}
+	// Entries not read from a file have no position.
+	m.Chan() <- api.Entry{Entry: logproto.Entry{Line: "qux"}}
+
+	positions, err := m.SyncPositions()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"/a.log": 8, "/b.log": 4}, positions)
+
+	// Positions are only returned once.
+	m.Chan() <- api.Entry{Entry: logproto.Entry{Line: "foo"}, Position: api.Position{Path: "/b.log", Offset: 8}}
+	positions, err = m.SyncPositions()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"/b.log": 8}, positions)
+
+	m.Stop()
+	positions, err = m.SyncPositions()
+	require.NoError(t, err)
+	require.Empty(t, positions)
+}
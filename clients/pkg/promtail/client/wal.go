@@ -0,0 +1,676 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+const (
+	// walSegmentSize is the size after which the WAL starts a new segment.
+	// Segments are the unit of deletion, once all their entries have been
+	// sent, and of dropping, when the WAL limits are reached.
+	walSegmentSize = 8 << 20 // 8MB
+
+	walRecordHeaderSize = 8 // 4 bytes of length followed by 4 bytes of CRC32.
+	walSegmentNameLen   = 8
+
+	// walAcksSuffix is the suffix of the files recording the records of a
+	// segment which have been sent, so that they are skipped on replay.
+	walAcksSuffix = ".acks"
+	// walAckSize is the size of an ack: the start and end offsets of a record.
+	walAckSize = 16
+)
+
+var walCastagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// walSegment is a file of the WAL. Entries are appended to the last segment
+// only, and a segment is deleted once all its entries have been read and
+// sent to Loki.
+type walSegment struct {
+	id        int
+	size      int64
+	lastWrite time.Time
+
+	// ackedUpTo is the offset up to which every record has been sent, acked
+	// holds the end offsets of the records sent after it by start offset.
+	ackedUpTo int64
+	acked     map[int64]int64
+	// inflight holds the end offsets of the records read and not sent yet by
+	// start offset.
+	inflight map[int64]int64
+	// read is set once the reader went through the whole segment.
+	read bool
+	// closed is set once the segment doesn't receive writes anymore.
+	closed bool
+	// dropped is set once the segment has been dropped because of the WAL limits.
+	dropped bool
+}
+
+func newWALSegment(id int) *walSegment {
+	return &walSegment{
+		id:        id,
+		lastWrite: time.Now(),
+		acked:     map[int64]int64{},
+		inflight:  map[int64]int64{},
+	}
+}
+
+// markAcked records that the record between offset and end has been sent.
+func (s *walSegment) markAcked(offset, end int64) {
+	delete(s.inflight, offset)
+	if offset != s.ackedUpTo {
+		s.acked[offset] = end
+		return
+	}
+	s.ackedUpTo = end
+	for {
+		next, ok := s.acked[s.ackedUpTo]
+		if !ok {
+			return
+		}
+		delete(s.acked, s.ackedUpTo)
+		s.ackedUpTo = next
+	}
+}
+
+// skip returns the end offset of the record at offset when it must not be
+// read, because it has been sent already or is still being sent.
+func (s *walSegment) skip(offset int64) (int64, bool) {
+	if offset < s.ackedUpTo {
+		return s.ackedUpTo, true
+	}
+	if end, ok := s.acked[offset]; ok {
+		return end, true
+	}
+	end, ok := s.inflight[offset]
+	return end, ok
+}
+
+// walRecord locates a record of the WAL.
+type walRecord struct {
+	segment *walSegment
+	offset  int64
+	end     int64
+}
+
+// walEntry is an entry read from the WAL.
+type walEntry struct {
+	api.Entry
+	record walRecord
+}
+
+// wal is an on-disk write-ahead log of entries. Entries are appended by the
+// client as soon as they are received, and read back in order by the batch
+// sender. Entries which fail to be sent are requeued and read again, and
+// entries which have been sent are acked. When promtail restarts, the entries
+// that haven't been acked are replayed from the remaining segments.
+type wal struct {
+	cfg         WALConfig
+	logger      log.Logger
+	metrics     *Metrics
+	host        string
+	segmentSize int64
+
+	mtx      sync.Mutex
+	segments []*walSegment // ordered by id, the last one is the head when head is set.
+	head     *os.File
+	size     int64
+	// lastID is the id of the last segment created.
+	lastID int
+	// notify is closed and replaced on every append and requeue to wake up
+	// the reader.
+	notify chan struct{}
+	// rewindID is the id of the first segment with requeued records, the
+	// reader goes back to it once rewindAt is reached. It is 0 when no records
+	// have been requeued.
+	rewindID int
+	rewindAt time.Time
+}
+
+func newWAL(cfg WALConfig, metrics *Metrics, host string, logger log.Logger) (*wal, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("client wal needs a directory")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating wal directory: %w", err)
+	}
+
+	w := &wal{
+		cfg:         cfg,
+		logger:      log.With(logger, "component", "wal", "dir", cfg.Dir),
+		metrics:     metrics,
+		host:        host,
+		segmentSize: walSegmentSize,
+		notify:      make(chan struct{}),
+	}
+
+	files, err := os.ReadDir(cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading wal directory: %w", err)
+	}
+	ids := map[int]struct{}{}
+	for _, f := range files {
+		id, err := strconv.Atoi(f.Name())
+		if err != nil || len(f.Name()) != walSegmentNameLen || f.IsDir() {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			return nil, err
+		}
+		seg := newWALSegment(id)
+		seg.size, seg.lastWrite, seg.closed = info.Size(), info.ModTime(), true
+		if err := w.loadAcks(seg); err != nil {
+			return nil, err
+		}
+		w.segments = append(w.segments, seg)
+		w.size += info.Size()
+		ids[id] = struct{}{}
+	}
+	sort.Slice(w.segments, func(i, j int) bool { return w.segments[i].id < w.segments[j].id })
+	// Remove the acks of segments which have been deleted before them.
+	for _, f := range files {
+		name := strings.TrimSuffix(f.Name(), walAcksSuffix)
+		if name == f.Name() {
+			continue
+		}
+		if id, err := strconv.Atoi(name); err == nil {
+			if _, ok := ids[id]; !ok {
+				_ = os.Remove(filepath.Join(cfg.Dir, f.Name()))
+			}
+		}
+	}
+	if len(w.segments) > 0 {
+		w.lastID = w.segments[len(w.segments)-1].id
+		level.Info(w.logger).Log("msg", "replaying wal segments", "segments", len(w.segments), "size", w.size)
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.enforceLimitsLocked(time.Now())
+	return w, nil
+}
+
+func segmentName(id int) string {
+	return fmt.Sprintf("%0*d", walSegmentNameLen, id)
+}
+
+// loadAcks reads the records of the segment which have been sent before a
+// restart. A partial ack at the end of the file is ignored.
+func (w *wal) loadAcks(seg *walSegment) error {
+	b, err := os.ReadFile(filepath.Join(w.cfg.Dir, segmentName(seg.id)+walAcksSuffix))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading wal acks: %w", err)
+	}
+	for ; len(b) >= walAckSize; b = b[walAckSize:] {
+		seg.markAcked(int64(binary.BigEndian.Uint64(b[0:8])), int64(binary.BigEndian.Uint64(b[8:16])))
+	}
+	return nil
+}
+
+// append writes the entry at the end of the WAL.
+func (w *wal) append(e api.Entry) error {
+	stream := logproto.Stream{
+		Labels:  labelsMapToString(e.Labels),
+		Entries: []logproto.Entry{e.Entry},
+	}
+	payload, err := stream.Marshal()
+	if err != nil {
+		return err
+	}
+	rec := make([]byte, walRecordHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(rec[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(rec[4:8], crc32.Checksum(payload, walCastagnoliTable))
+	copy(rec[walRecordHeaderSize:], payload)
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.head != nil && w.segments[len(w.segments)-1].size+int64(len(rec)) > w.segmentSize {
+		if err := w.closeHeadLocked(); err != nil {
+			return err
+		}
+	}
+	if w.head == nil {
+		if err := w.openHeadLocked(); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.head.Write(rec)
+	seg := w.segments[len(w.segments)-1]
+	seg.size += int64(n)
+	seg.lastWrite = time.Now()
+	w.size += int64(n)
+	if err != nil {
+		return err
+	}
+
+	w.enforceLimitsLocked(seg.lastWrite)
+	w.notifyLocked()
+	return nil
+}
+
+func (w *wal) notifyLocked() {
+	close(w.notify)
+	w.notify = make(chan struct{})
+}
+
+func (w *wal) openHeadLocked() error {
+	id := w.lastID + 1
+	f, err := os.OpenFile(filepath.Join(w.cfg.Dir, segmentName(id)), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return fmt.Errorf("creating wal segment: %w", err)
+	}
+	w.head = f
+	w.lastID = id
+	w.segments = append(w.segments, newWALSegment(id))
+	return nil
+}
+
+func (w *wal) closeHeadLocked() error {
+	if w.head == nil {
+		return nil
+	}
+	seg := w.segments[len(w.segments)-1]
+	seg.closed = true
+	err := w.head.Sync()
+	if closeErr := w.head.Close(); err == nil {
+		err = closeErr
+	}
+	w.head = nil
+	w.deleteIfDoneLocked(seg)
+	return err
+}
+
+// sync makes the entries appended so far durable.
+func (w *wal) sync() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.head == nil {
+		return nil
+	}
+	return w.head.Sync()
+}
+
+// close syncs and closes the segment being written. The remaining segments
+// are kept on disk to be replayed on the next start.
+func (w *wal) close() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.closeHeadLocked()
+}
+
+// enforceLimits drops the oldest segments when the WAL is over its size limit
+// and the segments older than the max age. The segment being written is
+// never dropped.
+func (w *wal) enforceLimits(now time.Time) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.enforceLimitsLocked(now)
+}
+
+func (w *wal) enforceLimitsLocked(now time.Time) {
+	for len(w.segments) > 0 {
+		seg := w.segments[0]
+		if !seg.closed {
+			break
+		}
+		reason := ""
+		switch {
+		case w.cfg.MaxSize > 0 && w.size > int64(w.cfg.MaxSize):
+			reason = "size"
+		case w.cfg.MaxAge > 0 && now.Sub(seg.lastWrite) > w.cfg.MaxAge:
+			reason = "age"
+		}
+		if reason == "" {
+			break
+		}
+		level.Warn(w.logger).Log("msg", "dropping wal segment", "segment", segmentName(seg.id), "reason", reason)
+		seg.dropped = true
+		w.metrics.walDroppedSegments.WithLabelValues(w.host, reason).Inc()
+		w.removeLocked(seg)
+	}
+	w.updateMetricsLocked()
+}
+
+// ack marks the given records as sent, deleting the segments which have been
+// entirely sent. The acks of the remaining segments are written next to them
+// so that the records aren't sent again after a restart.
+func (w *wal) ack(records []walRecord) {
+	if len(records) == 0 {
+		return
+	}
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	acks := map[*walSegment][]byte{}
+	for _, rec := range records {
+		rec.segment.markAcked(rec.offset, rec.end)
+		if rec.segment.dropped {
+			continue
+		}
+		var ack [walAckSize]byte
+		binary.BigEndian.PutUint64(ack[0:8], uint64(rec.offset))
+		binary.BigEndian.PutUint64(ack[8:16], uint64(rec.end))
+		acks[rec.segment] = append(acks[rec.segment], ack[:]...)
+	}
+	for seg, b := range acks {
+		if w.deleteIfDoneLocked(seg) {
+			continue
+		}
+		if err := w.writeAcksLocked(seg, b); err != nil {
+			level.Warn(w.logger).Log("msg", "error writing wal acks, the entries may be sent again after a restart", "segment", segmentName(seg.id), "error", err)
+		}
+	}
+	w.updateMetricsLocked()
+}
+
+func (w *wal) writeAcksLocked(seg *walSegment, b []byte) error {
+	f, err := os.OpenFile(filepath.Join(w.cfg.Dir, segmentName(seg.id)+walAcksSuffix), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// requeue makes the reader read the given records again from the given time,
+// after they failed to be sent.
+func (w *wal) requeue(records []walRecord, at time.Time) {
+	if len(records) == 0 {
+		return
+	}
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	for _, rec := range records {
+		if rec.segment.dropped {
+			continue
+		}
+		delete(rec.segment.inflight, rec.offset)
+		if w.rewindID == 0 || rec.segment.id < w.rewindID {
+			w.rewindID = rec.segment.id
+		}
+	}
+	if at.After(w.rewindAt) {
+		w.rewindAt = at
+	}
+	w.notifyLocked()
+}
+
+// deleteIfDoneLocked deletes the segment once all its records have been sent,
+// returning whether it has been deleted.
+func (w *wal) deleteIfDoneLocked(seg *walSegment) bool {
+	if seg.dropped || !seg.closed || !seg.read || len(seg.inflight) > 0 {
+		return false
+	}
+	// The segment has requeued records to read again.
+	if w.rewindID != 0 && w.rewindID <= seg.id {
+		return false
+	}
+	w.removeLocked(seg)
+	return true
+}
+
+func (w *wal) removeLocked(seg *walSegment) {
+	for i, s := range w.segments {
+		if s != seg {
+			continue
+		}
+		w.segments = append(w.segments[:i], w.segments[i+1:]...)
+		w.size -= seg.size
+		for _, name := range []string{segmentName(seg.id), segmentName(seg.id) + walAcksSuffix} {
+			if err := os.Remove(filepath.Join(w.cfg.Dir, name)); err != nil && !os.IsNotExist(err) {
+				level.Error(w.logger).Log("msg", "error removing wal segment", "segment", segmentName(seg.id), "error", err)
+			}
+		}
+		return
+	}
+}
+
+func (w *wal) updateMetricsLocked() {
+	w.metrics.walSizeBytes.WithLabelValues(w.host).Set(float64(w.size))
+	w.metrics.walSegments.WithLabelValues(w.host).Set(float64(len(w.segments)))
+}
+
+// walReader reads the entries of the WAL in order, waiting for new entries
+// once it reaches the end of the segment being written. Records which have
+// been sent or are being sent are skipped, and the reader goes back to the
+// first segment with requeued records when they are due.
+type walReader struct {
+	wal *wal
+
+	segment *walSegment
+	file    *os.File
+	offset  int64
+	// lastID is the id of the last segment read.
+	lastID int
+}
+
+func (w *wal) newReader() *walReader {
+	return &walReader{wal: w}
+}
+
+// next returns the next entry of the WAL, blocking until one is available or
+// the context is done.
+func (r *walReader) next(ctx context.Context) (walEntry, error) {
+	for {
+		r.wal.mtx.Lock()
+		if r.wal.rewindID != 0 && !time.Now().Before(r.wal.rewindAt) {
+			r.rewindLocked()
+		}
+		if r.segment == nil {
+			if err := r.openNextLocked(); err != nil {
+				r.wal.mtx.Unlock()
+				return walEntry{}, err
+			}
+		}
+		if r.segment == nil {
+			wait, rewindAt := r.wal.notify, r.rewindAtLocked()
+			r.wal.mtx.Unlock()
+			if err := r.wait(ctx, wait, rewindAt); err != nil {
+				return walEntry{}, err
+			}
+			continue
+		}
+		if r.segment.dropped {
+			r.closeSegmentLocked()
+			r.wal.mtx.Unlock()
+			continue
+		}
+
+		if end, ok := r.segment.skip(r.offset); ok {
+			r.offset = end
+			r.wal.mtx.Unlock()
+			continue
+		}
+
+		offset := r.offset
+		e, err := r.readRecord()
+		switch {
+		case err == nil:
+			r.segment.inflight[offset] = r.offset
+			rec := walRecord{segment: r.segment, offset: offset, end: r.offset}
+			r.wal.mtx.Unlock()
+			return walEntry{Entry: e, record: rec}, nil
+
+		case errors.As(err, &walDecodeError{}):
+			level.Warn(r.wal.logger).Log("msg", "skipping invalid wal record", "segment", segmentName(r.segment.id), "error", err)
+			r.segment.markAcked(offset, r.offset)
+			r.wal.mtx.Unlock()
+
+		case !r.segment.closed && err == io.EOF:
+			// We've read everything written so far in the head segment.
+			wait, rewindAt := r.wal.notify, r.rewindAtLocked()
+			r.wal.mtx.Unlock()
+			if err := r.wait(ctx, wait, rewindAt); err != nil {
+				return walEntry{}, err
+			}
+
+		default:
+			if err != io.EOF {
+				// Segments can end with a partial record if promtail crashed while writing it.
+				level.Warn(r.wal.logger).Log("msg", "error reading wal segment, skipping the rest of it", "segment", segmentName(r.segment.id), "error", err)
+			}
+			r.segment.read = true
+			r.wal.deleteIfDoneLocked(r.segment)
+			r.closeSegmentLocked()
+			r.wal.updateMetricsLocked()
+			r.wal.mtx.Unlock()
+		}
+	}
+}
+
+// wait waits for the WAL to notify the reader, or for requeued records to be
+// due when rewindAt is set.
+func (r *walReader) wait(ctx context.Context, notify chan struct{}, rewindAt time.Time) error {
+	var rewind <-chan time.Time
+	if !rewindAt.IsZero() {
+		t := time.NewTimer(time.Until(rewindAt))
+		defer t.Stop()
+		rewind = t.C
+	}
+	select {
+	case <-notify:
+		return nil
+	case <-rewind:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *walReader) rewindAtLocked() time.Time {
+	if r.wal.rewindID == 0 {
+		return time.Time{}
+	}
+	return r.wal.rewindAt
+}
+
+// rewindLocked goes back to the first segment with requeued records. The
+// segments from there are read again, skipping the records which have been
+// sent or are being sent.
+func (r *walReader) rewindLocked() {
+	r.closeSegmentLocked()
+	r.lastID = r.wal.rewindID - 1
+	for _, seg := range r.wal.segments {
+		if seg.id >= r.wal.rewindID {
+			seg.read = false
+		}
+	}
+	r.wal.rewindID = 0
+}
+
+func (r *walReader) openNextLocked() error {
+	for _, seg := range r.wal.segments {
+		if seg.id <= r.lastID {
+			continue
+		}
+		f, err := os.Open(filepath.Join(r.wal.cfg.Dir, segmentName(seg.id)))
+		if err != nil {
+			return fmt.Errorf("opening wal segment: %w", err)
+		}
+		r.segment, r.file, r.offset, r.lastID = seg, f, 0, seg.id
+		return nil
+	}
+	return nil
+}
+
+func (r *walReader) closeSegmentLocked() {
+	if r.file != nil {
+		_ = r.file.Close()
+	}
+	r.segment, r.file = nil, nil
+}
+
+// walDecodeError is returned for records which are complete but can't be
+// decoded. Such records are skipped.
+type walDecodeError struct {
+	err error
+}
+
+func (e walDecodeError) Error() string {
+	return e.err.Error()
+}
+
+// readRecord reads the record at the current offset. io.EOF is returned when
+// there is no complete record left, in which case the offset is unchanged.
+func (r *walReader) readRecord() (api.Entry, error) {
+	var header [walRecordHeaderSize]byte
+	if _, err := r.file.ReadAt(header[:], r.offset); err != nil {
+		if err == io.EOF {
+			return api.Entry{}, io.EOF
+		}
+		return api.Entry{}, err
+	}
+	// A corrupted length could allocate up to 4GB: a record can't be larger than what's left of its segment,
+	// since records are written whole and the segment size only accounts for the complete writes.
+	length := int64(binary.BigEndian.Uint32(header[0:4]))
+	if remaining := r.segment.size - r.offset - walRecordHeaderSize; length > remaining {
+		return api.Entry{}, fmt.Errorf("wal record length %d exceeds the %d bytes left in the segment", length, remaining)
+	}
+	payload := make([]byte, length)
+	if _, err := r.file.ReadAt(payload, r.offset+walRecordHeaderSize); err != nil {
+		if err == io.EOF {
+			return api.Entry{}, io.EOF
+		}
+		return api.Entry{}, err
+	}
+	if crc32.Checksum(payload, walCastagnoliTable) != binary.BigEndian.Uint32(header[4:8]) {
+		return api.Entry{}, errors.New("record checksum mismatch")
+	}
+
+	r.offset += walRecordHeaderSize + int64(len(payload))
+
+	var stream logproto.Stream
+	if err := stream.Unmarshal(payload); err != nil {
+		return api.Entry{}, walDecodeError{err}
+	}
+	if len(stream.Entries) != 1 {
+		return api.Entry{}, walDecodeError{fmt.Errorf("unexpected number of entries in wal record: %d", len(stream.Entries))}
+	}
+	ls := model.LabelSet{}
+	if stream.Labels != "{}" {
+		lbls, err := parser.ParseMetric(stream.Labels)
+		if err != nil {
+			return api.Entry{}, walDecodeError{err}
+		}
+		for _, l := range lbls {
+			ls[model.LabelName(l.Name)] = model.LabelValue(l.Value)
+		}
+	}
+	return api.Entry{Labels: ls, Entry: stream.Entries[0]}, nil
+}
+
+func (r *walReader) close() {
+	r.wal.mtx.Lock()
+	defer r.wal.mtx.Unlock()
+	r.closeSegmentLocked()
+}
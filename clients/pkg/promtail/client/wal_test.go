@@ -0,0 +1,352 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/backoff"
+	"github.com/grafana/dskit/flagext"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+
+	"github.com/grafana/loki/pkg/logproto"
+	lokiflag "github.com/grafana/loki/pkg/util/flagext"
+)
+
+func newTestWAL(t *testing.T, cfg WALConfig) *wal {
+	t.Helper()
+	w, err := newWAL(cfg, NewMetrics(prometheus.NewRegistry(), nil), "localhost", log.NewNopLogger())
+	require.NoError(t, err)
+	return w
+}
+
+func readWALEntries(t *testing.T, r *walReader, n int) ([]api.Entry, []walRecord) {
+	t.Helper()
+	entries := make([]api.Entry, 0, n)
+	records := make([]walRecord, 0, n)
+	for i := 0; i < n; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		e, err := r.next(ctx)
+		cancel()
+		require.NoError(t, err)
+		entries = append(entries, e.Entry)
+		records = append(records, e.record)
+	}
+	return entries, records
+}
+
+func requireNoWALEntry(t *testing.T, r *walReader) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := r.next(ctx)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func walFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	return names
+}
+
+func TestWAL_ReplayAndAck(t *testing.T) {
+	dir := t.TempDir()
+
+	w := newTestWAL(t, WALConfig{Dir: dir})
+	for _, e := range logEntries {
+		require.NoError(t, w.append(e))
+	}
+	require.NoError(t, w.close())
+	require.Equal(t, []string{"00000001"}, walFiles(t, dir))
+
+	// Entries not sent are read back after a restart.
+	w = newTestWAL(t, WALConfig{Dir: dir})
+	r := w.newReader()
+	defer r.close()
+	entries, records := readWALEntries(t, r, len(logEntries))
+	require.Equal(t, logEntries, entries)
+
+	// The segment is only deleted once all its entries have been sent.
+	requireNoWALEntry(t, r)
+	require.Equal(t, []string{"00000001"}, walFiles(t, dir))
+
+	w.ack(records)
+	require.Empty(t, walFiles(t, dir))
+
+	// New entries go to a new segment.
+	require.NoError(t, w.append(logEntries[0]))
+	entries, _ = readWALEntries(t, r, 1)
+	require.Equal(t, logEntries[:1], entries)
+	require.Equal(t, []string{"00000002"}, walFiles(t, dir))
+}
+
+func TestWAL_Limits(t *testing.T) {
+	dir := t.TempDir()
+	reg := prometheus.NewRegistry()
+	w, err := newWAL(WALConfig{Dir: dir, MaxAge: time.Hour}, NewMetrics(reg, nil), "localhost", log.NewNopLogger())
+	require.NoError(t, err)
+	// Every record gets its own segment.
+	w.segmentSize = 1
+
+	require.NoError(t, w.append(logEntries[0]))
+	// Keep room for three records.
+	w.cfg.MaxSize = lokiflag.ByteSize(3 * w.size)
+	for i := 1; i < 6; i++ {
+		require.NoError(t, w.append(logEntries[0]))
+	}
+	// The oldest segments are dropped to stay under the max size.
+	require.Equal(t, []string{"00000004", "00000005", "00000006"}, walFiles(t, dir))
+
+	// The segment being written is never dropped.
+	w.enforceLimits(time.Now().Add(2 * time.Hour))
+	require.Equal(t, []string{"00000006"}, walFiles(t, dir))
+
+	// The reader skips dropped segments.
+	r := w.newReader()
+	defer r.close()
+	entries, records := readWALEntries(t, r, 1)
+	require.Equal(t, logEntries[:1], entries)
+	require.Equal(t, 6, records[0].segment.id)
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP promtail_wal_dropped_segments_total Number of client WAL segments dropped before being sent because the WAL reached its size or age limit.
+		# TYPE promtail_wal_dropped_segments_total counter
+		promtail_wal_dropped_segments_total{host="localhost",reason="age"} 2
+		promtail_wal_dropped_segments_total{host="localhost",reason="size"} 3
+	`), "promtail_wal_dropped_segments_total"))
+}
+
+func TestClient_WAL(t *testing.T) {
+	dir := t.TempDir()
+	entries := []api.Entry{logEntries[0], logEntries[1], logEntries[2]}
+
+	newClient := func(url string) Client {
+		serverURL := flagext.URLValue{}
+		require.NoError(t, serverURL.Set(url))
+		c, err := New(NewMetrics(prometheus.NewRegistry(), nil), Config{
+			URL:           serverURL,
+			BatchWait:     10 * time.Millisecond,
+			BatchSize:     1024,
+			BackoffConfig: backoff.Config{MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, MaxRetries: 1},
+			Timeout:       time.Second,
+			WAL:           WALConfig{Enabled: true, Dir: dir, MaxSize: WALMaxSize, MaxAge: WALMaxAge},
+		}, nil, log.NewNopLogger())
+		require.NoError(t, err)
+		return c
+	}
+
+	// Entries are kept in the WAL while Loki is unavailable.
+	unavailable := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	c := newClient(unavailable.URL)
+	for _, e := range entries {
+		c.Chan() <- e
+	}
+	require.NoError(t, c.(Syncer).Sync())
+	time.Sleep(50 * time.Millisecond)
+	c.Stop()
+	require.Equal(t, []string{"00000001"}, walFiles(t, dir))
+
+	// And replayed once promtail restarts.
+	receivedReqsChan := make(chan receivedReq, 10)
+	available := httptest.NewServer(createServerHandler(receivedReqsChan, http.StatusNoContent))
+	defer available.Close()
+
+	c = newClient(available.URL)
+	select {
+	case req := <-receivedReqsChan:
+		require.Equal(t, logproto.PushRequest{Streams: []logproto.Stream{{
+			Labels:  "{}",
+			Entries: []logproto.Entry{entries[0].Entry, entries[1].Entry, entries[2].Entry},
+		}}}, req.pushReq)
+	case <-time.After(5 * time.Second):
+		t.Fatal("entries not replayed")
+	}
+
+	require.Eventually(t, func() bool {
+		return len(walFiles(t, dir)) == 0
+	}, time.Second, 10*time.Millisecond)
+	c.Stop()
+}
+
+func TestWAL_AcksAreKeptOnRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	w := newTestWAL(t, WALConfig{Dir: dir})
+	for _, e := range logEntries[:3] {
+		require.NoError(t, w.append(e))
+	}
+	r := w.newReader()
+	_, records := readWALEntries(t, r, 3)
+	// Batches of different tenants can be sent out of order.
+	w.ack([]walRecord{records[0], records[2]})
+	r.close()
+	require.NoError(t, w.close())
+	require.Equal(t, []string{"00000001", "00000001.acks"}, walFiles(t, dir))
+
+	// Only the entry which hasn't been sent is replayed.
+	w = newTestWAL(t, WALConfig{Dir: dir})
+	r = w.newReader()
+	defer r.close()
+	entries, records := readWALEntries(t, r, 1)
+	require.Equal(t, logEntries[1:2], entries)
+	requireNoWALEntry(t, r)
+	w.ack(records)
+	require.Empty(t, walFiles(t, dir))
+}
+
+func TestWAL_Requeue(t *testing.T) {
+	dir := t.TempDir()
+
+	w := newTestWAL(t, WALConfig{Dir: dir})
+	// Every record gets its own segment.
+	w.segmentSize = 1
+	for _, e := range logEntries[:4] {
+		require.NoError(t, w.append(e))
+	}
+	r := w.newReader()
+	defer r.close()
+	_, records := readWALEntries(t, r, 4)
+
+	// Entries being sent are not read again.
+	w.requeue(records[1:2], time.Now().Add(50*time.Millisecond))
+	w.ack(records[:1])
+	require.Equal(t, []string{"00000002", "00000003", "00000004"}, walFiles(t, dir))
+
+	// Requeued entries are read again once they are due, skipping the entries
+	// read after them which are still being sent.
+	start := time.Now()
+	entries, requeued := readWALEntries(t, r, 1)
+	require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	require.Equal(t, logEntries[1:2], entries)
+	requireNoWALEntry(t, r)
+
+	w.ack(records[2:])
+	w.ack(requeued)
+	// Only the segment being written is left.
+	require.Equal(t, []string{"00000004", "00000004.acks"}, walFiles(t, dir))
+}
+
+func TestClient_WALRetriesWhileRunning(t *testing.T) {
+	dir := t.TempDir()
+	entries := []api.Entry{logEntries[0], logEntries[1], logEntries[2]}
+
+	// Loki is unavailable for the first requests and then recovers.
+	failures := atomic.NewInt32(3)
+	receivedReqsChan := make(chan receivedReq, 10)
+	handler := createServerHandler(receivedReqsChan, http.StatusNoContent)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if failures.Dec() >= 0 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		handler(rw, req)
+	}))
+	defer server.Close()
+
+	serverURL := flagext.URLValue{}
+	require.NoError(t, serverURL.Set(server.URL))
+	c, err := New(NewMetrics(prometheus.NewRegistry(), nil), Config{
+		URL:           serverURL,
+		BatchWait:     10 * time.Millisecond,
+		BatchSize:     1024,
+		BackoffConfig: backoff.Config{MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, MaxRetries: 1},
+		Timeout:       time.Second,
+		WAL:           WALConfig{Enabled: true, Dir: dir, MaxSize: WALMaxSize, MaxAge: WALMaxAge},
+	}, nil, log.NewNopLogger())
+	require.NoError(t, err)
+	defer c.Stop()
+
+	for _, e := range entries {
+		c.Chan() <- e
+	}
+
+	// The entries are sent again from the WAL without a restart, and only once.
+	var received []logproto.Entry
+	for len(received) < len(entries) {
+		select {
+		case req := <-receivedReqsChan:
+			for _, s := range req.pushReq.Streams {
+				received = append(received, s.Entries...)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("entries not sent again")
+		}
+	}
+	require.Equal(t, []logproto.Entry{entries[0].Entry, entries[1].Entry, entries[2].Entry}, received)
+
+	require.NoError(t, c.(Syncer).Sync())
+	require.Never(t, func() bool {
+		return len(receivedReqsChan) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestWAL_InvalidRecord(t *testing.T) {
+	dir := t.TempDir()
+
+	w := newTestWAL(t, WALConfig{Dir: dir})
+	require.NoError(t, w.append(api.Entry{Labels: model.LabelSet{"foo": "bar"}, Entry: logEntries[0].Entry}))
+	require.NoError(t, w.close())
+
+	// A partial record at the end of a segment is ignored.
+	f, err := os.OpenFile(dir+"/00000001", os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 42, 1})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	w = newTestWAL(t, WALConfig{Dir: dir})
+	r := w.newReader()
+	defer r.close()
+	entries, records := readWALEntries(t, r, 1)
+	require.Equal(t, model.LabelSet{"foo": "bar"}, entries[0].Labels)
+
+	requireNoWALEntry(t, r)
+	w.ack(records)
+	require.Empty(t, walFiles(t, dir))
+}
+
+func TestWAL_CorruptedRecordLength(t *testing.T) {
+	dir := t.TempDir()
+
+	w := newTestWAL(t, WALConfig{Dir: dir})
+	require.NoError(t, w.append(logEntries[0]))
+	require.NoError(t, w.close())
+
+	// A record length larger than the segment is not allocated, the rest of
+	// the segment is skipped instead.
+	f, err := os.OpenFile(dir+"/00000001", os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, 1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	w = newTestWAL(t, WALConfig{Dir: dir})
+	r := w.newReader()
+	defer r.close()
+	entries, records := readWALEntries(t, r, 1)
+	require.Equal(t, logEntries[:1], entries)
+
+	requireNoWALEntry(t, r)
+	w.ack(records)
+	require.Empty(t, walFiles(t, dir))
+}
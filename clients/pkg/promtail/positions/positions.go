@@ -28,6 +28,12 @@ type Config struct {
 	PositionsFile     string        `yaml:"filename"`
 	IgnoreInvalidYaml bool          `yaml:"ignore_invalid_yaml"`
 	ReadOnly          bool          `yaml:"-"`
+
+	// SyncEntries, when set, makes the entries received so far durable and
+	// returns the positions of the files they have been read up to. It is
+	// called before saving the positions file, and the positions of files are
+	// only advanced to the positions it returns, see Positions.FromEntries.
+	SyncEntries func() (map[string]int64, error) `yaml:"-"`
 }
 
 // RegisterFlags with prefix registers flags where every name is prefixed by
@@ -77,6 +83,10 @@ type Positions interface {
 	Remove(path string)
 	// SyncPeriod returns how often the positions file gets resynced
 	SyncPeriod() time.Duration
+	// FromEntries tells if the positions of files are taken from the entries
+	// made durable by the clients. File readers must then set the position of
+	// the entries they read instead of putting their positions.
+	FromEntries() bool
 	// Stop the Position tracker.
 	Stop()
 }
@@ -145,6 +155,10 @@ func (p *positions) SyncPeriod() time.Duration {
 	return p.cfg.SyncPeriod
 }
 
+func (p *positions) FromEntries() bool {
+	return p.cfg.SyncEntries != nil
+}
+
 func (p *positions) run() {
 	defer func() {
 		p.save()
@@ -168,13 +182,30 @@ func (p *positions) save() {
 	if p.cfg.ReadOnly {
 		return
 	}
+	if p.cfg.SyncEntries != nil {
+		synced, err := p.cfg.SyncEntries()
+		if err != nil {
+			level.Error(p.logger).Log("msg", "error syncing entries, not writing positions file", "error", err)
+			return
+		}
+		for path, pos := range synced {
+			p.Put(path, pos)
+		}
+	}
+	p.write(p.snapshot())
+}
+
+func (p *positions) snapshot() map[string]string {
 	p.mtx.Lock()
+	defer p.mtx.Unlock()
 	positions := make(map[string]string, len(p.positions))
 	for k, v := range p.positions {
 		positions[k] = v
 	}
-	p.mtx.Unlock()
+	return positions
+}
 
+func (p *positions) write(positions map[string]string) {
 	if err := writePositionFile(p.cfg.PositionsFile, positions); err != nil {
 		level.Error(p.logger).Log("msg", "error writing positions file", "error", err)
 	}
@@ -1,6 +1,7 @@
 package positions
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"strings"
@@ -181,3 +182,46 @@ func Test_ReadOnly(t *testing.T) {
 	}, out)
 
 }
+
+func TestSyncEntries(t *testing.T) {
+	temp := tempFilename(t)
+	defer func() {
+		_ = os.Remove(temp)
+	}()
+
+	var (
+		synced  map[string]int64
+		syncErr error
+	)
+	p, err := New(util_log.Logger, Config{
+		SyncPeriod:    time.Hour,
+		PositionsFile: temp,
+		SyncEntries: func() (map[string]int64, error) {
+			return synced, syncErr
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, p.FromEntries())
+	pos := p.(*positions)
+
+	read := func() map[string]string {
+		out, err := readPositionsFile(Config{PositionsFile: temp}, log.NewNopLogger())
+		require.NoError(t, err)
+		return out
+	}
+
+	// Positions only advance to the positions of the synced entries.
+	synced = map[string]int64{"/tmp/random.log": 10}
+	pos.save()
+	require.Equal(t, map[string]string{"/tmp/random.log": "10"}, read())
+
+	// The positions file is not written when the entries fail to sync.
+	synced, syncErr = map[string]int64{"/tmp/random.log": 20}, errors.New("sync failed")
+	pos.save()
+	require.Equal(t, map[string]string{"/tmp/random.log": "10"}, read())
+
+	// Files without new synced entries keep their position.
+	synced, syncErr = map[string]int64{"/tmp/other.log": 5}, nil
+	p.Stop()
+	require.Equal(t, map[string]string{"/tmp/random.log": "10", "/tmp/other.log": "5"}, read())
+}
@@ -79,6 +79,13 @@ func New(cfg config.Config, metrics *client.Metrics, dryRun bool, opts ...Option
 		if err != nil {
 			return nil, err
 		}
+		// Only advance the file positions once the entries are in the clients WAL.
+		for _, c := range cfg.ClientConfigs {
+			if s, ok := promtail.client.(client.PositionsSyncer); ok && c.WAL.Enabled {
+				cfg.PositionsConfig.SyncEntries = s.SyncPositions
+				break
+			}
+		}
 	}
 
 	tms, err := targets.NewTargetManagers(promtail, promtail.reg, promtail.logger, cfg.PositionsConfig, promtail.client, cfg.ScrapeConfig, &cfg.TargetConfig)
@@ -44,6 +44,12 @@ type Config struct {
 	GelfConfig       *GelfTargetConfig          `yaml:"gelf,omitempty"`
 	CloudflareConfig *CloudflareConfig          `yaml:"cloudflare,omitempty"`
 	RelabelConfigs   []*relabel.Config          `yaml:"relabel_configs,omitempty"`
+	// DecompressionConfig configures how compressed files matched by the
+	// service discovery are read.
+	DecompressionConfig *DecompressionConfig `yaml:"decompression,omitempty"`
+	// Encoding is the IANA name of the character set of the files, their
+	// lines are transcoded to UTF-8. Defaults to UTF-8.
+	Encoding string `yaml:"encoding,omitempty"`
 	// List of Docker service discovery configurations.
 	DockerSDConfigs        []*moby.DockerSDConfig `yaml:"docker_sd_configs,omitempty"`
 	ServiceDiscoveryConfig ServiceDiscoveryConfig `yaml:",inline"`
@@ -366,6 +372,21 @@ type PushTargetConfig struct {
 	KeepTimestamp bool `yaml:"use_incoming_timestamp"`
 }
 
+// DecompressionConfig describes how file targets read compressed files.
+type DecompressionConfig struct {
+	// Enabled makes file targets read compressed files to completion instead
+	// of tailing them as plain text.
+	Enabled bool `yaml:"enabled"`
+
+	// InitialDelay is the time to wait before reading a newly discovered
+	// compressed file, to give the compression time to complete.
+	InitialDelay time.Duration `yaml:"initial_delay"`
+
+	// Formats are the compression formats to read. Supported formats are
+	// gz, bz2 and zip. Defaults to all of them.
+	Formats []string `yaml:"formats"`
+}
+
 // DefaultScrapeConfig is the default Config.
 var DefaultScrapeConfig = Config{
 	PipelineStages: stages.PipelineStages{},
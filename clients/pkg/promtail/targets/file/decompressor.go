@@ -0,0 +1,397 @@
+package file
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"go.uber.org/atomic"
+	"golang.org/x/text/encoding"
+
+	"github.com/grafana/loki/clients/pkg/promtail/api"
+	"github.com/grafana/loki/clients/pkg/promtail/positions"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
+)
+
+// Supported compression formats.
+const (
+	formatGzip  = "gz"
+	formatBzip2 = "bz2"
+	formatZip   = "zip"
+)
+
+var supportedFormats = []string{formatGzip, formatBzip2, formatZip}
+
+var magicBytes = map[string][]byte{
+	formatGzip:  {0x1f, 0x8b},
+	formatBzip2: []byte("BZh"),
+	formatZip:   []byte("PK\x03\x04"),
+}
+
+// decompressionFormats returns the set of compression formats enabled by cfg,
+// or nil if compressed files must be tailed as plain text.
+func decompressionFormats(cfg *scrapeconfig.DecompressionConfig) (map[string]struct{}, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+	formats := cfg.Formats
+	if len(formats) == 0 {
+		formats = supportedFormats
+	}
+	res := make(map[string]struct{}, len(formats))
+	for _, f := range formats {
+		if _, ok := magicBytes[f]; !ok {
+			return nil, fmt.Errorf("unsupported compression format %q, supported formats are %s", f, strings.Join(supportedFormats, ", "))
+		}
+		res[f] = struct{}{}
+	}
+	return res, nil
+}
+
+// compressionFormat returns the compression format of the file, detected from
+// its extension or else from its first bytes. It returns an empty string for
+// files which are not compressed.
+func compressionFormat(path string) (string, error) {
+	if ext := strings.TrimPrefix(filepath.Ext(path), "."); ext != "" {
+		if _, ok := magicBytes[ext]; ok {
+			return ext, nil
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	header := make([]byte, 4)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	for _, format := range supportedFormats {
+		if bytes.HasPrefix(header[:n], magicBytes[format]) {
+			return format, nil
+		}
+	}
+	return "", nil
+}
+
+// decompressor reads a compressed file to completion.
+//
+// Compressed files are never appended to, so unlike the tailer it does not
+// follow the file. The position saved is the number of decompressed bytes
+// already sent, a decompressor restarted on the same file skips them so lines
+// are never read twice.
+type decompressor struct {
+	metrics   *Metrics
+	logger    log.Logger
+	handler   api.EntryHandler
+	positions positions.Positions
+
+	path         string
+	format       string
+	initialDelay time.Duration
+	// encoding of the decompressed lines, nil for UTF-8.
+	encoding encoding.Encoding
+	position *atomic.Int64
+	// fromEntries is true when the positions are saved from the entries once
+	// they are sent, the decompressor then only sets them on the entries.
+	fromEntries bool
+
+	posAndSizeMtx sync.Mutex
+	stopOnce      sync.Once
+
+	running *atomic.Bool
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+func newDecompressor(metrics *Metrics, logger log.Logger, handler api.EntryHandler, positions positions.Positions, path string, format string, initialDelay time.Duration, enc encoding.Encoding) (*decompressor, error) {
+	pos, err := positions.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	logger = log.With(logger, "component", "decompressor")
+	decompressor := &decompressor{
+		metrics:      metrics,
+		logger:       logger,
+		handler:      api.AddLabelsMiddleware(model.LabelSet{FilenameLabel: model.LabelValue(path)}).Wrap(handler),
+		positions:    positions,
+		path:         path,
+		format:       format,
+		initialDelay: initialDelay,
+		encoding:     enc,
+		position:     atomic.NewInt64(pos),
+		fromEntries:  positions.FromEntries(),
+		running:      atomic.NewBool(true),
+		quit:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	go decompressor.readLines()
+	metrics.filesActive.Add(1.)
+	return decompressor, nil
+}
+
+// readLines runs in a goroutine and sends every line of the decompressed file
+// to the handler. A decompressor which read its file to completion is still
+// running, so that the file target does not restart it: it only stops running
+// when it fails, to be retried on the next sync.
+func (d *decompressor) readLines() {
+	defer close(d.done)
+
+	if d.initialDelay > 0 {
+		level.Debug(d.logger).Log("msg", "waiting before reading compressed file", "path", d.path, "delay", d.initialDelay)
+		select {
+		case <-time.After(d.initialDelay):
+		case <-d.quit:
+			return
+		}
+	}
+
+	level.Info(d.logger).Log("msg", "decompressor: started", "path", d.path, "format", d.format)
+	if err := d.read(); err != nil {
+		level.Error(d.logger).Log("msg", "decompressor: error reading compressed file, stopping decompressor", "path", d.path, "error", err)
+		d.running.Store(false)
+		d.cleanupMetrics()
+		return
+	}
+
+	if err := d.markPositionAndSize(); err != nil {
+		level.Error(d.logger).Log("msg", "decompressor: error marking file position", "path", d.path, "error", err)
+	}
+}
+
+// read sends the lines of the decompressed file after the current position.
+// It returns early without error when the decompressor is stopped.
+func (d *decompressor) read() error {
+	r, closer, err := d.open()
+	if err != nil {
+		return err
+	}
+	defer func() { closer() }()
+
+	br := bufio.NewReader(r)
+	// Keep the start of the file to detect its byte order mark.
+	head, _ := br.Peek(2)
+	head = append([]byte(nil), head...)
+
+	pos := d.position.Load()
+	if pos > 0 {
+		if _, err := io.CopyN(io.Discard, br, pos); err != nil {
+			if err == io.EOF {
+				// The position is past the end of the file: it must have been
+				// replaced, read it again.
+				level.Warn(d.logger).Log("msg", "decompressor: position past the end of the file, reading it from the start", "path", d.path)
+				d.position.Store(0)
+				closer()
+				closer = func() {}
+				reopened, reopenedCloser, err := d.open()
+				if err != nil {
+					return err
+				}
+				closer = reopenedCloser
+				br = bufio.NewReader(reopened)
+			} else {
+				return err
+			}
+		}
+	}
+
+	var decoder *lineDecoder
+	if d.encoding != nil {
+		decoder = newLineDecoder(d.encoding, head, d.position.Load() == 0, func() (byte, bool) {
+			b, err := br.Peek(1)
+			if err != nil {
+				return 0, false
+			}
+			return b[0], true
+		})
+	}
+
+	entries := d.handler.Chan()
+	for {
+		line, err := br.ReadString('\n')
+		if decoder == nil {
+			if len(line) > 0 && !d.send(entries, strings.TrimRight(line, "\n"), len(line)) {
+				return nil
+			}
+		} else {
+			lines := decoder.write([]byte(line))
+			if err == io.EOF {
+				if last, ok := decoder.flush(); ok {
+					lines = append(lines, last)
+				}
+			}
+			for _, decoded := range lines {
+				if decoded.err != nil {
+					level.Debug(d.logger).Log("msg", "decompressor: error decoding line", "path", d.path, "error", decoded.err)
+					d.metrics.encodingFailures.WithLabelValues(d.path).Inc()
+					if decoded.err != errInvalidCharacters {
+						d.position.Add(int64(decoded.size))
+						continue
+					}
+				}
+				if !d.send(entries, decoded.text, decoded.size) {
+					return nil
+				}
+			}
+		}
+		if err == io.EOF {
+			level.Info(d.logger).Log("msg", "decompressor: finished reading compressed file", "path", d.path)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// send sends the line to the handler and advances the position by the size of
+// the line in the file. It returns false if the decompressor is stopped first.
+func (d *decompressor) send(entries chan<- api.Entry, line string, size int) bool {
+	e := newEntry(time.Now(), line)
+	if d.fromEntries {
+		e.Position = api.Position{Path: d.path, Offset: d.position.Load() + int64(size)}
+	}
+	select {
+	case entries <- e:
+	case <-d.quit:
+		return false
+	}
+	d.metrics.readLines.WithLabelValues(d.path).Inc()
+	d.position.Add(int64(size))
+	return true
+}
+
+// open returns a reader of the decompressed file content and a function to
+// close it.
+func (d *decompressor) open() (io.Reader, func(), error) {
+	if d.format == formatZip {
+		zr, err := zip.OpenReader(d.path)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Every file of the archive is read in order.
+		readers := make([]io.Reader, 0, len(zr.File))
+		closers := make([]io.Closer, 0, len(zr.File)+1)
+		closers = append(closers, zr)
+		closeAll := func() {
+			for _, c := range closers {
+				c.Close()
+			}
+		}
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				closeAll()
+				return nil, nil, err
+			}
+			readers = append(readers, rc)
+			closers = append(closers, rc)
+		}
+		return io.MultiReader(readers...), closeAll, nil
+	}
+
+	f, err := os.Open(d.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeFile := func() { f.Close() }
+	switch d.format {
+	case formatGzip:
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			closeFile()
+			return nil, nil, err
+		}
+		return gr, func() {
+			gr.Close()
+			closeFile()
+		}, nil
+	case formatBzip2:
+		return bzip2.NewReader(f), closeFile, nil
+	}
+	closeFile()
+	return nil, nil, fmt.Errorf("unsupported compression format %q", d.format)
+}
+
+func (d *decompressor) markPositionAndSize() error {
+	// Lock this update as it is called both by the sync in filetarget and when the file is read.
+	d.posAndSizeMtx.Lock()
+	defer d.posAndSizeMtx.Unlock()
+
+	fi, err := os.Stat(d.path)
+	if err != nil {
+		// If the file no longer exists, no need to save position information
+		if os.IsNotExist(err) {
+			level.Info(d.logger).Log("msg", "skipping update of position for a file which does not currently exist", "path", d.path)
+			return nil
+		}
+		return err
+	}
+	d.metrics.totalBytes.WithLabelValues(d.path).Set(float64(fi.Size()))
+
+	pos := d.position.Load()
+	d.metrics.readBytes.WithLabelValues(d.path).Set(float64(pos))
+	if !d.fromEntries {
+		d.positions.Put(d.path, pos)
+	}
+
+	return nil
+}
+
+func (d *decompressor) stop() {
+	// stop can be called by two separate threads in filetarget, to avoid a panic closing channels more than once
+	// we wrap the stop in a sync.Once.
+	d.stopOnce.Do(func() {
+		close(d.quit)
+		<-d.done
+
+		// Save the current position before shutting down.
+		if err := d.markPositionAndSize(); err != nil {
+			level.Error(d.logger).Log("msg", "error marking file position when stopping decompressor", "path", d.path, "error", err)
+		}
+
+		// The metrics are already removed if the decompressor failed.
+		if d.running.Swap(false) {
+			d.cleanupMetrics()
+		}
+		level.Info(d.logger).Log("msg", "stopped reading compressed file", "path", d.path)
+		d.handler.Stop()
+	})
+}
+
+func (d *decompressor) isRunning() bool {
+	return d.running.Load()
+}
+
+func (d *decompressor) getPath() string {
+	return d.path
+}
+
+// cleanupMetrics removes all metrics exported by this decompressor
+func (d *decompressor) cleanupMetrics() {
+	d.metrics.filesActive.Add(-1.)
+	d.metrics.readLines.DeleteLabelValues(d.path)
+	d.metrics.readBytes.DeleteLabelValues(d.path)
+	d.metrics.totalBytes.DeleteLabelValues(d.path)
+	d.metrics.encodingFailures.DeleteLabelValues(d.path)
+}
@@ -0,0 +1,261 @@
+package file
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/grafana/loki/clients/pkg/promtail/client/fake"
+	"github.com/grafana/loki/clients/pkg/promtail/positions"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
+)
+
+const testLines = "line 1\nline 2\nline 3"
+
+// bzip2 compressed testLines, the standard library has no bzip2 writer.
+var testBzip2 = []byte{
+	66, 90, 104, 57, 49, 65, 89, 38, 83, 89, 137, 105, 91, 104, 0, 0, 7, 89, 0, 0, 16, 64, 0, 56, 0, 2, 37, 32, 0, 34, 61, 64,
+	100, 32, 201, 136, 171, 211, 6, 49, 40, 103, 139, 185, 34, 156, 40, 72, 68, 180, 173, 180, 0,
+}
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zipped(t *testing.T, files ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for i, content := range files {
+		f, err := w.Create(filepath.Join("logs", string(rune('a'+i))+".log"))
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestCompressionFormat(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		name     string
+		content  []byte
+		expected string
+	}{
+		{name: "app.log.gz", content: []byte("not checked"), expected: formatGzip},
+		{name: "app.log.bz2", content: nil, expected: formatBzip2},
+		{name: "app.zip", content: nil, expected: formatZip},
+		{name: "app.log.1", content: gzipped(t, testLines), expected: formatGzip},
+		{name: "app.log.2", content: testBzip2, expected: formatBzip2},
+		{name: "app.log.3", content: zipped(t, testLines), expected: formatZip},
+		{name: "app.log", content: []byte(testLines), expected: ""},
+		{name: "empty.log", content: nil, expected: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, tc.name)
+			require.NoError(t, os.WriteFile(path, tc.content, 0o600))
+			format, err := compressionFormat(path)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, format)
+		})
+	}
+}
+
+func TestDecompressionFormats(t *testing.T) {
+	formats, err := decompressionFormats(nil)
+	require.NoError(t, err)
+	require.Nil(t, formats)
+
+	formats, err = decompressionFormats(&scrapeconfig.DecompressionConfig{Enabled: true})
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{formatGzip: {}, formatBzip2: {}, formatZip: {}}, formats)
+
+	formats, err = decompressionFormats(&scrapeconfig.DecompressionConfig{Enabled: true, Formats: []string{"gz"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{formatGzip: {}}, formats)
+
+	_, err = decompressionFormats(&scrapeconfig.DecompressionConfig{Enabled: true, Formats: []string{"xz"}})
+	require.Error(t, err)
+}
+
+func TestDecompressor(t *testing.T) {
+	utf16le := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
+	for _, tc := range []struct {
+		name     string
+		format   string
+		enc      encoding.Encoding
+		content  []byte
+		expected []string
+	}{
+		{name: "gzip", format: formatGzip, content: gzipped(t, testLines), expected: []string{"line 1", "line 2", "line 3"}},
+		{name: "bzip2", format: formatBzip2, content: testBzip2, expected: []string{"line 1", "line 2", "line 3"}},
+		{name: "zip", format: formatZip, content: zipped(t, "line 1\nline 2\n", "line 3\n"), expected: []string{"line 1", "line 2", "line 3"}},
+		{name: "gzip utf-16le", format: formatGzip, enc: utf16le, content: gzipped(t, string(encode(t, utf16le, "上 1\n上 2\n"))), expected: []string{"上 1", "上 2"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			logger := log.NewNopLogger()
+			path := filepath.Join(dir, "app.log."+tc.format)
+			require.NoError(t, os.WriteFile(path, tc.content, 0o600))
+
+			ps, err := positions.New(logger, positions.Config{
+				SyncPeriod:    10 * time.Minute,
+				PositionsFile: filepath.Join(dir, "positions.yml"),
+			})
+			require.NoError(t, err)
+			defer ps.Stop()
+
+			client := fake.New(func() {})
+			defer client.Stop()
+
+			d, err := newDecompressor(NewMetrics(nil), logger, client, ps, path, tc.format, 0, tc.enc)
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				return len(client.Received()) == len(tc.expected)
+			}, 5*time.Second, 10*time.Millisecond)
+			d.stop()
+
+			var lines []string
+			for _, e := range client.Received() {
+				lines = append(lines, e.Line)
+				require.Equal(t, path, string(e.Labels[FilenameLabel]))
+			}
+			require.Equal(t, tc.expected, lines)
+			require.True(t, d.position.Load() > 0)
+			pos, err := ps.Get(path)
+			require.NoError(t, err)
+			require.Equal(t, d.position.Load(), pos)
+
+			// The file is not read again once completed.
+			d, err = newDecompressor(NewMetrics(nil), logger, client, ps, path, tc.format, 0, tc.enc)
+			require.NoError(t, err)
+			time.Sleep(50 * time.Millisecond)
+			require.True(t, d.isRunning())
+			d.stop()
+			require.Len(t, client.Received(), len(tc.expected))
+		})
+	}
+}
+
+func TestDecompressor_PositionsFromEntries(t *testing.T) {
+	dir := t.TempDir()
+	logger := log.NewNopLogger()
+	path := filepath.Join(dir, "app.log.gz")
+	require.NoError(t, os.WriteFile(path, gzipped(t, testLines), 0o600))
+
+	ps, err := positions.New(logger, positions.Config{
+		SyncPeriod:    10 * time.Millisecond,
+		PositionsFile: filepath.Join(dir, "positions.yml"),
+		SyncEntries: func() (map[string]int64, error) {
+			return nil, nil
+		},
+	})
+	require.NoError(t, err)
+	defer ps.Stop()
+
+	client := fake.New(func() {})
+	defer client.Stop()
+
+	d, err := newDecompressor(NewMetrics(nil), logger, client, ps, path, formatGzip, 0, nil)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(client.Received()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	d.stop()
+
+	// Entries carry the position following their line.
+	var offsets []int64
+	for _, e := range client.Received() {
+		require.Equal(t, path, e.Position.Path)
+		offsets = append(offsets, e.Position.Offset)
+	}
+	require.Equal(t, []int64{7, 14, 20}, offsets)
+
+	// The decompressor doesn't advance the position itself.
+	pos, err := ps.Get(path)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), pos)
+}
+
+func TestFileTarget_Decompression(t *testing.T) {
+	logger := log.NewNopLogger()
+	dir := t.TempDir()
+	logDir := filepath.Join(dir, "log")
+	require.NoError(t, os.MkdirAll(logDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "app.log"), []byte("plain\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "app.log.1.gz"), gzipped(t, testLines), 0o600))
+
+	ps, err := positions.New(logger, positions.Config{
+		SyncPeriod:    10 * time.Minute,
+		PositionsFile: filepath.Join(dir, "positions.yml"),
+	})
+	require.NoError(t, err)
+	defer ps.Stop()
+
+	client := fake.New(func() {})
+	defer client.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeHandler := make(chan fileTargetEvent)
+	go func() {
+		for {
+			select {
+			case <-fakeHandler:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	newTarget := func(cfg *scrapeconfig.DecompressionConfig) *FileTarget {
+		target, err := NewFileTarget(NewMetrics(nil), logger, client, ps, logDir+"/app.log*", nil, nil, &Config{
+			SyncPeriod: 10 * time.Millisecond,
+		}, cfg, "", nil, fakeHandler)
+		require.NoError(t, err)
+		return target
+	}
+
+	target := newTarget(&scrapeconfig.DecompressionConfig{Enabled: true, InitialDelay: 10 * time.Millisecond})
+	require.Eventually(t, func() bool {
+		return len(client.Received()) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Syncs do not restart completed decompressors.
+	time.Sleep(100 * time.Millisecond)
+	require.Len(t, client.Received(), 4)
+	target.Stop()
+	require.IsType(t, &decompressor{}, target.tails[filepath.Join(logDir, "app.log.1.gz")])
+	require.IsType(t, &tailer{}, target.tails[filepath.Join(logDir, "app.log")])
+
+	var lines []string
+	for _, e := range client.Received() {
+		lines = append(lines, e.Line)
+	}
+	require.ElementsMatch(t, []string{"plain", "line 1", "line 2", "line 3"}, lines)
+
+	// Formats not enabled are tailed as plain text.
+	target = newTarget(&scrapeconfig.DecompressionConfig{Enabled: true, Formats: []string{formatZip}})
+	require.Eventually(t, func() bool {
+		return len(client.Received()) > 4
+	}, 5*time.Second, 10*time.Millisecond)
+	target.Stop()
+	require.IsType(t, &tailer{}, target.tails[filepath.Join(logDir, "app.log.1.gz")])
+}
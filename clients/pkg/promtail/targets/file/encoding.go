@@ -0,0 +1,186 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+var (
+	errInvalidCharacters = errors.New("line contains characters which could not be decoded")
+
+	utf8BOM = []byte{0xef, 0xbb, 0xbf}
+)
+
+// lookupEncoding returns the encoding with the given IANA name, or nil if the
+// lines are already UTF-8 and do not need to be decoded.
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	if name == "" {
+		return nil, nil
+	}
+	enc, err := ianaindex.IANA.Encoding(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encoding %q: %w", name, err)
+	}
+	if enc == nil {
+		return nil, fmt.Errorf("unsupported encoding %q", name)
+	}
+	if enc == unicode.UTF8 {
+		return nil, nil
+	}
+	return enc, nil
+}
+
+// decodedLine is a line transcoded to UTF-8.
+type decodedLine struct {
+	text string
+	// size is the number of bytes of the line in the file, including the newline.
+	size int
+	err  error
+}
+
+// lineDecoder splits the content of a file into lines and transcodes them
+// from the file encoding to UTF-8.
+//
+// Files are read in chunks ending with a '\n' byte, which in multi-byte
+// encodings like UTF-16 is also part of other characters and only the first
+// byte of an encoded newline. Chunks are buffered until a newline is found at
+// a character boundary.
+type lineDecoder struct {
+	decoder *encoding.Decoder
+	// newline is the encoded newline. Its length is the size of the encoding
+	// code units, newlines are only searched at offsets aligned to it.
+	newline []byte
+	// lookahead returns the byte following the chunks written so far, if it
+	// has already been written to the file.
+	lookahead func() (byte, bool)
+
+	pending []byte
+	// skip is the number of bytes at the start of the next chunk which were
+	// already consumed as the end of a newline.
+	skip int
+	// atStart is true until the first line of the file is decoded.
+	atStart bool
+}
+
+// newLineDecoder returns a decoder for enc. head are the first bytes of the
+// file, used to detect the byte order of UTF-16 files with a byte order mark.
+// atStart must be true when the file is read from its beginning.
+func newLineDecoder(enc encoding.Encoding, head []byte, atStart bool, lookahead func() (byte, bool)) *lineDecoder {
+	if enc == unicode.UTF16(unicode.BigEndian, unicode.UseBOM) {
+		// The byte order mark is only at the start of the file, decode it once
+		// to know the byte order of every line.
+		enc = unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)
+		if bytes.HasPrefix(head, []byte{0xff, 0xfe}) {
+			enc = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
+		}
+	}
+
+	newline, err := enc.NewEncoder().Bytes([]byte("\n"))
+	if err != nil || len(newline) == 0 {
+		newline = []byte("\n")
+	}
+
+	return &lineDecoder{
+		decoder:   enc.NewDecoder(),
+		newline:   newline,
+		lookahead: lookahead,
+		atStart:   atStart,
+	}
+}
+
+// write appends a chunk read from the file and returns the lines completed by
+// it.
+func (d *lineDecoder) write(chunk []byte) []decodedLine {
+	if d.skip > 0 {
+		n := d.skip
+		if n > len(chunk) {
+			n = len(chunk)
+		}
+		chunk = chunk[n:]
+		d.skip -= n
+	}
+	d.pending = append(d.pending, chunk...)
+
+	var lines []decodedLine
+	for {
+		i := d.newlineIndex()
+		if i < 0 {
+			break
+		}
+		size := i + len(d.newline)
+		lines = append(lines, d.decode(d.pending[:i], size))
+		d.pending = d.pending[size:]
+	}
+
+	// The chunk may end one byte before the end of a newline, check whether
+	// that byte follows to not wait for the next line.
+	if d.endsWithPartialNewline() && d.lookahead != nil {
+		if b, ok := d.lookahead(); ok && b == d.newline[len(d.newline)-1] {
+			i := len(d.pending) - len(d.newline) + 1
+			lines = append(lines, d.decode(d.pending[:i], i+len(d.newline)))
+			d.pending = d.pending[:0]
+			d.skip = 1
+		}
+	}
+
+	d.pending = append([]byte(nil), d.pending...)
+	return lines
+}
+
+// flush returns the last line of the file when it does not end with a newline.
+func (d *lineDecoder) flush() (decodedLine, bool) {
+	if len(d.pending) == 0 {
+		return decodedLine{}, false
+	}
+	line := d.decode(d.pending, len(d.pending))
+	d.pending = nil
+	return line, true
+}
+
+// buffered returns the number of bytes written but not returned as lines yet.
+func (d *lineDecoder) buffered() int {
+	return len(d.pending) - d.skip
+}
+
+func (d *lineDecoder) newlineIndex() int {
+	if len(d.newline) == 1 {
+		return bytes.Index(d.pending, d.newline)
+	}
+	for i := 0; i+len(d.newline) <= len(d.pending); i += len(d.newline) {
+		if bytes.Equal(d.pending[i:i+len(d.newline)], d.newline) {
+			return i
+		}
+	}
+	return -1
+}
+
+// endsWithPartialNewline returns true if the pending bytes end with all but
+// the last byte of a newline.
+func (d *lineDecoder) endsWithPartialNewline() bool {
+	p := len(d.newline) - 1
+	i := len(d.pending) - p
+	return p > 0 && i >= 0 && i%len(d.newline) == 0 && bytes.Equal(d.pending[i:], d.newline[:p])
+}
+
+func (d *lineDecoder) decode(raw []byte, size int) decodedLine {
+	text, err := d.decoder.Bytes(raw)
+	if err != nil {
+		return decodedLine{size: size, err: err}
+	}
+	if d.atStart {
+		// A byte order mark is decoded to the zero width no-break space.
+		text = bytes.TrimPrefix(text, utf8BOM)
+		d.atStart = false
+	}
+	line := decodedLine{text: string(text), size: size}
+	if bytes.ContainsRune(text, utf8.RuneError) {
+		line.err = errInvalidCharacters
+	}
+	return line
+}
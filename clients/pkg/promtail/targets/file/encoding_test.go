@@ -0,0 +1,198 @@
+package file
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/grafana/loki/clients/pkg/promtail/client/fake"
+	"github.com/grafana/loki/clients/pkg/promtail/positions"
+)
+
+func encode(t *testing.T, enc encoding.Encoding, s string) []byte {
+	t.Helper()
+	b, err := enc.NewEncoder().Bytes([]byte(s))
+	require.NoError(t, err)
+	return b
+}
+
+// decodeChunks writes content to a line decoder in chunks ending with a '\n'
+// byte, the way files are read.
+func decodeChunks(d *lineDecoder, content []byte, offset *int) []decodedLine {
+	var lines []decodedLine
+	for len(content) > 0 {
+		i := bytes.IndexByte(content, '\n')
+		if i < 0 {
+			// The last chunk of a file without a trailing newline.
+			i = len(content) - 1
+		}
+		*offset += i + 1
+		lines = append(lines, d.write(content[:i+1])...)
+		content = content[i+1:]
+	}
+	return lines
+}
+
+func TestLookupEncoding(t *testing.T) {
+	for name, expected := range map[string]encoding.Encoding{
+		"":           nil,
+		"UTF-8":      nil,
+		"utf-16le":   unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+		"UTF-16":     unicode.UTF16(unicode.BigEndian, unicode.UseBOM),
+		"ISO-8859-1": charmap.ISO8859_1,
+		"latin1":     charmap.ISO8859_1,
+	} {
+		enc, err := lookupEncoding(name)
+		require.NoError(t, err, name)
+		require.Equal(t, expected, enc, name)
+	}
+
+	_, err := lookupEncoding("not-an-encoding")
+	require.Error(t, err)
+	// Known by IANA but not supported.
+	_, err = lookupEncoding("UTF-32")
+	require.Error(t, err)
+}
+
+func TestLineDecoder(t *testing.T) {
+	utf16le := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
+	// 上 is encoded as 0a 4e in UTF-16LE and Ċ as 0a 01.
+	text := "first 上 line\nĊ second\n\nlast"
+
+	for _, tc := range []struct {
+		name    string
+		enc     encoding.Encoding
+		content []byte
+	}{
+		{name: "utf-16le", enc: utf16le, content: encode(t, utf16le, text)},
+		{name: "utf-16le with bom", enc: unicode.UTF16(unicode.BigEndian, unicode.UseBOM), content: encode(t, unicode.UTF16(unicode.LittleEndian, unicode.UseBOM), text)},
+		{name: "utf-16be with bom", enc: unicode.UTF16(unicode.BigEndian, unicode.UseBOM), content: encode(t, unicode.UTF16(unicode.BigEndian, unicode.UseBOM), text)},
+		{name: "utf-16be", enc: unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), content: encode(t, unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), text)},
+		{name: "latin1", enc: charmap.ISO8859_1, content: []byte("first \xe9 line\n\xc4 second\n\nlast")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var offset int
+			d := newLineDecoder(tc.enc, tc.content[:2], true, func() (byte, bool) {
+				if offset >= len(tc.content) {
+					return 0, false
+				}
+				return tc.content[offset], true
+			})
+
+			lines := decodeChunks(d, tc.content, &offset)
+			last, ok := d.flush()
+			require.True(t, ok)
+			lines = append(lines, last)
+
+			var texts []string
+			size := 0
+			for _, l := range lines {
+				require.NoError(t, l.err)
+				texts = append(texts, l.text)
+				size += l.size
+			}
+			if tc.enc == charmap.ISO8859_1 {
+				require.Equal(t, []string{"first é line", "Ä second", "", "last"}, texts)
+			} else {
+				require.Equal(t, []string{"first 上 line", "Ċ second", "", "last"}, texts)
+			}
+			require.Equal(t, len(tc.content), size)
+			require.Equal(t, 0, d.buffered())
+		})
+	}
+}
+
+func TestLineDecoder_Lookahead(t *testing.T) {
+	utf16le := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
+	content := encode(t, utf16le, "one\ntwo\n")
+
+	// The end of the newline is not written yet, the line is kept until it is.
+	var offset int
+	written := len(content) - 1
+	d := newLineDecoder(utf16le, nil, true, func() (byte, bool) {
+		if offset >= written {
+			return 0, false
+		}
+		return content[offset], true
+	})
+	lines := decodeChunks(d, content[:written], &offset)
+	require.Equal(t, []decodedLine{{text: "one", size: 8}}, lines)
+	require.Equal(t, 7, d.buffered())
+
+	written = len(content)
+	lines = d.write(content[offset:])
+	require.Equal(t, []decodedLine{{text: "two", size: 8}}, lines)
+	require.Equal(t, 0, d.buffered())
+}
+
+func TestLineDecoder_InvalidCharacters(t *testing.T) {
+	d := newLineDecoder(unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), nil, true, nil)
+	// An unpaired surrogate.
+	lines := d.write([]byte{0x00, 0xd8, 'a', 0x00, '\n', 0x00})
+	require.Len(t, lines, 1)
+	require.Equal(t, errInvalidCharacters, lines[0].err)
+	require.Equal(t, "�a", lines[0].text)
+}
+
+func TestTailer_Encoding(t *testing.T) {
+	dir := t.TempDir()
+	logger := log.NewNopLogger()
+	path := filepath.Join(dir, "app.log")
+	utf16 := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	require.NoError(t, os.WriteFile(path, encode(t, utf16, "héllo\r\nwörld\n"), 0o600))
+
+	ps, err := positions.New(logger, positions.Config{
+		SyncPeriod:    10 * time.Minute,
+		PositionsFile: filepath.Join(dir, "positions.yml"),
+	})
+	require.NoError(t, err)
+	defer ps.Stop()
+
+	client := fake.New(func() {})
+	defer client.Stop()
+
+	enc, err := lookupEncoding("UTF-16")
+	require.NoError(t, err)
+	metrics := NewMetrics(nil)
+	tailer, err := newTailer(metrics, logger, client, ps, path, enc)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(client.Received()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Lines with invalid characters are sent and counted.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0x00, 0xdc, '!', 0x00, '\n', 0x00})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.Eventually(t, func() bool {
+		return len(client.Received()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.encodingFailures.WithLabelValues(path)))
+	tailer.stop()
+
+	var lines []string
+	for _, e := range client.Received() {
+		lines = append(lines, e.Line)
+	}
+	require.Equal(t, []string{"héllo\r", "wörld", "�!"}, lines)
+
+	// The position is at the end of the last line sent.
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	pos, err := ps.Get(path)
+	require.NoError(t, err)
+	require.Equal(t, fi.Size(), pos)
+}
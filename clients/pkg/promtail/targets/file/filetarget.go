@@ -12,11 +12,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
+	"golang.org/x/text/encoding"
 	fsnotify "gopkg.in/fsnotify.v1"
 
 	"github.com/grafana/loki/clients/pkg/promtail/api"
 	"github.com/grafana/loki/clients/pkg/promtail/client"
 	"github.com/grafana/loki/clients/pkg/promtail/positions"
+	"github.com/grafana/loki/clients/pkg/promtail/scrapeconfig"
 	"github.com/grafana/loki/clients/pkg/promtail/targets/target"
 )
 
@@ -54,6 +56,15 @@ type fileTargetEvent struct {
 	eventType fileTargetEventType
 }
 
+// reader reads the lines of a file, either a tailer following a plain text
+// file or a decompressor reading a compressed file.
+type reader interface {
+	stop()
+	isRunning() bool
+	getPath() string
+	markPositionAndSize() error
+}
+
 // FileTarget describes a particular set of logs.
 // nolint:revive
 type FileTarget struct {
@@ -72,9 +83,15 @@ type FileTarget struct {
 	quit               chan struct{}
 	done               chan struct{}
 
-	tails map[string]*tailer
+	tails map[string]reader
 
-	targetConfig *Config
+	targetConfig  *Config
+	decompressCfg *scrapeconfig.DecompressionConfig
+	// decompressFormats are the compression formats read by decompressors,
+	// nil if compressed files are tailed as plain text.
+	decompressFormats map[string]struct{}
+	// encoding of the files, nil for UTF-8.
+	encoding encoding.Encoding
 }
 
 // NewFileTarget create a new FileTarget.
@@ -87,9 +104,20 @@ func NewFileTarget(
 	labels model.LabelSet,
 	discoveredLabels model.LabelSet,
 	targetConfig *Config,
+	decompressCfg *scrapeconfig.DecompressionConfig,
+	encodingName string,
 	fileEventWatcher chan fsnotify.Event,
 	targetEventHandler chan fileTargetEvent,
 ) (*FileTarget, error) {
+	decompressFormats, err := decompressionFormats(decompressCfg)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := lookupEncoding(encodingName)
+	if err != nil {
+		return nil, err
+	}
+
 	t := &FileTarget{
 		logger:             logger,
 		metrics:            metrics,
@@ -100,8 +128,11 @@ func NewFileTarget(
 		positions:          positions,
 		quit:               make(chan struct{}),
 		done:               make(chan struct{}),
-		tails:              map[string]*tailer{},
+		tails:              map[string]reader{},
 		targetConfig:       targetConfig,
+		decompressCfg:      decompressCfg,
+		decompressFormats:  decompressFormats,
+		encoding:           enc,
 		fileEventWatcher:   fileEventWatcher,
 		targetEventHandler: targetEventHandler,
 	}
@@ -290,23 +321,51 @@ func (t *FileTarget) startTailing(ps []string) {
 			continue
 		}
 
-		level.Debug(t.logger).Log("msg", "tailing new file", "filename", p)
-		tailer, err := newTailer(t.metrics, t.logger, t.handler, t.positions, p)
+		format, err := t.compressionFormat(p)
+		if err != nil {
+			level.Error(t.logger).Log("msg", "failed to tail file, compression format detection failed", "error", err, "filename", p)
+			continue
+		}
+
+		var reader reader
+		if format != "" {
+			level.Debug(t.logger).Log("msg", "reading new compressed file", "filename", p, "format", format)
+			reader, err = newDecompressor(t.metrics, t.logger, t.handler, t.positions, p, format, t.decompressCfg.InitialDelay, t.encoding)
+		} else {
+			level.Debug(t.logger).Log("msg", "tailing new file", "filename", p)
+			reader, err = newTailer(t.metrics, t.logger, t.handler, t.positions, p, t.encoding)
+		}
 		if err != nil {
 			level.Error(t.logger).Log("msg", "failed to start tailer", "error", err, "filename", p)
 			continue
 		}
-		t.tails[p] = tailer
+		t.tails[p] = reader
+	}
+}
+
+// compressionFormat returns the format of the file if it is compressed with one
+// of the formats to decompress, or an empty string if it must be tailed.
+func (t *FileTarget) compressionFormat(path string) (string, error) {
+	if t.decompressFormats == nil {
+		return "", nil
+	}
+	format, err := compressionFormat(path)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := t.decompressFormats[format]; !ok {
+		return "", nil
 	}
+	return format, nil
 }
 
 // stopTailingAndRemovePosition will stop the tailer and remove the positions entry.
 // Call this when a file no longer exists and you want to remove all traces of it.
 func (t *FileTarget) stopTailingAndRemovePosition(ps []string) {
 	for _, p := range ps {
-		if tailer, ok := t.tails[p]; ok {
-			tailer.stop()
-			t.positions.Remove(tailer.path)
+		if reader, ok := t.tails[p]; ok {
+			reader.stop()
+			t.positions.Remove(reader.getPath())
 			delete(t.tails, p)
 		}
 		if h, ok := t.handler.(api.InstrumentedEntryHandler); ok {
@@ -329,7 +388,7 @@ func (t *FileTarget) pruneStoppedTailers() {
 	}
 }
 
-func toStopTailing(nt []string, et map[string]*tailer) []string {
+func toStopTailing(nt []string, et map[string]reader) []string {
 	// Make a set of all existing tails
 	existingTails := make(map[string]struct{}, len(et))
 	for file := range et {
@@ -353,9 +412,9 @@ func toStopTailing(nt []string, et map[string]*tailer) []string {
 
 func (t *FileTarget) reportSize(ms []string) {
 	for _, m := range ms {
-		// Ask the tailer to update the size if a tailer exists, this keeps position and size metrics in sync
-		if tailer, ok := t.tails[m]; ok {
-			err := tailer.markPositionAndSize()
+		// Ask the reader to update the size if a reader exists, this keeps position and size metrics in sync
+		if reader, ok := t.tails[m]; ok {
+			err := reader.markPositionAndSize()
 			if err != nil {
 				level.Warn(t.logger).Log("msg", "failed to get file size from tailer, ", "file", m, "error", err)
 				return
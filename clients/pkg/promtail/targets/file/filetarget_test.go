@@ -69,7 +69,7 @@ func TestFileTargetSync(t *testing.T) {
 	path := logDir1 + "/*.log"
 	target, err := NewFileTarget(metrics, logger, client, ps, path, nil, nil, &Config{
 		SyncPeriod: 1 * time.Minute, // assure the sync is not called by the ticker
-	}, nil, fakeHandler)
+	}, nil, "", nil, fakeHandler)
 	assert.NoError(t, err)
 
 	// Start with nothing watched.
@@ -212,7 +212,7 @@ func TestHandleFileCreationEvent(t *testing.T) {
 	target, err := NewFileTarget(metrics, logger, client, ps, path, nil, nil, &Config{
 		// To handle file creation event from channel, set enough long time as sync period
 		SyncPeriod: 10 * time.Minute,
-	}, fakeFileHandler, fakeTargetHandler)
+	}, nil, "", fakeFileHandler, fakeTargetHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -232,7 +232,7 @@ func TestHandleFileCreationEvent(t *testing.T) {
 
 func TestToStopTailing(t *testing.T) {
 	nt := []string{"file1", "file2", "file3", "file4", "file5", "file6", "file7", "file11", "file12", "file15"}
-	et := make(map[string]*tailer, 15)
+	et := make(map[string]reader, 15)
 	for i := 1; i <= 15; i++ {
 		et[fmt.Sprintf("file%d", i)] = nil
 	}
@@ -252,7 +252,7 @@ func TestToStopTailing(t *testing.T) {
 
 func BenchmarkToStopTailing(b *testing.B) {
 	nt := []string{"file1", "file2", "file3", "file4", "file5", "file6", "file7", "file11", "file12", "file15"}
-	et := make(map[string]*tailer, 15)
+	et := make(map[string]reader, 15)
 	for i := 1; i <= 15; i++ {
 		et[fmt.Sprintf("file%d", i)] = nil
 	}
@@ -313,3 +313,43 @@ func TestMissing(t *testing.T) {
 	}
 
 }
+
+func TestTailer_PositionsFromEntries(t *testing.T) {
+	dir := t.TempDir()
+	logger := log.NewNopLogger()
+	path := filepath.Join(dir, "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("one\ntwo\n"), 0o600))
+
+	ps, err := positions.New(logger, positions.Config{
+		SyncPeriod:    10 * time.Millisecond,
+		PositionsFile: filepath.Join(dir, "positions.yml"),
+		SyncEntries: func() (map[string]int64, error) {
+			return nil, nil
+		},
+	})
+	require.NoError(t, err)
+	defer ps.Stop()
+
+	client := fake.New(func() {})
+	defer client.Stop()
+
+	tailer, err := newTailer(NewMetrics(nil), logger, client, ps, path, nil)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(client.Received()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	tailer.stop()
+
+	// Entries carry the position following their line.
+	var offsets []int64
+	for _, e := range client.Received() {
+		require.Equal(t, path, e.Position.Path)
+		offsets = append(offsets, e.Position.Offset)
+	}
+	require.Equal(t, []int64{4, 8}, offsets)
+
+	// The tailer doesn't advance the position itself.
+	pos, err := ps.Get(path)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), pos)
+}
@@ -88,6 +88,13 @@ func NewFileTargetManager(
 			continue
 		}
 
+		if _, err := decompressionFormats(cfg.DecompressionConfig); err != nil {
+			return nil, fmt.Errorf("invalid decompression config for job %s: %w", cfg.JobName, err)
+		}
+		if _, err := lookupEncoding(cfg.Encoding); err != nil {
+			return nil, fmt.Errorf("invalid config for job %s: %w", cfg.JobName, err)
+		}
+
 		pipeline, err := stages.NewPipeline(log.With(logger, "component", "file_pipeline"), cfg.PipelineStages, &cfg.JobName, reg)
 		if err != nil {
 			return nil, err
@@ -128,6 +135,8 @@ func NewFileTargetManager(
 			hostname:          hostname,
 			entryHandler:      pipeline.Wrap(client),
 			targetConfig:      targetConfig,
+			decompressCfg:     cfg.DecompressionConfig,
+			encoding:          cfg.Encoding,
 			fileEventWatchers: map[string]chan fsnotify.Event{},
 		}
 		tm.syncers[cfg.JobName] = s
@@ -259,6 +268,8 @@ type targetSyncer struct {
 
 	relabelConfig []*relabel.Config
 	targetConfig  *Config
+	decompressCfg *scrapeconfig.DecompressionConfig
+	encoding      string
 }
 
 // sync synchronize target based on received target groups received by service discovery
@@ -388,7 +399,7 @@ func (s *targetSyncer) sendFileCreateEvent(event fsnotify.Event) {
 }
 
 func (s *targetSyncer) newTarget(path string, labels model.LabelSet, discoveredLabels model.LabelSet, fileEventWatcher chan fsnotify.Event, targetEventHandler chan fileTargetEvent) (*FileTarget, error) {
-	return NewFileTarget(s.metrics, s.log, s.entryHandler, s.positions, path, labels, discoveredLabels, s.targetConfig, fileEventWatcher, targetEventHandler)
+	return NewFileTarget(s.metrics, s.log, s.entryHandler, s.positions, path, labels, discoveredLabels, s.targetConfig, s.decompressCfg, s.encoding, fileEventWatcher, targetEventHandler)
 }
 
 func (s *targetSyncer) DroppedTargets() []target.Target {
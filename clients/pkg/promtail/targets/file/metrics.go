@@ -13,6 +13,8 @@ type Metrics struct {
 	readLines   *prometheus.CounterVec
 	filesActive prometheus.Gauge
 
+	encodingFailures *prometheus.CounterVec
+
 	// Manager metrics
 	failedTargets *prometheus.CounterVec
 	targetsActive prometheus.Gauge
@@ -45,6 +47,12 @@ func NewMetrics(reg prometheus.Registerer) *Metrics {
 		Help:      "Number of active files.",
 	})
 
+	m.encodingFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "promtail",
+		Name:      "encoding_failures_total",
+		Help:      "Number of lines which could not be decoded from the file encoding.",
+	}, []string{"path"})
+
 	m.failedTargets = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "promtail",
 		Name:      "targets_failed_total",
@@ -62,6 +70,7 @@ func NewMetrics(reg prometheus.Registerer) *Metrics {
 			m.totalBytes,
 			m.readLines,
 			m.filesActive,
+			m.encodingFailures,
 			m.failedTargets,
 			m.targetsActive,
 		)
@@ -10,6 +10,7 @@ import (
 	"github.com/hpcloud/tail"
 	"github.com/prometheus/common/model"
 	"go.uber.org/atomic"
+	"golang.org/x/text/encoding"
 
 	"github.com/grafana/loki/clients/pkg/promtail/api"
 	"github.com/grafana/loki/clients/pkg/promtail/positions"
@@ -27,6 +28,19 @@ type tailer struct {
 	path string
 	tail *tail.Tail
 
+	// decoder transcodes the lines when the file is not UTF-8, nil otherwise.
+	decoder *lineDecoder
+	// file is used to look at the bytes following the lines read by tail when
+	// decoding, offset is the position in the file of the next line. It is
+	// also tracked when the positions are taken from the entries.
+	file   *os.File
+	offset int64
+	// buffered is the number of bytes read by tail but not sent yet.
+	buffered *atomic.Int64
+	// fromEntries is set when the position of the file is advanced from the
+	// position of the entries, once the clients made them durable.
+	fromEntries bool
+
 	posAndSizeMtx sync.Mutex
 	stopOnce      sync.Once
 
@@ -36,7 +50,7 @@ type tailer struct {
 	done    chan struct{}
 }
 
-func newTailer(metrics *Metrics, logger log.Logger, handler api.EntryHandler, positions positions.Positions, path string) (*tailer, error) {
+func newTailer(metrics *Metrics, logger log.Logger, handler api.EntryHandler, positions positions.Positions, path string, enc encoding.Encoding) (*tailer, error) {
 	// Simple check to make sure the file we are tailing doesn't
 	// have a position already saved which is past the end of the file.
 	fi, err := os.Stat(path)
@@ -50,6 +64,7 @@ func newTailer(metrics *Metrics, logger log.Logger, handler api.EntryHandler, po
 
 	if fi.Size() < pos {
 		positions.Remove(path)
+		pos = 0
 	}
 
 	tail, err := tail.TailFile(path, tail.Config{
@@ -69,16 +84,30 @@ func newTailer(metrics *Metrics, logger log.Logger, handler api.EntryHandler, po
 
 	logger = log.With(logger, "component", "tailer")
 	tailer := &tailer{
-		metrics:   metrics,
-		logger:    logger,
-		handler:   api.AddLabelsMiddleware(model.LabelSet{FilenameLabel: model.LabelValue(path)}).Wrap(handler),
-		positions: positions,
-		path:      path,
-		tail:      tail,
-		running:   atomic.NewBool(false),
-		posquit:   make(chan struct{}),
-		posdone:   make(chan struct{}),
-		done:      make(chan struct{}),
+		metrics:     metrics,
+		logger:      logger,
+		handler:     api.AddLabelsMiddleware(model.LabelSet{FilenameLabel: model.LabelValue(path)}).Wrap(handler),
+		positions:   positions,
+		path:        path,
+		tail:        tail,
+		offset:      pos,
+		buffered:    atomic.NewInt64(0),
+		fromEntries: positions.FromEntries(),
+		running:     atomic.NewBool(false),
+		posquit:     make(chan struct{}),
+		posdone:     make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	if enc != nil {
+		level.Info(logger).Log("msg", "decoding lines to UTF-8", "path", path, "encoding", enc)
+		if tailer.file, err = os.Open(path); err != nil {
+			util.LogError("stopping tailer", tail.Stop)
+			return nil, err
+		}
+		head := make([]byte, 2)
+		n, _ := tailer.file.ReadAt(head, 0)
+		tailer.decoder = newLineDecoder(enc, head[:n], pos == 0, tailer.lookahead)
 	}
 
 	go tailer.readLines()
@@ -149,18 +178,75 @@ func (t *tailer) readLines() {
 			continue
 		}
 
-		t.metrics.readLines.WithLabelValues(t.path).Inc()
-		entries <- api.Entry{
-			Labels: model.LabelSet{},
-			Entry: logproto.Entry{
-				Timestamp: line.Time,
-				Line:      line.Text,
-			},
+		if t.decoder == nil {
+			t.metrics.readLines.WithLabelValues(t.path).Inc()
+			e := newEntry(line.Time, line.Text)
+			if t.fromEntries {
+				e.Position = t.linePosition(line.Text)
+			}
+			entries <- e
+			continue
 		}
 
+		// Lines decoded from the chunk are only read again after a restart up
+		// to the bytes still buffered by the decoder once the last one is sent.
+		prev := api.Position{Path: t.path, Offset: t.offset - t.buffered.Load()}
+		chunk := append([]byte(line.Text), '\n')
+		t.offset += int64(len(chunk))
+		decodedLines := t.decoder.write(chunk)
+		for i, decoded := range decodedLines {
+			if decoded.err != nil {
+				level.Debug(t.logger).Log("msg", "tail routine: error decoding line", "path", t.path, "error", decoded.err)
+				t.metrics.encodingFailures.WithLabelValues(t.path).Inc()
+				if decoded.err != errInvalidCharacters {
+					continue
+				}
+			}
+			t.metrics.readLines.WithLabelValues(t.path).Inc()
+			e := newEntry(line.Time, decoded.text)
+			if t.fromEntries {
+				e.Position = prev
+				if i == len(decodedLines)-1 {
+					e.Position.Offset = t.offset - int64(t.decoder.buffered())
+				}
+			}
+			entries <- e
+		}
+		t.buffered.Store(int64(t.decoder.buffered()))
 	}
 }
 
+// linePosition returns the position following a line read by tail. tail
+// reopens truncated files from the start, which shows as its position being
+// behind the lines read so far.
+func (t *tailer) linePosition(line string) api.Position {
+	t.offset += int64(len(line)) + 1
+	if pos, err := t.tail.Tell(); err == nil && pos < t.offset {
+		t.offset = int64(len(line)) + 1
+	}
+	return api.Position{Path: t.path, Offset: t.offset}
+}
+
+func newEntry(ts time.Time, line string) api.Entry {
+	return api.Entry{
+		Labels: model.LabelSet{},
+		Entry: logproto.Entry{
+			Timestamp: ts,
+			Line:      line,
+		},
+	}
+}
+
+// lookahead returns the byte following the lines read by tail so far, if it
+// was already written to the file.
+func (t *tailer) lookahead() (byte, bool) {
+	b := make([]byte, 1)
+	if _, err := t.file.ReadAt(b, t.offset); err != nil {
+		return 0, false
+	}
+	return b[0], true
+}
+
 func (t *tailer) markPositionAndSize() error {
 	// Lock this update as there are 2 timers calling this routine, the sync in filetarget and the positions sync in this file.
 	t.posAndSizeMtx.Lock()
@@ -181,8 +267,12 @@ func (t *tailer) markPositionAndSize() error {
 	if err != nil {
 		return err
 	}
+	// Lines being decoded are read again after a restart.
+	pos -= t.buffered.Load()
 	t.metrics.readBytes.WithLabelValues(t.path).Set(float64(pos))
-	t.positions.Put(t.path, pos)
+	if !t.fromEntries {
+		t.positions.Put(t.path, pos)
+	}
 
 	return nil
 }
@@ -208,6 +298,9 @@ func (t *tailer) stop() {
 		}
 		// Wait for readLines() to consume all the remaining messages and exit when the channel is closed
 		<-t.done
+		if t.file != nil {
+			util.LogError("closing file", t.file.Close)
+		}
 		level.Info(t.logger).Log("msg", "stopped tailing file", "path", t.path)
 		t.handler.Stop()
 	})
@@ -217,6 +310,10 @@ func (t *tailer) isRunning() bool {
 	return t.running.Load()
 }
 
+func (t *tailer) getPath() string {
+	return t.path
+}
+
 // cleanupMetrics removes all metrics exported by this tailer
 func (t *tailer) cleanupMetrics() {
 	// When we stop tailing the file, also un-export metrics related to the file
@@ -224,4 +321,5 @@ func (t *tailer) cleanupMetrics() {
 	t.metrics.readLines.DeleteLabelValues(t.path)
 	t.metrics.readBytes.DeleteLabelValues(t.path)
 	t.metrics.totalBytes.DeleteLabelValues(t.path)
+	t.metrics.encodingFailures.DeleteLabelValues(t.path)
 }
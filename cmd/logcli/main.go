@@ -18,6 +18,7 @@ import (
 	"github.com/grafana/loki/pkg/logcli/output"
 	"github.com/grafana/loki/pkg/logcli/query"
 	"github.com/grafana/loki/pkg/logcli/seriesquery"
+	"github.com/grafana/loki/pkg/logcli/volume"
 	_ "github.com/grafana/loki/pkg/util/build"
 )
 
@@ -109,6 +110,23 @@ Use the --analyze-labels flag to get a summary of the labels found in all stream
 This is helpful to find high cardinality labels.
 `)
 	seriesQuery = newSeriesQuery(seriesCmd)
+
+	volumeCmd = app.Command("volume", `Run a volume query.
+
+The "volume" command will take the provided label matcher and return
+the bytes and entries of the matching log streams found in the time window,
+largest first. The volumes are computed from the index only, without
+reading any chunk.
+
+By default the volumes are reported per stream. Use --target-labels
+to aggregate them by the given labels instead, for example to find
+which namespace or app sends the most logs:
+
+	logcli volume --target-labels=namespace,app '{cluster="prod"}'
+
+The output is limited to the 100 largest volumes by default; use --limit to modify.
+`)
+	volumeQuery = newVolumeQuery(volumeCmd)
 )
 
 func main() {
@@ -213,6 +231,8 @@ func main() {
 		labelsQuery.DoLabels(queryClient)
 	case seriesCmd.FullCommand():
 		seriesQuery.DoSeries(queryClient)
+	case volumeCmd.FullCommand():
+		volumeQuery.DoVolume(queryClient)
 	}
 }
 
@@ -303,6 +323,38 @@ func newSeriesQuery(cmd *kingpin.CmdClause) *seriesquery.SeriesQuery {
 	return q
 }
 
+func newVolumeQuery(cmd *kingpin.CmdClause) *volume.VolumeQuery {
+	// calculate volume range from cli params
+	var from, to, targetLabels string
+	var since time.Duration
+
+	q := &volume.VolumeQuery{}
+
+	// executed after all command flags are parsed
+	cmd.Action(func(c *kingpin.ParseContext) error {
+
+		defaultEnd := time.Now()
+		defaultStart := defaultEnd.Add(-since)
+
+		q.Start = mustParse(from, defaultStart)
+		q.End = mustParse(to, defaultEnd)
+		if targetLabels != "" {
+			q.TargetLabels = strings.Split(targetLabels, ",")
+		}
+		q.Quiet = *quiet
+		return nil
+	})
+
+	cmd.Arg("matcher", "eg '{foo=\"bar\",baz=~\".*blip\"}'").Required().StringVar(&q.QueryString)
+	cmd.Flag("since", "Lookback window.").Default("1h").DurationVar(&since)
+	cmd.Flag("from", "Start looking for logs at this absolute time (inclusive)").StringVar(&from)
+	cmd.Flag("to", "Stop looking for logs at this absolute time (exclusive)").StringVar(&to)
+	cmd.Flag("limit", "Limit on number of volumes to print.").Default("100").IntVar(&q.Limit)
+	cmd.Flag("target-labels", "Comma separated list of labels to aggregate the volumes by instead of by stream, eg 'namespace,app'").StringVar(&targetLabels)
+
+	return q
+}
+
 func newQuery(instant bool, cmd *kingpin.CmdClause) *query.Query {
 	// calculate query range from cli params
 	var now, from, to string
@@ -305,6 +305,13 @@ type LokiTemplateSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Index Gateway pods"
 	IndexGateway *LokiComponentSpec `json:"indexGateway,omitempty"`
+
+	// Ruler defines the ruler component spec.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ruler pods"
+	Ruler *LokiComponentSpec `json:"ruler,omitempty"`
 }
 
 // ObjectStorageSecretType defines the type of storage which can be used with the Loki cluster.
@@ -475,6 +482,117 @@ type LimitsSpec struct {
 	Tenants map[string]LimitsTemplateSpec `json:"tenants,omitempty"`
 }
 
+// PrometheusDuration defines the type for Prometheus durations.
+//
+// +kubebuilder:validation:Pattern:="((([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?|0)"
+type PrometheusDuration string
+
+// AlertManagerSpec defines the configuration for the ruler's alertmanager connectivity.
+type AlertManagerSpec struct {
+	// URL for alerts return path.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Alert External URL"
+	ExternalURL string `json:"externalUrl,omitempty"`
+
+	// Additional labels to add to all alerts.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Extra labels to add to all alerts"
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+
+	// If enabled, then requests to Alertmanager use the v2 API.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Enable AlertManager V2 API"
+	EnableV2 bool `json:"enableV2,omitempty"`
+
+	// List of AlertManager URLs to send notifications to. Each Alertmanager URL is treated as
+	// a separate group in the configuration. Multiple Alertmanagers in HA per group can be
+	// supported by using DNS resolution (See EnableDNSDiscovery).
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="AlertManager Endpoints"
+	Endpoints []string `json:"endpoints"`
+
+	// Use DNS SRV records to discover Alertmanager hosts.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Enable DNS Discovery"
+	EnableDNSDiscovery bool `json:"enableDNSDiscovery,omitempty"`
+}
+
+// RemoteWriteSpec defines the configuration for the ruler's remote_write connectivity.
+type RemoteWriteSpec struct {
+	// URL of the Prometheus compatible endpoint to send the samples of
+	// recording rules to.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint"
+	URL string `json:"url"`
+
+	// Timeout for requests to the remote write endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="30s"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remote Write Timeout"
+	Timeout PrometheusDuration `json:"timeout,omitempty"`
+
+	// Additional HTTP headers to be sent along with each remote write request.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Headers"
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// RulesSpec defines the spec for the ruler component.
+type RulesSpec struct {
+	// Enabled defines a flag to enable/disable the ruler component
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch",displayName="Enable"
+	Enabled bool `json:"enabled"`
+
+	// A selector to select which AlertingRule and RecordingRule custom resources
+	// are loaded by the ruler. An empty selector selects all of them.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Selector"
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Namespaces to be selected for AlertingRule and RecordingRule discovery.
+	// If unspecified, only the same namespace as the LokiStack object is in is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace Selector"
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// AlertManager defines the alertmanager settings for the alerts of the alerting rules.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="AlertManager Configuration"
+	AlertManager *AlertManagerSpec `json:"alertmanager,omitempty"`
+
+	// RemoteWrite defines the remote write settings for the samples of the recording rules.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remote Write Configuration"
+	RemoteWrite *RemoteWriteSpec `json:"remoteWrite,omitempty"`
+}
+
 // LokiStackSpec defines the desired state of LokiStack
 type LokiStackSpec struct {
 
@@ -516,6 +634,13 @@ type LokiStackSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:number",displayName="Replication Factor"
 	ReplicationFactor int32 `json:"replicationFactor"`
 
+	// Rules defines the spec for the ruler component
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced",displayName="Rules"
+	Rules *RulesSpec `json:"rules,omitempty"`
+
 	// Limits defines the limits to be applied to log stream processing.
 	//
 	// +optional
@@ -583,6 +708,9 @@ const (
 	ReasonInvalidTenantsConfiguration LokiStackConditionReason = "InvalidTenantsConfiguration"
 	// ReasonMissingGatewayOpenShiftBaseDomain when the reconciler cannot lookup the OpenShift DNS base domain.
 	ReasonMissingGatewayOpenShiftBaseDomain LokiStackConditionReason = "MissingGatewayOpenShiftBaseDomain"
+	// ReasonInvalidRulesConfiguration when the rules configuration or one of the
+	// AlertingRule or RecordingRule custom resources selected is invalid.
+	ReasonInvalidRulesConfiguration LokiStackConditionReason = "InvalidRulesConfiguration"
 )
 
 // PodStatusMap defines the type for mapping pod status to pod name.
@@ -639,6 +767,13 @@ type LokiStackComponentStatus struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Gateway",order=5
 	Gateway PodStatusMap `json:"gateway,omitempty"`
+
+	// Ruler is a map to the per pod status of the lokistack ruler statefulset.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses",displayName="Ruler",order=6
+	Ruler PodStatusMap `json:"ruler,omitempty"`
 }
 
 // LokiStackStatus defines the observed state of LokiStack
@@ -22,6 +22,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 var (
@@ -57,6 +58,7 @@ func TestLokiStackController_RegistersCustomResourceForCreateOrUpdate(t *testing
 
 	b.ForReturns(b)
 	b.OwnsReturns(b)
+	b.WatchesReturns(b)
 
 	err := c.buildController(b)
 	require.NoError(t, err)
@@ -150,6 +152,7 @@ func TestLokiStackController_RegisterOwnedResourcesForUpdateOrDeleteOnly(t *test
 		b := &k8sfakes.FakeBuilder{}
 		b.ForReturns(b)
 		b.OwnsReturns(b)
+		b.WatchesReturns(b)
 
 		c := &LokiStackReconciler{Client: k, Scheme: scheme, Flags: tst.flags}
 		err := c.buildController(b)
@@ -164,3 +167,25 @@ func TestLokiStackController_RegisterOwnedResourcesForUpdateOrDeleteOnly(t *test
 		require.Equal(t, tst.pred, opts[0])
 	}
 }
+
+func TestLokiStackController_RegisterWatchedRules(t *testing.T) {
+	b := &k8sfakes.FakeBuilder{}
+	k := &k8sfakes.FakeClient{}
+	c := &LokiStackReconciler{Client: k, Scheme: scheme}
+
+	b.ForReturns(b)
+	b.OwnsReturns(b)
+	b.WatchesReturns(b)
+
+	err := c.buildController(b)
+	require.NoError(t, err)
+
+	// Require Watches-Calls for all rule custom resources
+	require.Equal(t, 2, b.WatchesCallCount())
+
+	src, _, _ := b.WatchesArgsForCall(0)
+	require.Equal(t, &source.Kind{Type: &lokiv1beta1.AlertingRule{}}, src)
+
+	src, _, _ = b.WatchesArgsForCall(1)
+	require.Equal(t, &source.Kind{Type: &lokiv1beta1.RecordingRule{}}, src)
+}
@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/kverrors"
+	lokiv1beta1 "github.com/grafana/loki/operator/api/v1beta1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// List returns the AlertingRule and RecordingRule objects selected by the rules spec
+// of a LokiStack. Without a namespace selector only the rules in the namespace of the
+// LokiStack are selected.
+func List(ctx context.Context, k k8s.Client, stackNs string, rs *lokiv1beta1.RulesSpec) ([]lokiv1beta1.AlertingRule, []lokiv1beta1.RecordingRule, error) {
+	nsl, err := selectRulesNamespaces(ctx, k, stackNs, rs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rl, err := selectRules(rs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		alerts  []lokiv1beta1.AlertingRule
+		records []lokiv1beta1.RecordingRule
+	)
+	for _, ns := range nsl {
+		opts := []client.ListOption{
+			client.InNamespace(ns),
+			client.MatchingLabelsSelector{Selector: rl},
+		}
+
+		var ars lokiv1beta1.AlertingRuleList
+		if err := k.List(ctx, &ars, opts...); err != nil {
+			return nil, nil, kverrors.Wrap(err, "failed to list alerting rules", "namespace", ns)
+		}
+		alerts = append(alerts, ars.Items...)
+
+		var rrs lokiv1beta1.RecordingRuleList
+		if err := k.List(ctx, &rrs, opts...); err != nil {
+			return nil, nil, kverrors.Wrap(err, "failed to list recording rules", "namespace", ns)
+		}
+		records = append(records, rrs.Items...)
+	}
+
+	return alerts, records, nil
+}
+
+func selectRulesNamespaces(ctx context.Context, k k8s.Client, stackNs string, rs *lokiv1beta1.RulesSpec) ([]string, error) {
+	if rs.NamespaceSelector == nil {
+		return []string{stackNs}, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(rs.NamespaceSelector)
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to create namespace selector for rules")
+	}
+
+	var nsList corev1.NamespaceList
+	if err := k.List(ctx, &nsList, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return nil, kverrors.Wrap(err, "failed to list namespaces for rules")
+	}
+
+	nsl := make([]string, 0, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		nsl = append(nsl, ns.Name)
+	}
+	return nsl, nil
+}
+
+func selectRules(rs *lokiv1beta1.RulesSpec) (labels.Selector, error) {
+	if rs.Selector == nil {
+		return labels.Everything(), nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(rs.Selector)
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to create rules selector")
+	}
+	return sel, nil
+}
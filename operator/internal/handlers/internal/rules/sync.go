@@ -0,0 +1,272 @@
+package rules
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ViaQ/logerr/kverrors"
+	lokiv1beta1 "github.com/grafana/loki/operator/api/v1beta1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// serviceCAFile is the service CA bundle mounted with the service account token on OpenShift.
+	serviceCAFile = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+
+	alertingNamespacePrefix  = "alerting-"
+	recordingNamespacePrefix = "recording-"
+)
+
+// ErrRuleGroupRejected is returned when the ruler rejects a rule group, e.g. because one of its
+// expressions is not a valid LogQL metric query. Expressions are only validated by the ruler.
+var ErrRuleGroupRejected = errors.New("rule group rejected by the ruler")
+
+// Tenants returns the tenants of the rules along with the tenants which got rules synced before,
+// as recorded in the rules configmap of the LokiStack.
+func Tenants(ctx context.Context, k k8s.Client, req ctrl.Request, alerts []lokiv1beta1.AlertingRule, records []lokiv1beta1.RecordingRule) ([]string, error) {
+	tenants := map[string]struct{}{}
+	for _, r := range alerts {
+		tenants[r.Spec.TenantID] = struct{}{}
+	}
+	for _, r := range records {
+		tenants[r.Spec.TenantID] = struct{}{}
+	}
+
+	var cm corev1.ConfigMap
+	key := client.ObjectKey{Name: manifests.RulesConfigMapName(req.Name), Namespace: req.Namespace}
+	if err := k.Get(ctx, key, &cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, kverrors.Wrap(err, "failed to lookup rules configmap", "name", key)
+		}
+	} else {
+		synced, err := manifests.ExtractRulesTenants(&cm)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range synced {
+			tenants[t] = struct{}{}
+		}
+	}
+
+	tl := make([]string, 0, len(tenants))
+	for t := range tenants {
+		tl = append(tl, t)
+	}
+	sort.Strings(tl)
+	return tl, nil
+}
+
+// Sync writes the groups of the rules into the rule store of the ruler through its API, which persists
+// them in the object storage of the LokiStack. Each AlertingRule and RecordingRule gets its own rule
+// namespace in the rule store of its tenant. Rule namespaces and groups of the given tenants which are
+// not part of the rules anymore are deleted, other rule namespaces are left untouched.
+func Sync(ctx context.Context, c *RulerClient, tenants []string, alerts []lokiv1beta1.AlertingRule, records []lokiv1beta1.RecordingRule) error {
+	desired := map[string]map[string][]ruleGroup{}
+	add := func(tenant, namespace string, groups []ruleGroup) {
+		if desired[tenant] == nil {
+			desired[tenant] = map[string][]ruleGroup{}
+		}
+		desired[tenant][namespace] = groups
+	}
+	for _, r := range alerts {
+		groups := make([]ruleGroup, 0, len(r.Spec.Groups))
+		for _, g := range r.Spec.Groups {
+			groups = append(groups, ruleGroup{Name: g.Name, Group: g})
+		}
+		add(r.Spec.TenantID, ruleNamespace(alertingNamespacePrefix, r.ObjectMeta), groups)
+	}
+	for _, r := range records {
+		groups := make([]ruleGroup, 0, len(r.Spec.Groups))
+		for _, g := range r.Spec.Groups {
+			groups = append(groups, ruleGroup{Name: g.Name, Group: g})
+		}
+		add(r.Spec.TenantID, ruleNamespace(recordingNamespacePrefix, r.ObjectMeta), groups)
+	}
+
+	for _, tenant := range tenants {
+		existing, err := c.ListRuleGroups(ctx, tenant)
+		if err != nil {
+			return err
+		}
+
+		for ns, groups := range existing {
+			if !strings.HasPrefix(ns, alertingNamespacePrefix) && !strings.HasPrefix(ns, recordingNamespacePrefix) {
+				continue
+			}
+
+			want, ok := desired[tenant][ns]
+			if !ok {
+				if err := c.DeleteNamespace(ctx, tenant, ns); err != nil {
+					return err
+				}
+				continue
+			}
+
+			for _, g := range groups {
+				if !hasGroup(want, g) {
+					if err := c.DeleteRuleGroup(ctx, tenant, ns, g); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		for ns, groups := range desired[tenant] {
+			for _, g := range groups {
+				if err := c.SetRuleGroup(ctx, tenant, ns, g.Group); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+type ruleGroup struct {
+	Name  string
+	Group interface{}
+}
+
+func hasGroup(groups []ruleGroup, name string) bool {
+	for _, g := range groups {
+		if g.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleNamespace(prefix string, m metav1.ObjectMeta) string {
+	return fmt.Sprintf("%s%s-%s-%s", prefix, m.Namespace, m.Name, m.UID)
+}
+
+// RulerClient manages the rule groups of tenants through the rules API of the Loki ruler.
+type RulerClient struct {
+	client *http.Client
+	url    string
+}
+
+// NewRulerClient returns a client for the rules API of the ruler at rulerURL. The service CA bundle
+// is trusted when the ruler API is served over TLS.
+func NewRulerClient(rulerURL string, tlsEnabled bool) (*RulerClient, error) {
+	c := &http.Client{}
+	if tlsEnabled {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, kverrors.Wrap(err, "failed to load system cert pool")
+		}
+		if ca, err := os.ReadFile(serviceCAFile); err == nil {
+			pool.AppendCertsFromPEM(ca)
+		}
+		c.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				MinVersion: tls.VersionTLS12,
+			},
+		}
+	}
+	return NewRulerClientWithHTTPClient(c, rulerURL), nil
+}
+
+// NewRulerClientWithHTTPClient returns a client for the rules API of the ruler at rulerURL using c.
+func NewRulerClientWithHTTPClient(c *http.Client, rulerURL string) *RulerClient {
+	return &RulerClient{client: c, url: rulerURL}
+}
+
+// ListRuleGroups returns the names of the rule groups of a tenant by rule namespace.
+func (c *RulerClient) ListRuleGroups(ctx context.Context, tenant string) (map[string][]string, error) {
+	body, err := c.do(ctx, http.MethodGet, tenant, "/loki/api/v1/rules", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces map[string][]struct {
+		Name string `json:"name"`
+	}
+	if err := yaml.Unmarshal(body, &namespaces); err != nil {
+		return nil, kverrors.Wrap(err, "failed to unmarshal rule groups", "tenant", tenant)
+	}
+
+	groups := make(map[string][]string, len(namespaces))
+	for ns, gs := range namespaces {
+		for _, g := range gs {
+			groups[ns] = append(groups[ns], g.Name)
+		}
+	}
+	return groups, nil
+}
+
+// SetRuleGroup creates or replaces a rule group in a rule namespace of a tenant.
+func (c *RulerClient) SetRuleGroup(ctx context.Context, tenant, namespace string, group interface{}) error {
+	body, err := yaml.Marshal(group)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to marshal rule group", "tenant", tenant, "namespace", namespace)
+	}
+	_, err = c.do(ctx, http.MethodPost, tenant, "/loki/api/v1/rules/"+url.PathEscape(namespace), body)
+	return err
+}
+
+// DeleteNamespace deletes all the rule groups of a rule namespace of a tenant.
+func (c *RulerClient) DeleteNamespace(ctx context.Context, tenant, namespace string) error {
+	_, err := c.do(ctx, http.MethodDelete, tenant, "/loki/api/v1/rules/"+url.PathEscape(namespace), nil)
+	return err
+}
+
+// DeleteRuleGroup deletes a rule group from a rule namespace of a tenant.
+func (c *RulerClient) DeleteRuleGroup(ctx context.Context, tenant, namespace, group string) error {
+	_, err := c.do(ctx, http.MethodDelete, tenant, "/loki/api/v1/rules/"+url.PathEscape(namespace)+"/"+url.PathEscape(group), nil)
+	return err
+}
+
+// do sends a request to the rules API on behalf of a tenant. Not found responses to lookups and
+// deletions are returned as empty bodies, since the ruler answers them when there is no rule group.
+func (c *RulerClient) do(ctx context.Context, method, tenant, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to create ruler request", "method", method, "path", path)
+	}
+	req.Header.Set("X-Scope-OrgID", tenant)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/yaml")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to call the ruler", "method", method, "path", path, "tenant", tenant)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to read the ruler response", "method", method, "path", path, "tenant", tenant)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound && method != http.MethodPost:
+		return nil, nil
+	case resp.StatusCode == http.StatusBadRequest && method == http.MethodPost:
+		return nil, kverrors.Wrap(ErrRuleGroupRejected, "invalid rule group", "path", path, "tenant", tenant, "reason", string(bytes.TrimSpace(b)))
+	case resp.StatusCode/100 != 2:
+		return nil, kverrors.New("unexpected ruler response", "method", method, "path", path, "tenant", tenant, "status", resp.StatusCode, "body", string(b))
+	}
+	return b, nil
+}
@@ -0,0 +1,233 @@
+package rules
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	lokiv1beta1 "github.com/grafana/loki/operator/api/v1beta1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// fakeRuler implements the rules API of the ruler on top of an in-memory rule store.
+type fakeRuler struct {
+	mtx sync.Mutex
+	// store holds the rule groups by tenant, namespace and group name.
+	store map[string]map[string]map[string]string
+}
+
+func (f *fakeRuler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	tenant := r.Header.Get("X-Scope-OrgID")
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/loki/api/v1/rules"), "/")
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1:
+		if len(f.store[tenant]) == 0 {
+			http.Error(w, "no rule groups found", http.StatusNotFound)
+			return
+		}
+		out := map[string][]map[string]string{}
+		for ns, groups := range f.store[tenant] {
+			for name := range groups {
+				out[ns] = append(out[ns], map[string]string{"name": name})
+			}
+		}
+		b, _ := yaml.Marshal(out)
+		_, _ = w.Write(b)
+	case r.Method == http.MethodPost && len(parts) == 2:
+		b, _ := io.ReadAll(r.Body)
+		var g struct {
+			Name string `json:"name"`
+		}
+		if err := yaml.Unmarshal(b, &g); err != nil || g.Name == "" {
+			http.Error(w, "invalid rule group", http.StatusBadRequest)
+			return
+		}
+		if f.store[tenant] == nil {
+			f.store[tenant] = map[string]map[string]string{}
+		}
+		if f.store[tenant][parts[1]] == nil {
+			f.store[tenant][parts[1]] = map[string]string{}
+		}
+		f.store[tenant][parts[1]][g.Name] = string(b)
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodDelete && len(parts) == 2:
+		delete(f.store[tenant], parts[1])
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodDelete && len(parts) == 3:
+		delete(f.store[tenant][parts[1]], parts[2])
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func TestSync(t *testing.T) {
+	ruler := &fakeRuler{store: map[string]map[string]map[string]string{
+		"application": {
+			"alerting-ns-alerts-1":  {"app-errors": "", "removed-group": ""},
+			"alerting-ns-deleted-3": {"old-errors": ""},
+			// Rule namespaces not created by the operator are left untouched.
+			"user-rules": {"user-group": ""},
+		},
+		"infrastructure": {
+			"recording-ns-deleted-4": {"old-rates": ""},
+		},
+	}}
+	srv := httptest.NewServer(ruler)
+	defer srv.Close()
+
+	alerts := []lokiv1beta1.AlertingRule{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "alerts", Namespace: "ns", UID: "1"},
+			Spec: lokiv1beta1.AlertingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.AlertingRuleGroup{
+					{
+						Name: "app-errors",
+						Rules: []lokiv1beta1.AlertingRuleGroupSpec{
+							{Alert: "HighErrorRate", Expr: `sum(rate({app="foo"} |= "error" [5m])) > 10`, For: "10m"},
+						},
+					},
+				},
+			},
+		},
+	}
+	records := []lokiv1beta1.RecordingRule{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "records", Namespace: "ns", UID: "2"},
+			Spec: lokiv1beta1.RecordingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.RecordingRuleGroup{
+					{
+						Name: "app-rates",
+						Rules: []lokiv1beta1.RecordingRuleGroupSpec{
+							{Record: "app:log_lines:rate5m", Expr: `sum(rate({app="foo"}[5m]))`},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	c := NewRulerClientWithHTTPClient(srv.Client(), srv.URL)
+	err := Sync(context.TODO(), c, []string{"application", "infrastructure"}, alerts, records)
+	require.NoError(t, err)
+
+	require.Len(t, ruler.store["application"], 3)
+	require.Contains(t, ruler.store["application"], "user-rules")
+	require.Equal(t, []string{"app-errors"}, groupNames(ruler.store["application"]["alerting-ns-alerts-1"]))
+	require.Equal(t, []string{"app-rates"}, groupNames(ruler.store["application"]["recording-ns-records-2"]))
+	require.YAMLEq(t, `
+name: app-rates
+rules:
+- record: app:log_lines:rate5m
+  expr: sum(rate({app="foo"}[5m]))
+`, ruler.store["application"]["recording-ns-records-2"]["app-rates"])
+	require.Empty(t, ruler.store["infrastructure"])
+}
+
+func TestSync_RulerUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewRulerClientWithHTTPClient(srv.Client(), srv.URL)
+	err := Sync(context.TODO(), c, []string{"application"}, nil, nil)
+	require.Error(t, err)
+}
+
+func TestSync_RuleGroupRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			http.Error(w, "parse error at line 1, col 21: syntax error", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "no rule groups found", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	records := []lokiv1beta1.RecordingRule{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "records", Namespace: "ns", UID: "2"},
+			Spec: lokiv1beta1.RecordingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.RecordingRuleGroup{
+					{
+						Name: "app-rates",
+						Rules: []lokiv1beta1.RecordingRuleGroupSpec{
+							{Record: "app:log_lines:rate5m", Expr: `sum(rate({app=foo}[5m]))`},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	c := NewRulerClientWithHTTPClient(srv.Client(), srv.URL)
+	err := Sync(context.TODO(), c, []string{"application"}, nil, records)
+	require.ErrorIs(t, err, ErrRuleGroupRejected)
+}
+
+func TestTenants(t *testing.T) {
+	k := &k8sfakes.FakeClient{}
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "lokistack-dev",
+			Namespace: "some-ns",
+		},
+	}
+
+	alerts := []lokiv1beta1.AlertingRule{
+		{Spec: lokiv1beta1.AlertingRuleSpec{TenantID: "application"}},
+	}
+	records := []lokiv1beta1.RecordingRule{
+		{Spec: lokiv1beta1.RecordingRuleSpec{TenantID: "infrastructure"}},
+	}
+
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object) error {
+		return apierrors.NewNotFound(schema.GroupResource{}, "something is not found")
+	}
+	tenants, err := Tenants(context.TODO(), k, r, alerts, records)
+	require.NoError(t, err)
+	require.Equal(t, []string{"application", "infrastructure"}, tenants)
+
+	// Tenants which got rules synced before are kept.
+	cm, err := manifests.RulesConfigMap(manifests.Options{Name: "lokistack-dev", RulesTenants: []string{"audit", "application"}})
+	require.NoError(t, err)
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object) error {
+		if name.Name == manifests.RulesConfigMapName("lokistack-dev") && name.Namespace == "some-ns" {
+			k.SetClientObject(object, cm)
+			return nil
+		}
+		return apierrors.NewNotFound(schema.GroupResource{}, "something is not found")
+	}
+	tenants, err = Tenants(context.TODO(), k, r, alerts, records)
+	require.NoError(t, err)
+	require.Equal(t, []string{"application", "audit", "infrastructure"}, tenants)
+}
+
+func groupNames(groups map[string]string) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"github.com/ViaQ/logerr/kverrors"
+	lokiv1beta1 "github.com/grafana/loki/operator/api/v1beta1"
+
+	"github.com/prometheus/common/model"
+)
+
+// ValidateAlertingRule validates the groups and rules of an AlertingRule.
+func ValidateAlertingRule(r lokiv1beta1.AlertingRule) error {
+	if r.Spec.TenantID == "" {
+		return kverrors.New("missing tenant ID", "name", r.Name)
+	}
+
+	groups := map[string]struct{}{}
+	for _, g := range r.Spec.Groups {
+		if err := validateGroup(groups, g.Name, g.Interval); err != nil {
+			return kverrors.Wrap(err, "invalid alerting rule", "name", r.Name)
+		}
+
+		for _, rule := range g.Rules {
+			if rule.Alert == "" || !model.LabelValue(rule.Alert).IsValid() {
+				return kverrors.New("invalid alert name", "name", r.Name, "group", g.Name, "alert", rule.Alert)
+			}
+			if rule.Expr == "" {
+				return kverrors.New("missing expression", "name", r.Name, "group", g.Name, "alert", rule.Alert)
+			}
+			if err := validateDuration(rule.For); err != nil {
+				return kverrors.Wrap(err, "invalid for duration", "name", r.Name, "group", g.Name, "alert", rule.Alert)
+			}
+			if err := validateLabels(rule.Labels); err != nil {
+				return kverrors.Wrap(err, "invalid labels", "name", r.Name, "group", g.Name, "alert", rule.Alert)
+			}
+			if err := validateLabels(rule.Annotations); err != nil {
+				return kverrors.Wrap(err, "invalid annotations", "name", r.Name, "group", g.Name, "alert", rule.Alert)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateRecordingRule validates the groups and rules of a RecordingRule.
+func ValidateRecordingRule(r lokiv1beta1.RecordingRule) error {
+	if r.Spec.TenantID == "" {
+		return kverrors.New("missing tenant ID", "name", r.Name)
+	}
+
+	groups := map[string]struct{}{}
+	for _, g := range r.Spec.Groups {
+		if err := validateGroup(groups, g.Name, g.Interval); err != nil {
+			return kverrors.Wrap(err, "invalid recording rule", "name", r.Name)
+		}
+
+		for _, rule := range g.Rules {
+			if !model.IsValidMetricName(model.LabelValue(rule.Record)) {
+				return kverrors.New("invalid record name", "name", r.Name, "group", g.Name, "record", rule.Record)
+			}
+			if rule.Expr == "" {
+				return kverrors.New("missing expression", "name", r.Name, "group", g.Name, "record", rule.Record)
+			}
+			if err := validateLabels(rule.Labels); err != nil {
+				return kverrors.Wrap(err, "invalid labels", "name", r.Name, "group", g.Name, "record", rule.Record)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateGroup(groups map[string]struct{}, name string, interval lokiv1beta1.PrometheusDuration) error {
+	if name == "" {
+		return kverrors.New("missing group name")
+	}
+	if _, ok := groups[name]; ok {
+		return kverrors.New("duplicate group name", "group", name)
+	}
+	groups[name] = struct{}{}
+
+	if err := validateDuration(interval); err != nil {
+		return kverrors.Wrap(err, "invalid group interval", "group", name)
+	}
+	return nil
+}
+
+func validateDuration(d lokiv1beta1.PrometheusDuration) error {
+	if d == "" {
+		return nil
+	}
+	_, err := model.ParseDuration(string(d))
+	return err
+}
+
+func validateLabels(l map[string]string) error {
+	for name, value := range l {
+		if !model.LabelName(name).IsValid() {
+			return kverrors.New("invalid label name", "label", name)
+		}
+		if !model.LabelValue(value).IsValid() {
+			return kverrors.New("invalid label value", "label", name)
+		}
+	}
+	return nil
+}
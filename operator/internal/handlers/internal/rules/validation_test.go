@@ -0,0 +1,228 @@
+package rules
+
+import (
+	"testing"
+
+	lokiv1beta1 "github.com/grafana/loki/operator/api/v1beta1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateAlertingRule(t *testing.T) {
+	type test struct {
+		name    string
+		wantErr string
+		spec    lokiv1beta1.AlertingRuleSpec
+	}
+	table := []test{
+		{
+			name: "valid rule",
+			spec: lokiv1beta1.AlertingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.AlertingRuleGroup{
+					{
+						Name:     "group",
+						Interval: "1m",
+						Rules: []lokiv1beta1.AlertingRuleGroupSpec{
+							{
+								Alert:  "HighErrorRate",
+								Expr:   `sum(rate({app="foo"} |= "error" [5m])) > 10`,
+								For:    "10m",
+								Labels: map[string]string{"severity": "critical"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "missing tenant",
+			wantErr: "missing tenant ID",
+			spec:    lokiv1beta1.AlertingRuleSpec{},
+		},
+		{
+			name:    "duplicate group name",
+			wantErr: "invalid alerting rule",
+			spec: lokiv1beta1.AlertingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.AlertingRuleGroup{
+					{Name: "group"},
+					{Name: "group"},
+				},
+			},
+		},
+		{
+			name:    "invalid interval",
+			wantErr: "invalid alerting rule",
+			spec: lokiv1beta1.AlertingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.AlertingRuleGroup{
+					{Name: "group", Interval: "1 minute"},
+				},
+			},
+		},
+		{
+			name:    "missing alert name",
+			wantErr: "invalid alert name",
+			spec: lokiv1beta1.AlertingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.AlertingRuleGroup{
+					{
+						Name: "group",
+						Rules: []lokiv1beta1.AlertingRuleGroupSpec{
+							{Expr: `count_over_time({app="foo"}[5m]) > 0`},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "missing expression",
+			wantErr: "missing expression",
+			spec: lokiv1beta1.AlertingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.AlertingRuleGroup{
+					{
+						Name: "group",
+						Rules: []lokiv1beta1.AlertingRuleGroupSpec{
+							{Alert: "Alert"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid for duration",
+			wantErr: "invalid for duration",
+			spec: lokiv1beta1.AlertingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.AlertingRuleGroup{
+					{
+						Name: "group",
+						Rules: []lokiv1beta1.AlertingRuleGroupSpec{
+							{Alert: "Alert", Expr: `count_over_time({app="foo"}[5m]) > 0`, For: "ten minutes"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid label name",
+			wantErr: "invalid labels",
+			spec: lokiv1beta1.AlertingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.AlertingRuleGroup{
+					{
+						Name: "group",
+						Rules: []lokiv1beta1.AlertingRuleGroupSpec{
+							{Alert: "Alert", Expr: `count_over_time({app="foo"}[5m]) > 0`, Labels: map[string]string{"not-valid": "value"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := lokiv1beta1.AlertingRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "alerting-rule", Namespace: "some-ns"},
+				Spec:       tst.spec,
+			}
+			err := ValidateAlertingRule(r)
+			if tst.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tst.wantErr)
+		})
+	}
+}
+
+func TestValidateRecordingRule(t *testing.T) {
+	type test struct {
+		name    string
+		wantErr string
+		spec    lokiv1beta1.RecordingRuleSpec
+	}
+	table := []test{
+		{
+			name: "valid rule",
+			spec: lokiv1beta1.RecordingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.RecordingRuleGroup{
+					{
+						Name: "group",
+						Rules: []lokiv1beta1.RecordingRuleGroupSpec{
+							{Record: "app:log_lines:rate5m", Expr: `sum(rate({app="foo"}[5m]))`},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "missing tenant",
+			wantErr: "missing tenant ID",
+			spec:    lokiv1beta1.RecordingRuleSpec{},
+		},
+		{
+			name:    "missing group name",
+			wantErr: "invalid recording rule",
+			spec: lokiv1beta1.RecordingRuleSpec{
+				TenantID: "application",
+				Groups:   []lokiv1beta1.RecordingRuleGroup{{}},
+			},
+		},
+		{
+			name:    "invalid record name",
+			wantErr: "invalid record name",
+			spec: lokiv1beta1.RecordingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.RecordingRuleGroup{
+					{
+						Name: "group",
+						Rules: []lokiv1beta1.RecordingRuleGroupSpec{
+							{Record: "app-log-lines", Expr: `sum(rate({app="foo"}[5m]))`},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "missing expression",
+			wantErr: "missing expression",
+			spec: lokiv1beta1.RecordingRuleSpec{
+				TenantID: "application",
+				Groups: []lokiv1beta1.RecordingRuleGroup{
+					{
+						Name: "group",
+						Rules: []lokiv1beta1.RecordingRuleGroupSpec{
+							{Record: "app:log_lines:rate5m"},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := lokiv1beta1.RecordingRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "recording-rule", Namespace: "some-ns"},
+				Spec:       tst.spec,
+			}
+			err := ValidateRecordingRule(r)
+			if tst.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tst.wantErr)
+		})
+	}
+}
@@ -2,12 +2,14 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
 	lokiv1beta1 "github.com/grafana/loki/operator/api/v1beta1"
 	"github.com/grafana/loki/operator/internal/external/k8s"
 	"github.com/grafana/loki/operator/internal/handlers/internal/gateway"
+	"github.com/grafana/loki/operator/internal/handlers/internal/rules"
 	"github.com/grafana/loki/operator/internal/handlers/internal/secrets"
 	"github.com/grafana/loki/operator/internal/manifests"
 	"github.com/grafana/loki/operator/internal/manifests/openshift"
@@ -115,6 +117,44 @@ func CreateOrUpdateLokiStack(
 		}
 	}
 
+	var (
+		rulesEnabled   = stack.Spec.Rules != nil && stack.Spec.Rules.Enabled
+		alertingRules  []lokiv1beta1.AlertingRule
+		recordingRules []lokiv1beta1.RecordingRule
+		rulesTenants   []string
+	)
+	if rulesEnabled {
+		alertingRules, recordingRules, err = rules.List(ctx, k, req.Namespace, stack.Spec.Rules)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range alertingRules {
+			if err = rules.ValidateAlertingRule(r); err != nil {
+				return &status.DegradedError{
+					Message: fmt.Sprintf("Invalid rules configuration: %s", err),
+					Reason:  lokiv1beta1.ReasonInvalidRulesConfiguration,
+					Requeue: false,
+				}
+			}
+		}
+
+		for _, r := range recordingRules {
+			if err = rules.ValidateRecordingRule(r); err != nil {
+				return &status.DegradedError{
+					Message: fmt.Sprintf("Invalid rules configuration: %s", err),
+					Reason:  lokiv1beta1.ReasonInvalidRulesConfiguration,
+					Requeue: false,
+				}
+			}
+		}
+
+		rulesTenants, err = rules.Tenants(ctx, k, req, alertingRules, recordingRules)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Here we will translate the lokiv1beta1.LokiStack options into manifest options
 	opts := manifests.Options{
 		Name:              req.Name,
@@ -127,6 +167,7 @@ func CreateOrUpdateLokiStack(
 		ObjectStorage:     *storage,
 		TenantSecrets:     tenantSecrets,
 		TenantConfigMap:   tenantConfigMap,
+		RulesTenants:      rulesTenants,
 	}
 
 	ll.Info("begin building manifests")
@@ -185,6 +226,26 @@ func CreateOrUpdateLokiStack(
 		return kverrors.New("failed to configure lokistack resources", "name", req.NamespacedName)
 	}
 
+	// The rules are synced into the rule store through the ruler once it is deployed,
+	// reconciling again until the ruler is ready to take them.
+	if rulesEnabled {
+		tlsEnabled := flags.EnableTLSServiceMonitorConfig
+		rc, err := rules.NewRulerClient(manifests.RulerHTTPURL(req.Name, req.Namespace, tlsEnabled), tlsEnabled)
+		if err != nil {
+			return err
+		}
+		if err := rules.Sync(ctx, rc, rulesTenants, alertingRules, recordingRules); err != nil {
+			if errors.Is(err, rules.ErrRuleGroupRejected) {
+				return &status.DegradedError{
+					Message: fmt.Sprintf("Invalid rules configuration: %s", err),
+					Reason:  lokiv1beta1.ReasonInvalidRulesConfiguration,
+					Requeue: false,
+				}
+			}
+			return kverrors.Wrap(err, "failed to sync rules into the rule store", "name", req.NamespacedName)
+		}
+	}
+
 	// 1x.extra-small is used only for development, so the metrics will not
 	// be collected.
 	if opts.Stack.Size != lokiv1beta1.SizeOneXExtraSmall {
@@ -3,7 +3,9 @@ package manifests
 import (
 	"crypto/sha1"
 	"fmt"
+	"strings"
 
+	lokiv1beta1 "github.com/grafana/loki/operator/api/v1beta1"
 	"github.com/grafana/loki/operator/internal/manifests/internal/config"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -70,11 +72,42 @@ func ConfigOptions(opt Options) config.Options {
 			Directory:             walDirectory,
 			IngesterMemoryRequest: opt.ResourceRequirements.Ingester.Requests.Memory().Value(),
 		},
+		Ruler:                 rulerConfig(opt.Stack.Rules),
 		ObjectStorage:         opt.ObjectStorage,
 		EnableRemoteReporting: opt.Flags.EnableGrafanaLabsStats,
 	}
 }
 
+func rulerConfig(spec *lokiv1beta1.RulesSpec) config.Ruler {
+	if spec == nil || !spec.Enabled {
+		return config.Ruler{}
+	}
+
+	cfg := config.Ruler{
+		Enabled: true,
+	}
+
+	if am := spec.AlertManager; am != nil {
+		cfg.AlertManager = &config.AlertManagerConfig{
+			ExternalURL:     am.ExternalURL,
+			ExternalLabels:  am.ExternalLabels,
+			Hosts:           strings.Join(am.Endpoints, ","),
+			EnableV2:        am.EnableV2,
+			EnableDiscovery: am.EnableDNSDiscovery,
+		}
+	}
+
+	if rw := spec.RemoteWrite; rw != nil {
+		cfg.RemoteWrite = &config.RemoteWriteConfig{
+			URL:     rw.URL,
+			Timeout: string(rw.Timeout),
+			Headers: rw.Headers,
+		}
+	}
+
+	return cfg
+}
+
 func lokiConfigMapName(stackName string) string {
 	return fmt.Sprintf("%s-config", stackName)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"embed"
 	"io/ioutil"
+	"strconv"
 	"text/template"
 
 	"github.com/ViaQ/logerr/kverrors"
@@ -25,7 +26,11 @@ var (
 	//go:embed loki-runtime-config.yaml
 	lokiRuntimeConfigYAMLTmplFile embed.FS
 
-	lokiConfigYAMLTmpl = template.Must(template.ParseFS(lokiConfigYAMLTmplFile, "loki-config.yaml"))
+	lokiConfigYAMLTmpl = template.Must(template.New("loki-config.yaml").Funcs(template.FuncMap{
+		// yamlQuote writes user provided values as double-quoted YAML strings,
+		// Go escape sequences being valid in those.
+		"yamlQuote": strconv.Quote,
+	}).ParseFS(lokiConfigYAMLTmplFile, "loki-config.yaml"))
 
 	lokiRuntimeConfigYAMLTmpl = template.Must(template.ParseFS(lokiRuntimeConfigYAMLTmplFile, "loki-runtime-config.yaml"))
 )
@@ -21,6 +21,7 @@ type Options struct {
 	StorageDirectory      string
 	MaxConcurrent         MaxConcurrent
 	WriteAheadLog         WriteAheadLog
+	Ruler                 Ruler
 	EnableRemoteReporting bool
 
 	ObjectStorage storage.Options
@@ -51,3 +52,27 @@ func (w WriteAheadLog) ReplayMemoryCeiling() string {
 	value := int64(math.Ceil(float64(w.IngesterMemoryRequest) * float64(0.5)))
 	return fmt.Sprintf("%d", value)
 }
+
+// Ruler configuration
+type Ruler struct {
+	Enabled      bool
+	AlertManager *AlertManagerConfig
+	RemoteWrite  *RemoteWriteConfig
+}
+
+// AlertManagerConfig for ruler alert notifications
+type AlertManagerConfig struct {
+	ExternalURL    string
+	ExternalLabels map[string]string
+	// Hosts is the comma-separated list of Alertmanager URLs
+	Hosts           string
+	EnableV2        bool
+	EnableDiscovery bool
+}
+
+// RemoteWriteConfig for ruler recording rule samples
+type RemoteWriteConfig struct {
+	URL     string
+	Timeout string
+	Headers map[string]string
+}
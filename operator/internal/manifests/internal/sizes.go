@@ -11,6 +11,7 @@ type ComponentResources struct {
 	IndexGateway ResourceRequirements
 	Ingester     ResourceRequirements
 	Compactor    ResourceRequirements
+	Ruler        ResourceRequirements
 	WALStorage   ResourceRequirements
 	// these two don't need a PVCSize
 	Querier       corev1.ResourceRequirements
@@ -74,6 +75,13 @@ var ResourceRequirementsTable = map[lokiv1beta1.LokiStackSizeType]ComponentResou
 				corev1.ResourceMemory: resource.MustParse("1Gi"),
 			},
 		},
+		Ruler: ResourceRequirements{
+			PVCSize: resource.MustParse("10Gi"),
+			Requests: map[corev1.ResourceName]resource.Quantity{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		},
 		WALStorage: ResourceRequirements{
 			PVCSize: resource.MustParse("150Gi"),
 		},
@@ -124,6 +132,13 @@ var ResourceRequirementsTable = map[lokiv1beta1.LokiStackSizeType]ComponentResou
 				corev1.ResourceMemory: resource.MustParse("2Gi"),
 			},
 		},
+		Ruler: ResourceRequirements{
+			PVCSize: resource.MustParse("10Gi"),
+			Requests: map[corev1.ResourceName]resource.Quantity{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		},
 		WALStorage: ResourceRequirements{
 			PVCSize: resource.MustParse("150Gi"),
 		},
@@ -174,6 +189,13 @@ var ResourceRequirementsTable = map[lokiv1beta1.LokiStackSizeType]ComponentResou
 				corev1.ResourceMemory: resource.MustParse("2Gi"),
 			},
 		},
+		Ruler: ResourceRequirements{
+			PVCSize: resource.MustParse("10Gi"),
+			Requests: map[corev1.ResourceName]resource.Quantity{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("3Gi"),
+			},
+		},
 		WALStorage: ResourceRequirements{
 			PVCSize: resource.MustParse("150Gi"),
 		},
@@ -227,6 +249,9 @@ var StackSizeTable = map[lokiv1beta1.LokiStackSizeType]lokiv1beta1.LokiStackSpec
 			IndexGateway: &lokiv1beta1.LokiComponentSpec{
 				Replicas: 1,
 			},
+			Ruler: &lokiv1beta1.LokiComponentSpec{
+				Replicas: 1,
+			},
 		},
 	},
 
@@ -276,6 +301,9 @@ var StackSizeTable = map[lokiv1beta1.LokiStackSizeType]lokiv1beta1.LokiStackSpec
 			IndexGateway: &lokiv1beta1.LokiComponentSpec{
 				Replicas: 2,
 			},
+			Ruler: &lokiv1beta1.LokiComponentSpec{
+				Replicas: 2,
+			},
 		},
 	},
 
@@ -325,6 +353,9 @@ var StackSizeTable = map[lokiv1beta1.LokiStackSizeType]lokiv1beta1.LokiStackSpec
 			IndexGateway: &lokiv1beta1.LokiComponentSpec{
 				Replicas: 2,
 			},
+			Ruler: &lokiv1beta1.LokiComponentSpec{
+				Replicas: 2,
+			},
 		},
 	},
 }
@@ -27,6 +27,9 @@ type Options struct {
 	OpenShiftOptions openshift.Options
 	TenantSecrets    []*TenantSecrets
 	TenantConfigMap  map[string]openshift.TenantData
+
+	// RulesTenants are the tenants whose rules are synced into the rule store by the operator.
+	RulesTenants []string
 }
 
 // FeatureFlags contains flags that activate various features
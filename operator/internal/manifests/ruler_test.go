@@ -0,0 +1,76 @@
+package manifests_test
+
+import (
+	"testing"
+
+	lokiv1beta1 "github.com/grafana/loki/operator/api/v1beta1"
+	"github.com/grafana/loki/operator/internal/manifests"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRulerStatefulSet_HasTemplateConfigHashAnnotation(t *testing.T) {
+	ss := manifests.NewRulerStatefulSet(manifests.Options{
+		Name:       "abcd",
+		Namespace:  "efgh",
+		ConfigSHA1: "deadbeef",
+		Stack: lokiv1beta1.LokiStackSpec{
+			StorageClassName: "standard",
+			Template: &lokiv1beta1.LokiTemplateSpec{
+				Ruler: &lokiv1beta1.LokiComponentSpec{
+					Replicas: 1,
+				},
+			},
+		},
+	})
+
+	expected := "loki.grafana.com/config-hash"
+	annotations := ss.Spec.Template.Annotations
+	require.Contains(t, annotations, expected)
+	require.Equal(t, annotations[expected], "deadbeef")
+}
+
+func TestNewRulerStatefulSet_SelectorMatchesLabels(t *testing.T) {
+	// You must set the .spec.selector field of a StatefulSet to match the labels of
+	// its .spec.template.metadata.labels. Prior to Kubernetes 1.8, the
+	// .spec.selector field was defaulted when omitted. In 1.8 and later versions,
+	// failing to specify a matching Pod Selector will result in a validation error
+	// during StatefulSet creation.
+	// See https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#pod-selector
+	ss := manifests.NewRulerStatefulSet(manifests.Options{
+		Name:      "abcd",
+		Namespace: "efgh",
+		Stack: lokiv1beta1.LokiStackSpec{
+			StorageClassName: "standard",
+			Template: &lokiv1beta1.LokiTemplateSpec{
+				Ruler: &lokiv1beta1.LokiComponentSpec{
+					Replicas: 1,
+				},
+			},
+		},
+	})
+
+	l := ss.Spec.Template.GetObjectMeta().GetLabels()
+	for key, value := range ss.Spec.Selector.MatchLabels {
+		require.Contains(t, l, key)
+		require.Equal(t, l[key], value)
+	}
+}
+
+func TestRulesConfigMap_RecordsTenants(t *testing.T) {
+	cm, err := manifests.RulesConfigMap(manifests.Options{
+		Name:         "abcd",
+		Namespace:    "efgh",
+		RulesTenants: []string{"application", "infrastructure"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, manifests.RulesConfigMapName("abcd"), cm.Name)
+
+	tenants, err := manifests.ExtractRulesTenants(cm)
+	require.NoError(t, err)
+	require.Equal(t, []string{"application", "infrastructure"}, tenants)
+}
+
+func TestRulerHTTPURL(t *testing.T) {
+	require.Equal(t, "http://abcd-ruler-http.efgh.svc.cluster.local:3100", manifests.RulerHTTPURL("abcd", "efgh", false))
+	require.Equal(t, "https://abcd-ruler-http.efgh.svc.cluster.local:3100", manifests.RulerHTTPURL("abcd", "efgh", true))
+}
@@ -0,0 +1,52 @@
+package manifests
+
+import (
+	"github.com/ViaQ/logerr/kverrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// RulesTenantsFileName is the name of the file listing the tenants with synced rules in the rules configmap
+const RulesTenantsFileName = "tenants.yaml"
+
+type rulesTenantsJSON struct {
+	Tenants []string `json:"tenants"`
+}
+
+// RulesConfigMap creates the configmap recording the tenants which got rules synced into the rule store,
+// so that their rules are still removed from it once they have no AlertingRule or RecordingRule left.
+func RulesConfigMap(opts Options) (*corev1.ConfigMap, error) {
+	c, err := yaml.Marshal(rulesTenantsJSON{Tenants: opts.RulesTenants})
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to marshal rules tenants")
+	}
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   RulesConfigMapName(opts.Name),
+			Labels: commonLabels(opts.Name),
+		},
+		BinaryData: map[string][]byte{
+			RulesTenantsFileName: c,
+		},
+	}, nil
+}
+
+// ExtractRulesTenants returns the tenants recorded in the rules configmap.
+func ExtractRulesTenants(cm *corev1.ConfigMap) ([]string, error) {
+	c, ok := cm.BinaryData[RulesTenantsFileName]
+	if !ok {
+		return nil, nil
+	}
+
+	var rt rulesTenantsJSON
+	if err := yaml.Unmarshal(c, &rt); err != nil {
+		return nil, kverrors.Wrap(err, "failed to unmarshal rules tenants")
+	}
+	return rt.Tenants, nil
+}
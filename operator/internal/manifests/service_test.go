@@ -44,6 +44,9 @@ func TestServicesMatchPorts(t *testing.T) {
 				IndexGateway: &lokiv1beta1.LokiComponentSpec{
 					Replicas: 1,
 				},
+				Ruler: &lokiv1beta1.LokiComponentSpec{
+					Replicas: 1,
+				},
 			},
 		},
 	}
@@ -98,6 +101,13 @@ func TestServicesMatchPorts(t *testing.T) {
 				NewIndexGatewayHTTPService(opt),
 			},
 		},
+		{
+			Containers: NewRulerStatefulSet(opt).Spec.Template.Spec.Containers,
+			Services: []*corev1.Service{
+				NewRulerGRPCService(opt),
+				NewRulerHTTPService(opt),
+			},
+		},
 	}
 
 	containerHasPort := func(containers []corev1.Container, port int32) bool {
@@ -163,6 +173,9 @@ func TestServicesMatchLabels(t *testing.T) {
 				IndexGateway: &lokiv1beta1.LokiComponentSpec{
 					Replicas: 1,
 				},
+				Ruler: &lokiv1beta1.LokiComponentSpec{
+					Replicas: 1,
+				},
 			},
 		},
 	}
@@ -217,6 +230,13 @@ func TestServicesMatchLabels(t *testing.T) {
 				NewIndexGatewayHTTPService(opt),
 			},
 		},
+		{
+			Object: NewRulerStatefulSet(opt),
+			Services: []*corev1.Service{
+				NewRulerGRPCService(opt),
+				NewRulerHTTPService(opt),
+			},
+		},
 	}
 
 	for _, tst := range table {
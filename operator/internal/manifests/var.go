@@ -62,6 +62,8 @@ const (
 	LabelIndexGatewayComponent string = "index-gateway"
 	// LabelGatewayComponent is the label value for the lokiStack-gateway component
 	LabelGatewayComponent string = "lokistack-gateway"
+	// LabelRulerComponent is the label value for the lokiStack-ruler component
+	LabelRulerComponent string = "ruler"
 )
 
 var (
@@ -141,6 +143,26 @@ func GatewayName(stackName string) string {
 	return fmt.Sprintf("%s-gateway", stackName)
 }
 
+// RulerName is the name of the ruler statefulset
+func RulerName(stackName string) string {
+	return fmt.Sprintf("%s-ruler", stackName)
+}
+
+// RulesConfigMapName is the name of the alerting and recording rules configmap
+func RulesConfigMapName(stackName string) string {
+	return fmt.Sprintf("%s-rules", stackName)
+}
+
+// RulerHTTPURL is the URL of the ruler HTTP API, which is served over TLS
+// when the service monitors TLS config is enabled.
+func RulerHTTPURL(stackName, namespace string, tls bool) string {
+	scheme := "http"
+	if tls {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, fqdn(serviceNameRulerHTTP(stackName), namespace), httpPort)
+}
+
 // PrometheusRuleName is the name of the loki-prometheus-rule
 func PrometheusRuleName(stackName string) string {
 	return fmt.Sprintf("%s-prometheus-rule", stackName)
@@ -194,6 +216,14 @@ func serviceNameIndexGatewayGRPC(stackName string) string {
 	return fmt.Sprintf("%s-index-gateway-grpc", stackName)
 }
 
+func serviceNameRulerHTTP(stackName string) string {
+	return fmt.Sprintf("%s-ruler-http", stackName)
+}
+
+func serviceNameRulerGRPC(stackName string) string {
+	return fmt.Sprintf("%s-ruler-grpc", stackName)
+}
+
 func serviceNameGatewayHTTP(stackName string) string {
 	return fmt.Sprintf("%s-gateway-http", stackName)
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/weaveworks/common/httpgrpc"
 
@@ -12,12 +13,27 @@ import (
 	"github.com/grafana/dskit/tenant"
 
 	"github.com/grafana/loki/pkg/loghttp/push"
+	"github.com/grafana/loki/pkg/logproto"
 	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/validation"
 )
 
+// requestParser parses the HTTP body of a push request.
+type requestParser func(logger log.Logger, userID string, r *http.Request, tenantsRetention push.TenantsRetention) (*logproto.PushRequest, error)
+
 // PushHandler reads a snappy-compressed proto from the HTTP body.
 func (d *Distributor) PushHandler(w http.ResponseWriter, r *http.Request) {
+	d.pushHandler(w, r, push.ParseRequest)
+}
+
+// OTLPPushHandler reads OpenTelemetry (OTLP) logs from the HTTP body.
+func (d *Distributor) OTLPPushHandler(w http.ResponseWriter, r *http.Request) {
+	d.pushHandler(w, r, func(logger log.Logger, userID string, r *http.Request, tenantsRetention push.TenantsRetention) (*logproto.PushRequest, error) {
+		return push.ParseOTLPRequest(logger, userID, r, tenantsRetention, d.validator.Limits)
+	})
+}
+
+func (d *Distributor) pushHandler(w http.ResponseWriter, r *http.Request, parseRequest requestParser) {
 	logger := util_log.WithContext(r.Context(), util_log.Logger)
 	tenantID, err := tenant.TenantID(r.Context())
 	if err != nil {
@@ -25,7 +41,7 @@ func (d *Distributor) PushHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	req, err := push.ParseRequest(logger, tenantID, r, d.tenantsRetention)
+	req, err := parseRequest(logger, tenantID, r, d.tenantsRetention)
 	if err != nil {
 		if d.tenantConfigs.LogPushRequest(tenantID) {
 			level.Debug(logger).Log(
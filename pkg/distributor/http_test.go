@@ -5,11 +5,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/grafana/dskit/flagext"
 	"github.com/grafana/dskit/services"
 	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
 
 	"github.com/grafana/loki/pkg/validation"
 )
@@ -57,3 +59,42 @@ func TestDistributorRingHandler(t *testing.T) {
 		require.NotContains(t, string(body), "<th>Instance ID</th>")
 	})
 }
+
+func TestOTLPPushHandler(t *testing.T) {
+	limits := &validation.Limits{}
+	flagext.DefaultValues(limits)
+	limits.RejectOldSamples = false
+
+	d := prepare(t, limits, nil, nil)
+	defer services.StopAndAwaitTerminated(context.Background(), d) //nolint:errcheck
+
+	for _, tc := range []struct {
+		name         string
+		contentType  string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "valid request",
+			contentType:  "application/json",
+			body:         `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"api"}}]},"scopeLogs":[{"logRecords":[{"timeUnixNano":"1570818238000000000","body":{"stringValue":"hello"}}]}]}]}`,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "invalid body",
+			contentType:  "application/json",
+			body:         `{"resourceLogs":`,
+			expectedCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/otlp/v1/logs", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			req = req.WithContext(user.InjectOrgID(req.Context(), "test"))
+
+			rec := httptest.NewRecorder()
+			d.OTLPPushHandler(rec, req)
+			require.Equal(t, tc.expectedCode, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -314,6 +314,14 @@ func (s *mockStore) LabelNamesForMetricName(ctx context.Context, userID string,
 	return nil, nil
 }
 
+func (s *mockStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	return nil, nil
+}
+
+func (s *mockStore) Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error) {
+	return nil, nil
+}
+
 func (s *mockStore) GetChunkFetcher(tm model.Time) *fetcher.Fetcher {
 	return nil
 }
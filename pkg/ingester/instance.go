@@ -441,15 +441,15 @@ func (i *instance) Label(ctx context.Context, req *logproto.LabelRequest, matche
 		}, nil
 	}
 
-	labels := make([]string, 0)
+	labels := util.NewUniqueStrings(0)
 	err := i.forMatchingStreams(ctx, matchers, nil, func(s *stream) error {
 		for _, label := range s.labels {
 			if req.Values && label.Name == req.Name {
-				labels = append(labels, label.Value)
+				labels.Add(label.Value)
 				continue
 			}
 			if !req.Values {
-				labels = append(labels, label.Name)
+				labels.Add(label.Name)
 			}
 		}
 		return nil
@@ -459,7 +459,7 @@ func (i *instance) Label(ctx context.Context, req *logproto.LabelRequest, matche
 	}
 
 	return &logproto.LabelResponse{
-		Values: labels,
+		Values: labels.Strings(),
 	}, nil
 }
 
@@ -521,6 +521,105 @@ func (i *instance) Series(ctx context.Context, req *logproto.SeriesRequest) (*lo
 	return &logproto.SeriesResponse{Series: series}, nil
 }
 
+// GetStats returns the number of streams, chunks, entries and bytes held in memory for the streams matching the request.
+// Chunks which have already been flushed are skipped since they are accounted for by the index store.
+func (i *instance) GetStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &logproto.IndexStatsResponse{}
+	from, through := req.From.Time(), req.Through.Time()
+
+	err = i.forMatchingStreams(ctx, matchers, nil, func(s *stream) error {
+		streamFrom, streamThrough := s.Bounds()
+		// consider the stream only if it overlaps the request time range
+		if !through.After(streamFrom) || from.After(streamThrough) {
+			return nil
+		}
+
+		res.Streams++
+		s.chunkMtx.RLock()
+		defer s.chunkMtx.RUnlock()
+		for _, chk := range s.chunks {
+			if !chk.flushed.IsZero() {
+				continue
+			}
+			chkFrom, chkThrough := chk.chunk.Bounds()
+			if !through.After(chkFrom) || from.After(chkThrough) {
+				continue
+			}
+			res.Chunks++
+			res.Entries += uint64(chk.chunk.Size())
+			res.Bytes += uint64(chk.chunk.UncompressedSize())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetVolume returns the volumes of the in-memory streams matching the request.
+// All the volumes are returned, the querier only keeps the largest ones once merged with the other sources.
+func (i *instance) GetVolume(ctx context.Context, req *logproto.VolumeRequest) (*logproto.VolumeResponse, error) {
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := map[string]*logproto.Volume{}
+	from, through := req.From.Time(), req.Through.Time()
+
+	err = i.forMatchingStreams(ctx, matchers, nil, func(s *stream) error {
+		streamFrom, streamThrough := s.Bounds()
+		// consider the stream only if it overlaps the request time range
+		if !through.After(streamFrom) || from.After(streamThrough) {
+			return nil
+		}
+
+		name, ok := logproto.VolumeName(s.labels, req.TargetLabels)
+		if !ok {
+			return nil
+		}
+		v, ok := volumes[name]
+		if !ok {
+			v = &logproto.Volume{Name: name}
+			volumes[name] = v
+		}
+
+		s.chunkMtx.RLock()
+		defer s.chunkMtx.RUnlock()
+		for _, chk := range s.chunks {
+			if !chk.flushed.IsZero() {
+				continue
+			}
+			chkFrom, chkThrough := chk.chunk.Bounds()
+			if !through.After(chkFrom) || from.After(chkThrough) {
+				continue
+			}
+			v.Entries += uint64(chk.chunk.Size())
+			v.Bytes += uint64(chk.chunk.UncompressedSize())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &logproto.VolumeResponse{
+		Volumes: make([]logproto.Volume, 0, len(volumes)),
+		Limit:   req.Limit,
+	}
+	for _, v := range volumes {
+		res.Volumes = append(res.Volumes, *v)
+	}
+	return res, nil
+}
+
 func (i *instance) numStreams() int {
 	return i.streams.Len()
 }
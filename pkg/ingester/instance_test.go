@@ -15,6 +15,7 @@ import (
 	"github.com/grafana/loki/pkg/storage/chunk"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
 
@@ -255,6 +256,19 @@ func Test_LabelQuery(t *testing.T) {
 			},
 			[]*labels.Matcher{m},
 		},
+		{
+			"label values - with matcher matching multiple streams",
+			&logproto.LabelRequest{
+				Name:   "job",
+				Values: true,
+				Start:  start,
+				End:    end,
+			},
+			logproto.LabelResponse{
+				Values: []string{"varlogs"},
+			},
+			[]*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "app", "test.*")},
+		},
 	}
 
 	for _, tc := range tests {
@@ -352,6 +366,89 @@ func Test_SeriesQuery(t *testing.T) {
 	}
 }
 
+func Test_VolumeQuery(t *testing.T) {
+	instance, currentTime, _ := setupTestStreams(t)
+	from := model.TimeFromUnixNano(currentTime.Add(-time.Hour).UnixNano())
+	through := model.TimeFromUnixNano(currentTime.Add(time.Hour).UnixNano())
+
+	tests := []struct {
+		name             string
+		req              *logproto.VolumeRequest
+		expectedResponse *logproto.VolumeResponse
+	}{
+		{
+			"by series",
+			&logproto.VolumeRequest{
+				From:     from,
+				Through:  through,
+				Matchers: `{job="varlogs"}`,
+				Limit:    100,
+			},
+			&logproto.VolumeResponse{
+				Volumes: []logproto.Volume{
+					{Name: `{app="test", job="varlogs"}`, Bytes: 35, Entries: 5},
+					{Name: `{app="test2", job="varlogs"}`, Bytes: 35, Entries: 5},
+				},
+				Limit: 100,
+			},
+		},
+		{
+			"by target labels",
+			&logproto.VolumeRequest{
+				From:         from,
+				Through:      through,
+				Matchers:     `{job="varlogs"}`,
+				Limit:        100,
+				TargetLabels: []string{"job"},
+			},
+			&logproto.VolumeResponse{
+				Volumes: []logproto.Volume{
+					{Name: `{job="varlogs"}`, Bytes: 70, Entries: 10},
+				},
+				Limit: 100,
+			},
+		},
+		{
+			// the limit only applies once the volumes of all the ingesters and the store are merged.
+			"with limit",
+			&logproto.VolumeRequest{
+				From:     from,
+				Through:  through,
+				Matchers: `{job="varlogs"}`,
+				Limit:    1,
+			},
+			&logproto.VolumeResponse{
+				Volumes: []logproto.Volume{
+					{Name: `{app="test", job="varlogs"}`, Bytes: 35, Entries: 5},
+					{Name: `{app="test2", job="varlogs"}`, Bytes: 35, Entries: 5},
+				},
+				Limit: 1,
+			},
+		},
+		{
+			"non overlapping request",
+			&logproto.VolumeRequest{
+				From:     through,
+				Through:  through.Add(time.Hour),
+				Matchers: `{job="varlogs"}`,
+				Limit:    100,
+			},
+			&logproto.VolumeResponse{
+				Volumes: []logproto.Volume{},
+				Limit:   100,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := instance.GetVolume(context.Background(), tc.req)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResponse.Limit, resp.Limit)
+			require.ElementsMatch(t, tc.expectedResponse.Volumes, resp.Volumes)
+		})
+	}
+}
+
 func entries(n int, t time.Time) []logproto.Entry {
 	result := make([]logproto.Entry, 0, n)
 	for i := 0; i < n; i++ {
@@ -28,6 +28,7 @@ const (
 	labelValuesPath = "/loki/api/v1/label/%s/values"
 	seriesPath      = "/loki/api/v1/series"
 	tailPath        = "/loki/api/v1/tail"
+	volumePath      = "/loki/api/v1/index/volume"
 )
 
 var userAgent = fmt.Sprintf("loki-logcli/%s", build.Version)
@@ -40,6 +41,7 @@ type Client interface {
 	ListLabelValues(name string, quiet bool, start, end time.Time) (*loghttp.LabelResponse, error)
 	Series(matchers []string, start, end time.Time, quiet bool) (*loghttp.SeriesResponse, error)
 	LiveTailQueryConn(queryStr string, delayFor time.Duration, limit int, start time.Time, quiet bool) (*websocket.Conn, error)
+	GetVolume(queryStr string, start, end time.Time, limit int, targetLabels []string, quiet bool) (*logproto.VolumeResponse, error)
 	GetOrgID() string
 }
 
@@ -149,6 +151,24 @@ func (c *DefaultClient) LiveTailQueryConn(queryStr string, delayFor time.Duratio
 	return c.wsConnect(tailPath, params.Encode(), quiet)
 }
 
+// GetVolume uses the /loki/api/v1/index/volume endpoint to get the largest volumes of logs matching the query
+func (c *DefaultClient) GetVolume(queryStr string, start, end time.Time, limit int, targetLabels []string, quiet bool) (*logproto.VolumeResponse, error) {
+	params := util.NewQueryStringBuilder()
+	params.SetString("query", queryStr)
+	params.SetInt("start", start.UnixNano())
+	params.SetInt("end", end.UnixNano())
+	params.SetInt32("limit", limit)
+	if len(targetLabels) > 0 {
+		params.SetString("targetLabels", strings.Join(targetLabels, ","))
+	}
+
+	var volumeResponse logproto.VolumeResponse
+	if err := c.doRequest(volumePath, params.Encode(), quiet, &volumeResponse); err != nil {
+		return nil, err
+	}
+	return &volumeResponse, nil
+}
+
 func (c *DefaultClient) GetOrgID() string {
 	return c.OrgID
 }
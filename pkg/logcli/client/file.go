@@ -178,6 +178,10 @@ func (f *FileClient) LiveTailQueryConn(queryStr string, delayFor time.Duration,
 	return nil, fmt.Errorf("LiveTailQuery: %w", ErrNotSupported)
 }
 
+func (f *FileClient) GetVolume(queryStr string, start, end time.Time, limit int, targetLabels []string, quiet bool) (*logproto.VolumeResponse, error) {
+	return nil, fmt.Errorf("GetVolume: %w", ErrNotSupported)
+}
+
 func (f *FileClient) GetOrgID() string {
 	return f.orgID
 }
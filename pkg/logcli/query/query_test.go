@@ -554,6 +554,10 @@ func (t *testQueryClient) LiveTailQueryConn(queryStr string, delayFor time.Durat
 	panic("implement me")
 }
 
+func (t *testQueryClient) GetVolume(queryStr string, start, end time.Time, limit int, targetLabels []string, quiet bool) (*logproto.VolumeResponse, error) {
+	panic("implement me")
+}
+
 func (t *testQueryClient) GetOrgID() string {
 	panic("implement me")
 }
@@ -0,0 +1,48 @@
+package volume
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/grafana/loki/pkg/logcli/client"
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+// VolumeQuery contains all necessary fields to execute volume queries and print out the results
+type VolumeQuery struct {
+	QueryString  string
+	Start        time.Time
+	End          time.Time
+	Limit        int
+	TargetLabels []string
+	Quiet        bool
+}
+
+// DoVolume prints out the volume results
+func (q *VolumeQuery) DoVolume(c client.Client) {
+	q.printVolumes(os.Stdout, q.GetVolume(c))
+}
+
+// GetVolume returns the largest volumes matching the query
+func (q *VolumeQuery) GetVolume(c client.Client) []logproto.Volume {
+	volumeResponse, err := c.GetVolume(q.QueryString, q.Start, q.End, q.Limit, q.TargetLabels, q.Quiet)
+	if err != nil {
+		log.Fatalf("Error doing request: %+v", err)
+	}
+	return volumeResponse.Volumes
+}
+
+func (q *VolumeQuery) printVolumes(out io.Writer, volumes []logproto.Volume) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Name\tBytes\tEntries\n")
+	for _, v := range volumes {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", v.Name, humanize.Bytes(v.Bytes), v.Entries)
+	}
+	w.Flush()
+}
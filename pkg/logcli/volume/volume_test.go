@@ -0,0 +1,25 @@
+package volume
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+func TestPrintVolumes(t *testing.T) {
+	q := &VolumeQuery{}
+	out := &bytes.Buffer{}
+
+	q.printVolumes(out, []logproto.Volume{
+		{Name: `{app="foo"}`, Bytes: 2 << 20, Entries: 2000},
+		{Name: `{app="bar", namespace="ns1"}`, Bytes: 512, Entries: 3},
+	})
+
+	require.Equal(t, `Name                          Bytes   Entries
+{app="foo"}                   2.1 MB  2000
+{app="bar", namespace="ns1"}  512 B   3
+`, out.String())
+}
@@ -0,0 +1,67 @@
+package loghttp
+
+import (
+	"net/http"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/syntax"
+)
+
+// ParseIndexStatsQuery parses an IndexStatsRequest from an http request.
+// The query parameter must be a stream selector.
+func ParseIndexStatsQuery(r *http.Request) (*logproto.IndexStatsRequest, error) {
+	start, end, err := bounds(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if end.Before(start) {
+		return nil, errEndBeforeStart
+	}
+
+	req := &logproto.IndexStatsRequest{
+		From:     model.TimeFromUnixNano(start.UnixNano()),
+		Through:  model.TimeFromUnixNano(end.UnixNano()),
+		Matchers: query(r),
+	}
+
+	if _, err := syntax.ParseMatchers(req.Matchers); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ParseVolumeQuery parses a VolumeRequest from an http request.
+// The query parameter must be a stream selector and targetLabels an optional comma separated list of label names.
+func ParseVolumeQuery(r *http.Request) (*logproto.VolumeRequest, error) {
+	start, end, err := bounds(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if end.Before(start) {
+		return nil, errEndBeforeStart
+	}
+
+	limit, err := limit(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &logproto.VolumeRequest{
+		From:         model.TimeFromUnixNano(start.UnixNano()),
+		Through:      model.TimeFromUnixNano(end.UnixNano()),
+		Matchers:     query(r),
+		Limit:        int32(limit),
+		TargetLabels: targetLabels(r),
+	}
+
+	if _, err := syntax.ParseMatchers(req.Matchers); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,104 @@
+package loghttp
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logproto"
+)
+
+func TestParseIndexStatsQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		r       *http.Request
+		want    *logproto.IndexStatsRequest
+		wantErr bool
+	}{
+		{"missing query", &http.Request{URL: mustParseURL(`?start=2017-06-10T21:42:24.760738998Z&end=2017-07-10T21:42:24.760738998Z`)}, nil, true},
+		{"bad query", &http.Request{URL: mustParseURL(`?query={foo="bar"} |= "buzz"`)}, nil, true},
+		{"bad end", &http.Request{URL: mustParseURL(`?query={foo="bar"}&start=2017-06-10T21:42:24.760738998Z&end=2017-06-10T20:42:24.760738998Z`)}, nil, true},
+		{
+			"good",
+			&http.Request{URL: mustParseURL(`?query={foo="bar"}&start=2017-06-10T21:42:24.760738998Z&end=2017-07-10T21:42:24.760738998Z`)},
+			&logproto.IndexStatsRequest{
+				From:     model.TimeFromUnixNano(time.Date(2017, 06, 10, 21, 42, 24, 760738998, time.UTC).UnixNano()),
+				Through:  model.TimeFromUnixNano(time.Date(2017, 07, 10, 21, 42, 24, 760738998, time.UTC).UnixNano()),
+				Matchers: `{foo="bar"}`,
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.r.ParseForm()
+			require.Nil(t, err)
+			got, err := ParseIndexStatsQuery(tt.r)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseVolumeQuery(t *testing.T) {
+	t.Parallel()
+
+	from := model.TimeFromUnixNano(time.Date(2017, 06, 10, 21, 42, 24, 760738998, time.UTC).UnixNano())
+	through := model.TimeFromUnixNano(time.Date(2017, 07, 10, 21, 42, 24, 760738998, time.UTC).UnixNano())
+
+	tests := []struct {
+		name    string
+		r       *http.Request
+		want    *logproto.VolumeRequest
+		wantErr bool
+	}{
+		{"missing query", &http.Request{URL: mustParseURL(`?start=2017-06-10T21:42:24.760738998Z&end=2017-07-10T21:42:24.760738998Z`)}, nil, true},
+		{"bad query", &http.Request{URL: mustParseURL(`?query={foo="bar"} |= "buzz"`)}, nil, true},
+		{"bad limit", &http.Request{URL: mustParseURL(`?query={foo="bar"}&limit=-1`)}, nil, true},
+		{
+			"default limit",
+			&http.Request{URL: mustParseURL(`?query={foo="bar"}&start=2017-06-10T21:42:24.760738998Z&end=2017-07-10T21:42:24.760738998Z`)},
+			&logproto.VolumeRequest{
+				From:     from,
+				Through:  through,
+				Matchers: `{foo="bar"}`,
+				Limit:    100,
+			},
+			false,
+		},
+		{
+			"target labels",
+			&http.Request{URL: mustParseURL(`?query={foo="bar"}&start=2017-06-10T21:42:24.760738998Z&end=2017-07-10T21:42:24.760738998Z&limit=10&targetLabels=app,namespace`)},
+			&logproto.VolumeRequest{
+				From:         from,
+				Through:      through,
+				Matchers:     `{foo="bar"}`,
+				Limit:        10,
+				TargetLabels: []string{"app", "namespace"},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.r.ParseForm()
+			require.Nil(t, err)
+			got, err := ParseVolumeQuery(tt.r)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/syntax"
 )
 
 // LabelResponse represents the http json response to a label query
@@ -69,11 +70,20 @@ func (l LabelSet) String() string {
 }
 
 // ParseLabelQuery parses a LabelRequest request from an http request.
+// The optional query parameter must be a stream selector, it restricts the result to matching streams.
 func ParseLabelQuery(r *http.Request) (*logproto.LabelRequest, error) {
 	name, ok := mux.Vars(r)["name"]
 	req := &logproto.LabelRequest{
 		Values: ok,
 		Name:   name,
+		Query:  query(r),
+	}
+
+	if req.Query != "" {
+		// ensure the selector is valid before fanning out to ingesters/store.
+		if _, err := syntax.ParseMatchers(req.Query); err != nil {
+			return nil, err
+		}
 	}
 
 	start, end, err := bounds(r)
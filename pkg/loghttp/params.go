@@ -34,6 +34,14 @@ func query(r *http.Request) string {
 	return r.Form.Get("query")
 }
 
+func targetLabels(r *http.Request) []string {
+	lbls := strings.Split(r.Form.Get("targetLabels"), ",")
+	if len(lbls) == 1 && lbls[0] == "" {
+		return nil
+	}
+	return lbls
+}
+
 func ts(r *http.Request) (time.Time, error) {
 	return parseTimestamp(r.Form.Get("time"), time.Now())
 }
@@ -0,0 +1,273 @@
+package push
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-logfmt/logfmt"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/util/strutil"
+
+	"github.com/grafana/loki/pkg/loghttp/push/otlp"
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/util"
+)
+
+const (
+	applicationProtobuf = "application/x-protobuf"
+
+	// otlpUnknownService is the service name used by OpenTelemetry SDKs when
+	// none is configured. It is used as stream label for resources without
+	// any attribute mapped to a label, since a stream needs at least one label.
+	otlpUnknownService = "unknown_service"
+)
+
+// OTLPLimits are the per tenant limits used to convert OTLP logs.
+type OTLPLimits interface {
+	OTLPResourceAttributesAsLabels(userID string) []string
+}
+
+// ParseOTLPRequest reads an OTLP/HTTP logs export request, in either protobuf
+// or JSON encoding, and converts it to a push request.
+func ParseOTLPRequest(logger log.Logger, userID string, r *http.Request, tenantsRetention TenantsRetention, limits OTLPLimits) (*logproto.PushRequest, error) {
+	var body io.Reader
+	bodySize := util.NewSizeReader(r.Body)
+	contentEncoding := r.Header.Get(contentEnc)
+	switch contentEncoding {
+	case "":
+		body = bodySize
+	case "gzip":
+		gzipReader, err := gzip.NewReader(bodySize)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	case "deflate":
+		flateReader := flate.NewReader(bodySize)
+		defer flateReader.Close()
+		body = flateReader
+	default:
+		return nil, fmt.Errorf("Content-Encoding %q not supported", contentEncoding)
+	}
+
+	contentType, _ /* params */, err := mime.ParseMediaType(r.Header.Get(contentType))
+	if err != nil {
+		return nil, err
+	}
+
+	var otlpReq otlp.ExportLogsServiceRequest
+	switch contentType {
+	case applicationJSON:
+		if err := otlp.DecodeJSON(body, &otlpReq); err != nil {
+			return nil, err
+		}
+	case applicationProtobuf:
+		if err := util.ParseProtoReader(r.Context(), body, 0, math.MaxInt32, &otlpReq, util.NoCompression); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("Content-Type %q not supported", contentType)
+	}
+
+	req := OTLPToPushRequest(&otlpReq, limits.OTLPResourceAttributesAsLabels(userID), time.Now())
+	if err := recordPushRequest(logger, userID, r, req, tenantsRetention, contentType, contentEncoding, bodySize.Size()); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// OTLPToPushRequest converts OTLP logs to a push request.
+// Resource attributes listed in attributesAsLabels become stream labels, every
+// other attribute is added as logfmt to the log line together with the
+// severity and the trace and span ids. now is used as timestamp for records
+// without any.
+func OTLPToPushRequest(req *otlp.ExportLogsServiceRequest, attributesAsLabels []string, now time.Time) *logproto.PushRequest {
+	asLabel := make(map[string]struct{}, len(attributesAsLabels))
+	for _, name := range attributesAsLabels {
+		if name != "" {
+			asLabel[name] = struct{}{}
+		}
+	}
+
+	var (
+		streams []logproto.Stream
+		index   = map[string]int{}
+	)
+	for _, rl := range req.ResourceLogs {
+		var (
+			lbs        labels.Labels
+			attributes []*otlp.KeyValue
+		)
+		if rl.Resource != nil {
+			for _, kv := range rl.Resource.Attributes {
+				if _, ok := asLabel[kv.Key]; !ok {
+					attributes = append(attributes, kv)
+					continue
+				}
+				if value := anyValueString(kv.Value); value != "" {
+					lbs = append(lbs, labels.Label{Name: strutil.SanitizeLabelName(kv.Key), Value: value})
+				}
+			}
+		}
+		if len(lbs) == 0 {
+			lbs = append(lbs, labels.Label{Name: "service_name", Value: otlpUnknownService})
+		}
+		stream := labels.New(lbs...).String()
+
+		i, ok := index[stream]
+		if !ok {
+			i = len(streams)
+			index[stream] = i
+			streams = append(streams, logproto.Stream{Labels: stream})
+		}
+
+		for _, sl := range append(rl.ScopeLogs, rl.InstrumentationLibraryLogs...) {
+			for _, record := range sl.LogRecords {
+				streams[i].Entries = append(streams[i].Entries, logproto.Entry{
+					Timestamp: otlpTimestamp(record, now),
+					Line:      otlpLine(record, attributes),
+				})
+			}
+		}
+	}
+
+	return &logproto.PushRequest{Streams: streams}
+}
+
+func otlpTimestamp(record *otlp.LogRecord, now time.Time) time.Time {
+	switch {
+	case record.TimeUnixNano != 0:
+		return time.Unix(0, int64(record.TimeUnixNano))
+	case record.ObservedTimeUnixNano != 0:
+		return time.Unix(0, int64(record.ObservedTimeUnixNano))
+	}
+	return now
+}
+
+// otlpLine renders the record body followed by its metadata as logfmt.
+func otlpLine(record *otlp.LogRecord, resourceAttributes []*otlp.KeyValue) string {
+	var buf bytes.Buffer
+	buf.WriteString(anyValueString(record.Body))
+
+	enc := logfmt.NewEncoder(&buf)
+	if buf.Len() > 0 {
+		// The encoder only separates its own key/value pairs.
+		buf.WriteByte(' ')
+	}
+	encode := func(key string, value string) {
+		// Keys made only of invalid logfmt characters are dropped.
+		_ = enc.EncodeKeyval(key, value)
+	}
+
+	if severity := otlpSeverity(record); severity != "" {
+		encode("severity", severity)
+	}
+	if len(record.TraceId) > 0 {
+		encode("trace_id", hex.EncodeToString(record.TraceId))
+	}
+	if len(record.SpanId) > 0 {
+		encode("span_id", hex.EncodeToString(record.SpanId))
+	}
+	for _, kv := range resourceAttributes {
+		encode(kv.Key, anyValueString(kv.Value))
+	}
+	for _, kv := range record.Attributes {
+		encode(kv.Key, anyValueString(kv.Value))
+	}
+
+	return string(bytes.TrimRight(buf.Bytes(), " "))
+}
+
+// otlpSeverity returns the severity text of the record, or the short name of
+// its severity number when no text is set.
+func otlpSeverity(record *otlp.LogRecord) string {
+	if record.SeverityText != "" {
+		return record.SeverityText
+	}
+	switch n := record.SeverityNumber; {
+	case n >= 1 && n <= 4:
+		return "TRACE"
+	case n >= 5 && n <= 8:
+		return "DEBUG"
+	case n >= 9 && n <= 12:
+		return "INFO"
+	case n >= 13 && n <= 16:
+		return "WARN"
+	case n >= 17 && n <= 20:
+		return "ERROR"
+	case n >= 21 && n <= 24:
+		return "FATAL"
+	}
+	return ""
+}
+
+// anyValueString renders an OTLP value as string. Arrays and key/value lists
+// are rendered as JSON.
+func anyValueString(v *otlp.AnyValue) string {
+	if v == nil {
+		return ""
+	}
+	switch value := v.Value.(type) {
+	case *otlp.AnyValue_StringValue:
+		return value.StringValue
+	case *otlp.AnyValue_BoolValue:
+		return strconv.FormatBool(value.BoolValue)
+	case *otlp.AnyValue_IntValue:
+		return strconv.FormatInt(value.IntValue, 10)
+	case *otlp.AnyValue_DoubleValue:
+		return strconv.FormatFloat(value.DoubleValue, 'f', -1, 64)
+	case *otlp.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(value.BytesValue)
+	case *otlp.AnyValue_ArrayValue, *otlp.AnyValue_KvlistValue:
+		b, err := json.Marshal(anyValueInterface(v))
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	return ""
+}
+
+func anyValueInterface(v *otlp.AnyValue) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch value := v.Value.(type) {
+	case *otlp.AnyValue_StringValue:
+		return value.StringValue
+	case *otlp.AnyValue_BoolValue:
+		return value.BoolValue
+	case *otlp.AnyValue_IntValue:
+		return value.IntValue
+	case *otlp.AnyValue_DoubleValue:
+		return value.DoubleValue
+	case *otlp.AnyValue_BytesValue:
+		return value.BytesValue
+	case *otlp.AnyValue_ArrayValue:
+		values := make([]interface{}, 0, len(value.ArrayValue.GetValues()))
+		for _, v := range value.ArrayValue.GetValues() {
+			values = append(values, anyValueInterface(v))
+		}
+		return values
+	case *otlp.AnyValue_KvlistValue:
+		values := make(map[string]interface{}, len(value.KvlistValue.GetValues()))
+		for _, kv := range value.KvlistValue.GetValues() {
+			values[kv.Key] = anyValueInterface(kv.Value)
+		}
+		return values
+	}
+	return nil
+}
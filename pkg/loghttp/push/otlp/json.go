@@ -0,0 +1,209 @@
+package otlp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// DecodeJSON decodes an OTLP/HTTP JSON encoded logs export request.
+//
+// The OTLP JSON encoding follows the protobuf JSON mapping with a few
+// exceptions: trace and span ids are hex encoded instead of base64, and
+// 64-bit integers can be sent either as numbers or as strings.
+func DecodeJSON(r io.Reader, req *ExportLogsServiceRequest) error {
+	var in jsonExportLogsServiceRequest
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return err
+	}
+	return in.to(req)
+}
+
+type jsonExportLogsServiceRequest struct {
+	ResourceLogs []jsonResourceLogs `json:"resourceLogs"`
+}
+
+type jsonResourceLogs struct {
+	Resource                   *jsonResource   `json:"resource"`
+	ScopeLogs                  []jsonScopeLogs `json:"scopeLogs"`
+	InstrumentationLibraryLogs []jsonScopeLogs `json:"instrumentationLibraryLogs"`
+	SchemaURL                  string          `json:"schemaUrl"`
+}
+
+type jsonResource struct {
+	Attributes             []jsonKeyValue `json:"attributes"`
+	DroppedAttributesCount uint32         `json:"droppedAttributesCount"`
+}
+
+type jsonScopeLogs struct {
+	Scope *jsonScope `json:"scope"`
+	// InstrumentationLibrary is the pre v0.15.0 name of Scope.
+	InstrumentationLibrary *jsonScope      `json:"instrumentationLibrary"`
+	LogRecords             []jsonLogRecord `json:"logRecords"`
+	SchemaURL              string          `json:"schemaUrl"`
+}
+
+type jsonScope struct {
+	Name                   string         `json:"name"`
+	Version                string         `json:"version"`
+	Attributes             []jsonKeyValue `json:"attributes"`
+	DroppedAttributesCount uint32         `json:"droppedAttributesCount"`
+}
+
+type jsonLogRecord struct {
+	TimeUnixNano           jsonUint64     `json:"timeUnixNano"`
+	ObservedTimeUnixNano   jsonUint64     `json:"observedTimeUnixNano"`
+	SeverityNumber         jsonUint64     `json:"severityNumber"`
+	SeverityText           string         `json:"severityText"`
+	Body                   *jsonAnyValue  `json:"body"`
+	Attributes             []jsonKeyValue `json:"attributes"`
+	DroppedAttributesCount uint32         `json:"droppedAttributesCount"`
+	Flags                  uint32         `json:"flags"`
+	TraceID                string         `json:"traceId"`
+	SpanID                 string         `json:"spanId"`
+}
+
+type jsonKeyValue struct {
+	Key   string        `json:"key"`
+	Value *jsonAnyValue `json:"value"`
+}
+
+type jsonAnyValue struct {
+	StringValue *string     `json:"stringValue"`
+	BoolValue   *bool       `json:"boolValue"`
+	IntValue    *jsonUint64 `json:"intValue"`
+	DoubleValue *float64    `json:"doubleValue"`
+	ArrayValue  *struct {
+		Values []jsonAnyValue `json:"values"`
+	} `json:"arrayValue"`
+	KvlistValue *struct {
+		Values []jsonKeyValue `json:"values"`
+	} `json:"kvlistValue"`
+	BytesValue []byte `json:"bytesValue"`
+}
+
+// jsonUint64 holds a 64-bit integer sent either as a JSON number or string.
+type jsonUint64 uint64
+
+func (v *jsonUint64) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		*v = jsonUint64(u)
+		return nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s", string(b))
+	}
+	*v = jsonUint64(i)
+	return nil
+}
+
+func (in jsonExportLogsServiceRequest) to(req *ExportLogsServiceRequest) error {
+	req.Reset()
+	req.ResourceLogs = make([]*ResourceLogs, 0, len(in.ResourceLogs))
+	for _, rl := range in.ResourceLogs {
+		out := &ResourceLogs{SchemaUrl: rl.SchemaURL}
+		if rl.Resource != nil {
+			out.Resource = &Resource{
+				Attributes:             toKeyValues(rl.Resource.Attributes),
+				DroppedAttributesCount: rl.Resource.DroppedAttributesCount,
+			}
+		}
+		for _, sl := range append(rl.ScopeLogs, rl.InstrumentationLibraryLogs...) {
+			scopeLogs, err := sl.to()
+			if err != nil {
+				return err
+			}
+			out.ScopeLogs = append(out.ScopeLogs, scopeLogs)
+		}
+		req.ResourceLogs = append(req.ResourceLogs, out)
+	}
+	return nil
+}
+
+func (in jsonScopeLogs) to() (*ScopeLogs, error) {
+	out := &ScopeLogs{SchemaUrl: in.SchemaURL}
+	scope := in.Scope
+	if scope == nil {
+		scope = in.InstrumentationLibrary
+	}
+	if scope != nil {
+		out.Scope = &InstrumentationScope{
+			Name:                   scope.Name,
+			Version:                scope.Version,
+			Attributes:             toKeyValues(scope.Attributes),
+			DroppedAttributesCount: scope.DroppedAttributesCount,
+		}
+	}
+	out.LogRecords = make([]*LogRecord, 0, len(in.LogRecords))
+	for _, lr := range in.LogRecords {
+		traceID, err := hex.DecodeString(lr.TraceID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trace id %q: %w", lr.TraceID, err)
+		}
+		spanID, err := hex.DecodeString(lr.SpanID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid span id %q: %w", lr.SpanID, err)
+		}
+		out.LogRecords = append(out.LogRecords, &LogRecord{
+			TimeUnixNano:           uint64(lr.TimeUnixNano),
+			ObservedTimeUnixNano:   uint64(lr.ObservedTimeUnixNano),
+			SeverityNumber:         int32(lr.SeverityNumber),
+			SeverityText:           lr.SeverityText,
+			Body:                   lr.Body.to(),
+			Attributes:             toKeyValues(lr.Attributes),
+			DroppedAttributesCount: lr.DroppedAttributesCount,
+			Flags:                  lr.Flags,
+			TraceId:                traceID,
+			SpanId:                 spanID,
+		})
+	}
+	return out, nil
+}
+
+func (in *jsonAnyValue) to() *AnyValue {
+	if in == nil {
+		return nil
+	}
+	switch {
+	case in.StringValue != nil:
+		return &AnyValue{Value: &AnyValue_StringValue{StringValue: *in.StringValue}}
+	case in.BoolValue != nil:
+		return &AnyValue{Value: &AnyValue_BoolValue{BoolValue: *in.BoolValue}}
+	case in.IntValue != nil:
+		return &AnyValue{Value: &AnyValue_IntValue{IntValue: int64(*in.IntValue)}}
+	case in.DoubleValue != nil:
+		return &AnyValue{Value: &AnyValue_DoubleValue{DoubleValue: *in.DoubleValue}}
+	case in.ArrayValue != nil:
+		values := make([]*AnyValue, 0, len(in.ArrayValue.Values))
+		for i := range in.ArrayValue.Values {
+			values = append(values, in.ArrayValue.Values[i].to())
+		}
+		return &AnyValue{Value: &AnyValue_ArrayValue{ArrayValue: &ArrayValue{Values: values}}}
+	case in.KvlistValue != nil:
+		return &AnyValue{Value: &AnyValue_KvlistValue{KvlistValue: &KeyValueList{Values: toKeyValues(in.KvlistValue.Values)}}}
+	case in.BytesValue != nil:
+		return &AnyValue{Value: &AnyValue_BytesValue{BytesValue: in.BytesValue}}
+	}
+	return &AnyValue{}
+}
+
+func toKeyValues(in []jsonKeyValue) []*KeyValue {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*KeyValue, 0, len(in))
+	for _, kv := range in {
+		out = append(out, &KeyValue{Key: kv.Key, Value: kv.Value.to()})
+	}
+	return out
+}
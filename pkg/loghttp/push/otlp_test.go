@@ -0,0 +1,164 @@
+package push
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/loghttp/push/otlp"
+	"github.com/grafana/loki/pkg/logproto"
+	util_log "github.com/grafana/loki/pkg/util/log"
+)
+
+type fakeOTLPLimits []string
+
+func (l fakeOTLPLimits) OTLPResourceAttributesAsLabels(_ string) []string { return l }
+
+func stringValue(s string) *otlp.AnyValue {
+	return &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: s}}
+}
+
+func TestOTLPToPushRequest(t *testing.T) {
+	now := time.Unix(0, 1000)
+	req := &otlp.ExportLogsServiceRequest{
+		ResourceLogs: []*otlp.ResourceLogs{
+			{
+				Resource: &otlp.Resource{Attributes: []*otlp.KeyValue{
+					{Key: "service.name", Value: stringValue("checkout")},
+					{Key: "host.name", Value: stringValue("node-1")},
+				}},
+				ScopeLogs: []*otlp.ScopeLogs{{LogRecords: []*otlp.LogRecord{
+					{
+						TimeUnixNano:   1,
+						SeverityNumber: 17,
+						Body:           stringValue("payment failed"),
+						TraceId:        []byte{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c},
+						SpanId:         []byte{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74},
+						Attributes: []*otlp.KeyValue{
+							{Key: "order.id", Value: &otlp.AnyValue{Value: &otlp.AnyValue_IntValue{IntValue: 42}}},
+							{Key: "retry", Value: &otlp.AnyValue{Value: &otlp.AnyValue_BoolValue{BoolValue: true}}},
+						},
+					},
+				}}},
+			},
+			{
+				Resource: &otlp.Resource{Attributes: []*otlp.KeyValue{
+					{Key: "service.name", Value: stringValue("checkout")},
+				}},
+				InstrumentationLibraryLogs: []*otlp.ScopeLogs{{LogRecords: []*otlp.LogRecord{
+					{
+						ObservedTimeUnixNano: 2,
+						SeverityText:         "debug",
+						Body:                 &otlp.AnyValue{Value: &otlp.AnyValue_KvlistValue{KvlistValue: &otlp.KeyValueList{Values: []*otlp.KeyValue{{Key: "msg", Value: stringValue("hello")}}}}},
+					},
+				}}},
+			},
+			{
+				ScopeLogs: []*otlp.ScopeLogs{{LogRecords: []*otlp.LogRecord{
+					{
+						Attributes: []*otlp.KeyValue{{Key: "msg", Value: stringValue("no body here")}},
+					},
+				}}},
+			},
+		},
+	}
+
+	actual := OTLPToPushRequest(req, []string{"service.name", "k8s.namespace.name"}, now)
+	require.Equal(t, &logproto.PushRequest{Streams: []logproto.Stream{
+		{
+			Labels: `{service_name="checkout"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(0, 1), Line: `payment failed severity=ERROR trace_id=5b8efff798038103d269b633813fc60c span_id=eee19b7ec3c1b174 host.name=node-1 order.id=42 retry=true`},
+				{Timestamp: time.Unix(0, 2), Line: `{"msg":"hello"} severity=debug`},
+			},
+		},
+		{
+			Labels: `{service_name="unknown_service"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: now, Line: `msg="no body here"`},
+			},
+		},
+	}}, actual)
+}
+
+func TestParseOTLPRequest(t *testing.T) {
+	protoReq := &otlp.ExportLogsServiceRequest{
+		ResourceLogs: []*otlp.ResourceLogs{{
+			Resource: &otlp.Resource{Attributes: []*otlp.KeyValue{{Key: "service.name", Value: stringValue("api")}}},
+			ScopeLogs: []*otlp.ScopeLogs{{LogRecords: []*otlp.LogRecord{{
+				TimeUnixNano: 1570818238000000000,
+				SeverityText: "INFO",
+				Body:         stringValue("request served"),
+				TraceId:      []byte{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c},
+			}}}},
+		}},
+	}
+	protoBody, err := protoReq.Marshal()
+	require.NoError(t, err)
+
+	jsonBody := `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"api"}}]},
+		"scopeLogs":[{"logRecords":[{"timeUnixNano":"1570818238000000000","severityText":"INFO",
+		"body":{"stringValue":"request served"},"traceId":"5b8efff798038103d269b633813fc60c"}]}]}]}`
+
+	expected := &logproto.PushRequest{Streams: []logproto.Stream{{
+		Labels: `{service_name="api"}`,
+		Entries: []logproto.Entry{
+			{Timestamp: time.Unix(0, 1570818238000000000), Line: `request served severity=INFO trace_id=5b8efff798038103d269b633813fc60c`},
+		},
+	}}}
+
+	for _, tc := range []struct {
+		name            string
+		body            string
+		contentType     string
+		contentEncoding string
+		valid           bool
+	}{
+		{name: "protobuf", body: string(protoBody), contentType: `application/x-protobuf`, valid: true},
+		{name: "gzipped protobuf", body: gzipString(string(protoBody)), contentType: `application/x-protobuf`, contentEncoding: `gzip`, valid: true},
+		{name: "json", body: jsonBody, contentType: `application/json`, valid: true},
+		{name: "deflated json", body: deflateString(jsonBody), contentType: `application/json; charset=utf-8`, contentEncoding: `deflate`, valid: true},
+		{name: "invalid json", body: `{"resourceLogs":`, contentType: `application/json`},
+		{name: "invalid trace id", body: `{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"traceId":"xyz"}]}]}]}`, contentType: `application/json`},
+		{name: "missing content type", body: jsonBody},
+		{name: "unsupported content type", body: jsonBody, contentType: `text/plain`},
+		{name: "unsupported content encoding", body: string(protoBody), contentType: `application/x-protobuf`, contentEncoding: `snappy`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/otlp/v1/logs", bytes.NewReader([]byte(tc.body)))
+			if tc.contentType != "" {
+				request.Header.Add("Content-Type", tc.contentType)
+			}
+			if tc.contentEncoding != "" {
+				request.Header.Add("Content-Encoding", tc.contentEncoding)
+			}
+
+			data, err := ParseOTLPRequest(util_log.Logger, "fake", request, nil, fakeOTLPLimits{"service.name"})
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, expected, data)
+		})
+	}
+}
+
+func TestOTLPDecodeJSONInstrumentationLibrary(t *testing.T) {
+	var req otlp.ExportLogsServiceRequest
+	err := otlp.DecodeJSON(strings.NewReader(`{"resourceLogs":[{"instrumentationLibraryLogs":[{
+		"instrumentationLibrary":{"name":"lib"},"logRecords":[{"timeUnixNano":5,"severityNumber":9,
+		"body":{"arrayValue":{"values":[{"intValue":"1"},{"doubleValue":1.5}]}}}]}]}]}`), &req)
+	require.NoError(t, err)
+
+	require.Len(t, req.ResourceLogs, 1)
+	require.Len(t, req.ResourceLogs[0].ScopeLogs, 1)
+	require.Equal(t, "lib", req.ResourceLogs[0].ScopeLogs[0].Scope.Name)
+
+	actual := OTLPToPushRequest(&req, nil, time.Unix(0, 0))
+	require.Equal(t, []logproto.Entry{{Timestamp: time.Unix(0, 5), Line: `[1,1.5] severity=INFO`}}, actual.Streams[0].Entries)
+}
@@ -81,12 +81,7 @@ func ParseRequest(logger log.Logger, userID string, r *http.Request, tenantsRete
 	}
 
 	contentType := r.Header.Get(contentType)
-	var (
-		entriesSize      int64
-		streamLabelsSize int64
-		totalEntries     int64
-		req              logproto.PushRequest
-	)
+	var req logproto.PushRequest
 
 	contentType, _ /* params */, err := mime.ParseMediaType(contentType)
 	if err != nil {
@@ -118,6 +113,21 @@ func ParseRequest(logger log.Logger, userID string, r *http.Request, tenantsRete
 		}
 	}
 
+	if err := recordPushRequest(logger, userID, r, &req, tenantsRetention, contentType, contentEncoding, bodySize.Size()); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// recordPushRequest updates the ingestion metrics and usage stats with a parsed
+// push request and logs its statistics.
+func recordPushRequest(logger log.Logger, userID string, r *http.Request, req *logproto.PushRequest, tenantsRetention TenantsRetention, contentType, contentEncoding string, bodySize int64) error {
+	var (
+		entriesSize      int64
+		streamLabelsSize int64
+		totalEntries     int64
+	)
+
 	mostRecentEntry := time.Unix(0, 0)
 
 	for _, s := range req.Streams {
@@ -126,7 +136,7 @@ func ParseRequest(logger log.Logger, userID string, r *http.Request, tenantsRete
 		if tenantsRetention != nil {
 			lbs, err := syntax.ParseLabels(s.Labels)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			retentionHours = fmt.Sprintf("%d", int64(math.Floor(tenantsRetention.RetentionPeriodFor(userID, lbs).Hours())))
 		}
@@ -152,7 +162,7 @@ func ParseRequest(logger log.Logger, userID string, r *http.Request, tenantsRete
 		"path", r.URL.Path,
 		"contentType", contentType,
 		"contentEncoding", contentEncoding,
-		"bodySize", humanize.Bytes(uint64(bodySize.Size())),
+		"bodySize", humanize.Bytes(uint64(bodySize)),
 		"streams", len(req.Streams),
 		"entries", totalEntries,
 		"streamLabelsSize", humanize.Bytes(uint64(streamLabelsSize)),
@@ -160,5 +170,5 @@ func ParseRequest(logger log.Logger, userID string, r *http.Request, tenantsRete
 		"totalSize", humanize.Bytes(uint64(entriesSize+streamLabelsSize)),
 		"mostRecentLagMs", time.Since(mostRecentEntry).Milliseconds(),
 	)
-	return &req, nil
+	return nil
 }
@@ -265,3 +265,98 @@ func MergeSeriesResponses(responses []*SeriesResponse) (*SeriesResponse, error)
 
 	return result, nil
 }
+
+// Sum the stats from multiple IndexStatsResponse and return a single IndexStatsResponse.
+// Streams present in more than one response are counted once per response.
+func MergeIndexStatsResponses(responses []*IndexStatsResponse) (*IndexStatsResponse, error) {
+	result := &IndexStatsResponse{}
+
+	for _, r := range responses {
+		if r == nil {
+			continue
+		}
+		result.Streams += r.Streams
+		result.Chunks += r.Chunks
+		result.Bytes += r.Bytes
+		result.Entries += r.Entries
+	}
+
+	return result, nil
+}
+
+// VolumeName returns the name the volume of a series is aggregated under:
+// the full label set without the metric name when no target labels are given, only the target labels otherwise.
+// It returns false if the series has none of the target labels.
+func VolumeName(ls labels.Labels, targetLabels []string) (string, bool) {
+	if len(targetLabels) == 0 {
+		return ls.WithoutLabels(labels.MetricName).String(), true
+	}
+
+	b := labels.NewBuilder(nil)
+	found := false
+	for _, name := range targetLabels {
+		if v := ls.Get(name); v != "" {
+			b.Set(name, v)
+			found = true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	return b.Labels().String(), true
+}
+
+// TopVolumes sorts the volumes by bytes, largest first, and keeps at most limit of them.
+// A limit of zero or less keeps all the volumes.
+func TopVolumes(volumes []Volume, limit int32) []Volume {
+	sort.Slice(volumes, func(i, j int) bool {
+		if volumes[i].Bytes == volumes[j].Bytes {
+			return volumes[i].Name < volumes[j].Name
+		}
+		return volumes[i].Bytes > volumes[j].Bytes
+	})
+
+	if limit > 0 && len(volumes) > int(limit) {
+		volumes = volumes[:limit]
+	}
+	return volumes
+}
+
+// VolumeNoLimit is the limit of the volume requests asking for all the volumes,
+// e.g. the split requests of the query frontend, which only limits the merged volumes.
+const VolumeNoLimit = math.MaxInt32
+
+// Sum the volumes with the same name from multiple VolumeResponse and return a single VolumeResponse
+// holding all the volumes, largest first, with the highest limit of the responses.
+// The volumes are not limited: a volume just missing the largest ones of a response could be one of the largest
+// once summed. It's up to the caller to keep the largest volumes once all the responses are merged.
+func MergeVolumeResponses(responses []*VolumeResponse) (*VolumeResponse, error) {
+	result := &VolumeResponse{}
+	merged := map[string]*Volume{}
+
+	for _, r := range responses {
+		if r == nil {
+			continue
+		}
+		if r.Limit > result.Limit {
+			result.Limit = r.Limit
+		}
+		for _, v := range r.Volumes {
+			m, ok := merged[v.Name]
+			if !ok {
+				m = &Volume{Name: v.Name}
+				merged[v.Name] = m
+			}
+			m.Bytes += v.Bytes
+			m.Entries += v.Entries
+		}
+	}
+
+	result.Volumes = make([]Volume, 0, len(merged))
+	for _, v := range merged {
+		result.Volumes = append(result.Volumes, *v)
+	}
+	result.Volumes = TopVolumes(result.Volumes, 0)
+
+	return result, nil
+}
@@ -359,3 +359,155 @@ func BenchmarkMergeManySeriesResponses(b *testing.B) {
 	}
 	benchmarkMergeSeriesResponses(b, responses)
 }
+
+func TestMergeIndexStatsResponses(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		responses []*IndexStatsResponse
+		expected  *IndexStatsResponse
+	}{
+		{
+			desc:      "merge empty and expect zero",
+			responses: []*IndexStatsResponse{},
+			expected:  &IndexStatsResponse{},
+		},
+		{
+			desc: "merge one response",
+			responses: []*IndexStatsResponse{
+				{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4},
+			},
+			expected: &IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4},
+		},
+		{
+			desc: "merge responses skipping nil",
+			responses: []*IndexStatsResponse{
+				{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4},
+				nil,
+				{Streams: 2, Chunks: 3, Bytes: 4, Entries: 5},
+			},
+			expected: &IndexStatsResponse{Streams: 3, Chunks: 5, Bytes: 7, Entries: 9},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			merged, err := MergeIndexStatsResponses(tc.responses)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, merged)
+		})
+	}
+}
+
+func TestVolumeName(t *testing.T) {
+	ls := labels.Labels{
+		{Name: "app", Value: "foo"},
+		{Name: "namespace", Value: "ns1"},
+		{Name: "pod", Value: "foo-1"},
+	}
+
+	for _, tc := range []struct {
+		desc         string
+		targetLabels []string
+		expected     string
+		ok           bool
+	}{
+		{
+			desc:     "no target labels uses the series",
+			expected: `{app="foo", namespace="ns1", pod="foo-1"}`,
+			ok:       true,
+		},
+		{
+			desc:         "target labels",
+			targetLabels: []string{"namespace", "app"},
+			expected:     `{app="foo", namespace="ns1"}`,
+			ok:           true,
+		},
+		{
+			desc:         "missing target labels are ignored",
+			targetLabels: []string{"app", "cluster"},
+			expected:     `{app="foo"}`,
+			ok:           true,
+		},
+		{
+			desc:         "no target label found",
+			targetLabels: []string{"cluster"},
+			ok:           false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			name, ok := VolumeName(ls, tc.targetLabels)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, name)
+		})
+	}
+}
+
+func TestMergeVolumeResponses(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		responses []*VolumeResponse
+		expected  *VolumeResponse
+	}{
+		{
+			desc:      "merge empty",
+			responses: []*VolumeResponse{},
+			expected:  &VolumeResponse{Volumes: []Volume{}},
+		},
+		{
+			desc: "merge sums volumes with the same name and sorts by bytes",
+			responses: []*VolumeResponse{
+				{
+					Volumes: []Volume{
+						{Name: `{app="foo"}`, Bytes: 10, Entries: 1},
+						{Name: `{app="bar"}`, Bytes: 20, Entries: 2},
+					},
+				},
+				nil,
+				{
+					Volumes: []Volume{
+						{Name: `{app="foo"}`, Bytes: 30, Entries: 3},
+						{Name: `{app="baz"}`, Bytes: 5, Entries: 1},
+					},
+				},
+			},
+			expected: &VolumeResponse{
+				Volumes: []Volume{
+					{Name: `{app="foo"}`, Bytes: 40, Entries: 4},
+					{Name: `{app="bar"}`, Bytes: 20, Entries: 2},
+					{Name: `{app="baz"}`, Bytes: 5, Entries: 1},
+				},
+			},
+		},
+		{
+			// a volume that isn't among the largest ones of a response can be among the largest ones once merged,
+			// so the limit is only applied by the caller once all the responses are merged.
+			desc: "merge keeps every volume",
+			responses: []*VolumeResponse{
+				{
+					Volumes: []Volume{
+						{Name: `{app="foo"}`, Bytes: 10, Entries: 1},
+						{Name: `{app="bar"}`, Bytes: 20, Entries: 2},
+					},
+					Limit: 1,
+				},
+				{
+					Volumes: []Volume{
+						{Name: `{app="foo"}`, Bytes: 30, Entries: 3},
+					},
+					Limit: 1,
+				},
+			},
+			expected: &VolumeResponse{
+				Volumes: []Volume{
+					{Name: `{app="foo"}`, Bytes: 40, Entries: 4},
+					{Name: `{app="bar"}`, Bytes: 20, Entries: 2},
+				},
+				Limit: 1,
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			merged, err := MergeVolumeResponses(tc.responses)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, merged)
+		})
+	}
+}
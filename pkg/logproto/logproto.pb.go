@@ -449,6 +449,7 @@ type LabelRequest struct {
 	Values bool       `protobuf:"varint,2,opt,name=values,proto3" json:"values,omitempty"`
 	Start  *time.Time `protobuf:"bytes,3,opt,name=start,proto3,stdtime" json:"start,omitempty"`
 	End    *time.Time `protobuf:"bytes,4,opt,name=end,proto3,stdtime" json:"end,omitempty"`
+	Query  string     `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
 }
 
 func (m *LabelRequest) Reset()      { *m = LabelRequest{} }
@@ -511,6 +512,13 @@ func (m *LabelRequest) GetEnd() *time.Time {
 	return nil
 }
 
+func (m *LabelRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
 type LabelResponse struct {
 	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
 }
@@ -1653,6 +1661,289 @@ func (m *ChunkRef) GetChecksum() uint32 {
 	return 0
 }
 
+type IndexStatsRequest struct {
+	From     github_com_prometheus_common_model.Time `protobuf:"varint,1,opt,name=from,proto3,customtype=github.com/prometheus/common/model.Time" json:"from"`
+	Through  github_com_prometheus_common_model.Time `protobuf:"varint,2,opt,name=through,proto3,customtype=github.com/prometheus/common/model.Time" json:"through"`
+	Matchers string                                  `protobuf:"bytes,3,opt,name=matchers,proto3" json:"matchers,omitempty"`
+}
+
+func (m *IndexStatsRequest) Reset()      { *m = IndexStatsRequest{} }
+func (*IndexStatsRequest) ProtoMessage() {}
+func (*IndexStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c28a5f14f1f4c79a, []int{30}
+}
+func (m *IndexStatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IndexStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IndexStatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IndexStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexStatsRequest.Merge(m, src)
+}
+func (m *IndexStatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *IndexStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IndexStatsRequest proto.InternalMessageInfo
+
+func (m *IndexStatsRequest) GetMatchers() string {
+	if m != nil {
+		return m.Matchers
+	}
+	return ""
+}
+
+type IndexStatsResponse struct {
+	Streams uint64 `protobuf:"varint,1,opt,name=streams,proto3" json:"streams"`
+	Chunks  uint64 `protobuf:"varint,2,opt,name=chunks,proto3" json:"chunks"`
+	Bytes   uint64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes"`
+	Entries uint64 `protobuf:"varint,4,opt,name=entries,proto3" json:"entries"`
+}
+
+func (m *IndexStatsResponse) Reset()      { *m = IndexStatsResponse{} }
+func (*IndexStatsResponse) ProtoMessage() {}
+func (*IndexStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c28a5f14f1f4c79a, []int{31}
+}
+func (m *IndexStatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IndexStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IndexStatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IndexStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexStatsResponse.Merge(m, src)
+}
+func (m *IndexStatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *IndexStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IndexStatsResponse proto.InternalMessageInfo
+
+func (m *IndexStatsResponse) GetStreams() uint64 {
+	if m != nil {
+		return m.Streams
+	}
+	return 0
+}
+
+func (m *IndexStatsResponse) GetChunks() uint64 {
+	if m != nil {
+		return m.Chunks
+	}
+	return 0
+}
+
+func (m *IndexStatsResponse) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *IndexStatsResponse) GetEntries() uint64 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+type VolumeRequest struct {
+	From         github_com_prometheus_common_model.Time `protobuf:"varint,1,opt,name=from,proto3,customtype=github.com/prometheus/common/model.Time" json:"from"`
+	Through      github_com_prometheus_common_model.Time `protobuf:"varint,2,opt,name=through,proto3,customtype=github.com/prometheus/common/model.Time" json:"through"`
+	Matchers     string                                  `protobuf:"bytes,3,opt,name=matchers,proto3" json:"matchers,omitempty"`
+	Limit        int32                                   `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	TargetLabels []string                                `protobuf:"bytes,5,rep,name=targetLabels,proto3" json:"targetLabels,omitempty"`
+}
+
+func (m *VolumeRequest) Reset()      { *m = VolumeRequest{} }
+func (*VolumeRequest) ProtoMessage() {}
+func (*VolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c28a5f14f1f4c79a, []int{32}
+}
+func (m *VolumeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VolumeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeRequest.Merge(m, src)
+}
+func (m *VolumeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *VolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeRequest proto.InternalMessageInfo
+
+func (m *VolumeRequest) GetMatchers() string {
+	if m != nil {
+		return m.Matchers
+	}
+	return ""
+}
+
+func (m *VolumeRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *VolumeRequest) GetTargetLabels() []string {
+	if m != nil {
+		return m.TargetLabels
+	}
+	return nil
+}
+
+type VolumeResponse struct {
+	Volumes []Volume `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes"`
+	Limit   int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit"`
+}
+
+func (m *VolumeResponse) Reset()      { *m = VolumeResponse{} }
+func (*VolumeResponse) ProtoMessage() {}
+func (*VolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c28a5f14f1f4c79a, []int{33}
+}
+func (m *VolumeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VolumeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeResponse.Merge(m, src)
+}
+func (m *VolumeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *VolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeResponse proto.InternalMessageInfo
+
+func (m *VolumeResponse) GetVolumes() []Volume {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *VolumeResponse) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type Volume struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	Bytes   uint64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes"`
+	Entries uint64 `protobuf:"varint,3,opt,name=entries,proto3" json:"entries"`
+}
+
+func (m *Volume) Reset()      { *m = Volume{} }
+func (*Volume) ProtoMessage() {}
+func (*Volume) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c28a5f14f1f4c79a, []int{34}
+}
+func (m *Volume) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Volume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Volume.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Volume) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Volume.Merge(m, src)
+}
+func (m *Volume) XXX_Size() int {
+	return m.Size()
+}
+func (m *Volume) XXX_DiscardUnknown() {
+	xxx_messageInfo_Volume.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Volume proto.InternalMessageInfo
+
+func (m *Volume) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Volume) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *Volume) GetEntries() uint64 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("logproto.Direction", Direction_name, Direction_value)
 	proto.RegisterType((*PushRequest)(nil), "logproto.PushRequest")
@@ -1686,116 +1977,135 @@ func init() {
 	proto.RegisterType((*GetChunkIDsRequest)(nil), "logproto.GetChunkIDsRequest")
 	proto.RegisterType((*GetChunkIDsResponse)(nil), "logproto.GetChunkIDsResponse")
 	proto.RegisterType((*ChunkRef)(nil), "logproto.ChunkRef")
+	proto.RegisterType((*IndexStatsRequest)(nil), "logproto.IndexStatsRequest")
+	proto.RegisterType((*IndexStatsResponse)(nil), "logproto.IndexStatsResponse")
+	proto.RegisterType((*VolumeRequest)(nil), "logproto.VolumeRequest")
+	proto.RegisterType((*VolumeResponse)(nil), "logproto.VolumeResponse")
+	proto.RegisterType((*Volume)(nil), "logproto.Volume")
 }
 
 func init() { proto.RegisterFile("pkg/logproto/logproto.proto", fileDescriptor_c28a5f14f1f4c79a) }
 
 var fileDescriptor_c28a5f14f1f4c79a = []byte{
-	// 1654 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x49, 0x6f, 0x1b, 0xc9,
-	0x15, 0x66, 0x71, 0x69, 0x92, 0x8f, 0x8b, 0x88, 0x92, 0x2c, 0x71, 0x38, 0x33, 0x6c, 0x4e, 0x63,
-	0x30, 0x26, 0xbc, 0x90, 0xb1, 0xb2, 0xd8, 0x96, 0xb3, 0x40, 0xb4, 0x62, 0x5b, 0xb6, 0x12, 0xdb,
-	0x2d, 0x05, 0x06, 0x0c, 0x04, 0x46, 0x8b, 0x2c, 0x91, 0x0d, 0xb1, 0xd9, 0x74, 0x57, 0xd3, 0x80,
-	0x80, 0x00, 0xc9, 0x0f, 0x48, 0x00, 0xe7, 0x14, 0xe4, 0x9e, 0x43, 0x90, 0x43, 0x0e, 0xf9, 0x13,
-	0x71, 0x6e, 0x3e, 0x1a, 0x3e, 0x30, 0x31, 0x7d, 0x09, 0x88, 0x1c, 0xfc, 0x0b, 0x82, 0xa0, 0xb6,
-	0x66, 0x91, 0x96, 0x62, 0xd3, 0x97, 0xb9, 0x88, 0xf5, 0x5e, 0xbd, 0xad, 0xbe, 0x7a, 0x4b, 0xb5,
-	0xe0, 0xf3, 0xe1, 0x71, 0xb7, 0xd9, 0xf7, 0xbb, 0xc3, 0xc0, 0x0f, 0xfd, 0x68, 0xd1, 0xe0, 0x7f,
-	0x71, 0x46, 0xd1, 0x15, 0xb3, 0xeb, 0xfb, 0xdd, 0x3e, 0x69, 0x72, 0xea, 0x70, 0x74, 0xd4, 0x0c,
-	0x5d, 0x8f, 0xd0, 0xd0, 0xf1, 0x86, 0x42, 0xb4, 0x72, 0xb9, 0xeb, 0x86, 0xbd, 0xd1, 0x61, 0xa3,
-	0xed, 0x7b, 0xcd, 0xae, 0xdf, 0xf5, 0x67, 0x92, 0x8c, 0x12, 0xd6, 0xd9, 0x4a, 0x8a, 0xd7, 0xa4,
-	0xdb, 0xa7, 0x7d, 0xcf, 0xef, 0x90, 0x7e, 0x93, 0x86, 0x4e, 0x48, 0xc5, 0x5f, 0x21, 0x61, 0x3d,
-	0x82, 0xdc, 0x83, 0x11, 0xed, 0xd9, 0xe4, 0xe9, 0x88, 0xd0, 0x10, 0xdf, 0x81, 0x34, 0x0d, 0x03,
-	0xe2, 0x78, 0xb4, 0x8c, 0x6a, 0x89, 0x7a, 0x6e, 0x73, 0xa3, 0x11, 0x05, 0xbb, 0xcf, 0x37, 0xb6,
-	0x3b, 0xce, 0x30, 0x24, 0x41, 0xeb, 0xdc, 0xeb, 0xb1, 0x69, 0x08, 0xd6, 0x74, 0x6c, 0x2a, 0x2d,
-	0x5b, 0x2d, 0xac, 0x22, 0xe4, 0x85, 0x61, 0x3a, 0xf4, 0x07, 0x94, 0x58, 0x7f, 0x8f, 0x43, 0xfe,
-	0xe1, 0x88, 0x04, 0x27, 0xca, 0x55, 0x05, 0x32, 0x94, 0xf4, 0x49, 0x3b, 0xf4, 0x83, 0x32, 0xaa,
-	0xa1, 0x7a, 0xd6, 0x8e, 0x68, 0xbc, 0x06, 0xa9, 0xbe, 0xeb, 0xb9, 0x61, 0x39, 0x5e, 0x43, 0xf5,
-	0x82, 0x2d, 0x08, 0xbc, 0x05, 0x29, 0x1a, 0x3a, 0x41, 0x58, 0x4e, 0xd4, 0x50, 0x3d, 0xb7, 0x59,
-	0x69, 0x08, 0xb4, 0x1a, 0x0a, 0x83, 0xc6, 0x81, 0x42, 0xab, 0x95, 0x79, 0x31, 0x36, 0x63, 0xcf,
-	0xff, 0x69, 0x22, 0x5b, 0xa8, 0xe0, 0x1f, 0x40, 0x82, 0x0c, 0x3a, 0xe5, 0xe4, 0x12, 0x9a, 0x4c,
-	0x01, 0x5f, 0x81, 0x6c, 0xc7, 0x0d, 0x48, 0x3b, 0x74, 0xfd, 0x41, 0x39, 0x55, 0x43, 0xf5, 0xe2,
-	0xe6, 0xea, 0x0c, 0x92, 0x1d, 0xb5, 0x65, 0xcf, 0xa4, 0xf0, 0x25, 0x30, 0x68, 0xcf, 0x09, 0x3a,
-	0xb4, 0x9c, 0xae, 0x25, 0xea, 0xd9, 0xd6, 0xda, 0x74, 0x6c, 0x96, 0x04, 0xe7, 0x92, 0xef, 0xb9,
-	0x21, 0xf1, 0x86, 0xe1, 0x89, 0x2d, 0x65, 0xf0, 0x05, 0x48, 0x77, 0x48, 0x9f, 0x84, 0x84, 0x96,
-	0x33, 0x1c, 0xf1, 0x92, 0x66, 0x9e, 0x6f, 0xd8, 0x4a, 0xe0, 0x6e, 0x32, 0x63, 0x94, 0xd2, 0xd6,
-	0x7f, 0x11, 0xe0, 0x7d, 0xc7, 0x1b, 0xf6, 0xc9, 0x47, 0xe3, 0x19, 0x21, 0x17, 0xff, 0x64, 0xe4,
-	0x12, 0xcb, 0x22, 0x37, 0x83, 0x21, 0xb9, 0x1c, 0x0c, 0xa9, 0x0f, 0xc0, 0x60, 0xed, 0x81, 0x21,
-	0x58, 0x1f, 0xca, 0xa1, 0xd9, 0x99, 0x13, 0xea, 0x34, 0xa5, 0xd9, 0x69, 0x12, 0x3c, 0x4e, 0xeb,
-	0xd7, 0x50, 0x90, 0x38, 0x8a, 0x4c, 0xc5, 0xdb, 0x1f, 0x5d, 0x03, 0xc5, 0x17, 0x63, 0x13, 0xcd,
-	0xea, 0x20, 0x4a, 0x7e, 0x7c, 0x91, 0xfb, 0x0e, 0xa9, 0xc4, 0x7b, 0xa5, 0x21, 0x4a, 0x6e, 0x77,
-	0xd0, 0x25, 0x94, 0x29, 0x26, 0x19, 0x54, 0xb6, 0x90, 0xb1, 0x7e, 0x05, 0xab, 0x73, 0xd7, 0x29,
-	0xc3, 0xb8, 0x06, 0x06, 0x25, 0x81, 0x4b, 0x54, 0x14, 0x1a, 0x20, 0xfb, 0x9c, 0xaf, 0xb9, 0xe7,
-	0xb4, 0x2d, 0xe5, 0x97, 0xf3, 0xfe, 0x57, 0x04, 0xf9, 0x3d, 0xe7, 0x90, 0xf4, 0x55, 0x1e, 0x61,
-	0x48, 0x0e, 0x1c, 0x8f, 0x48, 0x3c, 0xf9, 0x1a, 0xaf, 0x83, 0xf1, 0xcc, 0xe9, 0x8f, 0x88, 0x30,
-	0x99, 0xb1, 0x25, 0xb5, 0x6c, 0x45, 0xa2, 0x4f, 0xae, 0x48, 0x14, 0xe5, 0x95, 0x75, 0x1e, 0x0a,
-	0x32, 0x5e, 0x09, 0xd4, 0x2c, 0x38, 0x06, 0x54, 0x56, 0x05, 0x67, 0xfd, 0x1e, 0x41, 0x61, 0xee,
-	0xbe, 0xb0, 0x05, 0x46, 0x9f, 0xa9, 0x52, 0x71, 0xb8, 0x16, 0x4c, 0xc7, 0xa6, 0xe4, 0xd8, 0xf2,
-	0x97, 0xdd, 0x3e, 0x19, 0x84, 0x1c, 0xf7, 0x38, 0xc7, 0x7d, 0x7d, 0x86, 0xfb, 0x4f, 0x07, 0x61,
-	0x70, 0xa2, 0x2e, 0x7f, 0x85, 0xa1, 0xc8, 0x5a, 0x9f, 0x14, 0xb7, 0xd5, 0x02, 0x7f, 0x06, 0xc9,
-	0x9e, 0x43, 0x7b, 0x1c, 0x94, 0x64, 0x2b, 0x35, 0x1d, 0x9b, 0xe8, 0xb2, 0xcd, 0x59, 0xd6, 0x33,
-	0xc8, 0xeb, 0x46, 0xf0, 0x1d, 0xc8, 0x46, 0x2d, 0x9e, 0x07, 0xf5, 0xff, 0xa1, 0x28, 0x4a, 0x9f,
-	0xf1, 0x90, 0x72, 0x40, 0x66, 0xca, 0xf8, 0x0b, 0x48, 0xf6, 0xdd, 0x01, 0xe1, 0x17, 0x94, 0x6d,
-	0x65, 0xa6, 0x63, 0x93, 0xd3, 0x36, 0xff, 0x6b, 0x79, 0x60, 0x88, 0x1c, 0xc3, 0x5f, 0x2f, 0x7a,
-	0x4c, 0xb4, 0x0c, 0x61, 0x51, 0xb7, 0x66, 0x42, 0x8a, 0xa3, 0xc8, 0xcd, 0xa1, 0x56, 0x76, 0x3a,
-	0x36, 0x05, 0xc3, 0x16, 0x3f, 0xcc, 0x9d, 0x76, 0x46, 0xee, 0x8e, 0xd1, 0xf2, 0x98, 0xb7, 0x21,
-	0xbf, 0x47, 0xba, 0x4e, 0xfb, 0x44, 0x3a, 0x5d, 0x53, 0xe6, 0x98, 0x43, 0xa4, 0x6c, 0x7c, 0x05,
-	0xf9, 0xc8, 0xe3, 0x13, 0x8f, 0xca, 0x42, 0xcd, 0x45, 0xbc, 0x9f, 0x51, 0xeb, 0x8f, 0x08, 0x64,
-	0x76, 0x7f, 0xd4, 0xe5, 0xdd, 0x80, 0x34, 0xe5, 0x1e, 0xd5, 0xe5, 0xe9, 0x45, 0xc3, 0x37, 0x66,
-	0xd7, 0x26, 0x05, 0x6d, 0xb5, 0xc0, 0x0d, 0x00, 0x51, 0xbf, 0x77, 0x66, 0x07, 0x2b, 0x4e, 0xc7,
-	0xa6, 0xc6, 0xb5, 0xb5, 0xb5, 0xf5, 0x07, 0x04, 0xb9, 0x03, 0xc7, 0x8d, 0x0a, 0x67, 0x0d, 0x52,
-	0x4f, 0x59, 0x05, 0xcb, 0xca, 0x11, 0x04, 0x6b, 0x51, 0x1d, 0xd2, 0x77, 0x4e, 0x6e, 0xf9, 0x01,
-	0xb7, 0x59, 0xb0, 0x23, 0x7a, 0x36, 0xe6, 0x92, 0xa7, 0x8e, 0xb9, 0xd4, 0xd2, 0xcd, 0xfa, 0x6e,
-	0x32, 0x13, 0x2f, 0x25, 0xac, 0xdf, 0x22, 0xc8, 0x8b, 0xc8, 0x64, 0x89, 0xdc, 0x00, 0x43, 0x04,
-	0x2e, 0x73, 0xec, 0xcc, 0x8e, 0x06, 0x5a, 0x37, 0x93, 0x2a, 0xf8, 0x27, 0x50, 0xec, 0x04, 0xfe,
-	0x70, 0x48, 0x3a, 0xfb, 0xb2, 0x2d, 0xc6, 0x17, 0xdb, 0xe2, 0x8e, 0xbe, 0x6f, 0x2f, 0x88, 0x5b,
-	0xff, 0x60, 0x85, 0x28, 0x5a, 0x94, 0x84, 0x2a, 0x3a, 0x22, 0xfa, 0xe4, 0x79, 0x14, 0x5f, 0x76,
-	0x1e, 0xad, 0x83, 0xd1, 0x0d, 0xfc, 0xd1, 0x90, 0x96, 0x13, 0xa2, 0x4d, 0x08, 0x6a, 0xb9, 0x39,
-	0x65, 0xdd, 0x85, 0xa2, 0x3a, 0xca, 0x19, 0x7d, 0xba, 0xb2, 0xd8, 0xa7, 0x77, 0x3b, 0x64, 0x10,
-	0xba, 0x47, 0x6e, 0xd4, 0x79, 0xa5, 0xbc, 0xf5, 0x3b, 0x04, 0xa5, 0x45, 0x11, 0xfc, 0x63, 0x2d,
-	0xcd, 0x99, 0xb9, 0x6f, 0xce, 0x36, 0xd7, 0xe0, 0x7d, 0x90, 0xf2, 0x86, 0xa2, 0x4a, 0xa0, 0x72,
-	0x1d, 0x72, 0x1a, 0x9b, 0xcd, 0xbb, 0x63, 0xa2, 0x52, 0x92, 0x2d, 0x67, 0xb5, 0x18, 0x17, 0x69,
-	0xca, 0x89, 0xad, 0xf8, 0x35, 0xc4, 0x12, 0xba, 0x30, 0x77, 0x93, 0xf8, 0x1a, 0x24, 0x8f, 0x02,
-	0xdf, 0x5b, 0xea, 0x9a, 0xb8, 0x06, 0xfe, 0x1e, 0xc4, 0x43, 0x7f, 0xa9, 0x4b, 0x8a, 0x87, 0x3e,
-	0xbb, 0x23, 0x79, 0xf8, 0x04, 0x0f, 0x4e, 0x52, 0xd6, 0x5f, 0x10, 0xac, 0x30, 0x1d, 0x81, 0xc0,
-	0xcd, 0xde, 0x68, 0x70, 0x8c, 0xeb, 0x50, 0x62, 0x9e, 0x9e, 0xb8, 0x72, 0xac, 0x3d, 0x71, 0x3b,
-	0xf2, 0x98, 0x45, 0xc6, 0x57, 0xd3, 0x6e, 0xb7, 0x83, 0x37, 0x20, 0x3d, 0xa2, 0x42, 0x40, 0x9c,
-	0xd9, 0x60, 0xe4, 0x6e, 0x07, 0x5f, 0xd4, 0xdc, 0x31, 0xac, 0xb5, 0x97, 0x1d, 0xc7, 0xf0, 0x81,
-	0xe3, 0x06, 0x51, 0x6f, 0x39, 0x0f, 0x46, 0x9b, 0x39, 0x16, 0x79, 0xc2, 0xc6, 0x6a, 0x24, 0xcc,
-	0x03, 0xb2, 0xe5, 0xb6, 0xf5, 0x7d, 0xc8, 0x46, 0xda, 0xa7, 0x4e, 0xd3, 0x53, 0x6f, 0xc0, 0xba,
-	0x01, 0x2b, 0xa2, 0x67, 0x9e, 0xae, 0x9c, 0x3f, 0x4d, 0x39, 0xaf, 0x94, 0x3f, 0x87, 0x94, 0x40,
-	0x05, 0x43, 0xb2, 0xe3, 0x84, 0x8e, 0x52, 0x61, 0x6b, 0xab, 0x0c, 0xeb, 0x07, 0x81, 0x33, 0xa0,
-	0x47, 0x24, 0xe0, 0x42, 0x51, 0xee, 0x5a, 0xe7, 0x60, 0x95, 0xf5, 0x09, 0x12, 0xd0, 0x9b, 0xfe,
-	0x68, 0x10, 0xca, 0xf2, 0xb4, 0x2e, 0xc1, 0xda, 0x3c, 0x5b, 0xa6, 0xfa, 0x1a, 0xa4, 0xda, 0x8c,
-	0xc1, 0xad, 0x17, 0x6c, 0x41, 0x58, 0x7f, 0x42, 0x80, 0x6f, 0x93, 0x90, 0x9b, 0xde, 0xdd, 0xa1,
-	0xda, 0x7b, 0xd4, 0x73, 0xc2, 0x76, 0x8f, 0x04, 0x54, 0xbd, 0xcd, 0x14, 0xfd, 0x6d, 0xbc, 0x47,
-	0xad, 0x2b, 0xb0, 0x3a, 0x17, 0xa5, 0x3c, 0x53, 0x05, 0x32, 0x6d, 0xc9, 0x93, 0xef, 0x87, 0x88,
-	0xb6, 0xfe, 0x16, 0x87, 0x8c, 0xb8, 0x5b, 0x72, 0x84, 0xaf, 0x40, 0xee, 0x88, 0xe5, 0x5a, 0x30,
-	0x0c, 0x5c, 0x09, 0x41, 0xb2, 0xb5, 0x32, 0x1d, 0x9b, 0x3a, 0xdb, 0xd6, 0x09, 0x7c, 0x79, 0x21,
-	0xf1, 0x5a, 0x6b, 0x93, 0xb1, 0x69, 0xfc, 0x82, 0x25, 0xdf, 0x0e, 0x9b, 0x5e, 0x3c, 0x0d, 0x77,
-	0xa2, 0x74, 0xbc, 0x27, 0xab, 0x8d, 0x3f, 0x4e, 0x5b, 0x57, 0x59, 0xf8, 0xaf, 0xc7, 0xe6, 0x79,
-	0xed, 0x93, 0x6f, 0x18, 0xf8, 0x1e, 0x09, 0x7b, 0x64, 0x44, 0x9b, 0x6d, 0xdf, 0xf3, 0xfc, 0x41,
-	0x93, 0x7f, 0xd7, 0xf1, 0x43, 0xb3, 0x11, 0xcc, 0xd4, 0x65, 0x01, 0x1e, 0x40, 0x3a, 0xec, 0x05,
-	0xfe, 0xa8, 0xdb, 0xe3, 0xd3, 0x25, 0xd1, 0xda, 0x5a, 0xde, 0x9e, 0xb2, 0x60, 0xab, 0x05, 0xfe,
-	0x8a, 0xa1, 0x45, 0xda, 0xc7, 0x74, 0xe4, 0xf1, 0xf1, 0x54, 0x50, 0xcf, 0x9b, 0x88, 0x7d, 0xe1,
-	0x1b, 0xc8, 0x46, 0x9f, 0x45, 0x38, 0x07, 0xe9, 0x5b, 0xf7, 0xed, 0x47, 0xdb, 0xf6, 0x4e, 0x29,
-	0x86, 0xf3, 0x90, 0x69, 0x6d, 0xdf, 0xbc, 0xc7, 0x29, 0xb4, 0xb9, 0x0d, 0x06, 0xfb, 0x40, 0x24,
-	0x01, 0xbe, 0x0a, 0x49, 0xb6, 0xc2, 0xe7, 0x66, 0x15, 0xa5, 0x7d, 0x93, 0x56, 0xd6, 0x17, 0xd9,
-	0x32, 0x79, 0x63, 0x9b, 0xff, 0x49, 0x40, 0x9a, 0x3d, 0x9a, 0x59, 0xdf, 0xfc, 0x21, 0xa4, 0xf8,
-	0xfb, 0x19, 0x6b, 0xe2, 0xfa, 0xf7, 0x51, 0x65, 0xe3, 0x3d, 0xbe, 0xb2, 0xf3, 0x1d, 0x84, 0x7f,
-	0x0e, 0x39, 0xce, 0x94, 0xef, 0x95, 0x2f, 0x16, 0x9f, 0x0d, 0x73, 0x96, 0xbe, 0x3c, 0x63, 0x57,
-	0xb3, 0xb7, 0x05, 0x29, 0x5e, 0xc6, 0x7a, 0x34, 0xfa, 0x2b, 0x5b, 0x8f, 0x66, 0xee, 0x35, 0x6b,
-	0xc5, 0xf0, 0x75, 0x48, 0xb2, 0xea, 0xd3, 0xe1, 0xd0, 0x9e, 0x19, 0x3a, 0x1c, 0xfa, 0x8c, 0xe7,
-	0x6e, 0x7f, 0x14, 0xbd, 0x96, 0x36, 0x16, 0xc7, 0x86, 0x52, 0x2f, 0xbf, 0xbf, 0x11, 0x79, 0xbe,
-	0x2f, 0x9e, 0x0d, 0xaa, 0xee, 0xf1, 0x97, 0xf3, 0xae, 0x16, 0xda, 0x44, 0xa5, 0x7a, 0xd6, 0x76,
-	0x64, 0x70, 0x0f, 0x72, 0x5a, 0xcd, 0xe9, 0xb0, 0xbe, 0xdf, 0x30, 0x74, 0x58, 0x4f, 0x29, 0x54,
-	0x2b, 0xb6, 0xf9, 0x4b, 0xc8, 0xa8, 0xae, 0x8e, 0x1f, 0x42, 0x71, 0xbe, 0xa7, 0xe1, 0xcf, 0xb4,
-	0x68, 0xe6, 0x47, 0x45, 0xa5, 0xa6, 0x6d, 0x9d, 0xde, 0x08, 0x63, 0x75, 0xd4, 0x7a, 0xfc, 0xf2,
-	0x4d, 0x35, 0xf6, 0xea, 0x4d, 0x35, 0xf6, 0xee, 0x4d, 0x15, 0xfd, 0x66, 0x52, 0x45, 0x7f, 0x9e,
-	0x54, 0xd1, 0x8b, 0x49, 0x15, 0xbd, 0x9c, 0x54, 0xd1, 0xbf, 0x26, 0x55, 0xf4, 0xef, 0x49, 0x35,
-	0xf6, 0x6e, 0x52, 0x45, 0xcf, 0xdf, 0x56, 0x63, 0x2f, 0xdf, 0x56, 0x63, 0xaf, 0xde, 0x56, 0x63,
-	0x8f, 0xbf, 0xd6, 0xff, 0x23, 0x13, 0x38, 0x47, 0xce, 0xc0, 0x69, 0xf6, 0xfd, 0x63, 0xb7, 0xa9,
-	0xff, 0xc7, 0xe7, 0xd0, 0xe0, 0x3f, 0xdf, 0xfd, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4b, 0xf9,
-	0x78, 0x9a, 0x08, 0x12, 0x00, 0x00,
+	// 1884 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x18, 0x4d, 0x6f, 0x1b, 0xc7,
+	0x95, 0x43, 0x2e, 0xbf, 0x1e, 0x3f, 0xa4, 0x8e, 0x65, 0x89, 0xa1, 0x6d, 0x52, 0x19, 0xa4, 0xb1,
+	0x90, 0xd8, 0x64, 0xad, 0x7e, 0xc4, 0xb1, 0xfb, 0x25, 0x5a, 0x8d, 0x2c, 0xc7, 0x6d, 0xe2, 0x95,
+	0xdb, 0x00, 0x01, 0x0a, 0x63, 0x45, 0x8e, 0xc8, 0x85, 0xb8, 0xbb, 0xf4, 0xee, 0xd0, 0xa8, 0x80,
+	0x02, 0xed, 0x0f, 0x68, 0x81, 0xf4, 0x54, 0xf4, 0x5e, 0xa0, 0x45, 0x8f, 0xfd, 0x03, 0xbd, 0x14,
+	0xa8, 0x7b, 0xf3, 0x31, 0xc8, 0x81, 0xad, 0xe5, 0x4b, 0xa1, 0x93, 0x2f, 0x45, 0x6f, 0x45, 0x31,
+	0x5f, 0xbb, 0x43, 0x8a, 0x82, 0x4d, 0x5d, 0x8a, 0x5c, 0xc8, 0x79, 0x6f, 0xde, 0xbc, 0x37, 0xef,
+	0xfb, 0xcd, 0xc2, 0xa5, 0xd1, 0x61, 0xbf, 0x3d, 0x0c, 0xfa, 0xa3, 0x30, 0x60, 0x41, 0xbc, 0x68,
+	0x89, 0x5f, 0x5c, 0xd0, 0x70, 0xbd, 0xd9, 0x0f, 0x82, 0xfe, 0x90, 0xb6, 0x05, 0xb4, 0x3f, 0x3e,
+	0x68, 0x33, 0xd7, 0xa3, 0x11, 0x73, 0xbc, 0x91, 0x24, 0xad, 0x5f, 0xef, 0xbb, 0x6c, 0x30, 0xde,
+	0x6f, 0x75, 0x03, 0xaf, 0xdd, 0x0f, 0xfa, 0x41, 0x42, 0xc9, 0x21, 0xc9, 0x9d, 0xaf, 0x14, 0xf9,
+	0xba, 0x12, 0xfb, 0x78, 0xe8, 0x05, 0x3d, 0x3a, 0x6c, 0x47, 0xcc, 0x61, 0x91, 0xfc, 0x95, 0x14,
+	0xe4, 0x13, 0x28, 0x7d, 0x3c, 0x8e, 0x06, 0x36, 0x7d, 0x3c, 0xa6, 0x11, 0xc3, 0x77, 0x21, 0x1f,
+	0xb1, 0x90, 0x3a, 0x5e, 0x54, 0x43, 0xeb, 0x99, 0x8d, 0xd2, 0xe6, 0x5a, 0x2b, 0xbe, 0xec, 0x9e,
+	0xd8, 0xd8, 0xea, 0x39, 0x23, 0x46, 0xc3, 0xce, 0xc5, 0x2f, 0x26, 0xcd, 0x9c, 0x44, 0x9d, 0x4c,
+	0x9a, 0xfa, 0x94, 0xad, 0x17, 0xa4, 0x0a, 0x65, 0xc9, 0x38, 0x1a, 0x05, 0x7e, 0x44, 0xc9, 0xdf,
+	0xd2, 0x50, 0x7e, 0x30, 0xa6, 0xe1, 0x91, 0x16, 0x55, 0x87, 0x42, 0x44, 0x87, 0xb4, 0xcb, 0x82,
+	0xb0, 0x86, 0xd6, 0xd1, 0x46, 0xd1, 0x8e, 0x61, 0xbc, 0x02, 0xd9, 0xa1, 0xeb, 0xb9, 0xac, 0x96,
+	0x5e, 0x47, 0x1b, 0x15, 0x5b, 0x02, 0xf8, 0x16, 0x64, 0x23, 0xe6, 0x84, 0xac, 0x96, 0x59, 0x47,
+	0x1b, 0xa5, 0xcd, 0x7a, 0x4b, 0x5a, 0xab, 0xa5, 0x6d, 0xd0, 0x7a, 0xa8, 0xad, 0xd5, 0x29, 0x3c,
+	0x9d, 0x34, 0x53, 0x9f, 0xfd, 0xa3, 0x89, 0x6c, 0x79, 0x04, 0x7f, 0x0b, 0x32, 0xd4, 0xef, 0xd5,
+	0xac, 0x05, 0x4e, 0xf2, 0x03, 0xf8, 0x06, 0x14, 0x7b, 0x6e, 0x48, 0xbb, 0xcc, 0x0d, 0xfc, 0x5a,
+	0x76, 0x1d, 0x6d, 0x54, 0x37, 0x2f, 0x24, 0x26, 0xd9, 0xd6, 0x5b, 0x76, 0x42, 0x85, 0xaf, 0x41,
+	0x2e, 0x1a, 0x38, 0x61, 0x2f, 0xaa, 0xe5, 0xd7, 0x33, 0x1b, 0xc5, 0xce, 0xca, 0xc9, 0xa4, 0xb9,
+	0x2c, 0x31, 0xd7, 0x02, 0xcf, 0x65, 0xd4, 0x1b, 0xb1, 0x23, 0x5b, 0xd1, 0xe0, 0x77, 0x20, 0xdf,
+	0xa3, 0x43, 0xca, 0x68, 0x54, 0x2b, 0x08, 0x8b, 0x2f, 0x1b, 0xec, 0xc5, 0x86, 0xad, 0x09, 0xee,
+	0x59, 0x85, 0xdc, 0x72, 0x9e, 0xfc, 0x17, 0x01, 0xde, 0x73, 0xbc, 0xd1, 0x90, 0xbe, 0xb6, 0x3d,
+	0x63, 0xcb, 0xa5, 0xcf, 0x6d, 0xb9, 0xcc, 0xa2, 0x96, 0x4b, 0xcc, 0x60, 0x2d, 0x66, 0x86, 0xec,
+	0x2b, 0xcc, 0x40, 0xee, 0x43, 0x4e, 0xa2, 0x5e, 0x15, 0x43, 0x89, 0xce, 0x19, 0xad, 0xcd, 0x72,
+	0xa2, 0x4d, 0x46, 0xdc, 0x93, 0xfc, 0x02, 0x2a, 0xca, 0x8e, 0x32, 0x52, 0xf1, 0xd6, 0x6b, 0xe7,
+	0x40, 0xf5, 0xe9, 0xa4, 0x89, 0x92, 0x3c, 0x88, 0x83, 0x1f, 0xbf, 0x2b, 0x64, 0xb3, 0x48, 0xd9,
+	0x7b, 0xa9, 0x25, 0xa0, 0xd6, 0xae, 0xdf, 0xa7, 0x11, 0x3f, 0x68, 0x71, 0x53, 0xd9, 0x92, 0x86,
+	0xfc, 0x1c, 0x2e, 0x4c, 0xb9, 0x53, 0x5d, 0xe3, 0x26, 0xe4, 0x22, 0x1a, 0xba, 0x54, 0xdf, 0xc2,
+	0x30, 0xc8, 0x9e, 0xc0, 0x1b, 0xe2, 0x05, 0x6c, 0x2b, 0xfa, 0xc5, 0xa4, 0xff, 0x15, 0x41, 0xf9,
+	0xbe, 0xb3, 0x4f, 0x87, 0x3a, 0x8e, 0x30, 0x58, 0xbe, 0xe3, 0x51, 0x65, 0x4f, 0xb1, 0xc6, 0xab,
+	0x90, 0x7b, 0xe2, 0x0c, 0xc7, 0x54, 0xb2, 0x2c, 0xd8, 0x0a, 0x5a, 0x34, 0x23, 0xd1, 0xb9, 0x33,
+	0x12, 0x25, 0x71, 0xb5, 0x02, 0xd9, 0xc7, 0xdc, 0x50, 0x22, 0x1b, 0x8b, 0xb6, 0x04, 0xc8, 0x55,
+	0xa8, 0x28, 0x2d, 0x94, 0xf9, 0x92, 0x2b, 0x73, 0xf3, 0x15, 0xf5, 0x95, 0xc9, 0x6f, 0x10, 0x54,
+	0xa6, 0xbc, 0x88, 0x09, 0xe4, 0x86, 0xfc, 0x68, 0x24, 0x55, 0xee, 0xc0, 0xc9, 0xa4, 0xa9, 0x30,
+	0xb6, 0xfa, 0xe7, 0x31, 0x41, 0x7d, 0x26, 0xbc, 0x91, 0x16, 0xde, 0x58, 0x4d, 0xbc, 0xf1, 0x03,
+	0x9f, 0x85, 0x47, 0x3a, 0x24, 0x96, 0xb8, 0x6d, 0x79, 0x41, 0x54, 0xe4, 0xb6, 0x5e, 0xe0, 0x37,
+	0xc0, 0x1a, 0x38, 0xd1, 0x40, 0x98, 0xca, 0xea, 0x64, 0x4f, 0x26, 0x4d, 0x74, 0xdd, 0x16, 0x28,
+	0xf2, 0x04, 0xca, 0x26, 0x13, 0x7c, 0x17, 0x8a, 0x71, 0xe1, 0xaf, 0xa1, 0x57, 0x1a, 0xa8, 0xaa,
+	0x64, 0xa6, 0x59, 0x24, 0xcc, 0x94, 0x1c, 0xc6, 0x97, 0xc1, 0x1a, 0xba, 0x3e, 0x15, 0x6e, 0x2b,
+	0x76, 0x0a, 0x27, 0x93, 0xa6, 0x80, 0x6d, 0xf1, 0x4b, 0x3c, 0xc8, 0xc9, 0xc8, 0xc3, 0x6f, 0xcd,
+	0x4a, 0xcc, 0x74, 0x72, 0x92, 0xa3, 0xc9, 0xad, 0x09, 0x59, 0x61, 0x45, 0xc1, 0x0e, 0x75, 0x8a,
+	0x27, 0x93, 0xa6, 0x44, 0xd8, 0xf2, 0x8f, 0x8b, 0x33, 0x74, 0x14, 0xe2, 0x38, 0xac, 0xd4, 0xdc,
+	0x81, 0xf2, 0x7d, 0xda, 0x77, 0xba, 0x47, 0x4a, 0xe8, 0x8a, 0x66, 0xc7, 0x05, 0x22, 0xcd, 0xe3,
+	0x4d, 0x28, 0xc7, 0x12, 0x1f, 0x79, 0x91, 0x4a, 0xdf, 0x52, 0x8c, 0xfb, 0x61, 0x44, 0x7e, 0x87,
+	0x40, 0xc5, 0xfc, 0x6b, 0x39, 0xef, 0x36, 0xe4, 0x23, 0x21, 0x51, 0x3b, 0xcf, 0x4c, 0x25, 0xb1,
+	0x91, 0xb8, 0x4d, 0x11, 0xda, 0x7a, 0x81, 0x5b, 0x00, 0x32, 0xab, 0xef, 0x26, 0x8a, 0x55, 0x4f,
+	0x26, 0x4d, 0x03, 0x6b, 0x1b, 0x6b, 0xf2, 0x5b, 0x04, 0xa5, 0x87, 0x8e, 0x1b, 0xa7, 0x53, 0x1c,
+	0xae, 0xc8, 0x08, 0x57, 0x5e, 0xb8, 0x7a, 0x74, 0xe8, 0x1c, 0x7d, 0x10, 0x84, 0x82, 0x67, 0xc5,
+	0x8e, 0xe1, 0xa4, 0xf9, 0x59, 0x73, 0x9b, 0x5f, 0x76, 0xe1, 0x12, 0x7e, 0xcf, 0x2a, 0xa4, 0x97,
+	0x33, 0xe4, 0x57, 0x08, 0xca, 0xf2, 0x66, 0x2a, 0x45, 0x6e, 0x43, 0x4e, 0x5e, 0x5c, 0xc5, 0xd8,
+	0x99, 0x75, 0x0e, 0x8c, 0x1a, 0xa7, 0x8e, 0xe0, 0xef, 0x41, 0xb5, 0x17, 0x06, 0xa3, 0x11, 0xed,
+	0xed, 0xa9, 0x62, 0x99, 0x9e, 0x2d, 0x96, 0xdb, 0xe6, 0xbe, 0x3d, 0x43, 0x4e, 0xfe, 0xce, 0x13,
+	0x51, 0x16, 0x2e, 0x65, 0xaa, 0x58, 0x45, 0x74, 0xee, 0x2e, 0x95, 0x5e, 0xb4, 0x4b, 0xad, 0x42,
+	0xae, 0x1f, 0x06, 0xe3, 0x51, 0x54, 0xcb, 0xc8, 0x32, 0x21, 0xa1, 0xc5, 0xba, 0x17, 0xb9, 0x07,
+	0x55, 0xad, 0xca, 0x19, 0xd5, 0xbb, 0x3e, 0x5b, 0xbd, 0x77, 0x7b, 0xd4, 0x67, 0xee, 0x81, 0x1b,
+	0xd7, 0x63, 0x45, 0x4f, 0x7e, 0x8d, 0x60, 0x79, 0x96, 0x04, 0x7f, 0xd7, 0x08, 0x73, 0xce, 0xee,
+	0xed, 0xb3, 0xd9, 0xb5, 0x44, 0x1d, 0x8c, 0x44, 0x41, 0xd1, 0x29, 0x50, 0x7f, 0x1f, 0x4a, 0x06,
+	0x9a, 0x77, 0xc1, 0x43, 0xaa, 0x43, 0x92, 0x2f, 0x93, 0x5c, 0x4c, 0xcb, 0x30, 0x15, 0xc0, 0xad,
+	0xf4, 0x4d, 0xc4, 0x03, 0xba, 0x32, 0xe5, 0x49, 0x7c, 0x13, 0xac, 0x83, 0x30, 0xf0, 0x16, 0x72,
+	0x93, 0x38, 0x81, 0xbf, 0x01, 0x69, 0x16, 0x2c, 0xe4, 0xa4, 0x34, 0x0b, 0xb8, 0x8f, 0x94, 0xf2,
+	0x19, 0x71, 0x39, 0x05, 0x91, 0x3f, 0x21, 0x58, 0xe2, 0x67, 0xa4, 0x05, 0xee, 0x0c, 0xc6, 0xfe,
+	0x21, 0xde, 0x80, 0x65, 0x2e, 0xe9, 0x91, 0xab, 0x9a, 0xdd, 0x23, 0xb7, 0xa7, 0xd4, 0xac, 0x72,
+	0xbc, 0xee, 0x81, 0xbb, 0x3d, 0xbc, 0x06, 0xf9, 0x71, 0x24, 0x09, 0xa4, 0xce, 0x39, 0x0e, 0xee,
+	0xf6, 0xf0, 0xbb, 0x86, 0x38, 0x6e, 0x6b, 0x63, 0xde, 0x13, 0x36, 0xfc, 0xd8, 0x71, 0xc3, 0xb8,
+	0xb6, 0x5c, 0x85, 0x5c, 0x97, 0x0b, 0x96, 0x71, 0xc2, 0x9b, 0x6d, 0x4c, 0x2c, 0x2e, 0x64, 0xab,
+	0x6d, 0xf2, 0x4d, 0x28, 0xc6, 0xa7, 0xe7, 0xf6, 0xd8, 0xb9, 0x1e, 0x20, 0xb7, 0x61, 0x49, 0xd6,
+	0xcc, 0xf9, 0x87, 0xcb, 0xf3, 0x0e, 0x97, 0xf5, 0xe1, 0x4b, 0x90, 0x95, 0x56, 0xc1, 0x60, 0xf5,
+	0x1c, 0xe6, 0xe8, 0x23, 0x7c, 0x4d, 0x6a, 0xb0, 0xfa, 0x30, 0x74, 0xfc, 0xe8, 0x80, 0x86, 0x82,
+	0x28, 0x8e, 0x5d, 0x72, 0x11, 0x2e, 0xf0, 0x3a, 0x41, 0xc3, 0xe8, 0x4e, 0x30, 0xf6, 0x99, 0x4a,
+	0x4f, 0x72, 0x0d, 0x56, 0xa6, 0xd1, 0x2a, 0xd4, 0x57, 0x20, 0xdb, 0xe5, 0x08, 0xc1, 0xbd, 0x62,
+	0x4b, 0x80, 0xfc, 0x1e, 0x01, 0xde, 0xa1, 0x4c, 0xb0, 0xde, 0xdd, 0x8e, 0x8c, 0x29, 0xd5, 0x73,
+	0x58, 0x77, 0x40, 0xc3, 0x48, 0x4f, 0x6c, 0x1a, 0xfe, 0x7f, 0x4c, 0xa9, 0xe4, 0x06, 0x5c, 0x98,
+	0xba, 0xa5, 0xd2, 0xa9, 0x0e, 0x85, 0xae, 0xc2, 0xa9, 0xf9, 0x21, 0x86, 0xc9, 0x9f, 0xd3, 0x50,
+	0x90, 0xbe, 0xa5, 0x07, 0xf8, 0x06, 0x94, 0x0e, 0x78, 0xac, 0x85, 0xa3, 0xd0, 0x55, 0x26, 0xb0,
+	0x3a, 0x4b, 0x27, 0x93, 0xa6, 0x89, 0xb6, 0x4d, 0x00, 0x5f, 0x9f, 0x09, 0xbc, 0xce, 0xca, 0xf1,
+	0xa4, 0x99, 0xfb, 0x31, 0x0f, 0xbe, 0x6d, 0xde, 0xbd, 0x44, 0x18, 0x6e, 0xc7, 0xe1, 0xf8, 0xa1,
+	0xca, 0x36, 0x31, 0xb2, 0x76, 0xde, 0xe3, 0xd7, 0xff, 0x62, 0xd2, 0xbc, 0x6a, 0x3c, 0x04, 0x47,
+	0x61, 0xe0, 0x51, 0x36, 0xa0, 0xe3, 0xa8, 0xdd, 0x0d, 0x3c, 0x2f, 0xf0, 0xdb, 0xe2, 0xb5, 0x27,
+	0x94, 0xe6, 0x2d, 0x98, 0x1f, 0x57, 0x09, 0xf8, 0x10, 0xf2, 0x6c, 0x10, 0x06, 0xe3, 0xfe, 0x40,
+	0x74, 0x97, 0x4c, 0xe7, 0xd6, 0xe2, 0xfc, 0x34, 0x07, 0x5b, 0x2f, 0xf0, 0x9b, 0xdc, 0x5a, 0xb4,
+	0x7b, 0x18, 0x8d, 0x3d, 0xd1, 0x9e, 0x2a, 0x7a, 0xbc, 0x89, 0xd1, 0xe4, 0x2f, 0x08, 0xbe, 0xb2,
+	0xeb, 0xf7, 0xe8, 0xcf, 0xf6, 0x98, 0xc3, 0xe2, 0x68, 0xb8, 0x63, 0x54, 0x92, 0x4c, 0xa7, 0xbd,
+	0xe0, 0x5d, 0x94, 0x4e, 0xbb, 0x89, 0x4e, 0xe9, 0xf3, 0xf1, 0x89, 0x15, 0x31, 0xa3, 0x33, 0x33,
+	0x1d, 0x9d, 0xe4, 0x0f, 0x08, 0xb0, 0xa9, 0x81, 0x8a, 0x94, 0xaf, 0x9a, 0xaf, 0x05, 0xee, 0xfc,
+	0xd2, 0xbc, 0xe7, 0x30, 0x9f, 0x53, 0x54, 0x9d, 0x48, 0x0b, 0x2a, 0x31, 0xa7, 0x48, 0x8c, 0x2e,
+	0x11, 0x7c, 0xbc, 0xda, 0x3f, 0x62, 0x54, 0x8a, 0xb6, 0xe4, 0x78, 0x25, 0x10, 0xb6, 0xfc, 0xe3,
+	0xb2, 0xf4, 0x14, 0x6a, 0x25, 0xb2, 0x66, 0x27, 0x4d, 0xf2, 0x6f, 0x04, 0x95, 0x9f, 0x04, 0xc3,
+	0xb1, 0x47, 0xbf, 0x84, 0x76, 0x9e, 0x1e, 0x7f, 0xb2, 0x7a, 0xfc, 0x21, 0x50, 0x66, 0x4e, 0xd8,
+	0xa7, 0x4c, 0xb6, 0x31, 0xf1, 0x48, 0x2c, 0xda, 0x53, 0x38, 0xe2, 0x43, 0x55, 0xab, 0x1d, 0x4f,
+	0x38, 0xf9, 0x27, 0x02, 0x33, 0xe7, 0x11, 0x25, 0x49, 0x93, 0xc9, 0x4f, 0x11, 0xda, 0x7a, 0xc1,
+	0xdd, 0x91, 0x7c, 0x84, 0xc8, 0x4a, 0x77, 0x08, 0x84, 0xba, 0x13, 0xf1, 0x21, 0x27, 0x99, 0xf0,
+	0xb9, 0x37, 0xa9, 0xe7, 0x72, 0xee, 0xe5, 0xb0, 0x2a, 0xce, 0xb1, 0x5f, 0xd3, 0xaf, 0xf6, 0x6b,
+	0xe6, 0x6c, 0xbf, 0xbe, 0xf3, 0x36, 0x14, 0xe3, 0x0f, 0x0e, 0xb8, 0x04, 0xf9, 0x0f, 0x3e, 0xb2,
+	0x3f, 0xd9, 0xb2, 0xb7, 0x97, 0x53, 0xb8, 0x0c, 0x85, 0xce, 0xd6, 0x9d, 0x0f, 0x05, 0x84, 0x36,
+	0xb7, 0x20, 0xc7, 0x3f, 0xbd, 0xd0, 0x10, 0xbf, 0x07, 0x16, 0x5f, 0xe1, 0x8b, 0x89, 0xda, 0xc6,
+	0xd7, 0x9e, 0xfa, 0xea, 0x2c, 0x5a, 0x35, 0x80, 0xd4, 0xe6, 0x7f, 0x2c, 0xc8, 0xf3, 0xe7, 0x28,
+	0x9f, 0x3d, 0xbe, 0x0d, 0xd9, 0x07, 0x62, 0x68, 0x35, 0xc8, 0xcd, 0x2f, 0x0f, 0xf5, 0xb5, 0x53,
+	0x78, 0xcd, 0xe7, 0x6b, 0x08, 0xff, 0x08, 0x4a, 0x02, 0xa9, 0x66, 0xfe, 0xcb, 0xb3, 0xa3, 0xf7,
+	0x14, 0xa7, 0x2b, 0x67, 0xec, 0x1a, 0xfc, 0x6e, 0x41, 0x56, 0xb8, 0xdb, 0xbc, 0x8d, 0xf9, 0x7e,
+	0xad, 0xaf, 0x9d, 0xc2, 0xeb, 0xd3, 0xf8, 0x7d, 0xb0, 0x78, 0x07, 0x33, 0xcd, 0x61, 0x8c, 0xea,
+	0xf5, 0xd5, 0x59, 0xb4, 0x21, 0xf6, 0x3b, 0xf1, 0x8b, 0x63, 0x6d, 0x76, 0xf4, 0xd2, 0xc7, 0x6b,
+	0xa7, 0x37, 0x62, 0xc9, 0x1f, 0x41, 0xd9, 0xec, 0x9d, 0xf8, 0xca, 0xb4, 0xa8, 0x99, 0x56, 0x5b,
+	0x6f, 0x9c, 0xb5, 0x1d, 0x33, 0xbc, 0x0f, 0x25, 0xa3, 0x6f, 0x99, 0x66, 0x3d, 0xdd, 0x74, 0xeb,
+	0x57, 0xce, 0xd8, 0x8d, 0xb9, 0xed, 0x40, 0x61, 0x87, 0x32, 0x51, 0xd8, 0xf0, 0xa5, 0x84, 0xf8,
+	0x54, 0xc1, 0xae, 0x5f, 0x9e, 0xbf, 0x19, 0x33, 0xfa, 0x3e, 0x14, 0x77, 0x28, 0x53, 0x59, 0xb1,
+	0x36, 0x9b, 0x6c, 0x73, 0x2c, 0x35, 0x9d, 0xb0, 0x24, 0xb5, 0xf9, 0x53, 0x28, 0xe8, 0x21, 0x0d,
+	0x3f, 0x80, 0xea, 0xf4, 0x88, 0x82, 0xdf, 0x30, 0x0c, 0x33, 0x3d, 0xf9, 0xd5, 0xd7, 0x8d, 0xad,
+	0xf9, 0x73, 0x4d, 0x6a, 0x03, 0x75, 0x3e, 0x7d, 0xf6, 0xbc, 0x91, 0xfa, 0xfc, 0x79, 0x23, 0xf5,
+	0xf2, 0x79, 0x03, 0xfd, 0xf2, 0xb8, 0x81, 0xfe, 0x78, 0xdc, 0x40, 0x4f, 0x8f, 0x1b, 0xe8, 0xd9,
+	0x71, 0x03, 0xfd, 0xf3, 0xb8, 0x81, 0xfe, 0x75, 0xdc, 0x48, 0xbd, 0x3c, 0x6e, 0xa0, 0xcf, 0x5e,
+	0x34, 0x52, 0xcf, 0x5e, 0x34, 0x52, 0x9f, 0xbf, 0x68, 0xa4, 0x3e, 0x7d, 0xcb, 0xfc, 0xec, 0x1a,
+	0x3a, 0x07, 0x8e, 0xef, 0xb4, 0x87, 0xc1, 0xa1, 0xdb, 0x36, 0x3f, 0xeb, 0xee, 0xe7, 0xc4, 0xdf,
+	0xd7, 0xff, 0x37, 0x00, 0x03, 0xad, 0xb8, 0xe6, 0xed, 0x15, 0x00, 0x00,
 }
 
 func (x Direction) String() string {
@@ -2086,6 +2396,9 @@ func (this *LabelRequest) Equal(that interface{}) bool {
 	} else if !this.End.Equal(*that1.End) {
 		return false
 	}
+	if this.Query != that1.Query {
+		return false
+	}
 	return true
 }
 func (this *LabelResponse) Equal(that interface{}) bool {
@@ -2753,62 +3066,228 @@ func (this *ChunkRef) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *PushRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *IndexStatsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&logproto.PushRequest{")
-	s = append(s, "Streams: "+fmt.Sprintf("%#v", this.Streams)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *PushResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*IndexStatsRequest)
+	if !ok {
+		that2, ok := that.(IndexStatsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&logproto.PushResponse{")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *QueryRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 11)
-	s = append(s, "&logproto.QueryRequest{")
-	s = append(s, "Selector: "+fmt.Sprintf("%#v", this.Selector)+",\n")
-	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
-	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
-	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
-	s = append(s, "Direction: "+fmt.Sprintf("%#v", this.Direction)+",\n")
-	s = append(s, "Shards: "+fmt.Sprintf("%#v", this.Shards)+",\n")
-	if this.Deletes != nil {
-		s = append(s, "Deletes: "+fmt.Sprintf("%#v", this.Deletes)+",\n")
+	if !this.From.Equal(that1.From) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *SampleQueryRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Through.Equal(that1.Through) {
+		return false
 	}
-	s := make([]string, 0, 9)
-	s = append(s, "&logproto.SampleQueryRequest{")
-	s = append(s, "Selector: "+fmt.Sprintf("%#v", this.Selector)+",\n")
-	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
-	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
-	s = append(s, "Shards: "+fmt.Sprintf("%#v", this.Shards)+",\n")
-	if this.Deletes != nil {
-		s = append(s, "Deletes: "+fmt.Sprintf("%#v", this.Deletes)+",\n")
+	if this.Matchers != that1.Matchers {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *Delete) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *IndexStatsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*IndexStatsResponse)
+	if !ok {
+		that2, ok := that.(IndexStatsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Streams != that1.Streams {
+		return false
+	}
+	if this.Chunks != that1.Chunks {
+		return false
+	}
+	if this.Bytes != that1.Bytes {
+		return false
+	}
+	if this.Entries != that1.Entries {
+		return false
+	}
+	return true
+}
+func (this *VolumeRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*VolumeRequest)
+	if !ok {
+		that2, ok := that.(VolumeRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.From.Equal(that1.From) {
+		return false
+	}
+	if !this.Through.Equal(that1.Through) {
+		return false
+	}
+	if this.Matchers != that1.Matchers {
+		return false
+	}
+	if this.Limit != that1.Limit {
+		return false
+	}
+	if len(this.TargetLabels) != len(that1.TargetLabels) {
+		return false
+	}
+	for i := range this.TargetLabels {
+		if this.TargetLabels[i] != that1.TargetLabels[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *VolumeResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*VolumeResponse)
+	if !ok {
+		that2, ok := that.(VolumeResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Volumes) != len(that1.Volumes) {
+		return false
+	}
+	for i := range this.Volumes {
+		if !this.Volumes[i].Equal(&that1.Volumes[i]) {
+			return false
+		}
+	}
+	if this.Limit != that1.Limit {
+		return false
+	}
+	return true
+}
+func (this *Volume) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Volume)
+	if !ok {
+		that2, ok := that.(Volume)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	if this.Bytes != that1.Bytes {
+		return false
+	}
+	if this.Entries != that1.Entries {
+		return false
+	}
+	return true
+}
+func (this *PushRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&logproto.PushRequest{")
+	s = append(s, "Streams: "+fmt.Sprintf("%#v", this.Streams)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PushResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&logproto.PushResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *QueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 11)
+	s = append(s, "&logproto.QueryRequest{")
+	s = append(s, "Selector: "+fmt.Sprintf("%#v", this.Selector)+",\n")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
+	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
+	s = append(s, "Direction: "+fmt.Sprintf("%#v", this.Direction)+",\n")
+	s = append(s, "Shards: "+fmt.Sprintf("%#v", this.Shards)+",\n")
+	if this.Deletes != nil {
+		s = append(s, "Deletes: "+fmt.Sprintf("%#v", this.Deletes)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SampleQueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&logproto.SampleQueryRequest{")
+	s = append(s, "Selector: "+fmt.Sprintf("%#v", this.Selector)+",\n")
+	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
+	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
+	s = append(s, "Shards: "+fmt.Sprintf("%#v", this.Shards)+",\n")
+	if this.Deletes != nil {
+		s = append(s, "Deletes: "+fmt.Sprintf("%#v", this.Deletes)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Delete) GoString() string {
+	if this == nil {
+		return "nil"
 	}
 	s := make([]string, 0, 7)
 	s = append(s, "&logproto.Delete{")
@@ -2844,12 +3323,13 @@ func (this *LabelRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 8)
+	s := make([]string, 0, 9)
 	s = append(s, "&logproto.LabelRequest{")
 	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
 	s = append(s, "Values: "+fmt.Sprintf("%#v", this.Values)+",\n")
 	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
 	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
+	s = append(s, "Query: "+fmt.Sprintf("%#v", this.Query)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -3135,6 +3615,74 @@ func (this *ChunkRef) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *IndexStatsRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&logproto.IndexStatsRequest{")
+	s = append(s, "From: "+fmt.Sprintf("%#v", this.From)+",\n")
+	s = append(s, "Through: "+fmt.Sprintf("%#v", this.Through)+",\n")
+	s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *IndexStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&logproto.IndexStatsResponse{")
+	s = append(s, "Streams: "+fmt.Sprintf("%#v", this.Streams)+",\n")
+	s = append(s, "Chunks: "+fmt.Sprintf("%#v", this.Chunks)+",\n")
+	s = append(s, "Bytes: "+fmt.Sprintf("%#v", this.Bytes)+",\n")
+	s = append(s, "Entries: "+fmt.Sprintf("%#v", this.Entries)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *VolumeRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&logproto.VolumeRequest{")
+	s = append(s, "From: "+fmt.Sprintf("%#v", this.From)+",\n")
+	s = append(s, "Through: "+fmt.Sprintf("%#v", this.Through)+",\n")
+	s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "TargetLabels: "+fmt.Sprintf("%#v", this.TargetLabels)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *VolumeResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&logproto.VolumeResponse{")
+	if this.Volumes != nil {
+		vs := make([]*Volume, len(this.Volumes))
+		for i := range vs {
+			vs[i] = &this.Volumes[i]
+		}
+		s = append(s, "Volumes: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Volume) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&logproto.Volume{")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Bytes: "+fmt.Sprintf("%#v", this.Bytes)+",\n")
+	s = append(s, "Entries: "+fmt.Sprintf("%#v", this.Entries)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringLogproto(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -3235,6 +3783,8 @@ type QuerierClient interface {
 	Series(ctx context.Context, in *SeriesRequest, opts ...grpc.CallOption) (*SeriesResponse, error)
 	TailersCount(ctx context.Context, in *TailersCountRequest, opts ...grpc.CallOption) (*TailersCountResponse, error)
 	GetChunkIDs(ctx context.Context, in *GetChunkIDsRequest, opts ...grpc.CallOption) (*GetChunkIDsResponse, error)
+	GetStats(ctx context.Context, in *IndexStatsRequest, opts ...grpc.CallOption) (*IndexStatsResponse, error)
+	GetVolume(ctx context.Context, in *VolumeRequest, opts ...grpc.CallOption) (*VolumeResponse, error)
 }
 
 type querierClient struct {
@@ -3377,6 +3927,24 @@ func (c *querierClient) GetChunkIDs(ctx context.Context, in *GetChunkIDsRequest,
 	return out, nil
 }
 
+func (c *querierClient) GetStats(ctx context.Context, in *IndexStatsRequest, opts ...grpc.CallOption) (*IndexStatsResponse, error) {
+	out := new(IndexStatsResponse)
+	err := c.cc.Invoke(ctx, "/logproto.Querier/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *querierClient) GetVolume(ctx context.Context, in *VolumeRequest, opts ...grpc.CallOption) (*VolumeResponse, error) {
+	out := new(VolumeResponse)
+	err := c.cc.Invoke(ctx, "/logproto.Querier/GetVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QuerierServer is the server API for Querier service.
 type QuerierServer interface {
 	Query(*QueryRequest, Querier_QueryServer) error
@@ -3386,6 +3954,8 @@ type QuerierServer interface {
 	Series(context.Context, *SeriesRequest) (*SeriesResponse, error)
 	TailersCount(context.Context, *TailersCountRequest) (*TailersCountResponse, error)
 	GetChunkIDs(context.Context, *GetChunkIDsRequest) (*GetChunkIDsResponse, error)
+	GetStats(context.Context, *IndexStatsRequest) (*IndexStatsResponse, error)
+	GetVolume(context.Context, *VolumeRequest) (*VolumeResponse, error)
 }
 
 // UnimplementedQuerierServer can be embedded to have forward compatible implementations.
@@ -3413,6 +3983,12 @@ func (*UnimplementedQuerierServer) TailersCount(ctx context.Context, req *Tailer
 func (*UnimplementedQuerierServer) GetChunkIDs(ctx context.Context, req *GetChunkIDsRequest) (*GetChunkIDsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChunkIDs not implemented")
 }
+func (*UnimplementedQuerierServer) GetStats(ctx context.Context, req *IndexStatsRequest) (*IndexStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (*UnimplementedQuerierServer) GetVolume(ctx context.Context, req *VolumeRequest) (*VolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolume not implemented")
+}
 
 func RegisterQuerierServer(s *grpc.Server, srv QuerierServer) {
 	s.RegisterService(&_Querier_serviceDesc, srv)
@@ -3553,29 +4129,73 @@ func _Querier_GetChunkIDs_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Querier_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "logproto.Querier",
-	HandlerType: (*QuerierServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Label",
-			Handler:    _Querier_Label_Handler,
-		},
-		{
-			MethodName: "Series",
-			Handler:    _Querier_Series_Handler,
-		},
-		{
-			MethodName: "TailersCount",
-			Handler:    _Querier_TailersCount_Handler,
-		},
-		{
-			MethodName: "GetChunkIDs",
-			Handler:    _Querier_GetChunkIDs_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
+func _Querier_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuerierServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/logproto.Querier/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuerierServer).GetStats(ctx, req.(*IndexStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Querier_GetVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuerierServer).GetVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/logproto.Querier/GetVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuerierServer).GetVolume(ctx, req.(*VolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Querier_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "logproto.Querier",
+	HandlerType: (*QuerierServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Label",
+			Handler:    _Querier_Label_Handler,
+		},
+		{
+			MethodName: "Series",
+			Handler:    _Querier_Series_Handler,
+		},
+		{
+			MethodName: "TailersCount",
+			Handler:    _Querier_TailersCount_Handler,
+		},
+		{
+			MethodName: "GetChunkIDs",
+			Handler:    _Querier_GetChunkIDs_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Querier_GetStats_Handler,
+		},
+		{
+			MethodName: "GetVolume",
+			Handler:    _Querier_GetVolume_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
 			StreamName:    "Query",
 			Handler:       _Querier_Query_Handler,
 			ServerStreams: true,
@@ -4062,6 +4682,13 @@ func (m *LabelRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintLogproto(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.End != nil {
 		n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.End, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.End):])
 		if err7 != nil {
@@ -4993,6 +5620,225 @@ func (m *ChunkRef) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *IndexStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IndexStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		i -= len(m.Matchers)
+		copy(dAtA[i:], m.Matchers)
+		i = encodeVarintLogproto(dAtA, i, uint64(len(m.Matchers)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Through != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Through))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.From != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.From))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IndexStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IndexStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Entries != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Entries))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Bytes != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Bytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Chunks != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Chunks))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Streams != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Streams))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VolumeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TargetLabels) > 0 {
+		for iNdEx := len(m.TargetLabels) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TargetLabels[iNdEx])
+			copy(dAtA[i:], m.TargetLabels[iNdEx])
+			i = encodeVarintLogproto(dAtA, i, uint64(len(m.TargetLabels[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Limit != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Matchers) > 0 {
+		i -= len(m.Matchers)
+		copy(dAtA[i:], m.Matchers)
+		i = encodeVarintLogproto(dAtA, i, uint64(len(m.Matchers)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Through != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Through))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.From != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.From))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VolumeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VolumeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VolumeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Volumes) > 0 {
+		for iNdEx := len(m.Volumes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Volumes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLogproto(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Volume) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Volume) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Volume) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Entries != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Entries))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Bytes != 0 {
+		i = encodeVarintLogproto(dAtA, i, uint64(m.Bytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintLogproto(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintLogproto(dAtA []byte, offset int, v uint64) int {
 	offset -= sovLogproto(v)
 	base := offset
@@ -5166,6 +6012,10 @@ func (m *LabelRequest) Size() (n int) {
 		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.End)
 		n += 1 + l + sovLogproto(uint64(l))
 	}
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovLogproto(uint64(l))
+	}
 	return n
 }
 
@@ -5553,15 +6403,120 @@ func (m *ChunkRef) Size() (n int) {
 	return n
 }
 
-func sovLogproto(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozLogproto(x uint64) (n int) {
-	return sovLogproto(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *PushRequest) String() string {
-	if this == nil {
-		return "nil"
+func (m *IndexStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.From != 0 {
+		n += 1 + sovLogproto(uint64(m.From))
+	}
+	if m.Through != 0 {
+		n += 1 + sovLogproto(uint64(m.Through))
+	}
+	l = len(m.Matchers)
+	if l > 0 {
+		n += 1 + l + sovLogproto(uint64(l))
+	}
+	return n
+}
+
+func (m *IndexStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Streams != 0 {
+		n += 1 + sovLogproto(uint64(m.Streams))
+	}
+	if m.Chunks != 0 {
+		n += 1 + sovLogproto(uint64(m.Chunks))
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovLogproto(uint64(m.Bytes))
+	}
+	if m.Entries != 0 {
+		n += 1 + sovLogproto(uint64(m.Entries))
+	}
+	return n
+}
+
+func (m *VolumeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.From != 0 {
+		n += 1 + sovLogproto(uint64(m.From))
+	}
+	if m.Through != 0 {
+		n += 1 + sovLogproto(uint64(m.Through))
+	}
+	l = len(m.Matchers)
+	if l > 0 {
+		n += 1 + l + sovLogproto(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovLogproto(uint64(m.Limit))
+	}
+	if len(m.TargetLabels) > 0 {
+		for _, s := range m.TargetLabels {
+			l = len(s)
+			n += 1 + l + sovLogproto(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *VolumeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Volumes) > 0 {
+		for _, e := range m.Volumes {
+			l = e.Size()
+			n += 1 + l + sovLogproto(uint64(l))
+		}
+	}
+	if m.Limit != 0 {
+		n += 1 + sovLogproto(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *Volume) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovLogproto(uint64(l))
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovLogproto(uint64(m.Bytes))
+	}
+	if m.Entries != 0 {
+		n += 1 + sovLogproto(uint64(m.Entries))
+	}
+	return n
+}
+
+func sovLogproto(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLogproto(x uint64) (n int) {
+	return sovLogproto(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *PushRequest) String() string {
+	if this == nil {
+		return "nil"
 	}
 	s := strings.Join([]string{`&PushRequest{`,
 		`Streams:` + fmt.Sprintf("%v", this.Streams) + `,`,
@@ -5661,6 +6616,7 @@ func (this *LabelRequest) String() string {
 		`Values:` + fmt.Sprintf("%v", this.Values) + `,`,
 		`Start:` + strings.Replace(fmt.Sprintf("%v", this.Start), "Timestamp", "types.Timestamp", 1) + `,`,
 		`End:` + strings.Replace(fmt.Sprintf("%v", this.End), "Timestamp", "types.Timestamp", 1) + `,`,
+		`Query:` + fmt.Sprintf("%v", this.Query) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -5951,6 +6907,73 @@ func (this *ChunkRef) String() string {
 	}, "")
 	return s
 }
+func (this *IndexStatsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&IndexStatsRequest{`,
+		`From:` + fmt.Sprintf("%v", this.From) + `,`,
+		`Through:` + fmt.Sprintf("%v", this.Through) + `,`,
+		`Matchers:` + fmt.Sprintf("%v", this.Matchers) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *IndexStatsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&IndexStatsResponse{`,
+		`Streams:` + fmt.Sprintf("%v", this.Streams) + `,`,
+		`Chunks:` + fmt.Sprintf("%v", this.Chunks) + `,`,
+		`Bytes:` + fmt.Sprintf("%v", this.Bytes) + `,`,
+		`Entries:` + fmt.Sprintf("%v", this.Entries) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *VolumeRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&VolumeRequest{`,
+		`From:` + fmt.Sprintf("%v", this.From) + `,`,
+		`Through:` + fmt.Sprintf("%v", this.Through) + `,`,
+		`Matchers:` + fmt.Sprintf("%v", this.Matchers) + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`TargetLabels:` + fmt.Sprintf("%v", this.TargetLabels) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *VolumeResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForVolumes := "[]Volume{"
+	for _, f := range this.Volumes {
+		repeatedStringForVolumes += strings.Replace(strings.Replace(f.String(), "Volume", "Volume", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForVolumes += "}"
+	s := strings.Join([]string{`&VolumeResponse{`,
+		`Volumes:` + repeatedStringForVolumes + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Volume) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Volume{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Bytes:` + fmt.Sprintf("%v", this.Bytes) + `,`,
+		`Entries:` + fmt.Sprintf("%v", this.Entries) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringLogproto(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -7087,6 +8110,38 @@ func (m *LabelRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLogproto(dAtA[iNdEx:])
@@ -9740,6 +10795,661 @@ func (m *ChunkRef) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *IndexStatsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogproto
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IndexStatsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IndexStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			m.From = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.From |= github_com_prometheus_common_model.Time(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Through", wireType)
+			}
+			m.Through = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Through |= github_com_prometheus_common_model.Time(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogproto(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IndexStatsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogproto
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IndexStatsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IndexStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Streams", wireType)
+			}
+			m.Streams = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Streams |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
+			}
+			m.Chunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Chunks |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			m.Entries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Entries |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogproto(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VolumeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogproto
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VolumeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			m.From = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.From |= github_com_prometheus_common_model.Time(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Through", wireType)
+			}
+			m.Through = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Through |= github_com_prometheus_common_model.Time(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetLabels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetLabels = append(m.TargetLabels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogproto(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VolumeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogproto
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VolumeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VolumeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Volumes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Volumes = append(m.Volumes, Volume{})
+			if err := m.Volumes[len(m.Volumes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogproto(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Volume) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogproto
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Volume: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Volume: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			m.Entries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogproto
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Entries |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogproto(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLogproto
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipLogproto(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,211 @@
+package logql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	promql_parser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/loki/pkg/logql/sketch"
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/logqlmodel"
+	"github.com/grafana/loki/pkg/util"
+)
+
+// ValueTypeCountMinSketchVector is the promql.ValueType of the result of count_min_sketch.
+const ValueTypeCountMinSketchVector = "count_min_sketch_vector"
+
+// CountMinSketchVector is the result of a count_min_sketch query: the sketch of
+// the values of all the series of a vector and the metrics of its top k series.
+// The vectors of every shard of an approx_topk query are merged before selecting
+// the top k series, see CountMinSketchMergeExpr.
+type CountMinSketchVector struct {
+	T       int64
+	Sketch  *sketch.CountMinSketch
+	Metrics []labels.Labels
+}
+
+func (CountMinSketchVector) Type() promql_parser.ValueType { return ValueTypeCountMinSketchVector }
+
+func (v CountMinSketchVector) String() string {
+	var sb strings.Builder
+	for i, m := range v.Metrics {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("%s => %v @[%d]", m.String(), v.Sketch.Count(m.String()), v.T))
+	}
+	return sb.String()
+}
+
+// TopK returns the k series of the vector with the highest estimated values.
+func (v CountMinSketchVector) TopK(k int) promql.Vector {
+	result := make(promql.Vector, 0, len(v.Metrics))
+	for _, m := range v.Metrics {
+		result = append(result, promql.Sample{
+			Metric: m,
+			Point:  promql.Point{T: v.T, V: v.Sketch.Count(m.String())},
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].V != result[j].V {
+			return result[i].V > result[j].V
+		}
+		return labels.Compare(result[i].Metric, result[j].Metric) < 0
+	})
+	if k < 0 {
+		k = 0
+	}
+	if len(result) > k {
+		result = result[:k]
+	}
+	return result
+}
+
+// MergeCountMinSketchVectors merges the sketches of multiple vectors and the
+// metrics of their top series. Sketches of the given vectors are merged in place.
+func MergeCountMinSketchVectors(vectors ...CountMinSketchVector) (CountMinSketchVector, error) {
+	if len(vectors) == 0 {
+		return CountMinSketchVector{}, errors.New("no count-min sketch to merge")
+	}
+	merged := CountMinSketchVector{
+		T:      vectors[0].T,
+		Sketch: vectors[0].Sketch,
+	}
+	seen := map[uint64]struct{}{}
+	for i, v := range vectors {
+		if i > 0 {
+			if err := merged.Sketch.Merge(v.Sketch); err != nil {
+				return CountMinSketchVector{}, err
+			}
+		}
+		for _, m := range v.Metrics {
+			hash := m.Hash()
+			if _, ok := seen[hash]; ok {
+				continue
+			}
+			seen[hash] = struct{}{}
+			merged.Metrics = append(merged.Metrics, m)
+		}
+	}
+	return merged, nil
+}
+
+// CountMinSketchMergeExpr is a SampleExpr computing approx_topk from the sketches
+// returned by its downstream count_min_sketch expressions.
+type CountMinSketchMergeExpr struct {
+	*ConcatSampleExpr
+	k int
+}
+
+func (e *CountMinSketchMergeExpr) String() string {
+	return fmt.Sprintf("count_min_sketch_merge<%d, %s>", e.k, e.ConcatSampleExpr.String())
+}
+
+func (e *CountMinSketchMergeExpr) Walk(f syntax.WalkFn) {
+	f(e)
+	e.ConcatSampleExpr.Walk(f)
+}
+
+// validateApproxTopK ensures approx_topk is only used in instant queries: a
+// sketch per step of range queries would use too much memory.
+func validateApproxTopK(expr syntax.SampleExpr, params Params) error {
+	if GetRangeType(params) == InstantType {
+		return nil
+	}
+	var approx bool
+	expr.Walk(func(e interface{}) {
+		switch e := e.(type) {
+		case *syntax.VectorAggregationExpr:
+			if e.Operation == syntax.OpTypeApproxTopK || e.Operation == syntax.OpTypeCountMinSketch {
+				approx = true
+			}
+		case *CountMinSketchMergeExpr:
+			approx = true
+		}
+	})
+	if approx {
+		return logqlmodel.NewParseError(fmt.Sprintf("%s is only supported for instant queries", syntax.OpTypeApproxTopK), 0, 0)
+	}
+	return nil
+}
+
+// countMinSketchEvaluator is implemented by evaluators able to evaluate
+// count_min_sketch, whose result cannot be represented by a StepEvaluator.
+type countMinSketchEvaluator interface {
+	countMinSketch(ctx context.Context, expr *syntax.VectorAggregationExpr, q Params) (CountMinSketchVector, error)
+}
+
+func (ev *DefaultEvaluator) countMinSketch(ctx context.Context, expr *syntax.VectorAggregationExpr, q Params) (CountMinSketchVector, error) {
+	stepEvaluator, err := ev.StepEvaluator(ctx, ev, expr.Left, q)
+	if err != nil {
+		return CountMinSketchVector{}, err
+	}
+	defer util.LogErrorWithContext(ctx, "closing SampleExpr", stepEvaluator.Close)
+
+	_, ts, vec := stepEvaluator.Next()
+	if err := stepEvaluator.Error(); err != nil {
+		return CountMinSketchVector{}, err
+	}
+
+	s, err := sketch.NewCountMinSketch(sketch.DefaultCountMinSketchDepth, sketch.DefaultCountMinSketchWidth)
+	if err != nil {
+		return CountMinSketchVector{}, err
+	}
+	top := make(promql.Vector, 0, len(vec))
+	for _, sample := range vec {
+		if math.IsNaN(sample.V) {
+			continue
+		}
+		s.Add(sample.Metric.String(), sample.V)
+		top = append(top, sample)
+	}
+
+	// only the top k series of every shard are candidates for the top k series
+	// of the merged sketch.
+	sort.Slice(top, func(i, j int) bool { return top[i].V > top[j].V })
+	if len(top) > expr.Params {
+		top = top[:expr.Params]
+	}
+	metrics := make([]labels.Labels, 0, len(top))
+	for _, sample := range top {
+		metrics = append(metrics, sample.Metric)
+	}
+	return CountMinSketchVector{T: ts, Sketch: s, Metrics: metrics}, nil
+}
+
+// countMinSketchMergeEvaluator downstreams the count_min_sketch expressions of a
+// CountMinSketchMergeExpr and exposes the top k series of the merged sketches as a StepEvaluator.
+func (ev *DownstreamEvaluator) countMinSketchMergeEvaluator(ctx context.Context, expr *CountMinSketchMergeExpr, params Params) (StepEvaluator, error) {
+	queries := downstreamQueries(expr.ConcatSampleExpr, params)
+	results, err := ev.Downstream(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([]CountMinSketchVector, 0, len(results))
+	for i, res := range results {
+		v, ok := res.Data.(CountMinSketchVector)
+		if !ok {
+			return nil, errors.Errorf("unexpected type (%T) for downstream query %s, expected %s", res.Data, queries[i].Expr.String(), ValueTypeCountMinSketchVector)
+		}
+		vectors = append(vectors, v)
+	}
+
+	merged, err := MergeCountMinSketchVectors(vectors...)
+	if err != nil {
+		return nil, err
+	}
+	top := merged.TopK(expr.k)
+	matrix := make(promql.Matrix, 0, len(top))
+	for _, s := range top {
+		matrix = append(matrix, promql.Series{Metric: s.Metric, Points: []promql.Point{s.Point}})
+	}
+	return NewMatrixStepper(params.Start(), params.End(), params.Step(), matrix), nil
+}
@@ -238,20 +238,7 @@ func (ev *DownstreamEvaluator) StepEvaluator(
 		return ResultStepEvaluator(results[0], params)
 
 	case *ConcatSampleExpr:
-		cur := e
-		var queries []DownstreamQuery
-		for cur != nil {
-			qry := DownstreamQuery{
-				Expr:   cur.DownstreamSampleExpr.SampleExpr,
-				Params: params,
-			}
-			if shard := cur.DownstreamSampleExpr.shard; shard != nil {
-				qry.Shards = Shards{*shard}
-			}
-			queries = append(queries, qry)
-			cur = cur.next
-		}
-
+		queries := downstreamQueries(e, params)
 		results, err := ev.Downstream(ctx, queries)
 		if err != nil {
 			return nil, err
@@ -273,11 +260,33 @@ func (ev *DownstreamEvaluator) StepEvaluator(
 
 		return ConcatEvaluator(xs)
 
+	case *QuantileSketchMergeExpr:
+		return ev.quantileSketchMergeEvaluator(ctx, e, params)
+
+	case *CountMinSketchMergeExpr:
+		return ev.countMinSketchMergeEvaluator(ctx, e, params)
+
 	default:
 		return ev.defaultEvaluator.StepEvaluator(ctx, nextEv, e, params)
 	}
 }
 
+// downstreamQueries returns the queries of every SampleExpr of a ConcatSampleExpr.
+func downstreamQueries(e *ConcatSampleExpr, params Params) []DownstreamQuery {
+	var queries []DownstreamQuery
+	for cur := e; cur != nil; cur = cur.next {
+		qry := DownstreamQuery{
+			Expr:   cur.DownstreamSampleExpr.SampleExpr,
+			Params: params,
+		}
+		if shard := cur.DownstreamSampleExpr.shard; shard != nil {
+			qry.Shards = Shards{*shard}
+		}
+		queries = append(queries, qry)
+	}
+	return queries
+}
+
 // Iterator returns the iter.EntryIterator for a given LogSelectorExpr
 func (ev *DownstreamEvaluator) Iterator(
 	ctx context.Context,
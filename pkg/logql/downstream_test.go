@@ -3,6 +3,7 @@ package logql
 import (
 	"context"
 	"math"
+	"sort"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/weaveworks/common/user"
 
 	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/sketch"
 )
 
 var nilMetrics = NewShardingMetrics(nil)
@@ -48,6 +50,17 @@ func TestMappingEquivalence(t *testing.T) {
 		{`sum(max(rate({a=~".+"}[1s])))`, false},
 		{`max(count(rate({a=~".+"}[1s])))`, false},
 		{`max(sum by (cluster) (rate({a=~".+"}[1s]))) / count(rate({a=~".+"}[1s]))`, false},
+		{`sum by (a) (rate({a=~".+"}[1s] @ 10))`, false},
+		{`rate({a=~".+"}[1s]) / rate({a=~".+"}[1s] @ end())`, false},
+		{`max_over_time(rate({a=~".+"}[1s])[5s:1s])`, false},
+		{`max_over_time(sum by (a) (rate({a=~".+"}[1s]))[5s:2s] offset 1s)`, false},
+		{`sum(avg_over_time(count_over_time({a=~".+"}[2s])[4s:1s]))`, false},
+		{`sort(sum by (a) (rate({a=~".+"}[1s])))`, false},
+		{`rate_counter({a=~".+"} | logfmt | unwrap line [2s])`, false},
+		{`sum by (a) (rate_counter({a=~".+"} | logfmt | unwrap line [2s]))`, true},
+		{`label_join(sum by (a, b) (rate({a=~".+"}[1s])), "c", "-", "a", "b")`, false},
+		{`sum by (a) (rate({a="none"}[1s])) or vector(0)`, false},
+		{`count(vector(1)) + sum(rate({a=~".+"}[1s]))`, false},
 		// topk prefers already-seen values in tiebreakers. Since the test data generates
 		// the same log lines for each series & the resulting promql.Vectors aren't deterministically
 		// sorted by labels, we don't expect this to pass.
@@ -77,7 +90,7 @@ func TestMappingEquivalence(t *testing.T) {
 			qry := regular.Query(params)
 			ctx := user.InjectOrgID(context.Background(), "fake")
 
-			mapper, err := NewShardMapper(shards, nilMetrics)
+			mapper, err := NewShardMapper(shards, nilMetrics, false)
 			require.Nil(t, err)
 			_, mapped, err := mapper.Parse(tc.query)
 			require.Nil(t, err)
@@ -99,6 +112,195 @@ func TestMappingEquivalence(t *testing.T) {
 	}
 }
 
+func TestSortMappingEquivalence(t *testing.T) {
+	var (
+		shards   = 3
+		nStreams = 60
+		rounds   = 20
+		streams  = randomStreams(nStreams, rounds+1, shards, []string{"a", "b", "c", "d"})
+		end      = time.Unix(0, int64(time.Second*time.Duration(rounds)))
+		limit    = 100
+	)
+
+	for _, query := range []string{
+		`sort(sum by (a) (count_over_time({a=~".+"}[10s])))`,
+		`sort_desc(sum by (a) (count_over_time({a=~".+"}[10s])))`,
+		`sort_desc(label_join(sum by (a, b) (rate({a=~".+"}[10s])), "c", "-", "a", "b"))`,
+		`sort_desc(sum by (a) (count_over_time({a=~".+"}[10s])) or vector(0))`,
+	} {
+		q := NewMockQuerier(
+			shards,
+			streams,
+		)
+
+		opts := EngineOpts{}
+		regular := NewEngine(opts, q, NoLimits, log.NewNopLogger())
+		sharded := NewDownstreamEngine(opts, MockDownstreamer{regular}, nilMetrics, NoLimits, log.NewNopLogger())
+
+		t.Run(query, func(t *testing.T) {
+			params := NewLiteralParams(query, end, end, 0, 0, logproto.FORWARD, uint32(limit), nil)
+			ctx := user.InjectOrgID(context.Background(), "fake")
+
+			mapper, err := NewShardMapper(shards, nilMetrics, false)
+			require.Nil(t, err)
+			noop, mapped, err := mapper.Parse(query)
+			require.Nil(t, err)
+			require.False(t, noop)
+
+			res, err := regular.Query(params).Exec(ctx)
+			require.Nil(t, err)
+
+			shardedRes, err := sharded.Query(params, mapped).Exec(ctx)
+			require.Nil(t, err)
+
+			require.Equal(t, res.Data, shardedRes.Data)
+		})
+	}
+}
+
+func TestQuantileSketchMappingEquivalence(t *testing.T) {
+	var (
+		shards   = 3
+		nStreams = 60
+		rounds   = 20
+		streams  = randomStreams(nStreams, rounds+1, shards, []string{"a", "b", "c", "d"})
+		start    = time.Unix(0, 0)
+		end      = time.Unix(0, int64(time.Second*time.Duration(rounds)))
+		step     = time.Second
+		interval = time.Duration(0)
+		limit    = 100
+	)
+
+	for _, tc := range []struct {
+		query string
+		start time.Time
+		step  time.Duration
+	}{
+		{`quantile_over_time(0.99, {a=~".+"} | logfmt | unwrap line [2s])`, start, step},
+		{`quantile_over_time(0.5, {a=~".+"} | logfmt | unwrap line [5s]) by (a)`, start, step},
+		{`quantile_over_time(0.9, {a=~".+"} | logfmt | unwrap line [5s]) without (b, c, d, index, level, stream)`, start, step},
+		{`quantile_over_time(0.75, {a=~".+"} | logfmt | drop level | unwrap line [3s] offset 2s) by (b)`, start, step},
+		{`max by (a) (quantile_over_time(0.99, {a=~".+"} | logfmt | unwrap line [2s]) by (a, b))`, start, step},
+		{`quantile_over_time(0.99, {a=~".+"} | logfmt | unwrap line [10s]) by (a)`, end, 0},
+	} {
+		q := NewMockQuerier(
+			shards,
+			streams,
+		)
+
+		opts := EngineOpts{}
+		regular := NewEngine(opts, q, NoLimits, log.NewNopLogger())
+		sharded := NewDownstreamEngine(opts, MockDownstreamer{regular}, nilMetrics, NoLimits, log.NewNopLogger())
+
+		t.Run(tc.query, func(t *testing.T) {
+			params := NewLiteralParams(
+				tc.query,
+				tc.start,
+				end,
+				tc.step,
+				interval,
+				logproto.FORWARD,
+				uint32(limit),
+				nil,
+			)
+			ctx := user.InjectOrgID(context.Background(), "fake")
+
+			mapper, err := NewShardMapper(shards, nilMetrics, true)
+			require.Nil(t, err)
+			noop, mapped, err := mapper.Parse(tc.query)
+			require.Nil(t, err)
+			require.False(t, noop)
+
+			res, err := regular.Query(params).Exec(ctx)
+			require.Nil(t, err)
+
+			shardedRes, err := sharded.Query(params, mapped).Exec(ctx)
+			require.Nil(t, err)
+
+			expected, actual := res.Data, shardedRes.Data
+			if vec, ok := expected.(promql.Vector); ok {
+				expected = vectorToMatrix(vec)
+				actual = vectorToMatrix(actual.(promql.Vector))
+			}
+			relativelyEquals(t, expected.(promql.Matrix), actual.(promql.Matrix), sketch.DefaultRelativeAccuracy)
+		})
+	}
+}
+
+func TestApproxTopKMappingEquivalence(t *testing.T) {
+	var (
+		shards   = 3
+		nStreams = 60
+		rounds   = 20
+		streams  = randomStreams(nStreams, rounds+1, shards, []string{"a", "b", "c", "d"})
+		end      = time.Unix(0, int64(time.Second*time.Duration(rounds)))
+		limit    = 100
+	)
+
+	for _, tc := range []struct {
+		query    string
+		expected string
+	}{
+		{`approx_topk(3, sum by (a) (count_over_time({a=~".+"}[10s])))`, `topk(3, sum by (a) (count_over_time({a=~".+"}[10s])))`},
+		{`approx_topk(5, sum by (a, b) (rate({a=~".+"} | logfmt | drop level [10s])))`, `topk(5, sum by (a, b) (rate({a=~".+"} | logfmt | drop level [10s])))`},
+		{`approx_topk(2, sum by (b) (sum_over_time({a=~".+"} | logfmt | unwrap line [10s])))`, `topk(2, sum by (b) (sum_over_time({a=~".+"} | logfmt | unwrap line [10s])))`},
+	} {
+		q := NewMockQuerier(
+			shards,
+			streams,
+		)
+
+		opts := EngineOpts{}
+		regular := NewEngine(opts, q, NoLimits, log.NewNopLogger())
+		sharded := NewDownstreamEngine(opts, MockDownstreamer{regular}, nilMetrics, NoLimits, log.NewNopLogger())
+
+		t.Run(tc.query, func(t *testing.T) {
+			params := NewLiteralParams(
+				tc.query,
+				end,
+				end,
+				0,
+				0,
+				logproto.FORWARD,
+				uint32(limit),
+				nil,
+			)
+			ctx := user.InjectOrgID(context.Background(), "fake")
+
+			mapper, err := NewShardMapper(shards, nilMetrics, false)
+			require.Nil(t, err)
+			noop, mapped, err := mapper.Parse(tc.query)
+			require.Nil(t, err)
+			require.False(t, noop)
+
+			expectedParams := NewLiteralParams(tc.expected, end, end, 0, 0, logproto.FORWARD, uint32(limit), nil)
+			res, err := regular.Query(expectedParams).Exec(ctx)
+			require.Nil(t, err)
+
+			shardedRes, err := sharded.Query(params, mapped).Exec(ctx)
+			require.Nil(t, err)
+
+			// with few series the estimates of the sketch are exact.
+			require.NotEmpty(t, shardedRes.Data)
+			require.Equal(t, res.Data, shardedRes.Data)
+		})
+	}
+}
+
+func TestApproxTopKRangeQuery(t *testing.T) {
+	q := NewMockQuerier(1, randomStreams(5, 10, 1, []string{"a"}))
+	regular := NewEngine(EngineOpts{}, q, NoLimits, log.NewNopLogger())
+	ctx := user.InjectOrgID(context.Background(), "fake")
+
+	query := `approx_topk(3, sum by (index) (count_over_time({a=~".+"}[1s])))`
+	_, err := regular.Query(NewLiteralParams(query, time.Unix(0, 0), time.Unix(10, 0), time.Second, 0, logproto.FORWARD, 100, nil)).Exec(ctx)
+	require.Error(t, err)
+
+	res, err := regular.Query(NewLiteralParams(query, time.Unix(10, 0), time.Unix(10, 0), 0, 0, logproto.FORWARD, 100, nil)).Exec(ctx)
+	require.Nil(t, err)
+	require.Len(t, res.Data.(promql.Vector), 3)
+}
+
 func TestRangeMappingEquivalence(t *testing.T) {
 	var (
 		shards   = 3
@@ -252,6 +454,10 @@ func TestRangeMappingEquivalence(t *testing.T) {
 
 		// range with offset
 		{`rate({a=~".+"}[2s] offset 2s)`, time.Second},
+
+		// range with @ modifier
+		{`rate({a=~".+"}[2s] @ 10)`, time.Second},
+		{`sum by (a) (count_over_time({a=~".+"}[3s] @ 10 offset 2s))`, time.Second},
 	} {
 		q := NewMockQuerier(
 			shards,
@@ -318,3 +524,30 @@ func approximatelyEquals(t *testing.T, as, bs promql.Matrix) {
 		require.Equal(t, a, b)
 	}
 }
+
+// relativelyEquals ensures two responses are equal within a relative error per sample.
+func relativelyEquals(t *testing.T, as, bs promql.Matrix, relativeError float64) {
+	require.Equal(t, len(as), len(bs))
+	sort.Sort(as)
+	sort.Sort(bs)
+
+	for i := 0; i < len(as); i++ {
+		a := as[i]
+		b := bs[i]
+		require.Equal(t, a.Metric, b.Metric)
+		require.Equal(t, len(a.Points), len(b.Points))
+
+		for j := 0; j < len(a.Points); j++ {
+			require.Equal(t, a.Points[j].T, b.Points[j].T)
+			require.InDelta(t, a.Points[j].V, b.Points[j].V, math.Abs(a.Points[j].V)*relativeError+1e-9, "series %s at %d", a.Metric, a.Points[j].T)
+		}
+	}
+}
+
+func vectorToMatrix(v promql.Vector) promql.Matrix {
+	m := make(promql.Matrix, 0, len(v))
+	for _, s := range v {
+		m = append(m, promql.Series{Metric: s.Metric, Points: []promql.Point{s.Point}})
+	}
+	return m
+}
@@ -74,19 +74,33 @@ type SelectSampleParams struct {
 // Expr returns the SampleExpr from the SelectSampleParams.
 // The `LogSelectorExpr` can then returns all matchers and filters to use for that request.
 func (s SelectSampleParams) Expr() (syntax.SampleExpr, error) {
-	return syntax.ParseSampleExpr(s.Selector)
+	return parseDownstreamSampleExpr(s.Selector)
 }
 
 // LogSelector returns the LogSelectorExpr from the SelectParams.
 // The `LogSelectorExpr` can then returns all matchers and filters to use for that request.
 func (s SelectSampleParams) LogSelector() (syntax.LogSelectorExpr, error) {
-	expr, err := syntax.ParseSampleExpr(s.Selector)
+	expr, err := parseDownstreamSampleExpr(s.Selector)
 	if err != nil {
 		return nil, err
 	}
 	return expr.Selector(), nil
 }
 
+// parseDownstreamSampleExpr parses the sample expression of a downstream query, which can
+// contain the operations only built by the shard mapper.
+func parseDownstreamSampleExpr(q string) (syntax.SampleExpr, error) {
+	expr, err := syntax.ParseDownstreamExpr(q)
+	if err != nil {
+		return nil, err
+	}
+	sampleExpr, ok := expr.(syntax.SampleExpr)
+	if !ok {
+		return nil, errors.New("only sample expression supported")
+	}
+	return sampleExpr, nil
+}
+
 // Querier allows a LogQL expression to fetch an EntryIterator for a
 // set of matchers and filters
 type Querier interface {
@@ -147,6 +161,10 @@ func (ng *Engine) Query(params Params) Query {
 		params:    params,
 		evaluator: ng.evaluator,
 		parse: func(_ context.Context, query string) (syntax.Expr, error) {
+			// sharded queries are downstream queries of the query frontend.
+			if len(params.Shards()) > 0 {
+				return syntax.ParseDownstreamExpr(query)
+			}
 			return syntax.ParseExpr(query)
 		},
 		record: true,
@@ -250,17 +268,36 @@ func (q *query) evalSample(ctx context.Context, expr syntax.SampleExpr) (promql_
 		return nil, err
 	}
 
-	stepEvaluator, err := q.evaluator.StepEvaluator(ctx, q.evaluator, expr, q.params)
+	tenantIDs, err := tenant.TenantIDs(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer util.LogErrorWithContext(ctx, "closing SampleExpr", stepEvaluator.Close)
+	maxSeries := validation.SmallestPositiveIntPerTenant(tenantIDs, q.limits.MaxQuerySeries)
 
-	tenantIDs, err := tenant.TenantIDs(ctx)
+	if e, ok := expr.(*syntax.RangeAggregationExpr); ok && e.Operation == syntax.OpRangeTypeQuantileSketch {
+		ev, ok := q.evaluator.(quantileSketchEvaluator)
+		if !ok {
+			return nil, EvaluatorUnsupportedType(e, q.evaluator)
+		}
+		return ev.quantileSketches(ctx, e, q.params, maxSeries)
+	}
+
+	if err := validateApproxTopK(expr, q.params); err != nil {
+		return nil, err
+	}
+	if e, ok := expr.(*syntax.VectorAggregationExpr); ok && e.Operation == syntax.OpTypeCountMinSketch {
+		ev, ok := q.evaluator.(countMinSketchEvaluator)
+		if !ok {
+			return nil, EvaluatorUnsupportedType(e, q.evaluator)
+		}
+		return ev.countMinSketch(ctx, e, q.params)
+	}
+
+	stepEvaluator, err := q.evaluator.StepEvaluator(ctx, q.evaluator, expr, q.params)
 	if err != nil {
 		return nil, err
 	}
-	maxSeries := validation.SmallestPositiveIntPerTenant(tenantIDs, q.limits.MaxQuerySeries)
+	defer util.LogErrorWithContext(ctx, "closing SampleExpr", stepEvaluator.Close)
 	seriesIndex := map[uint64]*promql.Series{}
 
 	next, ts, vec := stepEvaluator.Next()
@@ -274,7 +311,10 @@ func (q *query) evalSample(ctx context.Context, expr syntax.SampleExpr) (promql_
 	}
 
 	if GetRangeType(q.params) == InstantType {
-		sort.Slice(vec, func(i, j int) bool { return labels.Compare(vec[i].Metric, vec[j].Metric) < 0 })
+		// the samples of sort and sort_desc are already ordered by value.
+		if !sortedByValue(expr) {
+			sort.Slice(vec, func(i, j int) bool { return labels.Compare(vec[i].Metric, vec[j].Metric) < 0 })
+		}
 		return vec, nil
 	}
 
@@ -324,6 +364,12 @@ func (q *query) evalSample(ctx context.Context, expr syntax.SampleExpr) (promql_
 	return result, stepEvaluator.Error()
 }
 
+// sortedByValue tells if the instant vector of an expression is ordered by value.
+func sortedByValue(expr syntax.SampleExpr) bool {
+	e, ok := expr.(*syntax.VectorAggregationExpr)
+	return ok && (e.Operation == syntax.OpTypeSort || e.Operation == syntax.OpTypeSortDesc)
+}
+
 func (q *query) evalLiteral(_ context.Context, expr *syntax.LiteralExpr) (promql_parser.Value, error) {
 	s := promql.Scalar{
 		T: q.params.Start().UnixNano() / int64(time.Millisecond),
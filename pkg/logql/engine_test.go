@@ -376,6 +376,69 @@ func TestEngine_LogsInstantQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			`sort(sum by (app) (count_over_time({app=~"foo|bar"}[1m])))`, time.Unix(60, 0), logproto.FORWARD, 100,
+			[][]logproto.Series{
+				{
+					newSeries(testSize, factor(5, identity), `{app="bar"}`),
+					newSeries(testSize, factor(10, identity), `{app="foo"}`),
+				},
+			},
+			[]SelectSampleParams{
+				{&logproto.SampleQueryRequest{Start: time.Unix(0, 0), End: time.Unix(60, 0), Selector: `sum by (app) (count_over_time({app=~"foo|bar"}[1m]))`}},
+			},
+			promql.Vector{
+				promql.Sample{Point: promql.Point{T: 60 * 1000, V: 6}, Metric: labels.Labels{{Name: "app", Value: "foo"}}},
+				promql.Sample{Point: promql.Point{T: 60 * 1000, V: 12}, Metric: labels.Labels{{Name: "app", Value: "bar"}}},
+			},
+		},
+		{
+			`sort_desc(sum by (app) (count_over_time({app=~"foo|bar"}[1m])))`, time.Unix(60, 0), logproto.FORWARD, 100,
+			[][]logproto.Series{
+				{
+					newSeries(testSize, factor(10, identity), `{app="bar"}`),
+					newSeries(testSize, factor(5, identity), `{app="foo"}`),
+				},
+			},
+			[]SelectSampleParams{
+				{&logproto.SampleQueryRequest{Start: time.Unix(0, 0), End: time.Unix(60, 0), Selector: `sum by (app) (count_over_time({app=~"foo|bar"}[1m]))`}},
+			},
+			promql.Vector{
+				promql.Sample{Point: promql.Point{T: 60 * 1000, V: 12}, Metric: labels.Labels{{Name: "app", Value: "foo"}}},
+				promql.Sample{Point: promql.Point{T: 60 * 1000, V: 6}, Metric: labels.Labels{{Name: "app", Value: "bar"}}},
+			},
+		},
+		{
+			`label_join(sum by (app, namespace) (count_over_time({app="foo"}[1m])), "new", "-", "app", "namespace")`, time.Unix(60, 0), logproto.FORWARD, 100,
+			[][]logproto.Series{
+				{newSeries(testSize, factor(10, identity), `{app="foo", namespace="a"}`)},
+			},
+			[]SelectSampleParams{
+				{&logproto.SampleQueryRequest{Start: time.Unix(0, 0), End: time.Unix(60, 0), Selector: `sum by (app,namespace) (count_over_time({app="foo"}[1m]))`}},
+			},
+			promql.Vector{
+				promql.Sample{
+					Point: promql.Point{T: 60 * 1000, V: 6},
+					Metric: labels.Labels{
+						labels.Label{Name: "app", Value: "foo"},
+						labels.Label{Name: "namespace", Value: "a"},
+						labels.Label{Name: "new", Value: "foo-a"},
+					},
+				},
+			},
+		},
+		{
+			`sum(count_over_time({app="foo"}[1m])) or vector(0)`, time.Unix(60, 0), logproto.FORWARD, 100,
+			[][]logproto.Series{
+				{},
+			},
+			[]SelectSampleParams{
+				{&logproto.SampleQueryRequest{Start: time.Unix(0, 0), End: time.Unix(60, 0), Selector: `sum(count_over_time({app="foo"}[1m]))`}},
+			},
+			promql.Vector{
+				promql.Sample{Point: promql.Point{T: 60 * 1000, V: 0}, Metric: labels.Labels{}},
+			},
+		},
 		{
 			`count(count_over_time({app=~"foo|bar"} |~".+bar" [1m])) without (app)`, time.Unix(60, 0), logproto.FORWARD, 100,
 			[][]logproto.Series{
@@ -948,6 +1011,38 @@ func TestEngine_RangeQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			`count_over_time({app="foo"}[10s] @ 30) / count_over_time({app="foo"}[10s])`, time.Unix(60, 0), time.Unix(120, 0), 30 * time.Second, 0, logproto.FORWARD, 10,
+			[][]logproto.Series{
+				{newSeries(testSize, factor(10, identity), `{app="foo"}`)}, // 1 sample per 10s.
+				{newSeries(testSize, factor(5, identity), `{app="foo"}`)},  // 2 samples per 10s.
+			},
+			[]SelectSampleParams{
+				{&logproto.SampleQueryRequest{Start: time.Unix(20, 0), End: time.Unix(30, 0), Selector: `count_over_time({app="foo"}[10s] @ 30)`}},
+				{&logproto.SampleQueryRequest{Start: time.Unix(50, 0), End: time.Unix(120, 0), Selector: `count_over_time({app="foo"}[10s])`}},
+			},
+			promql.Matrix{
+				promql.Series{
+					Metric: labels.Labels{{Name: "app", Value: "foo"}},
+					Points: []promql.Point{{T: 60 * 1000, V: 0.5}, {T: 90 * 1000, V: 0.5}, {T: 120 * 1000, V: 0.5}},
+				},
+			},
+		},
+		{
+			`sum_over_time(count_over_time({app="foo"}[10s])[30s:10s])`, time.Unix(60, 0), time.Unix(120, 0), 30 * time.Second, 0, logproto.FORWARD, 10,
+			[][]logproto.Series{
+				{newSeries(testSize, factor(2, identity), `{app="foo"}`)}, // 5 samples per 10s, 3 steps of the subquery per 30s.
+			},
+			[]SelectSampleParams{
+				{&logproto.SampleQueryRequest{Start: time.Unix(30, 0), End: time.Unix(120, 0), Selector: `count_over_time({app="foo"}[10s])`}},
+			},
+			promql.Matrix{
+				promql.Series{
+					Metric: labels.Labels{{Name: "app", Value: "foo"}},
+					Points: []promql.Point{{T: 60 * 1000, V: 15}, {T: 90 * 1000, V: 15}, {T: 120 * 1000, V: 15}},
+				},
+			},
+		},
 		{
 			`count_over_time({app="foo"} |~".+bar" [1m])`, time.Unix(60, 0), time.Unix(120, 0), 30 * time.Second, 0, logproto.BACKWARD, 10,
 			[][]logproto.Series{
@@ -1738,6 +1833,18 @@ func TestEngine_RangeQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			`vector(1) + vector(2)`,
+			time.Unix(60, 0), time.Unix(120, 0), 30 * time.Second, 0, logproto.FORWARD, 100,
+			nil,
+			nil,
+			promql.Matrix{
+				promql.Series{
+					Metric: labels.Labels{},
+					Points: []promql.Point{{T: 60 * 1000, V: 3}, {T: 90 * 1000, V: 3}, {T: 120 * 1000, V: 3}},
+				},
+			},
+		},
 		{
 			` sum (
 					sum by (app) (rate({app=~"foo|bar"} |~".+bar" [1m])) +
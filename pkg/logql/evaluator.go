@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -171,7 +172,7 @@ func (ev *DefaultEvaluator) StepEvaluator(
 ) (StepEvaluator, error) {
 	switch e := expr.(type) {
 	case *syntax.VectorAggregationExpr:
-		if rangExpr, ok := e.Left.(*syntax.RangeAggregationExpr); ok && e.Operation == syntax.OpTypeSum {
+		if rangExpr, ok := e.Left.(*syntax.RangeAggregationExpr); ok && e.Operation == syntax.OpTypeSum && rangExpr.Left.At == nil {
 			// if range expression is wrapped with a vector expression
 			// we should send the vector expression for allowing reducing labels at the source.
 			nextEv = SampleEvaluatorFunc(func(ctx context.Context, nextEvaluator SampleEvaluator, expr syntax.SampleExpr, p Params) (StepEvaluator, error) {
@@ -191,6 +192,9 @@ func (ev *DefaultEvaluator) StepEvaluator(
 		}
 		return vectorAggEvaluator(ctx, nextEv, e, q)
 	case *syntax.RangeAggregationExpr:
+		if e.Left.At != nil {
+			return ev.pinnedRangeAggEvaluator(ctx, e, q)
+		}
 		it, err := ev.querier.SelectSamples(ctx, SelectSampleParams{
 			&logproto.SampleQueryRequest{
 				Start:    q.Start().Add(-e.Left.Interval).Add(-e.Left.Offset),
@@ -203,10 +207,16 @@ func (ev *DefaultEvaluator) StepEvaluator(
 			return nil, err
 		}
 		return rangeAggEvaluator(iter.NewPeekingSampleIterator(it), e, q, e.Left.Offset)
+	case *syntax.SubqueryAggregationExpr:
+		return subqueryAggEvaluator(ctx, nextEv, e, q)
 	case *syntax.BinOpExpr:
 		return binOpStepEvaluator(ctx, nextEv, e, q)
 	case *syntax.LabelReplaceExpr:
 		return labelReplaceEvaluator(ctx, nextEv, e, q)
+	case *syntax.LabelJoinExpr:
+		return labelJoinEvaluator(ctx, nextEv, e, q)
+	case *syntax.VectorExpr:
+		return vectorEvaluator(e, q)
 	default:
 		return nil, EvaluatorUnsupportedType(e, ev)
 	}
@@ -221,10 +231,25 @@ func vectorAggEvaluator(
 	if expr.Grouping == nil {
 		return nil, errors.Errorf("aggregation operator '%q' without grouping", expr.Operation)
 	}
+	switch expr.Operation {
+	case syntax.OpTypeApproxTopK:
+		// without sharding the top k series are exact.
+		expr = &syntax.VectorAggregationExpr{
+			Left:      expr.Left,
+			Grouping:  expr.Grouping,
+			Params:    expr.Params,
+			Operation: syntax.OpTypeTopK,
+		}
+	case syntax.OpTypeCountMinSketch:
+		return nil, errors.Errorf("%s is only supported as the root of a query", expr.Operation)
+	}
 	nextEvaluator, err := ev.StepEvaluator(ctx, ev, expr.Left, q)
 	if err != nil {
 		return nil, err
 	}
+	if expr.Operation == syntax.OpTypeSort || expr.Operation == syntax.OpTypeSortDesc {
+		return sortEvaluator(nextEvaluator, expr.Operation == syntax.OpTypeSortDesc)
+	}
 	lb := labels.NewBuilder(nil)
 	buf := make([]byte, 0, 1024)
 	sort.Strings(expr.Grouping.Groups)
@@ -408,6 +433,49 @@ func vectorAggEvaluator(
 	}, nextEvaluator.Close, nextEvaluator.Error)
 }
 
+// sortEvaluator orders the samples of every step by value, then by labels.
+// NaN values are always sorted last.
+func sortEvaluator(nextEvaluator StepEvaluator, desc bool) (StepEvaluator, error) {
+	return newStepEvaluator(func() (bool, int64, promql.Vector) {
+		next, ts, vec := nextEvaluator.Next()
+		if !next {
+			return false, 0, promql.Vector{}
+		}
+		sort.Slice(vec, func(i, j int) bool {
+			vi, vj := vec[i].V, vec[j].V
+			if vi == vj || (math.IsNaN(vi) && math.IsNaN(vj)) {
+				return labels.Compare(vec[i].Metric, vec[j].Metric) < 0
+			}
+			if math.IsNaN(vi) || math.IsNaN(vj) {
+				return math.IsNaN(vj)
+			}
+			if desc {
+				return vi > vj
+			}
+			return vi < vj
+		})
+		return next, ts, vec
+	}, nextEvaluator.Close, nextEvaluator.Error)
+}
+
+// vectorEvaluator returns the sample of a vector() expression at every step.
+func vectorEvaluator(expr *syntax.VectorExpr, q Params) (StepEvaluator, error) {
+	step := q.Step()
+	if step == 0 {
+		// forces at least one step.
+		step = 1
+	}
+	ts := q.Start().Add(-step)
+	return newStepEvaluator(func() (bool, int64, promql.Vector) {
+		ts = ts.Add(step)
+		if ts.After(q.End()) {
+			return false, 0, promql.Vector{}
+		}
+		t := ts.UnixNano() / int64(time.Millisecond)
+		return true, t, promql.Vector{{Metric: labels.Labels{}, Point: promql.Point{T: t, V: expr.Val}}}
+	}, nil, nil)
+}
+
 func rangeAggEvaluator(
 	it iter.PeekingSampleIterator,
 	expr *syntax.RangeAggregationExpr,
@@ -436,6 +504,152 @@ func rangeAggEvaluator(
 	}, nil
 }
 
+// pinnedRangeAggEvaluator evaluates a range aggregation with an @ modifier only
+// once, at the time it is pinned to, and returns the same samples at every step.
+func (ev *DefaultEvaluator) pinnedRangeAggEvaluator(
+	ctx context.Context,
+	expr *syntax.RangeAggregationExpr,
+	q Params,
+) (StepEvaluator, error) {
+	at := expr.Left.At.Time(q.Start(), q.End())
+	it, err := ev.querier.SelectSamples(ctx, SelectSampleParams{
+		&logproto.SampleQueryRequest{
+			Start:    at.Add(-expr.Left.Interval).Add(-expr.Left.Offset),
+			End:      at.Add(-expr.Left.Offset),
+			Selector: expr.String(),
+			Shards:   q.Shards(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	params := NewLiteralParams(q.Query(), at, at, 0, q.Interval(), q.Direction(), q.Limit(), q.Shards())
+	pinned, err := rangeAggEvaluator(iter.NewPeekingSampleIterator(it), expr, params, expr.Left.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer util.LogErrorWithContext(ctx, "closing SampleExpr", pinned.Close)
+
+	_, _, vec := pinned.Next()
+	if err := pinned.Error(); err != nil {
+		return nil, err
+	}
+	// copy the samples since range vector evaluators reuse their vectors.
+	samples := make(promql.Vector, len(vec))
+	copy(samples, vec)
+
+	step := q.Step()
+	if step == 0 {
+		// forces at least one step.
+		step = 1
+	}
+	ts := q.Start().Add(-step)
+	return newStepEvaluator(func() (bool, int64, promql.Vector) {
+		ts = ts.Add(step)
+		if ts.After(q.End()) {
+			return false, 0, promql.Vector{}
+		}
+		t := ts.UnixNano() / int64(time.Millisecond)
+		res := make(promql.Vector, 0, len(samples))
+		for _, s := range samples {
+			res = append(res, promql.Sample{Metric: s.Metric, Point: promql.Point{T: t, V: s.V}})
+		}
+		return true, t, res
+	}, nil, nil)
+}
+
+// subqueryAggEvaluator evaluates the metric expression of a subquery at every
+// step of the subquery and aggregates the resulting samples over the range of the
+// subquery, the same way range aggregations aggregate the samples of log lines.
+func subqueryAggEvaluator(
+	ctx context.Context,
+	ev SampleEvaluator,
+	expr *syntax.SubqueryAggregationExpr,
+	q Params,
+) (StepEvaluator, error) {
+	sub := expr.Left
+	agg, err := rangeAggregator(expr.Operation, sub.Range, true, expr.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix, err := subquerySeries(ctx, ev, sub, q)
+	if err != nil {
+		return nil, err
+	}
+	iter := newRangeVectorIterator(
+		iter.NewPeekingSampleIterator(iter.NewMultiSeriesIterator(matrix)),
+		sub.Range.Nanoseconds(),
+		q.Step().Nanoseconds(),
+		q.Start().UnixNano(), q.End().UnixNano(), sub.Offset.Nanoseconds(),
+	)
+	if expr.Operation == syntax.OpRangeTypeAbsent {
+		return &absentRangeVectorEvaluator{
+			iter: iter,
+			lbs:  absentLabels(expr),
+		}, nil
+	}
+	return &rangeVectorEvaluator{
+		iter: iter,
+		agg:  agg,
+	}, nil
+}
+
+// subquerySeries returns the samples of the metric expression of a subquery
+// within the ranges of all the steps of the query.
+func subquerySeries(ctx context.Context, ev SampleEvaluator, sub *syntax.SubqueryExpr, q Params) ([]logproto.Series, error) {
+	// Steps of the subquery are aligned to multiples of its step, so that they
+	// don't depend on the start of the query when it is split by time.
+	step := sub.Step.Nanoseconds()
+	start := q.Start().Add(-sub.Range).Add(-sub.Offset).UnixNano()
+	start = start - start%step + step
+	end := q.End().Add(-sub.Offset)
+	if start > end.UnixNano() {
+		// no step of the subquery within the range of the query.
+		return nil, nil
+	}
+
+	params := NewLiteralParams(
+		sub.Left.String(),
+		time.Unix(0, start),
+		end,
+		sub.Step,
+		q.Interval(),
+		q.Direction(),
+		q.Limit(),
+		q.Shards(),
+	)
+	nextEvaluator, err := ev.StepEvaluator(ctx, ev, sub.Left, params)
+	if err != nil {
+		return nil, err
+	}
+	defer util.LogErrorWithContext(ctx, "closing SampleExpr", nextEvaluator.Close)
+
+	series := map[string]*logproto.Series{}
+	for next, ts, vec := nextEvaluator.Next(); next; next, ts, vec = nextEvaluator.Next() {
+		for _, sample := range vec {
+			lbs := sample.Metric.String()
+			s, ok := series[lbs]
+			if !ok {
+				s = &logproto.Series{Labels: lbs, StreamHash: sample.Metric.Hash()}
+				series[lbs] = s
+			}
+			s.Samples = append(s.Samples, logproto.Sample{
+				Timestamp: ts * int64(time.Millisecond),
+				Value:     sample.V,
+			})
+		}
+	}
+	if err := nextEvaluator.Error(); err != nil {
+		return nil, err
+	}
+	result := make([]logproto.Series, 0, len(series))
+	for _, s := range series {
+		result = append(result, *s)
+	}
+	return result, nil
+}
+
 type rangeVectorEvaluator struct {
 	agg  RangeVectorAggregator
 	iter RangeVectorIterator
@@ -923,6 +1137,51 @@ func labelReplaceEvaluator(
 	}, nextEvaluator.Close, nextEvaluator.Error)
 }
 
+func labelJoinEvaluator(
+	ctx context.Context,
+	ev SampleEvaluator,
+	expr *syntax.LabelJoinExpr,
+	q Params,
+) (StepEvaluator, error) {
+	nextEvaluator, err := ev.StepEvaluator(ctx, ev, expr.Left, q)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 0, 1024)
+	var labelCache map[uint64]labels.Labels
+	return newStepEvaluator(func() (bool, int64, promql.Vector) {
+		next, ts, vec := nextEvaluator.Next()
+		if !next {
+			return false, 0, promql.Vector{}
+		}
+		if labelCache == nil {
+			labelCache = make(map[uint64]labels.Labels, len(vec))
+		}
+		var hash uint64
+		values := make([]string, len(expr.Src))
+		for i, s := range vec {
+			hash, buf = s.Metric.HashWithoutLabels(buf)
+			if labels, ok := labelCache[hash]; ok {
+				vec[i].Metric = labels
+				continue
+			}
+			for j, src := range expr.Src {
+				values[j] = s.Metric.Get(src)
+			}
+			res := strings.Join(values, expr.Separator)
+
+			lb := labels.NewBuilder(s.Metric).Del(expr.Dst)
+			if len(res) > 0 {
+				lb.Set(expr.Dst, res)
+			}
+			outLbs := lb.Labels()
+			labelCache[hash] = outLbs
+			vec[i].Metric = outLbs
+		}
+		return next, ts, vec
+	}, nextEvaluator.Close, nextEvaluator.Error)
+}
+
 // This is to replace missing timeseries during absent_over_time aggregation.
 func absentLabels(expr syntax.SampleExpr) labels.Labels {
 	m := labels.Labels{}
@@ -0,0 +1,84 @@
+package log
+
+import (
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logqlmodel"
+)
+
+// DropLabel is a label to drop from the labels set.
+// When a Matcher is set the label is only dropped if its value matches, otherwise Name is dropped unconditionally.
+type DropLabel struct {
+	Matcher *labels.Matcher
+	Name    string
+}
+
+// NewDropLabel creates a new DropLabel from either a matcher or a label name.
+func NewDropLabel(matcher *labels.Matcher, name string) DropLabel {
+	return DropLabel{
+		Matcher: matcher,
+		Name:    name,
+	}
+}
+
+// String implements fmt.Stringer.
+func (d DropLabel) String() string {
+	if d.Matcher != nil {
+		return d.Matcher.String()
+	}
+	return d.Name
+}
+
+// DropLabels is a stage that removes labels from the labels set.
+type DropLabels struct {
+	dropLabels []DropLabel
+}
+
+// NewDropLabels creates a new stage dropping the given labels.
+func NewDropLabels(dl []DropLabel) *DropLabels {
+	return &DropLabels{dropLabels: dl}
+}
+
+func (dl *DropLabels) Process(_ int64, line []byte, lbs *LabelsBuilder) ([]byte, bool) {
+	for _, d := range dl.dropLabels {
+		if d.Matcher != nil {
+			dropLabelMatches(d.Matcher, lbs)
+			continue
+		}
+		dropLabelName(d.Name, lbs)
+	}
+	return line, true
+}
+
+func (dl *DropLabels) RequiredLabelNames() []string {
+	var names []string
+	for _, d := range dl.dropLabels {
+		if d.Matcher != nil {
+			names = append(names, d.Matcher.Name)
+		}
+	}
+	return uniqueString(names)
+}
+
+func dropLabelName(name string, lbs *LabelsBuilder) {
+	if name == logqlmodel.ErrorLabel {
+		lbs.SetErr("")
+		return
+	}
+	if _, ok := lbs.Get(name); ok {
+		lbs.Del(name)
+	}
+}
+
+func dropLabelMatches(m *labels.Matcher, lbs *LabelsBuilder) {
+	if m.Name == logqlmodel.ErrorLabel {
+		if lbs.HasErr() && m.Matches(lbs.GetErr()) {
+			lbs.SetErr("")
+		}
+		return
+	}
+	v, ok := lbs.Get(m.Name)
+	if ok && m.Matches(v) {
+		lbs.Del(m.Name)
+	}
+}
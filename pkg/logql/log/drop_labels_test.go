@@ -0,0 +1,107 @@
+package log
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logqlmodel"
+)
+
+func Test_DropLabels(t *testing.T) {
+	tests := []struct {
+		Name       string
+		dropLabels []DropLabel
+		err        string
+		lbs        labels.Labels
+		want       labels.Labels
+	}{
+		{
+			"drop by name",
+			[]DropLabel{
+				NewDropLabel(nil, "app"),
+				NewDropLabel(nil, "namespace"),
+			},
+			"",
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+				{Name: "namespace", Value: "prod"},
+				{Name: "pod", Value: "foo-1"},
+			},
+			labels.Labels{
+				{Name: "pod", Value: "foo-1"},
+			},
+		},
+		{
+			"drop by matcher",
+			[]DropLabel{
+				NewDropLabel(labels.MustNewMatcher(labels.MatchEqual, "namespace", "prod"), ""),
+				NewDropLabel(labels.MustNewMatcher(labels.MatchEqual, "pod", "foo-2"), ""),
+			},
+			"",
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+				{Name: "namespace", Value: "prod"},
+				{Name: "pod", Value: "foo-1"},
+			},
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+				{Name: "pod", Value: "foo-1"},
+			},
+		},
+		{
+			"drop error by name",
+			[]DropLabel{
+				NewDropLabel(nil, logqlmodel.ErrorLabel),
+			},
+			errJSON,
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+			},
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+			},
+		},
+		{
+			"drop matching error",
+			[]DropLabel{
+				NewDropLabel(labels.MustNewMatcher(labels.MatchEqual, logqlmodel.ErrorLabel, errJSON), ""),
+			},
+			errJSON,
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+			},
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+			},
+		},
+		{
+			"keep non matching error",
+			[]DropLabel{
+				NewDropLabel(labels.MustNewMatcher(labels.MatchEqual, logqlmodel.ErrorLabel, errLogfmt), ""),
+			},
+			errJSON,
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+			},
+			labels.Labels{
+				{Name: logqlmodel.ErrorLabel, Value: errJSON},
+				{Name: "app", Value: "foo"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			dropLabels := NewDropLabels(tt.dropLabels)
+			lbls := NewBaseLabelsBuilder().ForLabels(tt.lbs, tt.lbs.Hash())
+			lbls.Reset()
+			lbls.SetErr(tt.err)
+			_, ok := dropLabels.Process(0, []byte(""), lbls)
+			require.True(t, ok)
+			sort.Sort(tt.want)
+			require.Equal(t, tt.want, lbls.Labels())
+		})
+	}
+}
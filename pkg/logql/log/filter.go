@@ -10,6 +10,8 @@ import (
 	"github.com/grafana/regexp/syntax"
 
 	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logql/log/pattern"
 )
 
 // Filterer is a interface to filter log lines.
@@ -43,7 +45,7 @@ func (n notFilter) Filter(line []byte) bool {
 
 func (n notFilter) ToStage() Stage {
 	return StageFunc{
-		process: func(line []byte, _ *LabelsBuilder) ([]byte, bool) {
+		process: func(_ int64, line []byte, _ *LabelsBuilder) ([]byte, bool) {
 			return line, n.Filter(line)
 		},
 	}
@@ -87,7 +89,7 @@ func (a andFilter) Filter(line []byte) bool {
 
 func (a andFilter) ToStage() Stage {
 	return StageFunc{
-		process: func(line []byte, _ *LabelsBuilder) ([]byte, bool) {
+		process: func(_ int64, line []byte, _ *LabelsBuilder) ([]byte, bool) {
 			return line, a.Filter(line)
 		},
 	}
@@ -164,7 +166,7 @@ func (a andFilters) Filter(line []byte) bool {
 
 func (a andFilters) ToStage() Stage {
 	return StageFunc{
-		process: func(line []byte, _ *LabelsBuilder) ([]byte, bool) {
+		process: func(_ int64, line []byte, _ *LabelsBuilder) ([]byte, bool) {
 			return line, a.Filter(line)
 		},
 	}
@@ -175,8 +177,8 @@ type orFilter struct {
 	right Filterer
 }
 
-// newOrFilter creates a new filter which matches only if left or right matches.
-func newOrFilter(left Filterer, right Filterer) Filterer {
+// NewOrFilter creates a new filter which matches only if left or right matches.
+func NewOrFilter(left Filterer, right Filterer) Filterer {
 	if left == nil || left == TrueFilter {
 		return right
 	}
@@ -196,7 +198,7 @@ func chainOrFilter(curr, new Filterer) Filterer {
 	if curr == nil {
 		return new
 	}
-	return newOrFilter(curr, new)
+	return NewOrFilter(curr, new)
 }
 
 func (a orFilter) Filter(line []byte) bool {
@@ -205,7 +207,7 @@ func (a orFilter) Filter(line []byte) bool {
 
 func (a orFilter) ToStage() Stage {
 	return StageFunc{
-		process: func(line []byte, _ *LabelsBuilder) ([]byte, bool) {
+		process: func(_ int64, line []byte, _ *LabelsBuilder) ([]byte, bool) {
 			return line, a.Filter(line)
 		},
 	}
@@ -235,7 +237,7 @@ func (r regexpFilter) Filter(line []byte) bool {
 
 func (r regexpFilter) ToStage() Stage {
 	return StageFunc{
-		process: func(line []byte, _ *LabelsBuilder) ([]byte, bool) {
+		process: func(_ int64, line []byte, _ *LabelsBuilder) ([]byte, bool) {
 			return line, r.Filter(line)
 		},
 	}
@@ -254,52 +256,80 @@ func contains(line, substr []byte, caseInsensitive bool) bool {
 	if !caseInsensitive {
 		return bytes.Contains(line, substr)
 	}
-	return containsLower(line, substr)
+	return containsFold(line, substr)
 }
 
-func containsLower(line, substr []byte) bool {
+// containsFold reports whether the lower case substr is within line, under Unicode case folding.
+// ASCII bytes are compared directly, other runes are compared with their case folding orbit.
+func containsFold(line, substr []byte) bool {
 	if len(substr) == 0 {
 		return true
 	}
-	if len(substr) > len(line) {
-		return false
-	}
-	j := 0
-	for len(line) > 0 {
-		// ascii fast case
-		if c := line[0]; c < utf8.RuneSelf {
-			if c == substr[j] || c+'a'-'A' == substr[j] {
-				j++
-				if j == len(substr) {
-					return true
-				}
-				line = line[1:]
+	first := substr[0]
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c < utf8.RuneSelf {
+			// ascii fast case, a non ascii rune of substr can still match an ascii byte (e.g. ſ and s).
+			if first < utf8.RuneSelf && toLowerASCII(c) != first {
 				continue
 			}
-			line = line[1:]
-			j = 0
+		} else if !utf8.RuneStart(c) {
 			continue
 		}
-		// unicode slow case
-		lr, lwid := utf8.DecodeRune(line)
-		mr, mwid := utf8.DecodeRune(substr[j:])
-		if lr == mr || mr == unicode.To(unicode.LowerCase, lr) {
-			j += mwid
-			if j == len(substr) {
-				return true
+		if hasPrefixFold(line[i:], substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrefixFold reports whether s begins with the lower case prefix, under Unicode case folding.
+func hasPrefixFold(s, prefix []byte) bool {
+	for len(prefix) > 0 {
+		if len(s) == 0 {
+			return false
+		}
+		// ascii fast case
+		if c, p := s[0], prefix[0]; c < utf8.RuneSelf && p < utf8.RuneSelf {
+			if toLowerASCII(c) != p {
+				return false
 			}
-			line = line[lwid:]
+			s, prefix = s[1:], prefix[1:]
 			continue
 		}
-		line = line[lwid:]
-		j = 0
+		// unicode slow case
+		r, size := utf8.DecodeRune(s)
+		pr, psize := utf8.DecodeRune(prefix)
+		if !equalFoldRune(r, pr) {
+			return false
+		}
+		s, prefix = s[size:], prefix[psize:]
+	}
+	return true
+}
+
+func equalFoldRune(r, other rune) bool {
+	if r == other {
+		return true
+	}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f == other {
+			return true
+		}
 	}
 	return false
 }
 
+func toLowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
 func (l containsFilter) ToStage() Stage {
 	return StageFunc{
-		process: func(line []byte, _ *LabelsBuilder) ([]byte, bool) {
+		process: func(_ int64, line []byte, _ *LabelsBuilder) ([]byte, bool) {
 			return line, l.Filter(line)
 		},
 	}
@@ -346,7 +376,7 @@ func (f containsAllFilter) Filter(line []byte) bool {
 
 func (f containsAllFilter) ToStage() Stage {
 	return StageFunc{
-		process: func(line []byte, _ *LabelsBuilder) ([]byte, bool) {
+		process: func(_ int64, line []byte, _ *LabelsBuilder) ([]byte, bool) {
 			return line, f.Filter(line)
 		},
 	}
@@ -368,6 +398,41 @@ func NewFilter(match string, mt labels.MatchType) (Filterer, error) {
 	}
 }
 
+type patternFilter struct {
+	matcher pattern.Matcher
+}
+
+// NewPatternFilter creates a line filter that matches whole lines against a pattern expression,
+// like the pattern parser does, without extracting labels.
+func NewPatternFilter(p string, mt labels.MatchType) (Filterer, error) {
+	switch mt {
+	case labels.MatchEqual, labels.MatchNotEqual:
+	default:
+		return nil, fmt.Errorf("unsupported match type for pattern filter: %v", mt)
+	}
+	m, err := pattern.ParseLineFilter(p)
+	if err != nil {
+		return nil, err
+	}
+	f := patternFilter{matcher: m}
+	if mt == labels.MatchEqual {
+		return f, nil
+	}
+	return newNotFilter(f), nil
+}
+
+func (f patternFilter) Filter(line []byte) bool {
+	return f.matcher.Test(line)
+}
+
+func (f patternFilter) ToStage() Stage {
+	return StageFunc{
+		process: func(_ int64, line []byte, _ *LabelsBuilder) ([]byte, bool) {
+			return line, f.Filter(line)
+		},
+	}
+}
+
 // parseRegexpFilter parses a regexp and attempt to simplify it with only literal filters.
 // If not possible it will returns the original regexp filter.
 func parseRegexpFilter(re string, match bool) (Filterer, error) {
@@ -415,12 +480,16 @@ func simplify(reg *syntax.Regexp) (Filterer, bool) {
 	case syntax.OpAlternate:
 		return simplifyAlternate(reg)
 	case syntax.OpConcat:
-		return simplifyConcat(reg, nil)
+		return simplifyConcat(reg, nil, false)
 	case syntax.OpCapture:
 		clearCapture(reg)
 		return simplify(reg)
 	case syntax.OpLiteral:
 		return newContainsFilter([]byte(string((reg.Rune))), isCaseInsensitive(reg)), true
+	case syntax.OpCharClass:
+		if alt, ok := foldCharClass(reg); ok {
+			return simplifyAlternate(alt)
+		}
 	case syntax.OpStar:
 		if reg.Sub[0].Op == syntax.OpAnyCharNotNL {
 			return TrueFilter, true
@@ -435,6 +504,47 @@ func isCaseInsensitive(reg *syntax.Regexp) bool {
 	return (reg.Flags & syntax.FoldCase) != 0
 }
 
+// maxFoldCharClassRunes is the maximum number of runes of a char class turned into case insensitive literals.
+const maxFoldCharClassRunes = 32
+
+// foldCharClass turns, when possible, a char class matching runes in any case into an alternate of case insensitive literals.
+// For example (?i)a|b is parsed as [A-Ba-b], which is the same as the alternate (?i)a|(?i)b.
+func foldCharClass(reg *syntax.Regexp) (*syntax.Regexp, bool) {
+	runes := make(map[rune]struct{})
+	for i := 0; i < len(reg.Rune); i += 2 {
+		if len(runes)+int(reg.Rune[i+1]-reg.Rune[i]) >= maxFoldCharClassRunes {
+			return nil, false
+		}
+		for r := reg.Rune[i]; r <= reg.Rune[i+1]; r++ {
+			runes[r] = struct{}{}
+		}
+	}
+	alt := &syntax.Regexp{Op: syntax.OpAlternate}
+	seen := make(map[rune]struct{}, len(runes))
+	// char classes are sorted, so the order of the literals is deterministic.
+	for i := 0; i < len(reg.Rune); i += 2 {
+		for r := reg.Rune[i]; r <= reg.Rune[i+1]; r++ {
+			if _, ok := seen[r]; ok {
+				continue
+			}
+			// runes without case, such as digits, are better matched by the regexp.
+			if unicode.SimpleFold(r) == r {
+				return nil, false
+			}
+			// every rune of the case folding orbit must be in the class.
+			seen[r] = struct{}{}
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				if _, ok := runes[f]; !ok {
+					return nil, false
+				}
+				seen[f] = struct{}{}
+			}
+			alt.Sub = append(alt.Sub, &syntax.Regexp{Op: syntax.OpLiteral, Flags: syntax.FoldCase, Rune: []rune{r}})
+		}
+	}
+	return alt, true
+}
+
 // clearCapture removes capture operation as they are not used for filtering.
 func clearCapture(regs ...*syntax.Regexp) {
 	for _, r := range regs {
@@ -459,7 +569,7 @@ func simplifyAlternate(reg *syntax.Regexp) (Filterer, bool) {
 		if !ok {
 			return nil, false
 		}
-		f = newOrFilter(f, f2)
+		f = NewOrFilter(f, f2)
 	}
 	return f, true
 }
@@ -469,7 +579,7 @@ func simplifyAlternate(reg *syntax.Regexp) (Filterer, bool) {
 // which is a literalFilter.
 // Or a literal and alternates operation (see simplifyConcatAlternate), which represent a multiplication of alternates.
 // Anything else is rejected.
-func simplifyConcat(reg *syntax.Regexp, baseLiteral []byte) (Filterer, bool) {
+func simplifyConcat(reg *syntax.Regexp, baseLiteral []byte, caseInsensitive bool) (Filterer, bool) {
 	clearCapture(reg.Sub...)
 	// we support only simplication of concat operation with 3 sub expressions.
 	// for instance .*foo.*bar contains 4 subs (.*+foo+.*+bar) and can't be simplified.
@@ -487,12 +597,23 @@ func simplifyConcat(reg *syntax.Regexp, baseLiteral []byte) (Filterer, bool) {
 				return nil, false
 			}
 			literals++
-			baseLiteral = append(baseLiteral, []byte(string(sub.Rune))...)
+			// a case sensitive literal can't be joined with a case insensitive one, e.g. foo(?i:bar).
+			if baseLiteral != nil && isCaseInsensitive(sub) != caseInsensitive {
+				return nil, false
+			}
+			caseInsensitive = isCaseInsensitive(sub)
+			// the base literal is shared by all legs of an alternate, so it must be copied.
+			baseLiteral = append(baseLiteral[:len(baseLiteral):len(baseLiteral)], []byte(string(sub.Rune))...)
 			continue
 		}
+		if sub.Op == syntax.OpCharClass && baseLiteral != nil {
+			if alt, ok := foldCharClass(sub); ok {
+				sub = alt
+			}
+		}
 		// if we have an alternate we must also have a base literal to apply the concatenation with.
 		if sub.Op == syntax.OpAlternate && baseLiteral != nil {
-			if curr, ok = simplifyConcatAlternate(sub, baseLiteral, curr); !ok {
+			if curr, ok = simplifyConcatAlternate(sub, baseLiteral, caseInsensitive, curr); !ok {
 				return nil, false
 			}
 			continue
@@ -510,7 +631,7 @@ func simplifyConcat(reg *syntax.Regexp, baseLiteral []byte) (Filterer, bool) {
 
 	// if we have only a concat with literals.
 	if baseLiteral != nil {
-		return newContainsFilter(baseLiteral, isCaseInsensitive(reg)), true
+		return newContainsFilter(baseLiteral, caseInsensitive), true
 	}
 
 	return nil, false
@@ -520,20 +641,23 @@ func simplifyConcat(reg *syntax.Regexp, baseLiteral []byte) (Filterer, bool) {
 // A concat alternate is found when a concat operation has a sub alternate and is preceded by a literal.
 // For instance bar|b|buzz is expressed as b(ar|(?:)|uzz) => b concat alternate(ar,(?:),uzz).
 // (?:) being an OpEmptyMatch and b being the literal to concat all alternates (ar,(?:),uzz) with.
-func simplifyConcatAlternate(reg *syntax.Regexp, literal []byte, curr Filterer) (Filterer, bool) {
+func simplifyConcatAlternate(reg *syntax.Regexp, literal []byte, caseInsensitive bool, curr Filterer) (Filterer, bool) {
 	for _, alt := range reg.Sub {
 		switch alt.Op {
 		case syntax.OpEmptyMatch:
-			curr = chainOrFilter(curr, newContainsFilter(literal, isCaseInsensitive(reg)))
+			curr = chainOrFilter(curr, newContainsFilter(literal, caseInsensitive))
 		case syntax.OpLiteral:
+			if isCaseInsensitive(alt) != caseInsensitive {
+				return nil, false
+			}
 			// concat the root literal with the alternate one.
 			altBytes := []byte(string(alt.Rune))
 			altLiteral := make([]byte, 0, len(literal)+len(altBytes))
 			altLiteral = append(altLiteral, literal...)
 			altLiteral = append(altLiteral, altBytes...)
-			curr = chainOrFilter(curr, newContainsFilter(altLiteral, isCaseInsensitive(reg)))
+			curr = chainOrFilter(curr, newContainsFilter(altLiteral, caseInsensitive))
 		case syntax.OpConcat:
-			f, ok := simplifyConcat(alt, literal)
+			f, ok := simplifyConcat(alt, literal, caseInsensitive)
 			if !ok {
 				return nil, false
 			}
@@ -542,7 +666,7 @@ func simplifyConcatAlternate(reg *syntax.Regexp, literal []byte, curr Filterer)
 			if alt.Sub[0].Op != syntax.OpAnyCharNotNL {
 				return nil, false
 			}
-			curr = chainOrFilter(curr, newContainsFilter(literal, isCaseInsensitive(reg)))
+			curr = chainOrFilter(curr, newContainsFilter(literal, caseInsensitive))
 		default:
 			return nil, false
 		}
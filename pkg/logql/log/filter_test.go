@@ -4,13 +4,14 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
 )
 
 func Test_SimplifiedRegex(t *testing.T) {
 	fixtures := []string{
 		"foo", "foobar", "bar", "foobuzz", "buzz", "f", "  ", "fba", "foofoofoo", "b", "foob", "bfoo", "FoO",
-		"foo, 世界", allunicode(), "fooÏbar",
+		"foo, 世界", allunicode(), "fooÏbar", "FOOBAR", "ffoo", "FoOB", "ïB", "ÏC", "ſ", "\u212a",
 	}
 	for _, test := range []struct {
 		re         string
@@ -22,33 +23,33 @@ func Test_SimplifiedRegex(t *testing.T) {
 		{"foo", true, newContainsFilter([]byte("foo"), false), true},
 		{"not", true, newNotFilter(newContainsFilter([]byte("not"), false)), false},
 		{"(foo)", true, newContainsFilter([]byte("foo"), false), true},
-		{"(foo|ba)", true, newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("ba"), false)), true},
-		{"(foo|ba|ar)", true, newOrFilter(newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("ba"), false)), newContainsFilter([]byte("ar"), false)), true},
-		{"(foo|(ba|ar))", true, newOrFilter(newContainsFilter([]byte("foo"), false), newOrFilter(newContainsFilter([]byte("ba"), false), newContainsFilter([]byte("ar"), false))), true},
+		{"(foo|ba)", true, NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("ba"), false)), true},
+		{"(foo|ba|ar)", true, NewOrFilter(NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("ba"), false)), newContainsFilter([]byte("ar"), false)), true},
+		{"(foo|(ba|ar))", true, NewOrFilter(newContainsFilter([]byte("foo"), false), NewOrFilter(newContainsFilter([]byte("ba"), false), newContainsFilter([]byte("ar"), false))), true},
 		{"foo.*", true, newContainsFilter([]byte("foo"), false), true},
 		{".*foo", true, newNotFilter(newContainsFilter([]byte("foo"), false)), false},
 		{".*foo.*", true, newContainsFilter([]byte("foo"), false), true},
 		{"(.*)(foo).*", true, newContainsFilter([]byte("foo"), false), true},
-		{"(foo.*|.*ba)", true, newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("ba"), false)), true},
-		{"(foo.*|.*bar.*)", true, newNotFilter(newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false))), false},
-		{".*foo.*|bar", true, newNotFilter(newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false))), false},
-		{".*foo|bar", true, newNotFilter(newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false))), false},
+		{"(foo.*|.*ba)", true, NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("ba"), false)), true},
+		{"(foo.*|.*bar.*)", true, newNotFilter(NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false))), false},
+		{".*foo.*|bar", true, newNotFilter(NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false))), false},
+		{".*foo|bar", true, newNotFilter(NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false))), false},
 		// This construct is similar to (...), but won't create a capture group.
-		{"(?:.*foo.*|bar)", true, newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false)), true},
+		{"(?:.*foo.*|bar)", true, NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false)), true},
 		// named capture group
-		{"(?P<foo>.*foo.*|bar)", true, newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false)), true},
+		{"(?P<foo>.*foo.*|bar)", true, NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false)), true},
 		// parsed as (?-s:.)*foo(?-s:.)*|b(?:ar|uzz)
-		{".*foo.*|bar|buzz", true, newOrFilter(newContainsFilter([]byte("foo"), false), newOrFilter(newContainsFilter([]byte("bar"), false), newContainsFilter([]byte("buzz"), false))), true},
+		{".*foo.*|bar|buzz", true, NewOrFilter(newContainsFilter([]byte("foo"), false), NewOrFilter(newContainsFilter([]byte("bar"), false), newContainsFilter([]byte("buzz"), false))), true},
 		// parsed as (?-s:.)*foo(?-s:.)*|bar|uzz
-		{".*foo.*|bar|uzz", true, newOrFilter(newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false)), newContainsFilter([]byte("uzz"), false)), true},
+		{".*foo.*|bar|uzz", true, NewOrFilter(NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("bar"), false)), newContainsFilter([]byte("uzz"), false)), true},
 		// parsed as foo|b(?:ar|(?:)|uzz)|zz
-		{"foo|bar|b|buzz|zz", true, newOrFilter(newOrFilter(newContainsFilter([]byte("foo"), false), newOrFilter(newOrFilter(newContainsFilter([]byte("bar"), false), newContainsFilter([]byte("b"), false)), newContainsFilter([]byte("buzz"), false))), newContainsFilter([]byte("zz"), false)), true},
+		{"foo|bar|b|buzz|zz", true, NewOrFilter(NewOrFilter(newContainsFilter([]byte("foo"), false), NewOrFilter(NewOrFilter(newContainsFilter([]byte("bar"), false), newContainsFilter([]byte("b"), false)), newContainsFilter([]byte("buzz"), false))), newContainsFilter([]byte("zz"), false)), true},
 		// parsed as f(?:(?:)|oo(?:(?:)|bar))
-		{"f|foo|foobar", true, newOrFilter(newContainsFilter([]byte("f"), false), newOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("foobar"), false))), true},
+		{"f|foo|foobar", true, NewOrFilter(newContainsFilter([]byte("f"), false), NewOrFilter(newContainsFilter([]byte("foo"), false), newContainsFilter([]byte("foobar"), false))), true},
 		// parsed as f(?:(?-s:.)*|oobar(?-s:.)*)|(?-s:.)*buzz
-		{"f.*|foobar.*|.*buzz", true, newOrFilter(newOrFilter(newContainsFilter([]byte("f"), false), newContainsFilter([]byte("foobar"), false)), newContainsFilter([]byte("buzz"), false)), true},
+		{"f.*|foobar.*|.*buzz", true, NewOrFilter(NewOrFilter(newContainsFilter([]byte("f"), false), newContainsFilter([]byte("foobar"), false)), newContainsFilter([]byte("buzz"), false)), true},
 		// parsed as ((f(?-s:.)*)|foobar(?-s:.)*)|(?-s:.)*buzz
-		{"((f.*)|foobar.*)|.*buzz", true, newOrFilter(newOrFilter(newContainsFilter([]byte("f"), false), newContainsFilter([]byte("foobar"), false)), newContainsFilter([]byte("buzz"), false)), true},
+		{"((f.*)|foobar.*)|.*buzz", true, NewOrFilter(NewOrFilter(newContainsFilter([]byte("f"), false), newContainsFilter([]byte("foobar"), false)), newContainsFilter([]byte("buzz"), false)), true},
 		{".*", true, TrueFilter, true},
 		{".*|.*", true, TrueFilter, true},
 		{".*||||", true, TrueFilter, true},
@@ -56,6 +57,13 @@ func Test_SimplifiedRegex(t *testing.T) {
 		{"(?i)foo", true, newContainsFilter([]byte("foo"), true), true},
 		{"(?i)界", true, newContainsFilter([]byte("界"), true), true},
 		{"(?i)ïB", true, newContainsFilter([]byte("ïB"), true), true},
+		{"(?i).*foo.*", true, newContainsFilter([]byte("foo"), true), true},
+		{"(?i)foo.*|bar", true, NewOrFilter(newContainsFilter([]byte("foo"), true), newContainsFilter([]byte("bar"), true)), true},
+		{"(?i)f|b", true, NewOrFilter(newContainsFilter([]byte("b"), true), newContainsFilter([]byte("f"), true)), true},
+		{"[Ff]oo", true, nil, false},
+		{"(?i)fooa|foob", true, NewOrFilter(newContainsFilter([]byte("fooa"), true), newContainsFilter([]byte("foob"), true)), true},
+		{"(?i)foo|foobar", true, NewOrFilter(newContainsFilter([]byte("foo"), true), newContainsFilter([]byte("foobar"), true)), true},
+		{"(?i)ïb|ïc", true, nil, true},
 
 		// regex we are not supporting.
 		{"[a-z]+foo", true, nil, false},
@@ -71,6 +79,9 @@ func Test_SimplifiedRegex(t *testing.T) {
 		{`foo|fo\d+`, true, nil, false},
 		{`(\w\d+)`, true, nil, false},
 		{`.*f.*oo|fo{1,2}`, true, nil, false},
+		{`foo(?i:bar)`, true, nil, true},
+		{`foo(?i:a|b)`, true, nil, true},
+		{`[0-9]`, false, nil, true},
 	} {
 		t.Run(test.re, func(t *testing.T) {
 			d, err := newRegexpFilter(test.re, test.match)
@@ -117,17 +128,17 @@ func Test_TrueFilter(t *testing.T) {
 		{"not empty match", newNotFilter(newContainsFilter(empty, true)), false},
 		{"match", newContainsFilter([]byte("foo"), false), false},
 		{"empty match and", NewAndFilter(newContainsFilter(empty, false), newContainsFilter(empty, false)), true},
-		{"empty match or", newOrFilter(newContainsFilter(empty, false), newContainsFilter(empty, false)), true},
+		{"empty match or", NewOrFilter(newContainsFilter(empty, false), newContainsFilter(empty, false)), true},
 		{"nil right and", NewAndFilter(newContainsFilter(empty, false), nil), true},
-		{"nil left or", newOrFilter(nil, newContainsFilter(empty, false)), true},
+		{"nil left or", NewOrFilter(nil, newContainsFilter(empty, false)), true},
 		{"nil right and not empty", NewAndFilter(newContainsFilter([]byte("foo"), false), nil), false},
-		{"nil left or not empty", newOrFilter(nil, newContainsFilter([]byte("foo"), false)), false},
+		{"nil left or not empty", NewOrFilter(nil, newContainsFilter([]byte("foo"), false)), false},
 		{"nil both and", NewAndFilter(nil, nil), false}, // returns nil
-		{"nil both or", newOrFilter(nil, nil), false},   // returns nil
+		{"nil both or", NewOrFilter(nil, nil), false},   // returns nil
 		{"empty match and chained", NewAndFilter(newContainsFilter(empty, false), NewAndFilter(newContainsFilter(empty, false), NewAndFilter(newContainsFilter(empty, false), newContainsFilter(empty, false)))), true},
-		{"empty match or chained", newOrFilter(newContainsFilter(empty, false), newOrFilter(newContainsFilter(empty, true), newOrFilter(newContainsFilter(empty, false), newContainsFilter(empty, false)))), true},
+		{"empty match or chained", NewOrFilter(newContainsFilter(empty, false), NewOrFilter(newContainsFilter(empty, true), NewOrFilter(newContainsFilter(empty, false), newContainsFilter(empty, false)))), true},
 		{"empty match and", newNotFilter(NewAndFilter(newContainsFilter(empty, false), newContainsFilter(empty, false))), false},
-		{"empty match or", newNotFilter(newOrFilter(newContainsFilter(empty, false), newContainsFilter(empty, false))), false},
+		{"empty match or", newNotFilter(NewOrFilter(newContainsFilter(empty, false), newContainsFilter(empty, false))), false},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			if test.expectTrue {
@@ -160,12 +171,33 @@ func Benchmark_LineFilter(b *testing.B) {
 		{"(node:24) buzz*"},
 		{"(HTTP/.*\\\"|HEAD|GET) (2..|5..)"},
 		{"\"@l\":\"(Warning|Error|Fatal)\""},
+		{"(?i)buzz"},
+		{"(?i).*buzz.*"},
+		{"(?i)error|buzz"},
+		{"(?i)b|z"},
 	} {
 		benchmarkRegex(b, test.re, logline, true)
 		benchmarkRegex(b, test.re, logline, false)
 	}
 }
 
+func Benchmark_CaseInsensitiveLineFilter(b *testing.B) {
+	b.ReportAllocs()
+	for _, test := range []struct {
+		name string
+		line string
+	}{
+		{"ascii", `level=bar ts=2020-02-22T14:57:59.398312973Z caller=logging.go:44 traceID=2107b6b551458908 msg="GET /buzz (200) 4.599635ms`},
+		{"unicode", `level=bar ts=2020-02-22T14:57:59.398312973Z caller=logging.go:44 traceID=2107b6b551458908 msg="GET /büzz (200) 4.599635ms 世界`},
+	} {
+		b.Run(test.name, func(b *testing.B) {
+			for _, re := range []string{"(?i)BUZZ", "(?i)BÜZZ", "(?i)error|warn"} {
+				benchmarkRegex(b, re, test.line, true)
+			}
+		})
+	}
+}
+
 // see https://dave.cheney.net/2013/06/30/how-to-write-benchmarks-in-go
 // A note on compiler optimizations
 var res bool
@@ -198,3 +230,50 @@ func benchmarkRegex(b *testing.B, re, line string, match bool) {
 func Test_rune(t *testing.T) {
 	require.True(t, newContainsFilter([]byte("foo"), true).Filter([]byte("foo")))
 }
+
+func Test_PatternFilter(t *testing.T) {
+	line := []byte(`127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`)
+
+	f, err := NewPatternFilter(`<_> - - <_> "<method> <_> <_>" 200 <_>`, labels.MatchEqual)
+	require.NoError(t, err)
+	require.True(t, f.Filter(line))
+	require.False(t, f.Filter([]byte(`127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 500 2326`)))
+
+	f, err = NewPatternFilter(`<_> - - <_> "<method> <_> <_>" 200 <_>`, labels.MatchNotEqual)
+	require.NoError(t, err)
+	require.False(t, f.Filter(line))
+
+	_, err = NewPatternFilter(`<_> - - <_> "<method> <_> <_>" 200 <_>`, labels.MatchRegexp)
+	require.Error(t, err)
+	_, err = NewPatternFilter(`<_><_>`, labels.MatchEqual)
+	require.Error(t, err)
+}
+
+func Test_containsFold(t *testing.T) {
+	for _, test := range []struct {
+		line, substr string
+		expected     bool
+	}{
+		{"", "", true},
+		{"foo", "", true},
+		{"", "foo", false},
+		{"FOO", "foo", true},
+		{"aaB", "ab", true},
+		{"xFoOx", "foo", true},
+		{"fo", "foo", false},
+		{"@", "`", false},
+		{"[", "{", false},
+		{"ÏB", "ïb", true},
+		{"foo ÏB", "ïb", true},
+		{"\u212a", "k", true},
+		{"K", "\u212a", true},
+		{"ſ", "s", true},
+		{"S", "ſ", true},
+		{"世界", "界", true},
+		{"世界", "世世", false},
+	} {
+		t.Run(test.line+"/"+test.substr, func(t *testing.T) {
+			require.Equal(t, test.expected, containsFold([]byte(test.line), []byte(test.substr)))
+		})
+	}
+}
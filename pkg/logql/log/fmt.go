@@ -3,9 +3,13 @@ package log
 import (
 	"bytes"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync"
 	"text/template"
 	"text/template/parse"
+	"time"
+	"unicode/utf8"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/grafana/regexp"
@@ -14,7 +18,8 @@ import (
 )
 
 const (
-	functionLineName = "__line__"
+	functionLineName      = "__line__"
+	functionTimestampName = "__timestamp__"
 )
 
 var (
@@ -41,6 +46,12 @@ var (
 			r := regexp.MustCompile(regex)
 			return r.ReplaceAllLiteralString(s, repl)
 		},
+		"urlencode":    url.QueryEscape,
+		"urldecode":    url.QueryUnescape,
+		"alignLeft":    alignLeft,
+		"alignRight":   alignRight,
+		"toDateInZone": toDateInZone,
+		"dateInZone":   dateInZone,
 	}
 
 	// sprig template functions
@@ -84,6 +95,9 @@ var (
 		"toDate",
 		"now",
 		"unixEpoch",
+		"default",
+		"b64enc",
+		"b64dec",
 	}
 )
 
@@ -96,11 +110,38 @@ func init() {
 	}
 }
 
+// entryFunctions exposes the line and the timestamp of the entry being formatted to templates,
+// as the __line__ and __timestamp__ functions.
+type entryFunctions struct {
+	currentLine []byte
+	currentTs   int64
+}
+
+func (e *entryFunctions) set(ts int64, line []byte) {
+	e.currentTs = ts
+	e.currentLine = line
+}
+
+// funcMap returns the map of functions available to templates, with the functions of the entry.
+func (e *entryFunctions) funcMap() template.FuncMap {
+	functions := make(map[string]interface{}, len(functionMap)+2)
+	for k, v := range functionMap {
+		functions[k] = v
+	}
+	functions[functionLineName] = func() string {
+		return unsafeGetString(e.currentLine)
+	}
+	functions[functionTimestampName] = func() time.Time {
+		return time.Unix(0, e.currentTs)
+	}
+	return functions
+}
+
 type LineFormatter struct {
 	*template.Template
 	buf *bytes.Buffer
 
-	currentLine []byte
+	entryFunctions
 }
 
 // NewFormatter creates a new log line formatter from a given text template.
@@ -108,14 +149,7 @@ func NewFormatter(tmpl string) (*LineFormatter, error) {
 	lf := &LineFormatter{
 		buf: bytes.NewBuffer(make([]byte, 4096)),
 	}
-	functions := make(map[string]interface{}, len(functionMap)+1)
-	for k, v := range functionMap {
-		functions[k] = v
-	}
-	functions[functionLineName] = func() string {
-		return unsafeGetString(lf.currentLine)
-	}
-	t, err := template.New("line").Option("missingkey=zero").Funcs(functions).Parse(tmpl)
+	t, err := template.New("line").Option("missingkey=zero").Funcs(lf.funcMap()).Parse(tmpl)
 	if err != nil {
 		return nil, fmt.Errorf("invalid line template: %w", err)
 	}
@@ -123,9 +157,9 @@ func NewFormatter(tmpl string) (*LineFormatter, error) {
 	return lf, nil
 }
 
-func (lf *LineFormatter) Process(line []byte, lbs *LabelsBuilder) ([]byte, bool) {
+func (lf *LineFormatter) Process(ts int64, line []byte, lbs *LabelsBuilder) ([]byte, bool) {
 	lf.buf.Reset()
-	lf.currentLine = line
+	lf.set(ts, line)
 
 	if err := lf.Template.Execute(lf.buf, lbs.Labels().Map()); err != nil {
 		lbs.SetErr(errTemplateFormat)
@@ -229,6 +263,8 @@ type labelFormatter struct {
 type LabelsFormatter struct {
 	formats []labelFormatter
 	buf     *bytes.Buffer
+
+	entryFunctions
 }
 
 // NewLabelsFormatter creates a new formatter that can format multiple labels at once.
@@ -239,11 +275,15 @@ func NewLabelsFormatter(fmts []LabelFmt) (*LabelsFormatter, error) {
 		return nil, err
 	}
 	formats := make([]labelFormatter, 0, len(fmts))
+	lf := &LabelsFormatter{
+		buf: bytes.NewBuffer(make([]byte, 1024)),
+	}
+	functions := lf.funcMap()
 
 	for _, fm := range fmts {
 		toAdd := labelFormatter{LabelFmt: fm}
 		if !fm.Rename {
-			t, err := template.New("label").Option("missingkey=zero").Funcs(functionMap).Parse(fm.Value)
+			t, err := template.New("label").Option("missingkey=zero").Funcs(functions).Parse(fm.Value)
 			if err != nil {
 				return nil, fmt.Errorf("invalid template for label '%s': %s", fm.Name, err)
 			}
@@ -251,10 +291,8 @@ func NewLabelsFormatter(fmts []LabelFmt) (*LabelsFormatter, error) {
 		}
 		formats = append(formats, toAdd)
 	}
-	return &LabelsFormatter{
-		formats: formats,
-		buf:     bytes.NewBuffer(make([]byte, 1024)),
-	}, nil
+	lf.formats = formats
+	return lf, nil
 }
 
 func validate(fmts []LabelFmt) error {
@@ -273,7 +311,8 @@ func validate(fmts []LabelFmt) error {
 	return nil
 }
 
-func (lf *LabelsFormatter) Process(l []byte, lbs *LabelsBuilder) ([]byte, bool) {
+func (lf *LabelsFormatter) Process(ts int64, l []byte, lbs *LabelsBuilder) ([]byte, bool) {
+	lf.set(ts, l)
 	var data interface{}
 	for _, f := range lf.formats {
 		if f.Rename {
@@ -309,6 +348,81 @@ func (lf *LabelsFormatter) RequiredLabelNames() []string {
 	return uniqueString(names)
 }
 
+// alignLeft pads s with spaces on the right, or truncates it, to count runes.
+func alignLeft(count int, s string) string {
+	l := utf8.RuneCountInString(s)
+	if count < 0 || l == count {
+		return s
+	}
+	if l < count {
+		return s + strings.Repeat(" ", count-l)
+	}
+	return string([]rune(s)[:count])
+}
+
+// alignRight pads s with spaces on the left, or truncates it from the left, to count runes.
+func alignRight(count int, s string) string {
+	l := utf8.RuneCountInString(s)
+	if count < 0 || l == count {
+		return s
+	}
+	if l < count {
+		return strings.Repeat(" ", count-l) + s
+	}
+	return string([]rune(s)[l-count:])
+}
+
+// toDateInZone parses a date with the layout in the given time zone, e.g. "UTC" or "Europe/Paris".
+func toDateInZone(layout, zone, value string) (time.Time, error) {
+	loc, err := loadLocation(zone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(layout, value, loc)
+}
+
+// dateInZone formats a date in the given time zone, like the sprig function of the same name
+// but without loading the time zone for every line.
+func dateInZone(layout string, date interface{}, zone string) string {
+	var t time.Time
+	switch date := date.(type) {
+	default:
+		t = time.Now()
+	case time.Time:
+		t = date
+	case *time.Time:
+		t = *date
+	case int64:
+		t = time.Unix(date, 0)
+	case int:
+		t = time.Unix(int64(date), 0)
+	case int32:
+		t = time.Unix(int64(date), 0)
+	}
+
+	loc, err := loadLocation(zone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(layout)
+}
+
+// locations caches the time zones by name, as loading one reads the time zone database.
+// Only valid time zones are cached, which bounds the size of the cache.
+var locations sync.Map
+
+func loadLocation(zone string) (*time.Location, error) {
+	if loc, ok := locations.Load(zone); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(zone, loc)
+	return loc, nil
+}
+
 func trunc(c int, s string) string {
 	runes := []rune(s)
 	l := len(runes)
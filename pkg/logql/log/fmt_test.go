@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
@@ -257,6 +258,54 @@ func Test_lineFormatter_Format(t *testing.T) {
 			labels.Labels{},
 			nil,
 		},
+		{
+			"default",
+			newMustLineFormatter(`{{ .foo | default "none" }} {{ .bar | default "none" }}`),
+			labels.Labels{{Name: "bar", Value: "2"}},
+			[]byte("none 2"),
+			labels.Labels{{Name: "bar", Value: "2"}},
+			nil,
+		},
+		{
+			"b64dec",
+			newMustLineFormatter(`{{ .foo | b64dec }} {{ "bar" | b64enc }}`),
+			labels.Labels{{Name: "foo", Value: "Zm9v"}},
+			[]byte("foo YmFy"),
+			labels.Labels{{Name: "foo", Value: "Zm9v"}},
+			nil,
+		},
+		{
+			"urldecode",
+			newMustLineFormatter(`{{ .foo | urldecode }} {{ urlencode "a b&c" }}`),
+			labels.Labels{{Name: "foo", Value: "%2Fapi%2Fv1%3Fq%3Da+b"}},
+			[]byte("/api/v1?q=a b a+b%26c"),
+			labels.Labels{{Name: "foo", Value: "%2Fapi%2Fv1%3Fq%3Da+b"}},
+			nil,
+		},
+		{
+			"urldecode error",
+			newMustLineFormatter(`{{ .foo | urldecode }}`),
+			labels.Labels{{Name: "foo", Value: "%zz"}},
+			[]byte("1"),
+			labels.Labels{{Name: "foo", Value: "%zz"}, {Name: logqlmodel.ErrorLabel, Value: errTemplateFormat}},
+			[]byte("1"),
+		},
+		{
+			"align",
+			newMustLineFormatter(`[{{ alignLeft 5 .foo }}][{{ alignRight 5 .foo }}][{{ alignLeft 2 .foo }}][{{ alignRight 2 .foo }}]`),
+			labels.Labels{{Name: "foo", Value: "世界!"}},
+			[]byte("[世界!  ][  世界!][世界][界!]"),
+			labels.Labels{{Name: "foo", Value: "世界!"}},
+			nil,
+		},
+		{
+			"dateinzone",
+			newMustLineFormatter(`{{ toDateInZone "2006-01-02 15:04" "Europe/Paris" .foo | unixEpoch }} {{ dateInZone "15:04" (toDateInZone "2006-01-02 15:04" "Europe/Paris" .foo) "UTC" }}`),
+			labels.Labels{{Name: "foo", Value: "2021-11-02 10:00"}},
+			[]byte("1635843600 09:00"),
+			labels.Labels{{Name: "foo", Value: "2021-11-02 10:00"}},
+			nil,
+		},
 		{
 			"line",
 			newMustLineFormatter("{{ __line__ }} bar {{ .bar }}"),
@@ -272,13 +321,32 @@ func Test_lineFormatter_Format(t *testing.T) {
 			sort.Sort(tt.wantLbs)
 			builder := NewBaseLabelsBuilder().ForLabels(tt.lbs, tt.lbs.Hash())
 			builder.Reset()
-			outLine, _ := tt.fmter.Process(tt.in, builder)
+			outLine, _ := tt.fmter.Process(0, tt.in, builder)
 			require.Equal(t, tt.want, outLine)
 			require.Equal(t, tt.wantLbs, builder.Labels())
 		})
 	}
 }
 
+func Test_formatters_Entry(t *testing.T) {
+	ts := time.Date(2021, 11, 2, 10, 30, 0, 0, time.UTC).UnixNano()
+	lbs := labels.Labels{{Name: "foo", Value: "bar"}}
+
+	builder := NewBaseLabelsBuilder().ForLabels(lbs, lbs.Hash())
+	builder.Reset()
+	line, ok := newMustLineFormatter(`{{ __timestamp__ | unixEpoch }} {{ dateInZone "15:04" __timestamp__ "UTC" }} {{ .foo }} {{ __line__ }}`).Process(ts, []byte("buzz"), builder)
+	require.True(t, ok)
+	require.Equal(t, "1635849000 10:30 bar buzz", string(line))
+
+	builder.Reset()
+	_, ok = mustNewLabelsFormatter([]LabelFmt{
+		NewTemplateLabelFmt("ts", `{{ __timestamp__ | unixEpoch }}`),
+		NewTemplateLabelFmt("line", `{{ __line__ | upper }}`),
+	}).Process(ts, []byte("buzz"), builder)
+	require.True(t, ok)
+	require.Equal(t, labels.Labels{{Name: "foo", Value: "bar"}, {Name: "line", Value: "BUZZ"}, {Name: "ts", Value: "1635849000"}}, builder.Labels())
+}
+
 func newMustLineFormatter(tmpl string) *LineFormatter {
 	l, err := NewFormatter(tmpl)
 	if err != nil {
@@ -331,7 +399,7 @@ func Test_labelsFormatter_Format(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			builder := NewBaseLabelsBuilder().ForLabels(tt.in, tt.in.Hash())
 			builder.Reset()
-			_, _ = tt.fmter.Process(nil, builder)
+			_, _ = tt.fmter.Process(0, nil, builder)
 			sort.Sort(tt.want)
 			require.Equal(t, tt.want, builder.Labels())
 		})
@@ -365,6 +433,26 @@ func Test_validate(t *testing.T) {
 	}
 }
 
+func Test_align(t *testing.T) {
+	for _, tt := range []struct {
+		s           string
+		c           int
+		left, right string
+	}{
+		{"foo", 5, "foo  ", "  foo"},
+		{"foo", 3, "foo", "foo"},
+		{"foo", 2, "fo", "oo"},
+		{"foo", 0, "", ""},
+		{"foo", -1, "foo", "foo"},
+		{"世界", 3, "世界 ", " 世界"},
+	} {
+		t.Run(fmt.Sprintf("%s%d", tt.s, tt.c), func(t *testing.T) {
+			require.Equal(t, tt.left, alignLeft(tt.c, tt.s))
+			require.Equal(t, tt.right, alignRight(tt.c, tt.s))
+		})
+	}
+}
+
 func Test_trunc(t *testing.T) {
 	tests := []struct {
 		s    string
@@ -413,6 +501,23 @@ func Test_substring(t *testing.T) {
 	}
 }
 
+func Test_loadLocation(t *testing.T) {
+	loc, err := loadLocation("Europe/Paris")
+	require.NoError(t, err)
+	cached, err := loadLocation("Europe/Paris")
+	require.NoError(t, err)
+	require.Same(t, loc, cached)
+
+	_, err = loadLocation("Not/AZone")
+	require.Error(t, err)
+	_, ok := locations.Load("Not/AZone")
+	require.False(t, ok)
+
+	ts := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, "13:00", dateInZone("15:04", ts, "Europe/Paris"))
+	require.Equal(t, "12:00", dateInZone("15:04", ts, "Not/AZone"))
+}
+
 func TestLineFormatter_RequiredLabelNames(t *testing.T) {
 	tests := []struct {
 		fmt  string
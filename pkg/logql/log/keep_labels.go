@@ -0,0 +1,83 @@
+package log
+
+import (
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logqlmodel"
+)
+
+// KeepLabel is a label to keep in the labels set.
+// When a Matcher is set the label is only kept if its value matches, otherwise Name is kept unconditionally.
+type KeepLabel struct {
+	Matcher *labels.Matcher
+	Name    string
+}
+
+// NewKeepLabel creates a new KeepLabel from either a matcher or a label name.
+func NewKeepLabel(matcher *labels.Matcher, name string) KeepLabel {
+	return KeepLabel{
+		Matcher: matcher,
+		Name:    name,
+	}
+}
+
+// String implements fmt.Stringer.
+func (k KeepLabel) String() string {
+	if k.Matcher != nil {
+		return k.Matcher.String()
+	}
+	return k.Name
+}
+
+// KeepLabels is a stage that removes all labels except the ones listed.
+// The __error__ label is always kept so that errors are not silently discarded.
+type KeepLabels struct {
+	keepLabels []KeepLabel
+}
+
+// NewKeepLabels creates a new stage keeping only the given labels.
+func NewKeepLabels(kl []KeepLabel) *KeepLabels {
+	return &KeepLabels{keepLabels: kl}
+}
+
+func (kl *KeepLabels) Process(_ int64, line []byte, lbs *LabelsBuilder) ([]byte, bool) {
+	if len(kl.keepLabels) == 0 {
+		return line, true
+	}
+	for _, l := range lbs.Labels() {
+		if l.Name == logqlmodel.ErrorLabel {
+			continue
+		}
+		if !kl.keep(l) {
+			lbs.Del(l.Name)
+		}
+	}
+	return line, true
+}
+
+func (kl *KeepLabels) keep(l labels.Label) bool {
+	for _, k := range kl.keepLabels {
+		if k.Matcher != nil {
+			if k.Matcher.Name == l.Name && k.Matcher.Matches(l.Value) {
+				return true
+			}
+			continue
+		}
+		if k.Name == l.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (kl *KeepLabels) RequiredLabelNames() []string {
+	names := make([]string, 0, len(kl.keepLabels))
+	for _, k := range kl.keepLabels {
+		if k.Matcher != nil {
+			names = append(names, k.Matcher.Name)
+			continue
+		}
+		names = append(names, k.Name)
+	}
+	return uniqueString(names)
+}
@@ -0,0 +1,82 @@
+package log
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logqlmodel"
+)
+
+func Test_KeepLabels(t *testing.T) {
+	tests := []struct {
+		Name       string
+		keepLabels []KeepLabel
+		err        string
+		lbs        labels.Labels
+		want       labels.Labels
+	}{
+		{
+			"keep by name",
+			[]KeepLabel{
+				NewKeepLabel(nil, "app"),
+				NewKeepLabel(nil, "namespace"),
+			},
+			"",
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+				{Name: "namespace", Value: "prod"},
+				{Name: "pod", Value: "foo-1"},
+			},
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+				{Name: "namespace", Value: "prod"},
+			},
+		},
+		{
+			"keep by matcher",
+			[]KeepLabel{
+				NewKeepLabel(labels.MustNewMatcher(labels.MatchEqual, "namespace", "prod"), ""),
+				NewKeepLabel(labels.MustNewMatcher(labels.MatchEqual, "pod", "foo-2"), ""),
+			},
+			"",
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+				{Name: "namespace", Value: "prod"},
+				{Name: "pod", Value: "foo-1"},
+			},
+			labels.Labels{
+				{Name: "namespace", Value: "prod"},
+			},
+		},
+		{
+			"error is always kept",
+			[]KeepLabel{
+				NewKeepLabel(nil, "app"),
+			},
+			errJSON,
+			labels.Labels{
+				{Name: "app", Value: "foo"},
+				{Name: "pod", Value: "foo-1"},
+			},
+			labels.Labels{
+				{Name: logqlmodel.ErrorLabel, Value: errJSON},
+				{Name: "app", Value: "foo"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			keepLabels := NewKeepLabels(tt.keepLabels)
+			lbls := NewBaseLabelsBuilder().ForLabels(tt.lbs, tt.lbs.Hash())
+			lbls.Reset()
+			lbls.SetErr(tt.err)
+			_, ok := keepLabels.Process(0, []byte(""), lbls)
+			require.True(t, ok)
+			sort.Sort(tt.want)
+			require.Equal(t, tt.want, lbls.Labels())
+		})
+	}
+}
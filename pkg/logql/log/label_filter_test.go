@@ -155,7 +155,7 @@ func TestBinary_Filter(t *testing.T) {
 			sort.Sort(tt.lbs)
 			b := NewBaseLabelsBuilder().ForLabels(tt.lbs, tt.lbs.Hash())
 			b.Reset()
-			_, got := tt.f.Process(nil, b)
+			_, got := tt.f.Process(0, nil, b)
 			require.Equal(t, tt.want, got)
 			sort.Sort(tt.wantLbs)
 			require.Equal(t, tt.wantLbs, b.Labels())
@@ -189,7 +189,7 @@ func TestBytes_Filter(t *testing.T) {
 		t.Run(f.String(), func(t *testing.T) {
 			b := NewBaseLabelsBuilder().ForLabels(lbs, lbs.Hash())
 			b.Reset()
-			_, got := f.Process(nil, b)
+			_, got := f.Process(0, nil, b)
 			require.Equal(t, tt.want, got)
 			wantLbs := labels.Labels{{Name: "bar", Value: tt.wantLabel}}
 			require.Equal(t, wantLbs, b.Labels())
@@ -270,7 +270,7 @@ func TestErrorFiltering(t *testing.T) {
 			b := NewBaseLabelsBuilder().ForLabels(tt.lbs, tt.lbs.Hash())
 			b.Reset()
 			b.SetErr(tt.err)
-			_, got := tt.f.Process(nil, b)
+			_, got := tt.f.Process(0, nil, b)
 			require.Equal(t, tt.want, got)
 			sort.Sort(tt.wantLbs)
 			require.Equal(t, tt.wantLbs, b.Labels())
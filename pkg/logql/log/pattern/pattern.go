@@ -13,6 +13,7 @@ var (
 type Matcher interface {
 	Matches(in []byte) [][]byte
 	Names() []string
+	Test(in []byte) bool
 }
 
 type matcher struct {
@@ -37,6 +38,20 @@ func New(in string) (Matcher, error) {
 	}, nil
 }
 
+// ParseLineFilter creates a matcher used to filter lines with Test.
+// Unlike New, captures are not required since nothing is extracted,
+// and their names are ignored.
+func ParseLineFilter(in string) (Matcher, error) {
+	e, err := parseExpr(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.validateNoConsecutiveCaptures(); err != nil {
+		return nil, err
+	}
+	return &matcher{e: e}, nil
+}
+
 // Matches matches the given line with the provided pattern.
 // Matches invalidates the previous returned captures array.
 func (m *matcher) Matches(in []byte) [][]byte {
@@ -90,6 +105,50 @@ func (m *matcher) Matches(in []byte) [][]byte {
 	return captures
 }
 
+// Test tells if the whole line matches the pattern: the line starts and ends with
+// the leading and trailing literals if any, contains every other literal in order,
+// and every capture matches at least one byte.
+func (m *matcher) Test(in []byte) bool {
+	if len(m.e) == 0 {
+		return len(in) == 0
+	}
+	var (
+		off  int
+		last = len(m.e) - 1
+	)
+	for i, n := range m.e {
+		ls, ok := n.(literals)
+		if !ok {
+			continue
+		}
+		switch {
+		case i == 0 && i == last:
+			return bytes.Equal(in, ls)
+		case i == 0:
+			if !bytes.HasPrefix(in, ls) {
+				return false
+			}
+			off = len(ls)
+		case i == last:
+			// the previous capture can't be empty.
+			rest := in[off:]
+			return len(rest) > len(ls) && bytes.HasSuffix(rest, ls)
+		default:
+			// the previous capture can't be empty, so the literals are searched from the next byte.
+			if off >= len(in) {
+				return false
+			}
+			j := bytes.Index(in[off+1:], ls)
+			if j == -1 {
+				return false
+			}
+			off += 1 + j + len(ls)
+		}
+	}
+	// we're ending on a capture, which can't be empty.
+	return off < len(in)
+}
+
 func (m *matcher) Names() []string {
 	return m.names
 }
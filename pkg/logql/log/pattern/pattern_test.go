@@ -122,6 +122,64 @@ func Test_matcher_Matches(t *testing.T) {
 	}
 }
 
+func Test_matcher_Test(t *testing.T) {
+	for _, tt := range []struct {
+		expr     string
+		in       string
+		expected bool
+	}{
+		{"foo <_> bar", "foo buzz bar", true},
+		{"foo <_> bar", "foo buzz bar bar", true},
+		{"foo <_> bar", "foo  bar", false},
+		{"foo <_> bar", "foo buzz bar baz", false},
+		{"foo <_> bar", "fo buzz bar", false},
+		{"<_> bar", "foo bar", true},
+		{"<_> bar", " bar", false},
+		{"foo <_>", "foo bar", true},
+		{"foo <_>", "foo ", false},
+		{"<a> - <b>", "a - - b", true},
+		{"<a> - <b>", " - b", false},
+		{"<_>", "", false},
+		{"<_>", "foo", true},
+		{"foo", "foo", true},
+		{"foo", "foo bar", false},
+		{
+			`<ip> - - <_> "<method> <uri> <_>" <status> <_>`,
+			`127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`,
+			true,
+		},
+		{
+			`<ip> - - <_> "<method> <uri> <_>" <status> <_>`,
+			`127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif" 200 2326`,
+			false,
+		},
+	} {
+		t.Run(tt.expr+"/"+tt.in, func(t *testing.T) {
+			m, err := ParseLineFilter(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, m.Test([]byte(tt.in)))
+		})
+	}
+}
+
+func Test_ParseLineFilter(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+	}{
+		{"<_>", nil},
+		{"foo bar buzz", nil},
+		{"<f> f<f>", nil},
+		{"", newParseError("syntax error: unexpected $end, expecting IDENTIFIER or LITERAL", 1, 1)},
+		{"<f><_>", fmt.Errorf("found consecutive capture '<f><_>': %w", ErrInvalidExpr)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseLineFilter(tt.name)
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
 var res [][]byte
 
 func Benchmark_matcher_Matches(b *testing.B) {
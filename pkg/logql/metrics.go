@@ -15,6 +15,7 @@ import (
 	"github.com/grafana/loki/pkg/logqlmodel"
 	logql_stats "github.com/grafana/loki/pkg/logqlmodel/stats"
 	"github.com/grafana/loki/pkg/usagestats"
+	"github.com/grafana/loki/pkg/util"
 	"github.com/grafana/loki/pkg/util/httpreq"
 	util_log "github.com/grafana/loki/pkg/util/log"
 )
@@ -103,6 +104,7 @@ func RecordMetrics(ctx context.Context, log log.Logger, p Params, status string,
 	logValues = append(logValues, []interface{}{
 		"latency", latencyType, // this can be used to filter log lines.
 		"query", p.Query(),
+		"query_hash", util.HashedQuery(p.Query()),
 		"query_type", queryType,
 		"range_type", rt,
 		"length", p.End().Sub(p.Start()),
@@ -149,7 +151,7 @@ func recordUsageStats(queryType string, stats logql_stats.Result) {
 }
 
 func QueryType(query string) (string, error) {
-	expr, err := syntax.ParseExpr(query)
+	expr, err := syntax.ParseDownstreamExpr(query)
 	if err != nil {
 		return "", err
 	}
@@ -19,6 +19,8 @@ func Test_optimizeSampleExpr(t *testing.T) {
 		{`sum by(name)(rate({region="us-east1"}[5m]))`, `sum by(name)(rate({region="us-east1"}[5m]))`},
 		{`sum by(name)(bytes_over_time({region="us-east1"} | line_format "something else"[5m]))`, `sum by(name)(bytes_over_time({region="us-east1"} | line_format "something else"[5m]))`},
 		{`sum by(name)(rate({region="us-east1"} | json | line_format "something else" |= "something"[5m]))`, `sum by(name)(rate({region="us-east1"} | json | line_format "something else" |= "something"[5m]))`},
+		{`sum by(name)(rate({region="us-east1"} | json | line_format "something else" |> "<_> else"[5m]))`, `sum by(name)(rate({region="us-east1"} | json | line_format "something else" |> "<_> else"[5m]))`},
+		{`sum by(name)(rate({region="us-east1"} |> "<_> else" | line_format "something else"[5m]))`, `sum by(name)(rate({region="us-east1"} |> "<_> else"[5m]))`},
 		{`sum by(name)(rate({region="us-east1"} | json | line_format "something else" | logfmt[5m]))`, `sum by(name)(rate({region="us-east1"} | json | line_format "something else" | logfmt[5m]))`},
 
 		// remove line_format that is not required.
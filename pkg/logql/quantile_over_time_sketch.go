@@ -0,0 +1,243 @@
+package logql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	promql_parser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/loki/pkg/iter"
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/sketch"
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/logqlmodel"
+	"github.com/grafana/loki/pkg/util"
+)
+
+// ValueTypeQuantileSketchMatrix is the promql.ValueType of the result of quantile_sketch_over_time.
+const ValueTypeQuantileSketchMatrix = "quantile_sketch_matrix"
+
+// QuantileSketchPoint is the sketch of the samples of a series within the range of a single step.
+type QuantileSketchPoint struct {
+	T      int64
+	Sketch *sketch.DDSketch
+}
+
+// QuantileSketchSeries is a series of sketches, one per step.
+type QuantileSketchSeries struct {
+	Metric labels.Labels
+	Points []QuantileSketchPoint
+}
+
+// QuantileSketchMatrix is the result of a quantile_sketch_over_time query.
+// The matrices of every shard of a quantile_over_time query are merged before
+// computing the quantile, see QuantileSketchMergeExpr.
+type QuantileSketchMatrix []QuantileSketchSeries
+
+func (QuantileSketchMatrix) Type() promql_parser.ValueType { return ValueTypeQuantileSketchMatrix }
+
+func (m QuantileSketchMatrix) String() string {
+	var sb strings.Builder
+	for i, s := range m {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(s.Metric.String())
+		sb.WriteString(" =>")
+		for _, p := range s.Points {
+			sb.WriteString(fmt.Sprintf(" %v @[%d]", p.Sketch.Count(), p.T))
+		}
+	}
+	return sb.String()
+}
+
+// Quantile computes the q-quantile of every sketch of the matrix.
+func (m QuantileSketchMatrix) Quantile(q float64) promql.Matrix {
+	result := make(promql.Matrix, 0, len(m))
+	for _, s := range m {
+		series := promql.Series{
+			Metric: s.Metric,
+			Points: make([]promql.Point, 0, len(s.Points)),
+		}
+		for _, p := range s.Points {
+			var v float64
+			switch {
+			case q < 0:
+				v = math.Inf(-1)
+			case q > 1:
+				v = math.Inf(+1)
+			default:
+				var err error
+				if v, err = p.Sketch.Quantile(q); err != nil {
+					// sketches are only built for non-empty ranges.
+					continue
+				}
+			}
+			series.Points = append(series.Points, promql.Point{T: p.T, V: v})
+		}
+		result = append(result, series)
+	}
+	return result
+}
+
+// MergeQuantileSketchMatrices merges the sketches of the same series and step
+// of multiple matrices. Sketches of the given matrices are merged in place.
+func MergeQuantileSketchMatrices(matrices ...QuantileSketchMatrix) (QuantileSketchMatrix, error) {
+	type mergedSeries struct {
+		metric labels.Labels
+		points map[int64]*sketch.DDSketch
+	}
+	seriesIndex := map[uint64]*mergedSeries{}
+	for _, m := range matrices {
+		for _, s := range m {
+			hash := s.Metric.Hash()
+			series, ok := seriesIndex[hash]
+			if !ok {
+				series = &mergedSeries{
+					metric: s.Metric,
+					points: make(map[int64]*sketch.DDSketch, len(s.Points)),
+				}
+				seriesIndex[hash] = series
+			}
+			for _, p := range s.Points {
+				merged, ok := series.points[p.T]
+				if !ok {
+					series.points[p.T] = p.Sketch
+					continue
+				}
+				if err := merged.Merge(p.Sketch); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	result := make(QuantileSketchMatrix, 0, len(seriesIndex))
+	for _, s := range seriesIndex {
+		series := QuantileSketchSeries{
+			Metric: s.metric,
+			Points: make([]QuantileSketchPoint, 0, len(s.points)),
+		}
+		for t, sk := range s.points {
+			series.Points = append(series.Points, QuantileSketchPoint{T: t, Sketch: sk})
+		}
+		sort.Slice(series.Points, func(i, j int) bool { return series.Points[i].T < series.Points[j].T })
+		result = append(result, series)
+	}
+	sort.Slice(result, func(i, j int) bool { return labels.Compare(result[i].Metric, result[j].Metric) < 0 })
+	return result, nil
+}
+
+// QuantileSketchMergeExpr is a SampleExpr computing quantile_over_time from the
+// sketches returned by its downstream quantile_sketch_over_time expressions.
+type QuantileSketchMergeExpr struct {
+	*ConcatSampleExpr
+	quantile float64
+}
+
+func (e *QuantileSketchMergeExpr) String() string {
+	return fmt.Sprintf("quantile_sketch_merge<%s, %s>", strconv.FormatFloat(e.quantile, 'f', -1, 64), e.ConcatSampleExpr.String())
+}
+
+func (e *QuantileSketchMergeExpr) Walk(f syntax.WalkFn) {
+	f(e)
+	e.ConcatSampleExpr.Walk(f)
+}
+
+// quantileSketchEvaluator is implemented by evaluators able to evaluate
+// quantile_sketch_over_time, whose result cannot be represented by a StepEvaluator.
+type quantileSketchEvaluator interface {
+	quantileSketches(ctx context.Context, expr *syntax.RangeAggregationExpr, q Params, maxSeries int) (QuantileSketchMatrix, error)
+}
+
+func (ev *DefaultEvaluator) quantileSketches(ctx context.Context, expr *syntax.RangeAggregationExpr, q Params, maxSeries int) (QuantileSketchMatrix, error) {
+	it, err := ev.querier.SelectSamples(ctx, SelectSampleParams{
+		&logproto.SampleQueryRequest{
+			Start:    q.Start().Add(-expr.Left.Interval).Add(-expr.Left.Offset),
+			End:      q.End().Add(-expr.Left.Offset),
+			Selector: expr.String(),
+			Shards:   q.Shards(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	rangeIt := newRangeVectorIterator(
+		iter.NewPeekingSampleIterator(it),
+		expr.Left.Interval.Nanoseconds(),
+		q.Step().Nanoseconds(),
+		q.Start().UnixNano(), q.End().UnixNano(), expr.Left.Offset.Nanoseconds(),
+	)
+	defer util.LogErrorWithContext(ctx, "closing iterator", rangeIt.Close)
+
+	seriesIndex := map[string]*QuantileSketchSeries{}
+	for rangeIt.Next() {
+		// convert ts from nano to milli seconds as the iterator work with nanoseconds
+		ts := rangeIt.current/1e+6 + rangeIt.offset/1e+6
+		for lbs, window := range rangeIt.window {
+			// Errors are not allowed in metrics.
+			if window.Metric.Has(logqlmodel.ErrorLabel) {
+				return nil, logqlmodel.NewPipelineErr(window.Metric)
+			}
+			s, err := sketch.NewDDSketch(sketch.DefaultRelativeAccuracy)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range window.Points {
+				s.Add(p.V)
+			}
+
+			series, ok := seriesIndex[lbs]
+			if !ok {
+				series = &QuantileSketchSeries{Metric: window.Metric}
+				seriesIndex[lbs] = series
+			}
+			series.Points = append(series.Points, QuantileSketchPoint{T: ts, Sketch: s})
+		}
+		if len(seriesIndex) > maxSeries {
+			return nil, logqlmodel.NewSeriesLimitError(maxSeries)
+		}
+	}
+	if err := rangeIt.Error(); err != nil {
+		return nil, err
+	}
+
+	result := make(QuantileSketchMatrix, 0, len(seriesIndex))
+	for _, s := range seriesIndex {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool { return labels.Compare(result[i].Metric, result[j].Metric) < 0 })
+	return result, nil
+}
+
+// quantileSketchMergeEvaluator downstreams the quantile_sketch_over_time expressions
+// of a QuantileSketchMergeExpr and exposes the quantile of the merged sketches as a StepEvaluator.
+func (ev *DownstreamEvaluator) quantileSketchMergeEvaluator(ctx context.Context, expr *QuantileSketchMergeExpr, params Params) (StepEvaluator, error) {
+	queries := downstreamQueries(expr.ConcatSampleExpr, params)
+	results, err := ev.Downstream(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	matrices := make([]QuantileSketchMatrix, 0, len(results))
+	for i, res := range results {
+		m, ok := res.Data.(QuantileSketchMatrix)
+		if !ok {
+			return nil, errors.Errorf("unexpected type (%T) for downstream query %s, expected %s", res.Data, queries[i].Expr.String(), ValueTypeQuantileSketchMatrix)
+		}
+		matrices = append(matrices, m)
+	}
+
+	merged, err := MergeQuantileSketchMatrices(matrices...)
+	if err != nil {
+		return nil, err
+	}
+	return NewMatrixStepper(params.Start(), params.End(), params.Step(), merged.Quantile(expr.quantile)), nil
+}
@@ -186,13 +186,22 @@ func putSeries(s *promql.Series) {
 }
 
 func aggregator(r *syntax.RangeAggregationExpr) (RangeVectorAggregator, error) {
-	switch r.Operation {
+	return rangeAggregator(r.Operation, r.Left.Interval, r.Left.Unwrap != nil, r.Params)
+}
+
+// rangeAggregator returns the aggregator of a range operation over the given
+// interval. Rates are computed from sample values instead of counting samples
+// when computeValues is set, e.g. for unwrapped ranges and subqueries.
+func rangeAggregator(operation string, interval time.Duration, computeValues bool, params *float64) (RangeVectorAggregator, error) {
+	switch operation {
 	case syntax.OpRangeTypeRate:
-		return rateLogs(r.Left.Interval, r.Left.Unwrap != nil), nil
+		return rateLogs(interval, computeValues), nil
+	case syntax.OpRangeTypeRateCounter:
+		return rateCounter(interval), nil
 	case syntax.OpRangeTypeCount:
 		return countOverTime, nil
 	case syntax.OpRangeTypeBytesRate:
-		return rateLogBytes(r.Left.Interval), nil
+		return rateLogBytes(interval), nil
 	case syntax.OpRangeTypeBytes, syntax.OpRangeTypeSum:
 		return sumOverTime, nil
 	case syntax.OpRangeTypeAvg:
@@ -206,7 +215,7 @@ func aggregator(r *syntax.RangeAggregationExpr) (RangeVectorAggregator, error) {
 	case syntax.OpRangeTypeStdvar:
 		return stdvarOverTime, nil
 	case syntax.OpRangeTypeQuantile:
-		return quantileOverTime(*r.Params), nil
+		return quantileOverTime(*params), nil
 	case syntax.OpRangeTypeFirst:
 		return first, nil
 	case syntax.OpRangeTypeLast:
@@ -214,7 +223,7 @@ func aggregator(r *syntax.RangeAggregationExpr) (RangeVectorAggregator, error) {
 	case syntax.OpRangeTypeAbsent:
 		return one, nil
 	default:
-		return nil, fmt.Errorf(syntax.UnsupportedErr, r.Operation)
+		return nil, fmt.Errorf(syntax.UnsupportedErr, operation)
 	}
 }
 
@@ -228,6 +237,14 @@ func rateLogs(selRange time.Duration, computeValues bool) func(samples []promql.
 	}
 }
 
+// rateCounter calculates the per-second rate of a counter, like the Prometheus rate function:
+// decreasing values are counter resets and the result is extrapolated to the boundaries of the range.
+func rateCounter(selRange time.Duration) func(samples []promql.Point) float64 {
+	return func(samples []promql.Point) float64 {
+		return extrapolatedRate(samples, selRange, true, true)
+	}
+}
+
 // extrapolatedRate function is taken from prometheus code promql/functions.go:59
 // extrapolatedRate is a utility function for rate/increase/delta.
 // It calculates the rate (allowing for counter resets if isCounter is true),
@@ -195,6 +195,35 @@ func Test_RangeVectorIterator(t *testing.T) {
 	}
 }
 
+func Test_RateCounter(t *testing.T) {
+	agg, err := rangeAggregator(syntax.OpRangeTypeRateCounter, 4*time.Second, true, nil)
+	require.NoError(t, err)
+
+	// the counter increases by 5 then resets, 25 overall, over 3s out of the 4s range.
+	// The zero point of the counter is 1.2s before the first sample, too far to extrapolate to it,
+	// so the start is extrapolated by half the interval between samples.
+	points := []promql.Point{
+		newPoint(time.Unix(1, 0), 10),
+		newPoint(time.Unix(2, 0), 20),
+		newPoint(time.Unix(3, 0), 5),
+		newPoint(time.Unix(4, 0), 15),
+	}
+	require.InDelta(t, 25*3.5/3/4, agg(points), 1e-9)
+
+	// without reset this is the same as the Prometheus rate, the zero point of the counter
+	// is close enough to the first sample to extrapolate the start to it.
+	points = []promql.Point{
+		newPoint(time.Unix(1, 0), 10),
+		newPoint(time.Unix(2, 0), 20),
+		newPoint(time.Unix(3, 0), 30),
+		newPoint(time.Unix(4, 0), 40),
+	}
+	require.InDelta(t, 30*4/3/4, agg(points), 1e-9)
+
+	// a single sample has no rate.
+	require.Equal(t, 0., agg(points[:1]))
+}
+
 func Test_RangeVectorIteratorBadLabels(t *testing.T) {
 	badIterator := iter.NewPeekingSampleIterator(
 		iter.NewSeriesIterator(logproto.Series{
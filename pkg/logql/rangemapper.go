@@ -356,6 +356,9 @@ func isSplittableByRange(expr syntax.SampleExpr) bool {
 	case *syntax.RangeAggregationExpr:
 		_, ok := splittableRangeVectorOp[e.Operation]
 		return ok
+	case *syntax.SubqueryAggregationExpr:
+		// subqueries are executed as a whole, they are never split by range.
+		return false
 	default:
 		return false
 	}
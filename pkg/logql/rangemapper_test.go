@@ -1037,6 +1037,16 @@ func Test_SplitRangeVectorMapping_Noop(t *testing.T) {
 			`max_over_time({app="foo"} | json | unwrap bar [3m])`,
 			`max_over_time({app="foo"} | json | unwrap bar [3m])`,
 		},
+
+		// subqueries are never split
+		{
+			`max_over_time(rate({app="foo"}[1m])[1h:1m])`,
+			`max_over_time(rate({app="foo"}[1m])[1h:1m])`,
+		},
+		{
+			`sum(max_over_time(rate({app="foo"}[1m])[1h:1m])) + sum(rate({app="foo"}[3m]))`,
+			`(sum(max_over_time(rate({app="foo"}[1m])[1h:1m])) + sum(rate({app="foo"}[3m])))`,
+		},
 	} {
 		tc := tc
 		t.Run(tc.expr, func(t *testing.T) {
@@ -83,19 +83,23 @@ func badASTMapping(got syntax.Expr) error {
 	return fmt.Errorf("bad AST mapping: expected SampleExpr, but got (%T)", got)
 }
 
-func NewShardMapper(shards int, metrics *ShardingMetrics) (ShardMapper, error) {
+// NewShardMapper creates a ShardMapper. When shardQuantileOverTime is set,
+// quantile_over_time is sharded using sketches, which makes its results approximate.
+func NewShardMapper(shards int, metrics *ShardingMetrics, shardQuantileOverTime bool) (ShardMapper, error) {
 	if shards < 2 {
 		return ShardMapper{}, fmt.Errorf("Cannot create ShardMapper with <2 shards. Received %d", shards)
 	}
 	return ShardMapper{
-		shards:  shards,
-		metrics: metrics,
+		shards:                shards,
+		metrics:               metrics,
+		shardQuantileOverTime: shardQuantileOverTime,
 	}, nil
 }
 
 type ShardMapper struct {
-	shards  int
-	metrics *ShardingMetrics
+	shards                int
+	metrics               *ShardingMetrics
+	shardQuantileOverTime bool
 }
 
 func (m ShardMapper) Parse(query string) (noop bool, expr syntax.Expr, err error) {
@@ -134,7 +138,7 @@ func (m ShardMapper) Map(expr syntax.Expr, r *shardRecorder) (syntax.Expr, error
 	}
 
 	switch e := expr.(type) {
-	case *syntax.LiteralExpr:
+	case *syntax.LiteralExpr, *syntax.VectorExpr:
 		return e, nil
 	case *syntax.MatchersExpr, *syntax.PipelineExpr:
 		return m.mapLogSelectorExpr(e.(syntax.LogSelectorExpr), r), nil
@@ -142,8 +146,22 @@ func (m ShardMapper) Map(expr syntax.Expr, r *shardRecorder) (syntax.Expr, error
 		return m.mapVectorAggregationExpr(e, r)
 	case *syntax.LabelReplaceExpr:
 		return m.mapLabelReplaceExpr(e, r)
+	case *syntax.LabelJoinExpr:
+		return m.mapLabelJoinExpr(e, r)
 	case *syntax.RangeAggregationExpr:
 		return m.mapRangeAggregationExpr(e, r), nil
+	case *syntax.SubqueryAggregationExpr:
+		// the subquery is evaluated by the frontend from the results of its sharded metric expression.
+		mapped, err := m.Map(e.Left.Left, r)
+		if err != nil {
+			return nil, err
+		}
+		sampleExpr, ok := mapped.(syntax.SampleExpr)
+		if !ok {
+			return nil, badASTMapping(mapped)
+		}
+		e.Left.Left = sampleExpr
+		return e, nil
 	case *syntax.BinOpExpr:
 		lhsMapped, err := m.Map(e.SampleExpr, r)
 		if err != nil {
@@ -210,6 +228,22 @@ func (m ShardMapper) mapSampleExpr(expr syntax.SampleExpr, r *shardRecorder) syn
 // technically, std{dev,var} are also parallelizable if there is no cross-shard merging
 // in descendent nodes in the AST. This optimization is currently avoided for simplicity.
 func (m ShardMapper) mapVectorAggregationExpr(expr *syntax.VectorAggregationExpr, r *shardRecorder) (syntax.SampleExpr, error) {
+	if expr.Operation == syntax.OpTypeApproxTopK && summableAcrossShards(expr.Left) {
+		// approx_topk(k, x) -> count_min_sketch_merge<k, count_min_sketch(k, x, shard=1) ++ count_min_sketch(k, x, shard=2)...>
+		// The sums of the same series returned by different shards are added up
+		// in the merged sketch, so only the top k series of every shard are returned.
+		sketchExpr := &syntax.VectorAggregationExpr{
+			Left:      expr.Left,
+			Operation: syntax.OpTypeCountMinSketch,
+			Params:    expr.Params,
+			Grouping:  &syntax.Grouping{},
+		}
+		return &CountMinSketchMergeExpr{
+			ConcatSampleExpr: m.mapSampleExpr(sketchExpr, r).(*ConcatSampleExpr),
+			k:                expr.Params,
+		}, nil
+	}
+
 	// if this AST contains unshardable operations, don't shard this at this level,
 	// but attempt to shard a child node.
 	if !expr.Shardable() {
@@ -285,6 +319,25 @@ func (m ShardMapper) mapVectorAggregationExpr(expr *syntax.VectorAggregationExpr
 	}
 }
 
+// summableAcrossShards tells if the results of an expression over every shard can be
+// added up into its result over all the shards, e.g. sum by (foo) (rate({app="foo"}[1m])).
+func summableAcrossShards(expr syntax.SampleExpr) bool {
+	vecExpr, ok := expr.(*syntax.VectorAggregationExpr)
+	if !ok || vecExpr.Operation != syntax.OpTypeSum {
+		return false
+	}
+	rangeExpr, ok := vecExpr.Left.(*syntax.RangeAggregationExpr)
+	if !ok {
+		return false
+	}
+	switch rangeExpr.Operation {
+	case syntax.OpRangeTypeCount, syntax.OpRangeTypeRate, syntax.OpRangeTypeBytes, syntax.OpRangeTypeBytesRate, syntax.OpRangeTypeSum:
+		return rangeExpr.Left.Shardable()
+	default:
+		return false
+	}
+}
+
 func (m ShardMapper) mapLabelReplaceExpr(expr *syntax.LabelReplaceExpr, r *shardRecorder) (syntax.SampleExpr, error) {
 	subMapped, err := m.Map(expr.Left, r)
 	if err != nil {
@@ -295,7 +348,31 @@ func (m ShardMapper) mapLabelReplaceExpr(expr *syntax.LabelReplaceExpr, r *shard
 	return &cpy, nil
 }
 
+func (m ShardMapper) mapLabelJoinExpr(expr *syntax.LabelJoinExpr, r *shardRecorder) (syntax.SampleExpr, error) {
+	subMapped, err := m.Map(expr.Left, r)
+	if err != nil {
+		return nil, err
+	}
+	cpy := *expr
+	cpy.Left = subMapped.(syntax.SampleExpr)
+	return &cpy, nil
+}
+
 func (m ShardMapper) mapRangeAggregationExpr(expr *syntax.RangeAggregationExpr, r *shardRecorder) syntax.SampleExpr {
+	if expr.Operation == syntax.OpRangeTypeQuantile && m.shardQuantileOverTime && expr.Left.At == nil {
+		// quantile_over_time(q, x) -> quantile_sketch_merge<q, quantile_sketch_over_time(x, shard=1) ++ quantile_sketch_over_time(x, shard=2)...>
+		// Sketches of the same series returned by different shards are merged,
+		// so unlike concatenation this also supports label modifiers.
+		sketchExpr := &syntax.RangeAggregationExpr{
+			Left:      expr.Left,
+			Operation: syntax.OpRangeTypeQuantileSketch,
+			Grouping:  expr.Grouping,
+		}
+		return &QuantileSketchMergeExpr{
+			ConcatSampleExpr: m.mapSampleExpr(sketchExpr, r).(*ConcatSampleExpr),
+			quantile:         *expr.Params,
+		}
+	}
 	if hasLabelModifier(expr) {
 		// if an expr can modify labels this means multiple shards can returns the same labelset.
 		// When this happens the merge strategy needs to be different than a simple concatenation.
@@ -304,10 +381,10 @@ func (m ShardMapper) mapRangeAggregationExpr(expr *syntax.RangeAggregationExpr,
 		return expr
 	}
 	switch expr.Operation {
-	case syntax.OpRangeTypeCount, syntax.OpRangeTypeRate, syntax.OpRangeTypeBytesRate, syntax.OpRangeTypeBytes:
+	case syntax.OpRangeTypeCount, syntax.OpRangeTypeRate, syntax.OpRangeTypeBytesRate, syntax.OpRangeTypeBytes, syntax.OpRangeTypeRateCounter:
 		// count_over_time(x) -> count_over_time(x, shard=1) ++ count_over_time(x, shard=2)...
 		// rate(x) -> rate(x, shard=1) ++ rate(x, shard=2)...
-		// same goes for bytes_rate and bytes_over_time
+		// same goes for bytes_rate, bytes_over_time and rate_counter
 		return m.mapSampleExpr(expr, r)
 	default:
 		return expr
@@ -322,7 +399,8 @@ func hasLabelModifier(expr *syntax.RangeAggregationExpr) bool {
 		return false
 	case *syntax.PipelineExpr:
 		for _, p := range ex.MultiStages {
-			if _, ok := p.(*syntax.LabelFmtExpr); ok {
+			switch p.(type) {
+			case *syntax.LabelFmtExpr, *syntax.DropLabelsExpr, *syntax.KeepLabelsExpr:
 				return true
 			}
 		}
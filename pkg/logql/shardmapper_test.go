@@ -51,7 +51,7 @@ func TestShardedStringer(t *testing.T) {
 }
 
 func TestMapSampleExpr(t *testing.T) {
-	m, err := NewShardMapper(2, nilMetrics)
+	m, err := NewShardMapper(2, nilMetrics, false)
 	require.Nil(t, err)
 
 	for _, tc := range []struct {
@@ -112,7 +112,7 @@ func TestMapSampleExpr(t *testing.T) {
 }
 
 func TestMappingStrings(t *testing.T) {
-	m, err := NewShardMapper(2, nilMetrics)
+	m, err := NewShardMapper(2, nilMetrics, false)
 	require.Nil(t, err)
 	for _, tc := range []struct {
 		in  string
@@ -135,6 +135,23 @@ func TestMappingStrings(t *testing.T) {
 				++ downstream<sum(rate({foo="bar"}[1m])), shard=1_of_2>
 			)`,
 		},
+		{
+			in: `approx_topk(10, sum by (client_ip) (count_over_time({foo="bar"} | json [5m])))`,
+			out: `count_min_sketch_merge<10,
+				downstream<count_min_sketch(10, sum by (client_ip) (count_over_time({foo="bar"} | json [5m]))), shard=0_of_2>
+				++ downstream<count_min_sketch(10, sum by (client_ip) (count_over_time({foo="bar"} | json [5m]))), shard=1_of_2>
+			>`,
+		},
+		{
+			// the max of every shard cannot be added up, approx_topk is then exact.
+			in: `approx_topk(10, max by (client_ip) (count_over_time({foo="bar"}[5m])))`,
+			out: `approx_topk(10,
+				max by (client_ip) (
+					downstream<count_over_time({foo="bar"}[5m]), shard=0_of_2>
+					++ downstream<count_over_time({foo="bar"}[5m]), shard=1_of_2>
+				)
+			)`,
+		},
 		{
 			in: `max(count(rate({foo="bar"}[5m]))) / 2`,
 			out: `(max(
@@ -166,6 +183,22 @@ func TestMappingStrings(t *testing.T) {
 			in:  `rate({foo="bar"} | json | label_format foo=bar [5m])`,
 			out: `rate({foo="bar"} | json | label_format foo=bar [5m])`,
 		},
+		{
+			in:  `rate({foo="bar"} | json | drop foo [5m])`,
+			out: `rate({foo="bar"} | json | drop foo [5m])`,
+		},
+		{
+			in:  `rate({foo="bar"} | json | keep bar [5m])`,
+			out: `rate({foo="bar"} | json | keep bar [5m])`,
+		},
+		{
+			// label_format doesn't prevent count from being sharded.
+			in: `count(rate({foo="bar"} | label_format foo=bar [5m]))`,
+			out: `sum(
+				downstream<count(rate({foo="bar"} | label_format foo=bar [5m])), shard=0_of_2>
+				++ downstream<count(rate({foo="bar"} | label_format foo=bar [5m])), shard=1_of_2>
+			)`,
+		},
 		{
 			in: `count(rate({foo="bar"} | json [5m]))`,
 			out: `count(
@@ -199,6 +232,13 @@ func TestMappingStrings(t *testing.T) {
 				++ downstream<sum by(cluster)(sum_over_time({foo="bar"}|="id=123"| logfmt | unwrap latency[5m])), shard=1_of_2>
 			)`,
 		},
+		{
+			in: `sum by (cluster) (rate_counter({foo="bar"} | logfmt | unwrap bytes_sent_total [5m]))`,
+			out: `sum by (cluster) (
+				downstream<sum by(cluster)(rate_counter({foo="bar"}| logfmt | unwrap bytes_sent_total[5m])), shard=0_of_2>
+				++ downstream<sum by(cluster)(rate_counter({foo="bar"}| logfmt | unwrap bytes_sent_total[5m])), shard=1_of_2>
+			)`,
+		},
 		{
 			in:  `sum by (cluster) (stddev_over_time({foo="bar"} |= "id=123" | logfmt | unwrap latency [5m]))`,
 			out: `sum by (cluster) (stddev_over_time({foo="bar"} |= "id=123" | logfmt | unwrap latency [5m]))`,
@@ -222,6 +262,40 @@ func TestMappingStrings(t *testing.T) {
 					)
 				)`,
 		},
+		{
+			in: `sort_desc(sum by (cluster) (rate({foo="bar"}[5m])))`,
+			out: `sort_desc(
+				sum by (cluster) (
+					downstream<sum by(cluster)(rate({foo="bar"}[5m])), shard=0_of_2>
+					++ downstream<sum by(cluster)(rate({foo="bar"}[5m])), shard=1_of_2>
+				)
+			)`,
+		},
+		{
+			in: `label_join(sum by (a, b) (rate({foo="bar"}[5m])), "c", "-", "a", "b")`,
+			out: `label_join(
+				sum by (a, b) (
+					downstream<sum by(a,b)(rate({foo="bar"}[5m])), shard=0_of_2>
+					++ downstream<sum by(a,b)(rate({foo="bar"}[5m])), shard=1_of_2>
+				),
+				"c", "-", "a", "b"
+			)`,
+		},
+		{
+			in: `sum(rate({foo="bar"}[5m])) or vector(0)`,
+			out: `(
+				sum(
+					downstream<sum(rate({foo="bar"}[5m])), shard=0_of_2>
+					++ downstream<sum(rate({foo="bar"}[5m])), shard=1_of_2>
+				)
+				or vector(0)
+			)`,
+		},
+		{
+			// vector() would be counted once per shard.
+			in:  `count(vector(1))`,
+			out: `count(vector(1))`,
+		},
 		{
 			// Ensure we don't try to shard expressions that include label reformatting.
 			in:  `sum(count_over_time({foo="bar"} | logfmt | label_format bar=baz | bar="buz" [5m]))`,
@@ -255,6 +329,25 @@ func TestMappingStrings(t *testing.T) {
 				)
 			)`,
 		},
+		{
+			in: `max_over_time(sum by (cluster) (rate({foo="bar"}[5m]))[1h:1m])`,
+			out: `max_over_time(
+				sum by (cluster) (
+					downstream<sum by (cluster) (rate({foo="bar"}[5m])), shard=0_of_2>
+					++ downstream<sum by (cluster) (rate({foo="bar"}[5m])), shard=1_of_2>
+				)[1h:1m]
+			)`,
+		},
+		{
+			in: `max(quantile_over_time(0.99, rate({foo="bar"}[5m])[1h:1m] offset 5m))`,
+			out: `max(
+				quantile_over_time(0.99,
+					downstream<rate({foo="bar"}[5m]), shard=0_of_2>
+					++ downstream<rate({foo="bar"}[5m]), shard=1_of_2>
+					[1h:1m] offset 5m0s
+				)
+			)`,
+		},
 	} {
 		t.Run(tc.in, func(t *testing.T) {
 			ast, err := syntax.ParseExpr(tc.in)
@@ -268,8 +361,52 @@ func TestMappingStrings(t *testing.T) {
 	}
 }
 
+func TestMappingStrings_QuantileOverTime(t *testing.T) {
+	for _, tc := range []struct {
+		in                    string
+		shardQuantileOverTime bool
+		out                   string
+	}{
+		{
+			in:  `quantile_over_time(0.99, {foo="bar"} | unwrap latency [5m]) by (cluster)`,
+			out: `quantile_over_time(0.99,{foo="bar"} | unwrap latency [5m]) by (cluster)`,
+		},
+		{
+			in:                    `quantile_over_time(0.99, {foo="bar"} | unwrap latency [5m]) by (cluster)`,
+			shardQuantileOverTime: true,
+			out: `quantile_sketch_merge<0.99,
+				downstream<quantile_sketch_over_time({foo="bar"} | unwrap latency [5m]) by (cluster), shard=0_of_2>
+				++ downstream<quantile_sketch_over_time({foo="bar"} | unwrap latency [5m]) by (cluster), shard=1_of_2>
+			>`,
+		},
+		{
+			in:                    `max by (cluster) (quantile_over_time(0.5, {foo="bar"} | label_format foo=bar | unwrap latency [5m]))`,
+			shardQuantileOverTime: true,
+			out: `max by (cluster) (
+				quantile_sketch_merge<0.5,
+					downstream<quantile_sketch_over_time({foo="bar"} | label_format foo=bar | unwrap latency [5m]), shard=0_of_2>
+					++ downstream<quantile_sketch_over_time({foo="bar"} | label_format foo=bar | unwrap latency [5m]), shard=1_of_2>
+				>
+			)`,
+		},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			m, err := NewShardMapper(2, nilMetrics, tc.shardQuantileOverTime)
+			require.Nil(t, err)
+
+			ast, err := syntax.ParseExpr(tc.in)
+			require.Nil(t, err)
+
+			mapped, err := m.Map(ast, nilMetrics.shardRecorder())
+			require.Nil(t, err)
+
+			require.Equal(t, removeWhiteSpace(tc.out), removeWhiteSpace(mapped.String()))
+		})
+	}
+}
+
 func TestMapping(t *testing.T) {
-	m, err := NewShardMapper(2, nilMetrics)
+	m, err := NewShardMapper(2, nilMetrics, false)
 	require.Nil(t, err)
 
 	for _, tc := range []struct {
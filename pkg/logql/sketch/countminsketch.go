@@ -0,0 +1,105 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	// DefaultCountMinSketchDepth is the number of rows of the sketches used for approximate topk.
+	DefaultCountMinSketchDepth = 4
+	// DefaultCountMinSketchWidth is the number of counters per row of the sketches used for approximate topk.
+	DefaultCountMinSketchWidth = 4096
+)
+
+var ErrIncompatibleCountMinSketches = errors.New("cannot merge count-min sketches with different dimensions")
+
+// CountMinSketch estimates the sum of the values added for each key, as described
+// in http://dimacs.rutgers.edu/~graham/pubs/papers/cm-full.pdf. Estimates are never
+// lower than the exact sum and exceed it by at most 2/width of the sum of all values
+// with a probability of 1-(1/2)^depth, as long as values are not negative.
+// Sketches with the same dimensions are merged by summing their counters, without
+// any loss of accuracy.
+type CountMinSketch struct {
+	depth, width uint32
+	counters     []float64
+}
+
+// NewCountMinSketch creates an empty sketch of depth rows of width counters.
+func NewCountMinSketch(depth, width uint32) (*CountMinSketch, error) {
+	if depth == 0 || width == 0 {
+		return nil, fmt.Errorf("depth and width of count-min sketches must be positive, got %dx%d", depth, width)
+	}
+	return &CountMinSketch{
+		depth:    depth,
+		width:    width,
+		counters: make([]float64, depth*width),
+	}, nil
+}
+
+// NewCountMinSketchFromCounters creates a sketch from the counters of another sketch, as returned by Counters.
+func NewCountMinSketchFromCounters(depth, width uint32, counters []float64) (*CountMinSketch, error) {
+	if depth == 0 || width == 0 {
+		return nil, fmt.Errorf("depth and width of count-min sketches must be positive, got %dx%d", depth, width)
+	}
+	if len(counters) != int(depth*width) {
+		return nil, fmt.Errorf("expected %d counters for a %dx%d count-min sketch, got %d", depth*width, depth, width, len(counters))
+	}
+	return &CountMinSketch{
+		depth:    depth,
+		width:    width,
+		counters: counters,
+	}, nil
+}
+
+// Depth returns the number of rows of the sketch.
+func (s *CountMinSketch) Depth() uint32 { return s.depth }
+
+// Width returns the number of counters per row of the sketch.
+func (s *CountMinSketch) Width() uint32 { return s.width }
+
+// Counters returns the counters of all the rows of the sketch.
+func (s *CountMinSketch) Counters() []float64 { return s.counters }
+
+// Add adds a value to the sum of a key.
+func (s *CountMinSketch) Add(key string, v float64) {
+	h1, h2 := hashes(key)
+	for i := uint32(0); i < s.depth; i++ {
+		s.counters[s.counter(i, h1, h2)] += v
+	}
+}
+
+// Count returns the estimated sum of the values added for a key.
+func (s *CountMinSketch) Count(key string) float64 {
+	h1, h2 := hashes(key)
+	count := math.Inf(1)
+	for i := uint32(0); i < s.depth; i++ {
+		count = math.Min(count, s.counters[s.counter(i, h1, h2)])
+	}
+	return count
+}
+
+// Merge adds the values of another sketch to this one.
+func (s *CountMinSketch) Merge(o *CountMinSketch) error {
+	if s.depth != o.depth || s.width != o.width {
+		return ErrIncompatibleCountMinSketches
+	}
+	for i, c := range o.counters {
+		s.counters[i] += c
+	}
+	return nil
+}
+
+// counter returns the index of the counter of a key in the given row. The
+// counters of every row are chosen with double hashing.
+func (s *CountMinSketch) counter(row uint32, h1, h2 uint32) uint32 {
+	return row*s.width + (h1+row*h2)%s.width
+}
+
+func hashes(key string) (uint32, uint32) {
+	h := xxhash.Sum64String(key)
+	return uint32(h), uint32(h >> 32)
+}
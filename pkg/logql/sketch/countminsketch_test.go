@@ -0,0 +1,79 @@
+package sketch
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountMinSketch_Count(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	s, err := NewCountMinSketch(DefaultCountMinSketchDepth, DefaultCountMinSketchWidth)
+	require.NoError(t, err)
+
+	exact := map[string]float64{}
+	var total float64
+	for i := 0; i < 100000; i++ {
+		// a few heavy hitters among a long tail of keys.
+		key := fmt.Sprintf("key-%d", r.Intn(10))
+		if r.Intn(2) == 0 {
+			key = fmt.Sprintf("tail-%d", r.Intn(50000))
+		}
+		exact[key]++
+		total++
+		s.Add(key, 1)
+	}
+
+	for key, count := range exact {
+		estimate := s.Count(key)
+		require.GreaterOrEqual(t, estimate, count, key)
+		if key[:3] == "key" {
+			require.InDelta(t, count, estimate, 2*total/DefaultCountMinSketchWidth, key)
+		}
+	}
+	require.Equal(t, float64(0), mustCountMinSketch(t).Count("missing"))
+}
+
+func TestCountMinSketch_Merge(t *testing.T) {
+	merged := mustCountMinSketch(t)
+	whole := mustCountMinSketch(t)
+	for shard := 0; shard < 3; shard++ {
+		s := mustCountMinSketch(t)
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("%d", i%(shard+5))
+			s.Add(key, float64(i))
+			whole.Add(key, float64(i))
+		}
+		require.NoError(t, merged.Merge(s))
+	}
+	require.Equal(t, whole.Counters(), merged.Counters())
+
+	other, err := NewCountMinSketch(2, 16)
+	require.NoError(t, err)
+	require.Equal(t, ErrIncompatibleCountMinSketches, merged.Merge(other))
+}
+
+func TestCountMinSketch_FromCounters(t *testing.T) {
+	s := mustCountMinSketch(t)
+	s.Add("foo", 3)
+	s.Add("bar", 5)
+
+	copied, err := NewCountMinSketchFromCounters(s.Depth(), s.Width(), s.Counters())
+	require.NoError(t, err)
+	require.Equal(t, float64(3), copied.Count("foo"))
+	require.Equal(t, float64(5), copied.Count("bar"))
+
+	_, err = NewCountMinSketchFromCounters(s.Depth(), s.Width(), s.Counters()[1:])
+	require.Error(t, err)
+	_, err = NewCountMinSketch(0, 1)
+	require.Error(t, err)
+}
+
+func mustCountMinSketch(t *testing.T) *CountMinSketch {
+	t.Helper()
+	s, err := NewCountMinSketch(DefaultCountMinSketchDepth, DefaultCountMinSketchWidth)
+	require.NoError(t, err)
+	return s
+}
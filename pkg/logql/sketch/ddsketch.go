@@ -0,0 +1,245 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DefaultRelativeAccuracy is the relative accuracy of the sketches used for approximate quantiles.
+const DefaultRelativeAccuracy = 0.01
+
+// maxBins bounds the number of bins of a store. When a store grows beyond it,
+// the lowest bins are collapsed, which trades accuracy for the smallest values
+// against bounded memory.
+const maxBins = 2048
+
+var (
+	ErrEmptySketch          = errors.New("empty sketch")
+	ErrIncompatibleSketches = errors.New("cannot merge sketches with different relative accuracies")
+)
+
+// DDSketch is a quantile sketch with relative-error guarantees as described in
+// https://arxiv.org/abs/1908.10693. Values are counted in logarithmically sized
+// bins, so sketches with the same relative accuracy can be merged by summing the
+// counts of their bins, without any loss of accuracy. This makes them suitable
+// for computing quantiles over the partial results of sharded queries.
+type DDSketch struct {
+	relativeAccuracy float64
+	gamma            float64
+	logGamma         float64
+
+	zeroCount float64
+	positive  Store
+	negative  Store
+}
+
+// Store holds the counts of a contiguous range of bins, starting at the bin
+// index Offset.
+type Store struct {
+	Offset int32
+	Counts []float64
+}
+
+// NewDDSketch creates an empty sketch. Quantiles returned by the sketch are
+// within relativeAccuracy of the exact value.
+func NewDDSketch(relativeAccuracy float64) (*DDSketch, error) {
+	if relativeAccuracy <= 0 || relativeAccuracy >= 1 {
+		return nil, fmt.Errorf("relative accuracy must be between 0 and 1, got %v", relativeAccuracy)
+	}
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	return &DDSketch{
+		relativeAccuracy: relativeAccuracy,
+		gamma:            gamma,
+		logGamma:         math.Log(gamma),
+	}, nil
+}
+
+// NewDDSketchFromBins creates a sketch from the bins of another sketch, as
+// returned by ZeroCount, Positive and Negative.
+func NewDDSketchFromBins(relativeAccuracy, zeroCount float64, positive, negative Store) (*DDSketch, error) {
+	s, err := NewDDSketch(relativeAccuracy)
+	if err != nil {
+		return nil, err
+	}
+	s.zeroCount = zeroCount
+	s.positive = positive
+	s.negative = negative
+	return s, nil
+}
+
+// RelativeAccuracy returns the relative accuracy the sketch was created with.
+func (s *DDSketch) RelativeAccuracy() float64 { return s.relativeAccuracy }
+
+// ZeroCount returns the number of zero values added to the sketch.
+func (s *DDSketch) ZeroCount() float64 { return s.zeroCount }
+
+// Positive returns the bins of the positive values added to the sketch.
+func (s *DDSketch) Positive() Store { return s.positive }
+
+// Negative returns the bins of the absolute negative values added to the sketch.
+func (s *DDSketch) Negative() Store { return s.negative }
+
+// Add adds a value to the sketch. NaN values are ignored.
+func (s *DDSketch) Add(v float64) {
+	switch {
+	case math.IsNaN(v):
+	case v > 0:
+		s.positive.add(s.index(v), 1)
+	case v < 0:
+		s.negative.add(s.index(-v), 1)
+	default:
+		s.zeroCount++
+	}
+}
+
+// Merge adds the values of another sketch to this one.
+func (s *DDSketch) Merge(o *DDSketch) error {
+	if s.relativeAccuracy != o.relativeAccuracy {
+		return ErrIncompatibleSketches
+	}
+	s.zeroCount += o.zeroCount
+	s.positive.merge(o.positive)
+	s.negative.merge(o.negative)
+	return nil
+}
+
+// Count returns the number of values added to the sketch.
+func (s *DDSketch) Count() float64 {
+	return s.zeroCount + s.positive.count() + s.negative.count()
+}
+
+// Quantile returns an approximation of the q-quantile of the values added to
+// the sketch, with q in [0, 1]. Like the exact quantile computed by LogQL, the
+// quantile is interpolated when it lies between two values.
+func (s *DDSketch) Quantile(q float64) (float64, error) {
+	if q < 0 || q > 1 {
+		return math.NaN(), fmt.Errorf("quantile must be between 0 and 1, got %v", q)
+	}
+	count := s.Count()
+	if count == 0 {
+		return math.NaN(), ErrEmptySketch
+	}
+
+	rank := q * (count - 1)
+	lowerRank := math.Floor(rank)
+	upperRank := math.Min(count-1, lowerRank+1)
+	weight := rank - lowerRank
+	return s.valueAtRank(lowerRank)*(1-weight) + s.valueAtRank(upperRank)*weight, nil
+}
+
+// valueAtRank returns the value of the given rank, in increasing order of the values.
+func (s *DDSketch) valueAtRank(rank float64) float64 {
+	var n float64
+	for i := len(s.negative.Counts) - 1; i >= 0; i-- {
+		n += s.negative.Counts[i]
+		if n > rank {
+			return -s.value(s.negative.Offset + int32(i))
+		}
+	}
+	n += s.zeroCount
+	if n > rank {
+		return 0
+	}
+	for i, c := range s.positive.Counts {
+		n += c
+		if n > rank {
+			return s.value(s.positive.Offset + int32(i))
+		}
+	}
+
+	// only reachable through rounding errors of the counts, return the highest value.
+	switch {
+	case len(s.positive.Counts) > 0:
+		return s.value(s.positive.Offset + int32(len(s.positive.Counts)) - 1)
+	case s.zeroCount > 0 || len(s.negative.Counts) == 0:
+		return 0
+	default:
+		return -s.value(s.negative.Offset)
+	}
+}
+
+// index returns the index of the bin of a positive value. The bin i holds the
+// values in (gamma^(i-1), gamma^i].
+func (s *DDSketch) index(v float64) int32 {
+	if v > math.MaxFloat64 {
+		v = math.MaxFloat64
+	}
+	return int32(math.Ceil(math.Log(v) / s.logGamma))
+}
+
+// value returns the value representing a bin, which is within the relative
+// accuracy of every value of the bin.
+func (s *DDSketch) value(index int32) float64 {
+	return 2 * math.Exp(float64(index)*s.logGamma) / (1 + s.gamma)
+}
+
+func (st *Store) add(index int32, count float64) {
+	if len(st.Counts) == 0 {
+		st.Offset = index
+		st.Counts = append(st.Counts, count)
+		return
+	}
+
+	lowest, highest := st.Offset, st.Offset+int32(len(st.Counts))-1
+	if index < lowest {
+		lowest = index
+	}
+	if index > highest {
+		highest = index
+	}
+	if highest-lowest+1 > maxBins {
+		lowest = highest - maxBins + 1
+	}
+	st.resize(lowest, highest)
+
+	if index < st.Offset {
+		index = st.Offset
+	}
+	st.Counts[index-st.Offset] += count
+}
+
+// resize changes the range of bins of the store to [lowest, highest]. The
+// counts of the bins below lowest are collapsed into the lowest bin.
+func (st *Store) resize(lowest, highest int32) {
+	size := int(highest - lowest + 1)
+	if lowest == st.Offset {
+		for len(st.Counts) < size {
+			st.Counts = append(st.Counts, 0)
+		}
+		return
+	}
+
+	counts := make([]float64, size)
+	for i, c := range st.Counts {
+		index := st.Offset + int32(i)
+		if index < lowest {
+			index = lowest
+		}
+		counts[index-lowest] += c
+	}
+	st.Offset = lowest
+	st.Counts = counts
+}
+
+func (st *Store) merge(o Store) {
+	if len(o.Counts) == 0 {
+		return
+	}
+	// grow the store once to the range of the other store before adding the counts.
+	st.add(o.Offset, 0)
+	st.add(o.Offset+int32(len(o.Counts))-1, 0)
+	for i, c := range o.Counts {
+		if c != 0 {
+			st.add(o.Offset+int32(i), c)
+		}
+	}
+}
+
+func (st *Store) count() float64 {
+	var n float64
+	for _, c := range st.Counts {
+		n += c
+	}
+	return n
+}
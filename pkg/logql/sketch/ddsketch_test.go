@@ -0,0 +1,146 @@
+package sketch
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func exactQuantile(q float64, values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := q * float64(len(sorted)-1)
+	lower := math.Floor(rank)
+	upper := math.Min(float64(len(sorted)-1), lower+1)
+	weight := rank - lower
+	return sorted[int(lower)]*(1-weight) + sorted[int(upper)]*weight
+}
+
+func requireRelativeAccuracy(t *testing.T, expected, actual float64) {
+	t.Helper()
+	require.InDelta(t, expected, actual, math.Abs(expected)*DefaultRelativeAccuracy+1e-12, "expected %v, got %v", expected, actual)
+}
+
+func TestDDSketch_Quantile(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		values func(r *rand.Rand) float64
+	}{
+		{name: "uniform", values: func(r *rand.Rand) float64 { return r.Float64() * 1000 }},
+		{name: "exponential", values: func(r *rand.Rand) float64 { return r.ExpFloat64() }},
+		{name: "normal", values: func(r *rand.Rand) float64 { return r.NormFloat64() * 100 }},
+		{name: "integers with zeros", values: func(r *rand.Rand) float64 { return float64(r.Intn(10)) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(42))
+			s, err := NewDDSketch(DefaultRelativeAccuracy)
+			require.NoError(t, err)
+
+			values := make([]float64, 10000)
+			for i := range values {
+				values[i] = tc.values(r)
+				s.Add(values[i])
+			}
+			require.Equal(t, float64(len(values)), s.Count())
+
+			for _, q := range []float64{0, 0.1, 0.5, 0.9, 0.99, 1} {
+				actual, err := s.Quantile(q)
+				require.NoError(t, err)
+				requireRelativeAccuracy(t, exactQuantile(q, values), actual)
+			}
+		})
+	}
+}
+
+func TestDDSketch_Merge(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	merged, err := NewDDSketch(DefaultRelativeAccuracy)
+	require.NoError(t, err)
+	whole, err := NewDDSketch(DefaultRelativeAccuracy)
+	require.NoError(t, err)
+
+	var values []float64
+	for shard := 0; shard < 4; shard++ {
+		s, err := NewDDSketch(DefaultRelativeAccuracy)
+		require.NoError(t, err)
+		for i := 0; i < 1000; i++ {
+			// every shard covers a different range of values.
+			v := r.Float64() * math.Pow(10, float64(shard))
+			values = append(values, v)
+			s.Add(v)
+			whole.Add(v)
+		}
+		require.NoError(t, merged.Merge(s))
+	}
+
+	require.Equal(t, whole.Count(), merged.Count())
+	for _, q := range []float64{0.01, 0.25, 0.5, 0.75, 0.99} {
+		expected, err := whole.Quantile(q)
+		require.NoError(t, err)
+		actual, err := merged.Quantile(q)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+		requireRelativeAccuracy(t, exactQuantile(q, values), actual)
+	}
+
+	other, err := NewDDSketch(0.05)
+	require.NoError(t, err)
+	require.Equal(t, ErrIncompatibleSketches, merged.Merge(other))
+}
+
+func TestDDSketch_FromBins(t *testing.T) {
+	s, err := NewDDSketch(DefaultRelativeAccuracy)
+	require.NoError(t, err)
+	for _, v := range []float64{-3, -1, 0, 0, 1, 2, 5, 100} {
+		s.Add(v)
+	}
+
+	copied, err := NewDDSketchFromBins(s.RelativeAccuracy(), s.ZeroCount(), s.Positive(), s.Negative())
+	require.NoError(t, err)
+	for _, q := range []float64{0, 0.2, 0.4, 0.6, 1} {
+		expected, err := s.Quantile(q)
+		require.NoError(t, err)
+		actual, err := copied.Quantile(q)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+}
+
+func TestDDSketch_Edges(t *testing.T) {
+	s, err := NewDDSketch(DefaultRelativeAccuracy)
+	require.NoError(t, err)
+
+	_, err = s.Quantile(0.5)
+	require.Equal(t, ErrEmptySketch, err)
+
+	s.Add(math.NaN())
+	require.Equal(t, float64(0), s.Count())
+
+	s.Add(42)
+	_, err = s.Quantile(1.5)
+	require.Error(t, err)
+
+	_, err = NewDDSketch(0)
+	require.Error(t, err)
+	_, err = NewDDSketch(1)
+	require.Error(t, err)
+}
+
+func TestDDSketch_CollapsesLowestBins(t *testing.T) {
+	s, err := NewDDSketch(DefaultRelativeAccuracy)
+	require.NoError(t, err)
+	// values spanning many orders of magnitude exceed the maximum number of bins.
+	for e := -300; e <= 300; e++ {
+		s.Add(math.Pow(10, float64(e)))
+	}
+	require.LessOrEqual(t, len(s.Positive().Counts), maxBins)
+	require.Equal(t, float64(601), s.Count())
+
+	// the highest values are still accurate.
+	actual, err := s.Quantile(1)
+	require.NoError(t, err)
+	requireRelativeAccuracy(t, 1e300, actual)
+}
@@ -208,7 +208,10 @@ func (e *PipelineExpr) HasFilter() bool {
 }
 
 type LineFilterExpr struct {
-	Left  *LineFilterExpr
+	Left *LineFilterExpr
+	// Or is the chain of alternatives of the filter, e.g. "bar" and "baz" in `|= "foo" or "bar" or "baz"`.
+	// They have the same type and operation as the filter.
+	Or    *LineFilterExpr
 	Ty    labels.MatchType
 	Match string
 	Op    string
@@ -224,14 +227,39 @@ func newLineFilterExpr(ty labels.MatchType, op, match string) *LineFilterExpr {
 }
 
 func newNestedLineFilterExpr(left *LineFilterExpr, right *LineFilterExpr) *LineFilterExpr {
+	if right.Left != nil {
+		// right is a chain itself, e.g. `!= "foo" or "bar"`.
+		left = newNestedLineFilterExpr(left, right.Left)
+	}
 	return &LineFilterExpr{
 		Left:  left,
+		Or:    right.Or,
 		Ty:    right.Ty,
 		Match: right.Match,
 		Op:    right.Op,
 	}
 }
 
+// newOrLineFilterExpr adds the alternatives of right to left, e.g. `|= "foo" or "bar"`.
+func newOrLineFilterExpr(left *LineFilterExpr, right *LineFilterExpr) *LineFilterExpr {
+	for curr := right; curr != nil; curr = curr.Or {
+		curr.Ty = left.Ty
+		curr.Op = left.Op
+	}
+	if left.Ty == labels.MatchEqual || left.Ty == labels.MatchRegexp {
+		left.Or = right
+		return left
+	}
+	// not(foo or bar) is not(foo) and not(bar), so negative alternatives are chained.
+	for curr := right; curr != nil; {
+		next := curr.Or
+		curr.Or = nil
+		left = newNestedLineFilterExpr(left, curr)
+		curr = next
+	}
+	return left
+}
+
 func (e *LineFilterExpr) Walk(f WalkFn) {
 	f(e)
 	if e.Left == nil {
@@ -262,6 +290,18 @@ func (e *LineFilterExpr) String() string {
 		sb.WriteString(e.Left.String())
 		sb.WriteString(" ")
 	}
+	if e.Op == OpFilterPattern {
+		// pattern filters have their own operators instead of a function.
+		if e.Ty == labels.MatchNotEqual {
+			sb.WriteString(OpPipeNotPattern)
+		} else {
+			sb.WriteString(OpPipePattern)
+		}
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Quote(e.Match))
+		e.writeOr(&sb)
+		return sb.String()
+	}
 	switch e.Ty {
 	case labels.MatchRegexp:
 		sb.WriteString("|~")
@@ -275,6 +315,7 @@ func (e *LineFilterExpr) String() string {
 	sb.WriteString(" ")
 	if e.Op == "" {
 		sb.WriteString(strconv.Quote(e.Match))
+		e.writeOr(&sb)
 		return sb.String()
 	}
 	sb.WriteString(e.Op)
@@ -284,24 +325,28 @@ func (e *LineFilterExpr) String() string {
 	return sb.String()
 }
 
+func (e *LineFilterExpr) writeOr(sb *strings.Builder) {
+	for curr := e.Or; curr != nil; curr = curr.Or {
+		sb.WriteString(" or ")
+		sb.WriteString(strconv.Quote(curr.Match))
+	}
+}
+
 func (e *LineFilterExpr) Filter() (log.Filterer, error) {
 	acc := make([]log.Filterer, 0)
 	for curr := e; curr != nil; curr = curr.Left {
-		switch curr.Op {
-		case OpFilterIP:
-			var err error
-			next, err := log.NewIPLineFilter(curr.Match, curr.Ty)
-			if err != nil {
-				return nil, err
-			}
-			acc = append(acc, next)
-		default:
-			next, err := log.NewFilter(curr.Match, curr.Ty)
+		next, err := curr.filter()
+		if err != nil {
+			return nil, err
+		}
+		for or := curr.Or; or != nil; or = or.Or {
+			alt, err := or.filter()
 			if err != nil {
 				return nil, err
 			}
-			acc = append(acc, next)
+			next = log.NewOrFilter(next, alt)
 		}
+		acc = append(acc, next)
 	}
 
 	if len(acc) == 1 {
@@ -317,6 +362,18 @@ func (e *LineFilterExpr) Filter() (log.Filterer, error) {
 	return log.NewAndFilters(acc), nil
 }
 
+// filter returns the filter of e alone, without its left filters nor its alternatives.
+func (e *LineFilterExpr) filter() (log.Filterer, error) {
+	switch e.Op {
+	case OpFilterIP:
+		return log.NewIPLineFilter(e.Match, e.Ty)
+	case OpFilterPattern:
+		return log.NewPatternFilter(e.Match, e.Ty)
+	default:
+		return log.NewFilter(e.Match, e.Ty)
+	}
+}
+
 func (e *LineFilterExpr) Stage() (log.Stage, error) {
 	f, err := e.Filter()
 	if err != nil {
@@ -459,6 +516,64 @@ func (e *LabelFmtExpr) String() string {
 	return sb.String()
 }
 
+type DropLabelsExpr struct {
+	dropLabels []log.DropLabel
+	implicit
+}
+
+func newDropLabelsExpr(dropLabels []log.DropLabel) *DropLabelsExpr {
+	return &DropLabelsExpr{dropLabels: dropLabels}
+}
+
+func (d *DropLabelsExpr) Shardable() bool { return true }
+
+func (d *DropLabelsExpr) Walk(f WalkFn) { f(d) }
+
+func (d *DropLabelsExpr) Stage() (log.Stage, error) {
+	return log.NewDropLabels(d.dropLabels), nil
+}
+
+func (d *DropLabelsExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s ", OpPipe, OpDrop))
+	for i, dl := range d.dropLabels {
+		sb.WriteString(dl.String())
+		if i+1 != len(d.dropLabels) {
+			sb.WriteString(",")
+		}
+	}
+	return sb.String()
+}
+
+type KeepLabelsExpr struct {
+	keepLabels []log.KeepLabel
+	implicit
+}
+
+func newKeepLabelsExpr(keepLabels []log.KeepLabel) *KeepLabelsExpr {
+	return &KeepLabelsExpr{keepLabels: keepLabels}
+}
+
+func (k *KeepLabelsExpr) Shardable() bool { return true }
+
+func (k *KeepLabelsExpr) Walk(f WalkFn) { f(k) }
+
+func (k *KeepLabelsExpr) Stage() (log.Stage, error) {
+	return log.NewKeepLabels(k.keepLabels), nil
+}
+
+func (k *KeepLabelsExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s ", OpPipe, OpKeep))
+	for i, kl := range k.keepLabels {
+		sb.WriteString(kl.String())
+		if i+1 != len(k.keepLabels) {
+			sb.WriteString(",")
+		}
+	}
+	return sb.String()
+}
+
 type JSONExpressionParser struct {
 	Expressions []log.JSONExpression
 
@@ -543,6 +658,7 @@ type LogRange struct {
 	Left     LogSelectorExpr
 	Interval time.Duration
 	Offset   time.Duration
+	At       *AtModifier
 
 	Unwrap *UnwrapExpr
 
@@ -557,6 +673,9 @@ func (r LogRange) String() string {
 		sb.WriteString(r.Unwrap.String())
 	}
 	sb.WriteString(fmt.Sprintf("[%v]", model.Duration(r.Interval)))
+	if r.At != nil {
+		sb.WriteString(r.At.String())
+	}
 	if r.Offset != 0 {
 		offsetExpr := OffsetExpr{Offset: r.Offset}
 		sb.WriteString(offsetExpr.String())
@@ -576,19 +695,24 @@ func (r *LogRange) Walk(f WalkFn) {
 
 func newLogRange(left LogSelectorExpr, interval time.Duration, u *UnwrapExpr, o *OffsetExpr) *LogRange {
 	var offset time.Duration
+	var at *AtModifier
 	if o != nil {
 		offset = o.Offset
+		at = o.At
 	}
 	return &LogRange{
 		Left:     left,
 		Interval: interval,
 		Unwrap:   u,
 		Offset:   offset,
+		At:       at,
 	}
 }
 
+// OffsetExpr holds the modifiers of a range: its offset and its @ modifier.
 type OffsetExpr struct {
 	Offset time.Duration
+	At     *AtModifier
 }
 
 func (o *OffsetExpr) String() string {
@@ -603,6 +727,60 @@ func newOffsetExpr(offset time.Duration) *OffsetExpr {
 	}
 }
 
+// AtModifier pins the evaluation time of a range to a timestamp, or to the
+// start or the end of the query, e.g. `rate({app="foo"}[5m] @ 1609746000)`.
+type AtModifier struct {
+	// Timestamp is the evaluation time of the range, unless StartOrEnd is set.
+	Timestamp time.Time
+	// StartOrEnd is either OpAtStart or OpAtEnd when the evaluation time is
+	// the start or the end of the query.
+	StartOrEnd string
+}
+
+func newAtModifier(ts string) *AtModifier {
+	f, err := strconv.ParseFloat(ts, 64)
+	if err != nil {
+		panic(logqlmodel.NewParseError(fmt.Sprintf("invalid @ modifier timestamp %s: %s", ts, err), 0, 0))
+	}
+	return &AtModifier{Timestamp: time.UnixMilli(int64(math.Round(f * 1000)))}
+}
+
+func newAtStartOrEnd(startOrEnd string) *AtModifier {
+	return &AtModifier{StartOrEnd: startOrEnd}
+}
+
+// Time returns the evaluation time of the range for a query from start to end.
+func (a *AtModifier) Time(start, end time.Time) time.Time {
+	switch a.StartOrEnd {
+	case OpAtStart:
+		return start
+	case OpAtEnd:
+		return end
+	default:
+		return a.Timestamp
+	}
+}
+
+func (a *AtModifier) String() string {
+	if a.StartOrEnd != "" {
+		return fmt.Sprintf(" %s %s()", OpAt, a.StartOrEnd)
+	}
+	return fmt.Sprintf(" %s %s", OpAt, strconv.FormatFloat(float64(a.Timestamp.UnixMilli())/1000, 'f', -1, 64))
+}
+
+// ResolveAtModifiers replaces the start() and end() @ modifiers of an expression
+// with the start and end of the query. Queries split by time must be resolved
+// before being split, since every split query has its own start and end.
+func ResolveAtModifiers(expr Expr, start, end time.Time) {
+	expr.Walk(func(e interface{}) {
+		r, ok := e.(*LogRange)
+		if !ok || r.At == nil || r.At.StartOrEnd == "" {
+			return
+		}
+		r.At = &AtModifier{Timestamp: r.At.Time(start, end)}
+	})
+}
+
 const (
 	// vector ops
 	OpTypeSum     = "sum"
@@ -615,6 +793,16 @@ const (
 	OpTypeBottomK = "bottomk"
 	OpTypeTopK    = "topk"
 
+	// sort ops, which order the samples of a vector by value.
+	OpTypeSort     = "sort"
+	OpTypeSortDesc = "sort_desc"
+
+	// OpTypeApproxTopK is topk without grouping, which is approximated when the query is sharded.
+	OpTypeApproxTopK = "approx_topk"
+	// OpTypeCountMinSketch is used by the query frontend to shard approx_topk.
+	// It returns a mergeable count-min sketch of the vector and its top k series instead of a vector.
+	OpTypeCountMinSketch = "count_min_sketch"
+
 	// range vector ops
 	OpRangeTypeCount     = "count_over_time"
 	OpRangeTypeRate      = "rate"
@@ -631,6 +819,13 @@ const (
 	OpRangeTypeLast      = "last_over_time"
 	OpRangeTypeAbsent    = "absent_over_time"
 
+	// OpRangeTypeRateCounter is the per-second rate of an unwrapped counter, which handles counter resets.
+	OpRangeTypeRateCounter = "rate_counter"
+
+	// OpRangeTypeQuantileSketch is used by the query frontend to shard quantile_over_time.
+	// It returns a mergeable sketch of the unwrapped values instead of a sample.
+	OpRangeTypeQuantileSketch = "quantile_sketch_over_time"
+
 	// binops - logical/set
 	OpTypeOr     = "or"
 	OpTypeAnd    = "and"
@@ -662,9 +857,17 @@ const (
 	OpFmtLine  = "line_format"
 	OpFmtLabel = "label_format"
 
+	OpDrop = "drop"
+	OpKeep = "keep"
+
 	OpPipe   = "|"
 	OpUnwrap = "unwrap"
 	OpOffset = "offset"
+	OpAt     = "@"
+
+	// @ modifier functions
+	OpAtStart = "start"
+	OpAtEnd   = "end"
 
 	OpOn       = "on"
 	OpIgnoring = "ignoring"
@@ -678,9 +881,17 @@ const (
 	OpConvDurationSeconds = "duration_seconds"
 
 	OpLabelReplace = "label_replace"
+	OpLabelJoin    = "label_join"
+
+	OpVector = "vector"
 
 	// function filters
 	OpFilterIP = "ip"
+
+	// pattern line filters
+	OpFilterPattern  = "pattern"
+	OpPipePattern    = "|>"
+	OpPipeNotPattern = "!>"
 )
 
 func IsComparisonOperator(op string) bool {
@@ -756,14 +967,14 @@ func (e *RangeAggregationExpr) Selector() LogSelectorExpr {
 func (e RangeAggregationExpr) validate() error {
 	if e.Grouping != nil {
 		switch e.Operation {
-		case OpRangeTypeAvg, OpRangeTypeStddev, OpRangeTypeStdvar, OpRangeTypeQuantile, OpRangeTypeMax, OpRangeTypeMin, OpRangeTypeFirst, OpRangeTypeLast:
+		case OpRangeTypeAvg, OpRangeTypeStddev, OpRangeTypeStdvar, OpRangeTypeQuantile, OpRangeTypeQuantileSketch, OpRangeTypeMax, OpRangeTypeMin, OpRangeTypeFirst, OpRangeTypeLast:
 		default:
 			return fmt.Errorf("grouping not allowed for %s aggregation", e.Operation)
 		}
 	}
 	if e.Left.Unwrap != nil {
 		switch e.Operation {
-		case OpRangeTypeAvg, OpRangeTypeSum, OpRangeTypeMax, OpRangeTypeMin, OpRangeTypeStddev, OpRangeTypeStdvar, OpRangeTypeQuantile, OpRangeTypeRate, OpRangeTypeAbsent, OpRangeTypeFirst, OpRangeTypeLast:
+		case OpRangeTypeAvg, OpRangeTypeSum, OpRangeTypeMax, OpRangeTypeMin, OpRangeTypeStddev, OpRangeTypeStdvar, OpRangeTypeQuantile, OpRangeTypeQuantileSketch, OpRangeTypeRate, OpRangeTypeRateCounter, OpRangeTypeAbsent, OpRangeTypeFirst, OpRangeTypeLast:
 			return nil
 		default:
 			return fmt.Errorf("invalid aggregation %s with unwrap", e.Operation)
@@ -807,6 +1018,131 @@ func (e *RangeAggregationExpr) Walk(f WalkFn) {
 	e.Left.Walk(f)
 }
 
+// SubqueryExpr is a metric expression evaluated at every step of a range,
+// e.g. rate({app="foo"}[1m])[1h:1m].
+type SubqueryExpr struct {
+	Left   SampleExpr
+	Range  time.Duration
+	Step   time.Duration
+	Offset time.Duration
+}
+
+func newSubqueryExpr(left SampleExpr, r SubqueryRange, o *OffsetExpr) *SubqueryExpr {
+	if r.Step <= 0 {
+		panic(logqlmodel.NewParseError("subquery step must be positive", 0, 0))
+	}
+	if o != nil && o.At != nil {
+		panic(logqlmodel.NewParseError("@ modifier is not supported in subqueries", 0, 0))
+	}
+	var offset time.Duration
+	if o != nil {
+		offset = o.Offset
+	}
+	return &SubqueryExpr{
+		Left:   left,
+		Range:  r.Range,
+		Step:   r.Step,
+		Offset: offset,
+	}
+}
+
+// impls Stringer
+func (s SubqueryExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(s.Left.String())
+	sb.WriteString(fmt.Sprintf("[%v:%v]", model.Duration(s.Range), model.Duration(s.Step)))
+	if s.Offset != 0 {
+		offsetExpr := OffsetExpr{Offset: s.Offset}
+		sb.WriteString(offsetExpr.String())
+	}
+	return sb.String()
+}
+
+func (s *SubqueryExpr) Walk(f WalkFn) {
+	f(s)
+	if s.Left == nil {
+		return
+	}
+	s.Left.Walk(f)
+}
+
+// SubqueryRange is the range and step of a subquery.
+type SubqueryRange struct {
+	Range time.Duration
+	Step  time.Duration
+}
+
+// SubqueryAggregationExpr is a range aggregation over the samples of a subquery,
+// e.g. max_over_time(rate({app="foo"}[1m])[1h:1m]).
+type SubqueryAggregationExpr struct {
+	Left      *SubqueryExpr
+	Operation string
+
+	Params *float64
+	implicit
+}
+
+func newSubqueryAggregationExpr(left *SubqueryExpr, operation string, stringParams *string) SampleExpr {
+	var params *float64
+	if stringParams != nil {
+		if operation != OpRangeTypeQuantile {
+			panic(logqlmodel.NewParseError(fmt.Sprintf("parameter %s not supported for operation %s", *stringParams, operation), 0, 0))
+		}
+		var err error
+		params = new(float64)
+		*params, err = strconv.ParseFloat(*stringParams, 64)
+		if err != nil {
+			panic(logqlmodel.NewParseError(fmt.Sprintf("invalid parameter for operation %s: %s", operation, err), 0, 0))
+		}
+	} else if operation == OpRangeTypeQuantile {
+		panic(logqlmodel.NewParseError(fmt.Sprintf("parameter required for operation %s", operation), 0, 0))
+	}
+
+	switch operation {
+	case OpRangeTypeBytes, OpRangeTypeBytesRate, OpRangeTypeQuantileSketch:
+		panic(logqlmodel.NewParseError(fmt.Sprintf("invalid aggregation %s of subquery", operation), 0, 0))
+	}
+	return &SubqueryAggregationExpr{
+		Left:      left,
+		Operation: operation,
+		Params:    params,
+	}
+}
+
+func (e *SubqueryAggregationExpr) Selector() LogSelectorExpr {
+	return e.Left.Left.Selector()
+}
+
+func (e *SubqueryAggregationExpr) Extractor() (log.SampleExtractor, error) {
+	return e.Left.Left.Extractor()
+}
+
+// impls Stringer
+func (e *SubqueryAggregationExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Operation)
+	sb.WriteString("(")
+	if e.Params != nil {
+		sb.WriteString(strconv.FormatFloat(*e.Params, 'f', -1, 64))
+		sb.WriteString(",")
+	}
+	sb.WriteString(e.Left.String())
+	sb.WriteString(")")
+	return sb.String()
+}
+
+// impl SampleExpr
+// Subqueries are not shardable as a whole, but their metric expression can be.
+func (e *SubqueryAggregationExpr) Shardable() bool { return false }
+
+func (e *SubqueryAggregationExpr) Walk(f WalkFn) {
+	f(e)
+	if e.Left == nil {
+		return
+	}
+	e.Left.Walk(f)
+}
+
 type Grouping struct {
 	Groups  []string
 	Without bool
@@ -843,7 +1179,7 @@ func mustNewVectorAggregationExpr(left SampleExpr, operation string, gr *Groupin
 	var p int
 	var err error
 	switch operation {
-	case OpTypeBottomK, OpTypeTopK:
+	case OpTypeBottomK, OpTypeTopK, OpTypeApproxTopK, OpTypeCountMinSketch:
 		if params == nil {
 			panic(logqlmodel.NewParseError(fmt.Sprintf("parameter required for operation %s", operation), 0, 0))
 		}
@@ -856,6 +1192,12 @@ func mustNewVectorAggregationExpr(left SampleExpr, operation string, gr *Groupin
 			panic(logqlmodel.NewParseError(fmt.Sprintf("unsupported parameter for operation %s(%s,", operation, *params), 0, 0))
 		}
 	}
+	switch operation {
+	case OpTypeApproxTopK, OpTypeCountMinSketch, OpTypeSort, OpTypeSortDesc:
+		if gr != nil {
+			panic(logqlmodel.NewParseError(fmt.Sprintf("grouping not allowed for %s aggregation", operation), 0, 0))
+		}
+	}
 	if gr == nil {
 		gr = &Grouping{}
 	}
@@ -914,7 +1256,10 @@ func (e *VectorAggregationExpr) Shardable() bool {
 		shardable := true
 		e.Walk(func(e interface{}) {
 			switch e.(type) {
-			case *LabelParserExpr, LabelFmtExpr:
+			case *LabelParserExpr, *DropLabelsExpr, *KeepLabelsExpr:
+				shardable = false
+			case *VectorExpr:
+				// vector() would be counted once per shard.
 				shardable = false
 			}
 		})
@@ -1366,6 +1711,27 @@ func (e *LiteralExpr) Matchers() []*labels.Matcher             { return nil }
 func (e *LiteralExpr) Extractor() (log.SampleExtractor, error) { return nil, nil }
 func (e *LiteralExpr) Value() float64                          { return e.Val }
 
+// VectorExpr is a vector with a single sample without labels, e.g. vector(0).
+type VectorExpr struct {
+	Val float64
+	implicit
+}
+
+func (e *VectorExpr) String() string {
+	return formatOperation(OpVector, nil, fmt.Sprint(e.Val))
+}
+
+// VectorExpr impls SampleExpr & LogSelectorExpr the same way LiteralExpr does.
+// It is never sharded, since every shard would return its sample.
+func (e *VectorExpr) Selector() LogSelectorExpr               { return e }
+func (e *VectorExpr) HasFilter() bool                         { return false }
+func (e *VectorExpr) Shardable() bool                         { return false }
+func (e *VectorExpr) Walk(f WalkFn)                           { f(e) }
+func (e *VectorExpr) Pipeline() (log.Pipeline, error)         { return log.NewNoopPipeline(), nil }
+func (e *VectorExpr) Matchers() []*labels.Matcher             { return nil }
+func (e *VectorExpr) Extractor() (log.SampleExtractor, error) { return nil, nil }
+func (e *VectorExpr) Value() float64                          { return e.Val }
+
 // helper used to impl Stringer for vector and range aggregations
 // nolint:interfacer
 func formatOperation(op string, grouping *Grouping, params ...string) string {
@@ -1450,6 +1816,69 @@ func (e *LabelReplaceExpr) String() string {
 	return sb.String()
 }
 
+type LabelJoinExpr struct {
+	Left      SampleExpr
+	Dst       string
+	Separator string
+	Src       []string
+
+	implicit
+}
+
+func mustNewLabelJoinExpr(left SampleExpr, dst, separator string, src []string) *LabelJoinExpr {
+	if !model.LabelName(dst).IsValid() {
+		panic(logqlmodel.NewParseError(fmt.Sprintf("invalid destination label name in label_join: %s", dst), 0, 0))
+	}
+	for _, name := range src {
+		if !model.LabelName(name).IsValid() {
+			panic(logqlmodel.NewParseError(fmt.Sprintf("invalid source label name in label_join: %s", name), 0, 0))
+		}
+	}
+	return &LabelJoinExpr{
+		Left:      left,
+		Dst:       dst,
+		Separator: separator,
+		Src:       src,
+	}
+}
+
+func (e *LabelJoinExpr) Selector() LogSelectorExpr {
+	return e.Left.Selector()
+}
+
+func (e *LabelJoinExpr) Extractor() (SampleExtractor, error) {
+	return e.Left.Extractor()
+}
+
+func (e *LabelJoinExpr) Shardable() bool {
+	return false
+}
+
+func (e *LabelJoinExpr) Walk(f WalkFn) {
+	f(e)
+	if e.Left == nil {
+		return
+	}
+	e.Left.Walk(f)
+}
+
+func (e *LabelJoinExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString(OpLabelJoin)
+	sb.WriteString("(")
+	sb.WriteString(e.Left.String())
+	sb.WriteString(",")
+	sb.WriteString(strconv.Quote(e.Dst))
+	sb.WriteString(",")
+	sb.WriteString(strconv.Quote(e.Separator))
+	for _, src := range e.Src {
+		sb.WriteString(",")
+		sb.WriteString(strconv.Quote(src))
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
 // shardableOps lists the operations which may be sharded.
 // topk, botk, max, & min all must be concatenated and then evaluated in order to avoid
 // potential data loss due to series distribution across shards.
@@ -1482,6 +1911,8 @@ var shardableOps = map[string]bool{
 	OpRangeTypeSum:       true,
 	OpRangeTypeMax:       true,
 	OpRangeTypeMin:       true,
+	// counters are per series, and series are never split across shards.
+	OpRangeTypeRateCounter: true,
 
 	// binops - arith
 	OpTypeAdd: true,
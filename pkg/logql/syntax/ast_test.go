@@ -2,6 +2,7 @@ package syntax
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql"
@@ -26,6 +27,9 @@ func Test_logSelectorExpr_String(t *testing.T) {
 		{`{foo="bar", bar!="baz"} |= ""`, false},
 		{`{foo="bar", bar!="baz"} |= "" |= ip("::1")`, true},
 		{`{foo="bar", bar!="baz"} |= "" != ip("127.0.0.1")`, true},
+		{`{foo="bar"} |> "<_> bar <_>"`, true},
+		{`{foo="bar"} |= "baz" or "qux" |~ "a.*" or "b.*" |> "<_> bar" or "bar <_>"`, true},
+		{`{foo="bar"} |= "baz" !> "<_> bar <_>" |> "<_> - <status> <_>"`, true},
 		{`{foo="bar", bar!="baz"} |~ ""`, false},
 		{`{foo="bar", bar!="baz"} |~ ".*"`, false},
 		{`{foo="bar", bar!="baz"} |= "" |= ""`, false},
@@ -40,6 +44,8 @@ func Test_logSelectorExpr_String(t *testing.T) {
 		{`{foo="bar"} |= "baz" |~ "blip" != "flip" !~ "flap" | logfmt | b=ip("127.0.0.1") | level="error" | c=ip("::1")`, true}, // chain inside label filters.
 		{`{foo="bar"} |= "baz" |~ "blip" != "flip" !~ "flap" | regexp "(?P<foo>foo|bar)"`, true},
 		{`{foo="bar"} |= "baz" |~ "blip" != "flip" !~ "flap" | regexp "(?P<foo>foo|bar)" | ( ( foo<5.01 , bar>20ms ) or foo="bar" ) | line_format "blip{{.boop}}bap" | label_format foo=bar,bar="blip{{.blop}}"`, true},
+		{`{foo="bar"} | logfmt | drop bar,foo="baz"`, true},
+		{`{foo="bar"} | logfmt | keep bar,foo=~"ba.+"`, true},
 	}
 
 	for _, tt := range tests {
@@ -74,6 +80,17 @@ func Test_SampleExpr_String(t *testing.T) {
 		`sum by(a) (rate( ( {job="mysql"} |="error" !="timeout" ) [10s] ) )`,
 		`sum(count_over_time({job="mysql"}[5m]))`,
 		`sum(count_over_time({job="mysql"}[5m] offset 10m))`,
+		`sum(count_over_time({job="mysql"}[5m] @ 1609746000 offset 10m))`,
+		`sum(count_over_time({job="mysql"} | json [5m] @ start()))`,
+		`rate({job="mysql"}[5m] @ end()) / rate({job="mysql"}[5m])`,
+		`max_over_time(rate({job="mysql"}[1m])[1h:1m])`,
+		`sort(sum by (a) (rate({job="mysql"}[5m])))`,
+		`sum(rate_counter({job="mysql"} | logfmt | unwrap bytes_sent_total [5m]))`,
+		`sort_desc(sum by (a) (rate({job="mysql"}[5m])))`,
+		`sum(rate({job="mysql"}[5m])) or vector(0)`,
+		`vector(-1.5)`,
+		`label_join(sum by (a, b) (rate({job="mysql"}[5m])), "c", "-", "a", "b")`,
+		`label_join(rate({job="mysql"}[5m]), "a", ",")`,
 		`sum(count_over_time({job="mysql"} | json [5m]))`,
 		`sum(count_over_time({job="mysql"} | json [5m] offset 10m))`,
 		`sum(count_over_time({job="mysql"} | logfmt [5m]))`,
@@ -178,6 +195,14 @@ func Test_SampleExpr_String(t *testing.T) {
 	}
 }
 
+func Test_ResolveAtModifiers(t *testing.T) {
+	expr, err := ParseExpr(`rate({job="mysql"}[5m] @ start()) / rate({job="mysql"}[5m] @ end() offset 1m) / rate({job="mysql"}[5m] @ 10)`)
+	require.Nil(t, err)
+
+	ResolveAtModifiers(expr, time.Unix(100, 0), time.Unix(200, 0))
+	require.Equal(t, `((rate({job="mysql"}[5m] @ 100) / rate({job="mysql"}[5m] @ 200 offset 1m0s)) / rate({job="mysql"}[5m] @ 10))`, expr.String())
+}
+
 func Test_NilFilterDoesntPanic(t *testing.T) {
 	t.Parallel()
 	for _, tc := range []string{
@@ -266,6 +291,27 @@ func Test_FilterMatcher(t *testing.T) {
 			},
 			[]linecheck{{"foo", true}, {"bar", false}, {"foobar", true}},
 		},
+		{
+			`{app="foo"} |= "foo" or "bar" != "baz" or "qux"`,
+			[]*labels.Matcher{
+				mustNewMatcher(labels.MatchEqual, "app", "foo"),
+			},
+			[]linecheck{{"foo", true}, {"bar", true}, {"buzz", false}, {"foo baz", false}, {"bar qux", false}},
+		},
+		{
+			`{app="foo"} |~ "fo+" or "ba[rz]" !~ "qu+x"`,
+			[]*labels.Matcher{
+				mustNewMatcher(labels.MatchEqual, "app", "foo"),
+			},
+			[]linecheck{{"foooo", true}, {"baz", true}, {"buzz", false}, {"foo quux", false}},
+		},
+		{
+			`{app="foo"} |> "<_> <method> <status>" !> "<_> GET <_>"`,
+			[]*labels.Matcher{
+				mustNewMatcher(labels.MatchEqual, "app", "foo"),
+			},
+			[]linecheck{{"127.0.0.1 POST 200", true}, {"127.0.0.1 GET 200", false}, {"127.0.0.1 POST", false}},
+		},
 		{
 			`{app="foo"} | logfmt | duration > 1s and total_bytes < 1GB`,
 			[]*labels.Matcher{
@@ -28,6 +28,9 @@ import (
   FilterOp                string
   BinOpExpr               SampleExpr
   LabelReplaceExpr        SampleExpr
+  LabelJoinExpr           SampleExpr
+  LabelJoinSources        []string
+  VectorExpr              SampleExpr
   binOp                   string
   bytes                   uint64
   str                     string
@@ -56,6 +59,15 @@ import (
   JSONExpressionList      []log.JSONExpression
   UnwrapExpr              *UnwrapExpr
   OffsetExpr              *OffsetExpr
+  DropLabel               log.DropLabel
+  DropLabels              []log.DropLabel
+  DropLabelsExpr          *DropLabelsExpr
+  KeepLabel               log.KeepLabel
+  KeepLabels              []log.KeepLabel
+  KeepLabelsExpr          *KeepLabelsExpr
+  SubqueryExpr            *SubqueryExpr
+  AtModifier              *AtModifier
+  subqueryRange           SubqueryRange
 }
 
 %start root
@@ -79,6 +91,9 @@ import (
 %type <BinOpExpr>             binOpExpr
 %type <LiteralExpr>           literalExpr
 %type <LabelReplaceExpr>      labelReplaceExpr
+%type <LabelJoinExpr>         labelJoinExpr
+%type <LabelJoinSources>      labelJoinSources
+%type <VectorExpr>            vectorExpr
 %type <BinOpModifier>         binOpModifier
 %type <BoolModifier>          boolModifier
 %type <OnOrIgnoringModifier>  onOrIgnoringModifier
@@ -91,6 +106,7 @@ import (
 %type <LabelFilter>           labelFilter
 %type <LineFilters>           lineFilters
 %type <LineFilter>            lineFilter
+%type <LineFilter>            orFilter
 %type <LineFormatExpr>        lineFormatExpr
 %type <LabelFormatExpr>       labelFormatExpr
 %type <LabelFormat>           labelFormat
@@ -102,15 +118,26 @@ import (
 %type <UnitFilter>            unitFilter
 %type <IPLabelFilter>         ipLabelFilter
 %type <OffsetExpr>            offsetExpr
+%type <DropLabel>             dropLabel
+%type <DropLabels>            dropLabels
+%type <DropLabelsExpr>        dropLabelsExpr
+%type <KeepLabel>             keepLabel
+%type <KeepLabels>            keepLabels
+%type <KeepLabelsExpr>        keepLabelsExpr
+%type <SubqueryExpr>          subqueryExpr
+%type <AtModifier>            atModifier
 
 %token <bytes> BYTES
 %token <str>      IDENTIFIER STRING NUMBER
 %token <duration> DURATION RANGE
+%token <subqueryRange> SUBQUERY_RANGE
 %token <val>      MATCHERS LABELS EQ RE NRE OPEN_BRACE CLOSE_BRACE OPEN_BRACKET CLOSE_BRACKET COMMA DOT PIPE_MATCH PIPE_EXACT
                   OPEN_PARENTHESIS CLOSE_PARENTHESIS BY WITHOUT COUNT_OVER_TIME RATE SUM AVG MAX MIN COUNT STDDEV STDVAR BOTTOMK TOPK
                   BYTES_OVER_TIME BYTES_RATE BOOL JSON REGEXP LOGFMT PIPE LINE_FMT LABEL_FMT UNWRAP AVG_OVER_TIME SUM_OVER_TIME MIN_OVER_TIME
                   MAX_OVER_TIME STDVAR_OVER_TIME STDDEV_OVER_TIME QUANTILE_OVER_TIME BYTES_CONV DURATION_CONV DURATION_SECONDS_CONV
                   FIRST_OVER_TIME LAST_OVER_TIME ABSENT_OVER_TIME LABEL_REPLACE UNPACK OFFSET PATTERN IP ON IGNORING GROUP_LEFT GROUP_RIGHT
+                  DROP KEEP QUANTILE_SKETCH_OVER_TIME APPROX_TOPK COUNT_MIN_SKETCH AT START END
+                  SORT SORT_DESC VECTOR LABEL_JOIN RATE_COUNTER PIPE_PATTERN NPA
 
 // Operators are listed with increasing precedence.
 %left <binOp> OR
@@ -135,6 +162,8 @@ metricExpr:
     | binOpExpr                                     { $$ = $1 }
     | literalExpr                                   { $$ = $1 }
     | labelReplaceExpr                              { $$ = $1 }
+    | labelJoinExpr                                 { $$ = $1 }
+    | vectorExpr                                    { $$ = $1 }
     | OPEN_PARENTHESIS metricExpr CLOSE_PARENTHESIS { $$ = $2 }
     ;
 
@@ -190,6 +219,13 @@ rangeAggregationExpr:
     | rangeOp OPEN_PARENTHESIS NUMBER COMMA logRangeExpr CLOSE_PARENTHESIS           { $$ = newRangeAggregationExpr($5, $1, nil, &$3) }
     | rangeOp OPEN_PARENTHESIS logRangeExpr CLOSE_PARENTHESIS grouping               { $$ = newRangeAggregationExpr($3, $1, $5, nil) }
     | rangeOp OPEN_PARENTHESIS NUMBER COMMA logRangeExpr CLOSE_PARENTHESIS grouping  { $$ = newRangeAggregationExpr($5, $1, $7, &$3) }
+    | rangeOp OPEN_PARENTHESIS subqueryExpr CLOSE_PARENTHESIS                        { $$ = newSubqueryAggregationExpr($3, $1, nil) }
+    | rangeOp OPEN_PARENTHESIS NUMBER COMMA subqueryExpr CLOSE_PARENTHESIS           { $$ = newSubqueryAggregationExpr($5, $1, &$3) }
+    ;
+
+subqueryExpr:
+      metricExpr SUBQUERY_RANGE            { $$ = newSubqueryExpr($1, $2, nil) }
+    | metricExpr SUBQUERY_RANGE offsetExpr { $$ = newSubqueryExpr($1, $2, $3) }
     ;
 
 vectorAggregationExpr:
@@ -208,6 +244,20 @@ labelReplaceExpr:
       { $$ = mustNewLabelReplaceExpr($3, $5, $7, $9, $11)}
     ;
 
+labelJoinExpr:
+    LABEL_JOIN OPEN_PARENTHESIS metricExpr COMMA STRING COMMA STRING labelJoinSources CLOSE_PARENTHESIS
+      { $$ = mustNewLabelJoinExpr($3, $5, $7, $8) }
+    ;
+
+labelJoinSources:
+      /* empty */                      { $$ = nil }
+    | labelJoinSources COMMA STRING    { $$ = append($1, $3) }
+    ;
+
+vectorExpr:
+    VECTOR OPEN_PARENTHESIS literalExpr CLOSE_PARENTHESIS { $$ = &VectorExpr{Val: $3.Val} }
+    ;
+
 filter:
       PIPE_MATCH                       { $$ = labels.MatchRegexp }
     | PIPE_EXACT                       { $$ = labels.MatchEqual }
@@ -245,6 +295,8 @@ pipelineStage:
   | PIPE labelFilter             { $$ = &LabelFilterExpr{LabelFilterer: $2 }}
   | PIPE lineFormatExpr          { $$ = $2 }
   | PIPE labelFormatExpr         { $$ = $2 }
+  | PIPE dropLabelsExpr          { $$ = $2 }
+  | PIPE keepLabelsExpr          { $$ = $2 }
   ;
 
 filterOp:
@@ -254,6 +306,16 @@ filterOp:
 lineFilter:
     filter STRING                                                   { $$ = newLineFilterExpr($1, "", $2) }
   | filter filterOp OPEN_PARENTHESIS STRING CLOSE_PARENTHESIS       { $$ = newLineFilterExpr($1, $2, $4) }
+  | PIPE_PATTERN STRING                                             { $$ = newLineFilterExpr(labels.MatchEqual, OpFilterPattern, $2) }
+  | NPA STRING                                                      { $$ = newLineFilterExpr(labels.MatchNotEqual, OpFilterPattern, $2) }
+  | filter STRING OR orFilter                                       { $$ = newOrLineFilterExpr(newLineFilterExpr($1, "", $2), $4) }
+  | PIPE_PATTERN STRING OR orFilter                                 { $$ = newOrLineFilterExpr(newLineFilterExpr(labels.MatchEqual, OpFilterPattern, $2), $4) }
+  | NPA STRING OR orFilter                                          { $$ = newOrLineFilterExpr(newLineFilterExpr(labels.MatchNotEqual, OpFilterPattern, $2), $4) }
+  ;
+
+orFilter:
+    STRING                    { $$ = newLineFilterExpr(labels.MatchEqual, "", $1) }
+  | STRING OR orFilter        { $$ = newOrLineFilterExpr(newLineFilterExpr(labels.MatchEqual, "", $1), $3) }
   ;
 
 lineFilters:
@@ -451,11 +513,16 @@ vectorOp:
       | STDVAR  { $$ = OpTypeStdvar }
       | BOTTOMK { $$ = OpTypeBottomK }
       | TOPK    { $$ = OpTypeTopK }
+      | APPROX_TOPK      { $$ = OpTypeApproxTopK }
+      | COUNT_MIN_SKETCH { $$ = OpTypeCountMinSketch }
+      | SORT             { $$ = OpTypeSort }
+      | SORT_DESC        { $$ = OpTypeSortDesc }
       ;
 
 rangeOp:
       COUNT_OVER_TIME    { $$ = OpRangeTypeCount }
     | RATE               { $$ = OpRangeTypeRate }
+    | RATE_COUNTER       { $$ = OpRangeTypeRateCounter }
     | BYTES_OVER_TIME    { $$ = OpRangeTypeBytes }
     | BYTES_RATE         { $$ = OpRangeTypeBytesRate }
     | AVG_OVER_TIME      { $$ = OpRangeTypeAvg }
@@ -468,10 +535,43 @@ rangeOp:
     | FIRST_OVER_TIME    { $$ = OpRangeTypeFirst }
     | LAST_OVER_TIME     { $$ = OpRangeTypeLast }
     | ABSENT_OVER_TIME   { $$ = OpRangeTypeAbsent }
+    | QUANTILE_SKETCH_OVER_TIME { $$ = OpRangeTypeQuantileSketch }
     ;
 
 offsetExpr:
-    OFFSET DURATION { $$ = newOffsetExpr( $2 ) }
+      OFFSET DURATION            { $$ = newOffsetExpr( $2 ) }
+    | atModifier                 { $$ = &OffsetExpr{At: $1} }
+    | OFFSET DURATION atModifier { $$ = newOffsetExpr( $2 ); $$.At = $3 }
+    | atModifier OFFSET DURATION { $$ = newOffsetExpr( $3 ); $$.At = $1 }
+    ;
+
+atModifier:
+      AT NUMBER                                       { $$ = newAtModifier($2) }
+    | AT START OPEN_PARENTHESIS CLOSE_PARENTHESIS     { $$ = newAtStartOrEnd(OpAtStart) }
+    | AT END OPEN_PARENTHESIS CLOSE_PARENTHESIS       { $$ = newAtStartOrEnd(OpAtEnd) }
+    ;
+
+dropLabel:
+    IDENTIFIER { $$ = log.NewDropLabel(nil, $1) }
+  | matcher    { $$ = log.NewDropLabel($1, "") }
+
+dropLabels:
+    dropLabel                  { $$ = []log.DropLabel{$1} }
+  | dropLabels COMMA dropLabel { $$ = append($1, $3) }
+  ;
+
+dropLabelsExpr: DROP dropLabels { $$ = newDropLabelsExpr($2) }
+
+keepLabel:
+    IDENTIFIER { $$ = log.NewKeepLabel(nil, $1) }
+  | matcher    { $$ = log.NewKeepLabel($1, "") }
+
+keepLabels:
+    keepLabel                  { $$ = []log.KeepLabel{$1} }
+  | keepLabels COMMA keepLabel { $$ = append($1, $3) }
+  ;
+
+keepLabelsExpr: KEEP keepLabels { $$ = newKeepLabelsExpr($2) }
 
 labels:
       IDENTIFIER                 { $$ = []string{ $1 } }
@@ -15,31 +15,34 @@ import (
 )
 
 var tokens = map[string]int{
-	",":            COMMA,
-	".":            DOT,
-	"{":            OPEN_BRACE,
-	"}":            CLOSE_BRACE,
-	"=":            EQ,
-	OpTypeNEQ:      NEQ,
-	"=~":           RE,
-	"!~":           NRE,
-	"|=":           PIPE_EXACT,
-	"|~":           PIPE_MATCH,
-	OpPipe:         PIPE,
-	OpUnwrap:       UNWRAP,
-	"(":            OPEN_PARENTHESIS,
-	")":            CLOSE_PARENTHESIS,
-	"by":           BY,
-	"without":      WITHOUT,
-	"bool":         BOOL,
-	"[":            OPEN_BRACKET,
-	"]":            CLOSE_BRACKET,
-	OpLabelReplace: LABEL_REPLACE,
-	OpOffset:       OFFSET,
-	OpOn:           ON,
-	OpIgnoring:     IGNORING,
-	OpGroupLeft:    GROUP_LEFT,
-	OpGroupRight:   GROUP_RIGHT,
+	",":              COMMA,
+	".":              DOT,
+	"{":              OPEN_BRACE,
+	"}":              CLOSE_BRACE,
+	"=":              EQ,
+	OpTypeNEQ:        NEQ,
+	"=~":             RE,
+	"!~":             NRE,
+	"|=":             PIPE_EXACT,
+	"|~":             PIPE_MATCH,
+	OpPipePattern:    PIPE_PATTERN,
+	OpPipeNotPattern: NPA,
+	OpPipe:           PIPE,
+	OpUnwrap:         UNWRAP,
+	"(":              OPEN_PARENTHESIS,
+	")":              CLOSE_PARENTHESIS,
+	"by":             BY,
+	"without":        WITHOUT,
+	"bool":           BOOL,
+	"[":              OPEN_BRACKET,
+	"]":              CLOSE_BRACKET,
+	OpLabelReplace:   LABEL_REPLACE,
+	OpOffset:         OFFSET,
+	OpAt:             AT,
+	OpOn:             ON,
+	OpIgnoring:       IGNORING,
+	OpGroupLeft:      GROUP_LEFT,
+	OpGroupRight:     GROUP_RIGHT,
 
 	// binops
 	OpTypeOr:     OR,
@@ -91,6 +94,8 @@ var functionTokens = map[string]int{
 	OpRangeTypeLast:      LAST_OVER_TIME,
 	OpRangeTypeAbsent:    ABSENT_OVER_TIME,
 
+	OpRangeTypeRateCounter: RATE_COUNTER,
+
 	// vec ops
 	OpTypeSum:      SUM,
 	OpTypeAvg:      AVG,
@@ -101,7 +106,13 @@ var functionTokens = map[string]int{
 	OpTypeStdvar:   STDVAR,
 	OpTypeBottomK:  BOTTOMK,
 	OpTypeTopK:     TOPK,
+	OpTypeSort:     SORT,
+	OpTypeSortDesc: SORT_DESC,
 	OpLabelReplace: LABEL_REPLACE,
+	OpLabelJoin:    LABEL_JOIN,
+	OpVector:       VECTOR,
+
+	OpTypeApproxTopK: APPROX_TOPK,
 
 	// conversion Op
 	OpConvBytes:           BYTES_CONV,
@@ -110,15 +121,42 @@ var functionTokens = map[string]int{
 
 	// filterOp
 	OpFilterIP: IP,
+
+	// @ modifier functions
+	OpAtStart: START,
+	OpAtEnd:   END,
+}
+
+// pipelineStageTokens are tokens only lexed right after a pipe, so that they can still be used as label names
+// everywhere else, e.g. in stream selectors, groupings and label filters.
+var pipelineStageTokens = map[string]int{
+	OpDrop: DROP,
+	OpKeep: KEEP,
+}
+
+// downstreamFunctionTokens are function tokens of operations only built by the shard mapper.
+// They are only lexed in the downstream queries sent by the query frontend to the queriers.
+var downstreamFunctionTokens = map[string]int{
+	OpRangeTypeQuantileSketch: QUANTILE_SKETCH_OVER_TIME,
+	OpTypeCountMinSketch:      COUNT_MIN_SKETCH,
 }
 
 type lexer struct {
 	scanner.Scanner
 	errs    []logqlmodel.ParseError
 	builder strings.Builder
+	// downstream enables the downstreamFunctionTokens.
+	downstream bool
+	// lastToken is the last token returned.
+	lastToken int
 }
 
 func (l *lexer) Lex(lval *exprSymType) int {
+	l.lastToken = l.lex(lval)
+	return l.lastToken
+}
+
+func (l *lexer) lex(lval *exprSymType) int {
 	r := l.Scan()
 
 	switch r {
@@ -127,7 +165,7 @@ func (l *lexer) Lex(lval *exprSymType) int {
 		for next := l.Peek(); !(next == '\n' || next == scanner.EOF); next = l.Next() {
 		}
 
-		return l.Lex(lval)
+		return l.lex(lval)
 
 	case scanner.EOF:
 		return 0
@@ -170,6 +208,9 @@ func (l *lexer) Lex(lval *exprSymType) int {
 		l.builder.Reset()
 		for r := l.Next(); r != scanner.EOF; r = l.Next() {
 			if r == ']' {
+				if i := strings.Index(l.builder.String(), ":"); i >= 0 {
+					return l.lexSubqueryRange(l.builder.String()[:i], l.builder.String()[i+1:], lval)
+				}
 				i, err := model.ParseDuration(l.builder.String())
 				if err != nil {
 					l.Error(err.Error())
@@ -186,7 +227,7 @@ func (l *lexer) Lex(lval *exprSymType) int {
 
 	tokenText := l.TokenText()
 	tokenNext := tokenText + string(l.Peek())
-	if tok, ok := functionTokens[tokenNext]; ok {
+	if tok, ok := l.functionToken(tokenNext); ok {
 		// create a copy to advance to the entire token for testing suffix
 		sc := l.Scanner
 		sc.Next()
@@ -196,7 +237,7 @@ func (l *lexer) Lex(lval *exprSymType) int {
 		}
 	}
 
-	if tok, ok := functionTokens[tokenText]; ok {
+	if tok, ok := l.functionToken(tokenText); ok {
 		if !isFunction(l.Scanner) {
 			lval.str = tokenText
 			return IDENTIFIER
@@ -213,10 +254,41 @@ func (l *lexer) Lex(lval *exprSymType) int {
 		return tok
 	}
 
+	if tok, ok := pipelineStageTokens[tokenText]; ok && l.lastToken == PIPE && !isLabelFilter(l.Scanner) {
+		return tok
+	}
+
 	lval.str = tokenText
 	return IDENTIFIER
 }
 
+func (l *lexer) functionToken(text string) (int, bool) {
+	if tok, ok := functionTokens[text]; ok {
+		return tok, true
+	}
+	if l.downstream {
+		tok, ok := downstreamFunctionTokens[text]
+		return tok, ok
+	}
+	return 0, false
+}
+
+// lexSubqueryRange scans the range and step of a subquery, e.g. [1h:1m].
+func (l *lexer) lexSubqueryRange(rng, step string, lval *exprSymType) int {
+	r, err := model.ParseDuration(rng)
+	if err != nil {
+		l.Error(err.Error())
+		return 0
+	}
+	s, err := model.ParseDuration(step)
+	if err != nil {
+		l.Error(err.Error())
+		return 0
+	}
+	lval.subqueryRange = SubqueryRange{Range: time.Duration(r), Step: time.Duration(s)}
+	return SUBQUERY_RANGE
+}
+
 func (l *lexer) Error(msg string) {
 	l.errs = append(l.errs, logqlmodel.NewParseError(msg, l.Line, l.Column))
 }
@@ -324,6 +396,17 @@ func isBytesSizeRune(r rune) bool {
 	}
 }
 
+// isLabelFilter checks if the next rune is a comparison operator, in which case the last token is the label name
+// of a label filter, e.g. | drop="foo".
+func isLabelFilter(sc scanner.Scanner) bool {
+	sc = trimSpace(sc)
+	switch sc.Peek() {
+	case '=', '!', '<', '>':
+		return true
+	}
+	return false
+}
+
 // isFunction check if the next runes are either an open parenthesis
 // or by/without tokens. This allows to dissociate functions and identifier correctly.
 func isFunction(sc scanner.Scanner) bool {
@@ -54,6 +54,14 @@ func TestLex(t *testing.T) {
 			`{foo="bar"} |~ "\\w+" | size > 200MiB or foo == 4.00`,
 			[]int{OPEN_BRACE, IDENTIFIER, EQ, STRING, CLOSE_BRACE, PIPE_MATCH, STRING, PIPE, IDENTIFIER, GT, BYTES, OR, IDENTIFIER, CMP_EQ, NUMBER},
 		},
+		{`rate({foo="bar"}[1m] @ end())`, []int{RATE, OPEN_PARENTHESIS, OPEN_BRACE, IDENTIFIER, EQ, STRING, CLOSE_BRACE, RANGE, AT, END, OPEN_PARENTHESIS, CLOSE_PARENTHESIS, CLOSE_PARENTHESIS}},
+		{`sort_desc(vector(1))`, []int{SORT_DESC, OPEN_PARENTHESIS, VECTOR, OPEN_PARENTHESIS, NUMBER, CLOSE_PARENTHESIS, CLOSE_PARENTHESIS}},
+		{`label_join(rate({vector="bar"}[1m]), "a", ",", "sort")`, []int{LABEL_JOIN, OPEN_PARENTHESIS, RATE, OPEN_PARENTHESIS, OPEN_BRACE, IDENTIFIER, EQ, STRING, CLOSE_BRACE, RANGE, CLOSE_PARENTHESIS, COMMA, STRING, COMMA, STRING, COMMA, STRING, CLOSE_PARENTHESIS}},
+		{`{foo="bar"} |> "<_> foo" !> "<_> bar"`, []int{OPEN_BRACE, IDENTIFIER, EQ, STRING, CLOSE_BRACE, PIPE_PATTERN, STRING, NPA, STRING}},
+		{`{foo="bar"} |= "foo" |> "<_> foo"`, []int{OPEN_BRACE, IDENTIFIER, EQ, STRING, CLOSE_BRACE, PIPE_EXACT, STRING, PIPE_PATTERN, STRING}},
+		{`{foo="bar"} |= "foo" or "bar"`, []int{OPEN_BRACE, IDENTIFIER, EQ, STRING, CLOSE_BRACE, PIPE_EXACT, STRING, OR, STRING}},
+		{`{foo="bar"} | start > 1`, []int{OPEN_BRACE, IDENTIFIER, EQ, STRING, CLOSE_BRACE, PIPE, IDENTIFIER, GT, NUMBER}},
+		{`rate({foo="bar"}[1m])[1h:5m]`, []int{RATE, OPEN_PARENTHESIS, OPEN_BRACE, IDENTIFIER, EQ, STRING, CLOSE_BRACE, RANGE, CLOSE_PARENTHESIS, SUBQUERY_RANGE}},
 		{`{ foo = "bar" }`, []int{OPEN_BRACE, IDENTIFIER, EQ, STRING, CLOSE_BRACE}},
 		{`{ foo != "bar" }`, []int{OPEN_BRACE, IDENTIFIER, NEQ, STRING, CLOSE_BRACE}},
 		{`{ foo =~ "bar" }`, []int{OPEN_BRACE, IDENTIFIER, RE, STRING, CLOSE_BRACE}},
@@ -38,6 +38,9 @@ func init() {
 	for str, tok := range tokens {
 		exprToknames[tok-exprPrivate+1] = str
 	}
+	for str, tok := range pipelineStageTokens {
+		exprToknames[tok-exprPrivate+1] = str
+	}
 }
 
 type parser struct {
@@ -49,6 +52,7 @@ type parser struct {
 
 func (p *parser) Parse() (Expr, error) {
 	p.lexer.errs = p.lexer.errs[:0]
+	p.lexer.lastToken = 0
 	p.lexer.Scanner.Error = func(_ *scanner.Scanner, msg string) {
 		p.lexer.Error(msg)
 	}
@@ -61,7 +65,18 @@ func (p *parser) Parse() (Expr, error) {
 
 // ParseExpr parses a string and returns an Expr.
 func ParseExpr(input string) (Expr, error) {
-	expr, err := parseExprWithoutValidation(input)
+	return parseExpr(input, false)
+}
+
+// ParseDownstreamExpr parses the query of a downstream request sent by the query frontend.
+// Unlike ParseExpr, it accepts the operations only built by the shard mapper, such as
+// quantile_sketch_over_time and count_min_sketch.
+func ParseDownstreamExpr(input string) (Expr, error) {
+	return parseExpr(input, true)
+}
+
+func parseExpr(input string, downstream bool) (Expr, error) {
+	expr, err := parseExprWithOptions(input, downstream)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +87,10 @@ func ParseExpr(input string) (Expr, error) {
 }
 
 func parseExprWithoutValidation(input string) (expr Expr, err error) {
+	return parseExprWithOptions(input, false)
+}
+
+func parseExprWithOptions(input string, downstream bool) (expr Expr, err error) {
 	if len(input) >= maxInputSize {
 		return nil, logqlmodel.NewParseError(fmt.Sprintf("input size too long (%d > %d)", len(input), maxInputSize), 0, 0)
 	}
@@ -93,6 +112,7 @@ func parseExprWithoutValidation(input string) (expr Expr, err error) {
 
 	p.Reader.Reset(input)
 	p.lexer.Init(p.Reader)
+	p.lexer.downstream = downstream
 	return p.Parse()
 }
 
@@ -163,7 +183,15 @@ func validateSampleExpr(expr SampleExpr) error {
 		}
 
 		return validateSampleExpr(e.RHS)
-	case *LiteralExpr:
+	case *SubqueryAggregationExpr:
+		return validateSampleExpr(e.Left.Left)
+	case *VectorAggregationExpr:
+		return validateSampleExpr(e.Left)
+	case *LabelReplaceExpr:
+		return validateSampleExpr(e.Left)
+	case *LabelJoinExpr:
+		return validateSampleExpr(e.Left)
+	case *LiteralExpr, *VectorExpr:
 		return nil
 	default:
 		return validateMatchers(expr.Selector().Matchers())
@@ -290,6 +290,119 @@ func TestParse(t *testing.T) {
 				Groups:  []string{"bar"},
 			}, NewStringLabelFilter("10")),
 		},
+		{
+			in: `approx_topk(10, sum by (foo) (rate({ foo = "bar" }[5h])))`,
+			exp: mustNewVectorAggregationExpr(mustNewVectorAggregationExpr(&RangeAggregationExpr{
+				Left: &LogRange{
+					Left:     &MatchersExpr{Mts: []*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}},
+					Interval: 5 * time.Hour,
+				},
+				Operation: "rate",
+			}, "sum", &Grouping{
+				Groups: []string{"foo"},
+			}, nil), "approx_topk", nil, NewStringLabelFilter("10")),
+		},
+		{
+			in: `rate({ foo = "bar" }[5m] @ 1609746000.5)`,
+			exp: &RangeAggregationExpr{
+				Left: &LogRange{
+					Left:     &MatchersExpr{Mts: []*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}},
+					Interval: 5 * time.Minute,
+					At:       &AtModifier{Timestamp: time.UnixMilli(1609746000500)},
+				},
+				Operation: "rate",
+			},
+		},
+		{
+			in: `count_over_time({ foo = "bar" } |= "baz" [5m] @ end() offset 1h)`,
+			exp: &RangeAggregationExpr{
+				Left: &LogRange{
+					Left: newPipelineExpr(
+						newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}),
+						MultiStageExpr{newLineFilterExpr(labels.MatchEqual, "", "baz")},
+					),
+					Interval: 5 * time.Minute,
+					Offset:   time.Hour,
+					At:       &AtModifier{StartOrEnd: OpAtEnd},
+				},
+				Operation: "count_over_time",
+			},
+		},
+		{
+			in: `sum(rate({ foo = "bar" }[5m] offset 1h @ start()))`,
+			exp: mustNewVectorAggregationExpr(&RangeAggregationExpr{
+				Left: &LogRange{
+					Left:     &MatchersExpr{Mts: []*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}},
+					Interval: 5 * time.Minute,
+					Offset:   time.Hour,
+					At:       &AtModifier{StartOrEnd: OpAtStart},
+				},
+				Operation: "rate",
+			}, "sum", nil, nil),
+		},
+		{
+			in: `max_over_time(rate({ foo = "bar" }[5m])[1h:1m])`,
+			exp: &SubqueryAggregationExpr{
+				Left: &SubqueryExpr{
+					Left: &RangeAggregationExpr{
+						Left: &LogRange{
+							Left:     &MatchersExpr{Mts: []*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}},
+							Interval: 5 * time.Minute,
+						},
+						Operation: "rate",
+					},
+					Range: time.Hour,
+					Step:  time.Minute,
+				},
+				Operation: "max_over_time",
+			},
+		},
+		{
+			in: `sort_desc(sum by (foo) (rate({ foo = "bar" }[5m])))`,
+			exp: mustNewVectorAggregationExpr(
+				mustNewVectorAggregationExpr(newRangeAggregationExpr(
+					newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, nil),
+					OpRangeTypeRate, nil, nil),
+					OpTypeSum, &Grouping{Groups: []string{"foo"}}, nil),
+				OpTypeSortDesc, nil, nil),
+		},
+		{
+			in: `sum(rate({ foo = "bar" }[5m])) or vector(-1)`,
+			exp: mustNewBinOpExpr(OpTypeOr, &BinOpOptions{VectorMatching: &VectorMatching{}},
+				mustNewVectorAggregationExpr(newRangeAggregationExpr(
+					newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, nil),
+					OpRangeTypeRate, nil, nil),
+					OpTypeSum, nil, nil),
+				&VectorExpr{Val: -1},
+			),
+		},
+		{
+			in: `label_join(rate({ foo = "bar" }[5m]), "foo", ",", "a", "b")`,
+			exp: mustNewLabelJoinExpr(
+				newRangeAggregationExpr(
+					newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}), 5*time.Minute, nil, nil),
+					OpRangeTypeRate, nil, nil),
+				"foo", ",", []string{"a", "b"},
+			),
+		},
+		{
+			in: `quantile_over_time(0.99, sum by (foo) (rate({ foo = "bar" }[5m]))[1h:1m] offset 10m)`,
+			exp: newSubqueryAggregationExpr(
+				newSubqueryExpr(
+					mustNewVectorAggregationExpr(&RangeAggregationExpr{
+						Left: &LogRange{
+							Left:     &MatchersExpr{Mts: []*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}},
+							Interval: 5 * time.Minute,
+						},
+						Operation: "rate",
+					}, "sum", &Grouping{Groups: []string{"foo"}}, nil),
+					SubqueryRange{Range: time.Hour, Step: time.Minute},
+					newOffsetExpr(10*time.Minute),
+				),
+				"quantile_over_time",
+				NewStringLabelFilter("0.99"),
+			),
+		},
 		{
 			in: `bottomk(30 ,sum(rate({ foo = "bar" }[5h])) by (foo))`,
 			exp: mustNewVectorAggregationExpr(mustNewVectorAggregationExpr(&RangeAggregationExpr{
@@ -396,6 +509,90 @@ func TestParse(t *testing.T) {
 				},
 			),
 		},
+		{
+			in: `{foo="bar"} |> "<_> foo <_>" !> "<_> bar"`,
+			exp: newPipelineExpr(
+				newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}),
+				MultiStageExpr{
+					newNestedLineFilterExpr(
+						newLineFilterExpr(labels.MatchEqual, OpFilterPattern, "<_> foo <_>"),
+						newLineFilterExpr(labels.MatchNotEqual, OpFilterPattern, "<_> bar"),
+					),
+				},
+			),
+		},
+		{
+			in: `{foo="bar"} |= "baz" or "qux" or "quux"`,
+			exp: newPipelineExpr(
+				newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}),
+				MultiStageExpr{
+					&LineFilterExpr{
+						Ty:    labels.MatchEqual,
+						Match: "baz",
+						Or: &LineFilterExpr{
+							Ty:    labels.MatchEqual,
+							Match: "qux",
+							Or:    newLineFilterExpr(labels.MatchEqual, "", "quux"),
+						},
+					},
+				},
+			),
+		},
+		{
+			in: `{foo="bar"} |~ "baz" or "qux" != "foo" or "bar"`,
+			exp: newPipelineExpr(
+				newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}),
+				MultiStageExpr{
+					newNestedLineFilterExpr(
+						newNestedLineFilterExpr(
+							&LineFilterExpr{
+								Ty:    labels.MatchRegexp,
+								Match: "baz",
+								Or:    newLineFilterExpr(labels.MatchRegexp, "", "qux"),
+							},
+							newLineFilterExpr(labels.MatchNotEqual, "", "foo"),
+						),
+						newLineFilterExpr(labels.MatchNotEqual, "", "bar"),
+					),
+				},
+			),
+		},
+		{
+			in: `{foo="bar"} |> "<_> foo" or "foo <_>"`,
+			exp: newPipelineExpr(
+				newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}),
+				MultiStageExpr{
+					&LineFilterExpr{
+						Ty:    labels.MatchEqual,
+						Op:    OpFilterPattern,
+						Match: "<_> foo",
+						Or:    newLineFilterExpr(labels.MatchEqual, OpFilterPattern, "foo <_>"),
+					},
+				},
+			),
+		},
+		{
+			in: `count_over_time({foo="bar"} |= "baz" or "qux" [5m]) or vector(0)`,
+			exp: mustNewBinOpExpr(
+				OpTypeOr,
+				&BinOpOptions{VectorMatching: &VectorMatching{}},
+				newRangeAggregationExpr(
+					newLogRange(
+						newPipelineExpr(
+							newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "foo", "bar")}),
+							MultiStageExpr{
+								&LineFilterExpr{
+									Ty:    labels.MatchEqual,
+									Match: "baz",
+									Or:    newLineFilterExpr(labels.MatchEqual, "", "qux"),
+								},
+							},
+						),
+						5*time.Minute, nil, nil),
+					OpRangeTypeCount, nil, nil),
+				&VectorExpr{Val: 0},
+			),
+		},
 		{
 			in: `{foo="bar"} |= ip("123.123.123.123")|= "baz" |=ip("123.123.123.123")`,
 			exp: newPipelineExpr(
@@ -602,6 +799,50 @@ func TestParse(t *testing.T) {
 			in:  `topk(count_over_time({ foo = "bar" }[5h]))`,
 			err: logqlmodel.NewParseError("parameter required for operation topk", 0, 0),
 		},
+		{
+			in:  `approx_topk(count_over_time({ foo = "bar" }[5h]))`,
+			err: logqlmodel.NewParseError("parameter required for operation approx_topk", 0, 0),
+		},
+		{
+			in:  `approx_topk(10, count_over_time({ foo = "bar" }[5h])) by (foo)`,
+			err: logqlmodel.NewParseError("grouping not allowed for approx_topk aggregation", 0, 0),
+		},
+		{
+			in:  `sort(count_over_time({ foo = "bar" }[5h])) by (foo)`,
+			err: logqlmodel.NewParseError("grouping not allowed for sort aggregation", 0, 0),
+		},
+		{
+			in:  `vector({ foo = "bar" })`,
+			err: logqlmodel.NewParseError("syntax error: unexpected {, expecting NUMBER or + or -", 1, 8),
+		},
+		{
+			in:  `label_join(rate({ foo = "bar" }[5m]), "foo-bar", ",", "a")`,
+			err: logqlmodel.NewParseError("invalid destination label name in label_join: foo-bar", 0, 0),
+		},
+		{
+			in:  `max_over_time(rate({ foo = "bar" }[5m])[1h:1m] @ end())`,
+			err: logqlmodel.NewParseError("@ modifier is not supported in subqueries", 0, 0),
+		},
+		{
+			in:  `rate({ foo = "bar" }[5m] @ now())`,
+			err: logqlmodel.NewParseError("syntax error: unexpected IDENTIFIER, expecting NUMBER or START or END", 1, 28),
+		},
+		{
+			in:  `max_over_time(rate({ foo = "bar" }[5m])[1h:0s])`,
+			err: logqlmodel.NewParseError("subquery step must be positive", 0, 0),
+		},
+		{
+			in:  `bytes_rate(rate({ foo = "bar" }[5m])[1h:1m])`,
+			err: logqlmodel.NewParseError("invalid aggregation bytes_rate of subquery", 0, 0),
+		},
+		{
+			in:  `quantile_over_time(rate({ foo = "bar" }[5m])[1h:1m])`,
+			err: logqlmodel.NewParseError("parameter required for operation quantile_over_time", 0, 0),
+		},
+		{
+			in:  `max_over_time({ foo = "bar" }[1h:1m])`,
+			err: logqlmodel.NewParseError("syntax error: unexpected SUBQUERY_RANGE", 0, 30),
+		},
 		{
 			in:  `bottomk(he,count_over_time({ foo = "bar" }[5h]))`,
 			err: logqlmodel.NewParseError("syntax error: unexpected IDENTIFIER", 1, 9),
@@ -1596,6 +1837,30 @@ func TestParse(t *testing.T) {
 			exp: nil,
 			err: logqlmodel.NewParseError("invalid aggregation count_over_time with unwrap", 0, 0),
 		},
+		{
+			in: `rate_counter({app="foo"} | logfmt | unwrap bytes_sent_total [5m])`,
+			exp: newRangeAggregationExpr(
+				newLogRange(&PipelineExpr{
+					Left:        newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+					MultiStages: MultiStageExpr{newLabelParserExpr(OpParserTypeLogfmt, "")},
+				},
+					5*time.Minute,
+					newUnwrapExpr("bytes_sent_total", ""), nil),
+				OpRangeTypeRateCounter,
+				nil,
+				nil,
+			),
+		},
+		{
+			in:  `rate_counter({app="foo"} |= "foo" [5m])`,
+			exp: nil,
+			err: logqlmodel.NewParseError("invalid aggregation rate_counter without unwrap", 0, 0),
+		},
+		{
+			in:  `rate_counter({app="foo"} | logfmt | unwrap bytes_sent_total [5m]) by (foo)`,
+			exp: nil,
+			err: logqlmodel.NewParseError("grouping not allowed for rate_counter aggregation", 0, 0),
+		},
 		{
 			in: `{app="foo"} |= "bar" | json |  status_code < 500 or status_code > 200 and size >= 2.5KiB `,
 			exp: &PipelineExpr{
@@ -2842,7 +3107,7 @@ func TestParse(t *testing.T) {
 		},
 		{
 			in:  `quantile_over_time(foo,{namespace="tns"} |= "level=error" | json |foo>=5,bar<25ms| unwrap latency [5m])`,
-			err: logqlmodel.NewParseError("syntax error: unexpected IDENTIFIER, expecting NUMBER or { or (", 1, 20),
+			err: logqlmodel.NewParseError("syntax error: unexpected IDENTIFIER", 1, 20),
 		},
 		{
 			in: `{app="foo"}
@@ -2943,6 +3208,89 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			in: `{app="foo"} | logfmt | drop level, __error__="LogfmtParserErr"`,
+			exp: &PipelineExpr{
+				Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+				MultiStages: MultiStageExpr{
+					newLabelParserExpr(OpParserTypeLogfmt, ""),
+					newDropLabelsExpr([]log.DropLabel{
+						log.NewDropLabel(nil, "level"),
+						log.NewDropLabel(mustNewMatcher(labels.MatchEqual, logqlmodel.ErrorLabel, "LogfmtParserErr"), ""),
+					}),
+				},
+			},
+		},
+		{
+			in: `{app="foo"} | json | keep level, status=~"5.."`,
+			exp: &PipelineExpr{
+				Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+				MultiStages: MultiStageExpr{
+					newLabelParserExpr(OpParserTypeJSON, ""),
+					newKeepLabelsExpr([]log.KeepLabel{
+						log.NewKeepLabel(nil, "level"),
+						log.NewKeepLabel(mustNewMatcher(labels.MatchRegexp, "status", "5.."), ""),
+					}),
+				},
+			},
+		},
+		{
+			in: `sum by (app) (count_over_time({app="foo"} | json | drop __error__ [5m]))`,
+			exp: mustNewVectorAggregationExpr(
+				newRangeAggregationExpr(
+					newLogRange(&PipelineExpr{
+						Left: newMatcherExpr([]*labels.Matcher{{Type: labels.MatchEqual, Name: "app", Value: "foo"}}),
+						MultiStages: MultiStageExpr{
+							newLabelParserExpr(OpParserTypeJSON, ""),
+							newDropLabelsExpr([]log.DropLabel{
+								log.NewDropLabel(nil, logqlmodel.ErrorLabel),
+							}),
+						},
+					},
+						5*time.Minute,
+						nil, nil),
+					OpRangeTypeCount,
+					nil,
+					nil,
+				),
+				OpTypeSum,
+				&Grouping{Groups: []string{"app"}},
+				nil,
+			),
+		},
+		{
+			// drop and keep are only keywords for pipeline stages.
+			in:  `{drop="a", keep="b"}`,
+			exp: newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "drop", "a"), mustNewMatcher(labels.MatchEqual, "keep", "b")}),
+		},
+		{
+			in: `sum by (keep) (rate({a="b"}[1m]))`,
+			exp: mustNewVectorAggregationExpr(newRangeAggregationExpr(
+				newLogRange(newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "a", "b")}), time.Minute, nil, nil),
+				OpRangeTypeRate, nil, nil),
+				OpTypeSum, &Grouping{Groups: []string{"keep"}}, nil),
+		},
+		{
+			in: `{a="b"} | label_format drop=foo | drop="x" | keep != "y"`,
+			exp: &PipelineExpr{
+				Left: newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "a", "b")}),
+				MultiStages: MultiStageExpr{
+					newLabelFmtExpr([]log.LabelFmt{log.NewRenameLabelFmt("drop", "foo")}),
+					newLabelFilterExpr(log.NewStringLabelFilter(mustNewMatcher(labels.MatchEqual, "drop", "x"))),
+					newLabelFilterExpr(log.NewStringLabelFilter(mustNewMatcher(labels.MatchNotEqual, "keep", "y"))),
+				},
+			},
+		},
+		{
+			in: `{a="b"} | drop keep, drop | keep drop`,
+			exp: &PipelineExpr{
+				Left: newMatcherExpr([]*labels.Matcher{mustNewMatcher(labels.MatchEqual, "a", "b")}),
+				MultiStages: MultiStageExpr{
+					newDropLabelsExpr([]log.DropLabel{log.NewDropLabel(nil, "keep"), log.NewDropLabel(nil, "drop")}),
+					newKeepLabelsExpr([]log.KeepLabel{log.NewKeepLabel(nil, "drop")}),
+				},
+			},
+		},
 	} {
 		t.Run(tc.in, func(t *testing.T) {
 			ast, err := ParseExpr(tc.in)
@@ -3171,3 +3519,22 @@ func TestParseLogSelectorExpr_equalityMatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDownstreamExpr(t *testing.T) {
+	for _, in := range []string{
+		`quantile_sketch_over_time({app="foo"} | unwrap latency [5m]) by (cluster)`,
+		`count_min_sketch(10, sum by (client_ip) (count_over_time({app="foo"} | json [5m])))`,
+	} {
+		t.Run(in, func(t *testing.T) {
+			// the operations only built by the shard mapper are not part of the language.
+			_, err := ParseExpr(in)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, logqlmodel.ErrParse))
+
+			expr, err := ParseDownstreamExpr(in)
+			require.NoError(t, err)
+			_, err = ParseDownstreamExpr(expr.String())
+			require.NoError(t, err)
+		})
+	}
+}
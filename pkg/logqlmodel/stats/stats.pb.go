@@ -106,6 +106,9 @@ type Summary struct {
 	QueueTime float64 `protobuf:"fixed64,6,opt,name=queueTime,proto3" json:"queueTime"`
 	// Total of subqueries created to fulfill this query.
 	Subqueries int64 `protobuf:"varint,7,opt,name=subqueries,proto3" json:"subqueries"`
+	// Total bytes the query was estimated to read from the index stats,
+	// before it was scheduled by the query frontend.
+	TotalBytesEstimated int64 `protobuf:"varint,8,opt,name=totalBytesEstimated,proto3" json:"totalBytesEstimated"`
 }
 
 func (m *Summary) Reset()      { *m = Summary{} }
@@ -189,6 +192,13 @@ func (m *Summary) GetSubqueries() int64 {
 	return 0
 }
 
+func (m *Summary) GetTotalBytesEstimated() int64 {
+	if m != nil {
+		return m.TotalBytesEstimated
+	}
+	return 0
+}
+
 type Querier struct {
 	Store Store `protobuf:"bytes,1,opt,name=store,proto3" json:"store"`
 }
@@ -482,53 +492,54 @@ func init() {
 func init() { proto.RegisterFile("pkg/logqlmodel/stats/stats.proto", fileDescriptor_6cdfe5d2aea33ebb) }
 
 var fileDescriptor_6cdfe5d2aea33ebb = []byte{
-	// 734 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4d, 0x6f, 0xd3, 0x4c,
-	0x10, 0xb6, 0x93, 0xd7, 0x49, 0xba, 0x6f, 0xbf, 0xd8, 0xaa, 0x34, 0x80, 0x64, 0x57, 0x39, 0x55,
-	0x02, 0x12, 0xf1, 0x71, 0x01, 0xd1, 0x8b, 0x5b, 0x21, 0x55, 0x02, 0x51, 0xa6, 0x70, 0xe1, 0xe6,
-	0x38, 0xdb, 0xc4, 0xaa, 0xe3, 0x4d, 0xfd, 0x21, 0xe8, 0x8d, 0x1b, 0x47, 0xf8, 0x19, 0x5c, 0xf8,
-	0x09, 0xdc, 0x7b, 0xec, 0x81, 0x43, 0x4f, 0x16, 0x75, 0x2f, 0xc8, 0xa7, 0xfe, 0x04, 0xe4, 0x59,
-	0xc7, 0x8e, 0x1d, 0x47, 0xe2, 0x92, 0xcc, 0x3c, 0xcf, 0x3c, 0x33, 0xeb, 0x99, 0x59, 0x2d, 0xd9,
-	0x9e, 0x9c, 0x0c, 0x7b, 0x36, 0x1f, 0x9e, 0xda, 0x63, 0x3e, 0x60, 0x76, 0xcf, 0xf3, 0x0d, 0xdf,
-	0x13, 0xbf, 0xdd, 0x89, 0xcb, 0x7d, 0x4e, 0x15, 0x74, 0xee, 0x3e, 0x1c, 0x5a, 0xfe, 0x28, 0xe8,
-	0x77, 0x4d, 0x3e, 0xee, 0x0d, 0xf9, 0x90, 0xf7, 0x90, 0xed, 0x07, 0xc7, 0xe8, 0xa1, 0x83, 0x96,
-	0x50, 0x75, 0x7e, 0xca, 0xa4, 0x01, 0xcc, 0x0b, 0x6c, 0x9f, 0x3e, 0x23, 0x4d, 0x2f, 0x18, 0x8f,
-	0x0d, 0xf7, 0xac, 0x2d, 0x6f, 0xcb, 0x3b, 0xff, 0x3f, 0x5e, 0xed, 0x8a, 0xfc, 0x47, 0x02, 0xd5,
-	0xd7, 0xce, 0x43, 0x4d, 0x8a, 0x43, 0x6d, 0x1a, 0x06, 0x53, 0x23, 0x91, 0x9e, 0x06, 0xcc, 0xb5,
-	0x98, 0xdb, 0xae, 0x15, 0xa4, 0x6f, 0x05, 0x9a, 0x4b, 0xd3, 0x30, 0x98, 0x1a, 0x74, 0x97, 0xb4,
-	0x2c, 0x67, 0xc8, 0x3c, 0x9f, 0xb9, 0xed, 0x3a, 0x6a, 0xd7, 0x52, 0xed, 0x41, 0x0a, 0xeb, 0xeb,
-	0xa9, 0x38, 0x0b, 0x84, 0xcc, 0xea, 0xfc, 0xaa, 0x93, 0x66, 0x7a, 0x3e, 0xfa, 0x9e, 0x6c, 0xf5,
-	0xcf, 0x7c, 0xe6, 0x1d, 0xba, 0xdc, 0x64, 0x9e, 0xc7, 0x06, 0x87, 0xcc, 0x3d, 0x62, 0x26, 0x77,
-	0x06, 0xf8, 0x41, 0x75, 0xfd, 0x5e, 0x1c, 0x6a, 0x8b, 0x42, 0x60, 0x11, 0x91, 0xa4, 0xb5, 0x2d,
-	0xa7, 0x32, 0x6d, 0x2d, 0x4f, 0xbb, 0x20, 0x04, 0x16, 0x11, 0xf4, 0x80, 0x6c, 0xf8, 0xdc, 0x37,
-	0x6c, 0xbd, 0x50, 0x16, 0x7b, 0x50, 0xd7, 0xb7, 0xe2, 0x50, 0xab, 0xa2, 0xa1, 0x0a, 0xcc, 0x52,
-	0xbd, 0x2a, 0x94, 0x6a, 0xff, 0x57, 0x4a, 0x55, 0xa4, 0xa1, 0x0a, 0xa4, 0x3b, 0xa4, 0xc5, 0x3e,
-	0x31, 0xf3, 0x9d, 0x35, 0x66, 0x6d, 0x65, 0x5b, 0xde, 0x91, 0xf5, 0xe5, 0xa4, 0xf3, 0x53, 0x0c,
-	0x32, 0x8b, 0xde, 0x27, 0x4b, 0xa7, 0x01, 0x0b, 0x18, 0x86, 0x36, 0x30, 0x74, 0x25, 0x0e, 0xb5,
-	0x1c, 0x84, 0xdc, 0xa4, 0x5d, 0x42, 0xbc, 0xa0, 0x2f, 0x66, 0xee, 0xb5, 0x9b, 0x78, 0xb0, 0xd5,
-	0x38, 0xd4, 0x66, 0x50, 0x98, 0xb1, 0x3b, 0x2f, 0x48, 0x33, 0x5d, 0x1d, 0xfa, 0x88, 0x28, 0x9e,
-	0xcf, 0x5d, 0x96, 0x2e, 0xe5, 0xf2, 0x74, 0x29, 0x13, 0x4c, 0x5f, 0x49, 0x57, 0x43, 0x84, 0x80,
-	0xf8, 0xeb, 0xfc, 0xa8, 0x91, 0xd6, 0x74, 0x7b, 0xe8, 0x53, 0xb2, 0x8c, 0x1f, 0x0a, 0xcc, 0x30,
-	0x47, 0x4c, 0xac, 0x82, 0xa2, 0xaf, 0xc7, 0xa1, 0x56, 0xc0, 0xa1, 0xe0, 0xd1, 0x97, 0x84, 0xa2,
-	0xbf, 0x37, 0x0a, 0x9c, 0x13, 0xef, 0xb5, 0xe1, 0xa3, 0x56, 0xcc, 0xfb, 0x76, 0x1c, 0x6a, 0x15,
-	0x2c, 0x54, 0x60, 0x59, 0x75, 0x1d, 0x7d, 0x2f, 0x1d, 0x6f, 0x5e, 0x3d, 0xc5, 0xa1, 0xe0, 0xd1,
-	0xe7, 0x64, 0x35, 0x1f, 0xce, 0x11, 0x73, 0xfc, 0x74, 0x96, 0x34, 0x0e, 0xb5, 0x12, 0x03, 0x25,
-	0x3f, 0xef, 0x97, 0xf2, 0xcf, 0xfd, 0xfa, 0x5a, 0x23, 0x0a, 0xf2, 0x59, 0x61, 0xf1, 0x11, 0xc0,
-	0x8e, 0xd3, 0x9b, 0x93, 0x17, 0xce, 0x18, 0x28, 0xf9, 0xf4, 0x0d, 0xd9, 0x9c, 0x41, 0xf6, 0xf9,
-	0x47, 0xc7, 0xe6, 0xc6, 0x20, 0xeb, 0xda, 0x9d, 0x38, 0xd4, 0xaa, 0x03, 0xa0, 0x1a, 0x4e, 0x66,
-	0x60, 0x16, 0x30, 0x5c, 0xb5, 0x7a, 0x3e, 0x83, 0x79, 0x16, 0x2a, 0xb0, 0xa4, 0x23, 0x88, 0x62,
-	0x13, 0xf3, 0x8e, 0x60, 0xbd, 0xbc, 0x23, 0x18, 0x02, 0xe2, 0xaf, 0xf3, 0xa5, 0x4e, 0x14, 0xe4,
-	0x93, 0x8e, 0x8c, 0x98, 0x31, 0x10, 0xc1, 0xc9, 0xb5, 0x9b, 0x1d, 0x45, 0x91, 0x81, 0x92, 0x5f,
-	0xd0, 0xe2, 0x80, 0x70, 0x26, 0x65, 0x2d, 0x32, 0x50, 0xf2, 0xe9, 0x1e, 0xb9, 0x35, 0x60, 0x26,
-	0x1f, 0x4f, 0x5c, 0xbc, 0x98, 0xa2, 0x74, 0x03, 0xe5, 0x9b, 0x71, 0xa8, 0xcd, 0x93, 0x30, 0x0f,
-	0x95, 0x93, 0x88, 0x33, 0x34, 0xab, 0x93, 0x88, 0x63, 0xcc, 0x43, 0x74, 0x97, 0xac, 0x95, 0xcf,
-	0xd1, 0xc2, 0x14, 0x1b, 0x71, 0xa8, 0x95, 0x29, 0x28, 0x03, 0x89, 0x1c, 0xc7, 0xbb, 0x1f, 0x4c,
-	0x6c, 0xcb, 0x34, 0x12, 0xf9, 0x52, 0x2e, 0x2f, 0x51, 0x50, 0x06, 0xf4, 0xfe, 0xc5, 0x95, 0x2a,
-	0x5d, 0x5e, 0xa9, 0xd2, 0xcd, 0x95, 0x2a, 0x7f, 0x8e, 0x54, 0xf9, 0x7b, 0xa4, 0xca, 0xe7, 0x91,
-	0x2a, 0x5f, 0x44, 0xaa, 0xfc, 0x3b, 0x52, 0xe5, 0x3f, 0x91, 0x2a, 0xdd, 0x44, 0xaa, 0xfc, 0xed,
-	0x5a, 0x95, 0x2e, 0xae, 0x55, 0xe9, 0xf2, 0x5a, 0x95, 0x3e, 0x3c, 0x98, 0x7d, 0x05, 0x5d, 0xe3,
-	0xd8, 0x70, 0x8c, 0x9e, 0xcd, 0x4f, 0xac, 0x5e, 0xd5, 0x33, 0xda, 0x6f, 0xe0, 0x5b, 0xf8, 0xe4,
-	0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4e, 0x99, 0x9a, 0xa3, 0x65, 0x07, 0x00, 0x00,
+	// 749 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xbd, 0x6e, 0xdb, 0x48,
+	0x10, 0x16, 0x25, 0x53, 0x92, 0xf7, 0xfc, 0x77, 0x6b, 0xf8, 0xcc, 0xbb, 0x03, 0x48, 0x43, 0x95,
+	0x81, 0x24, 0x12, 0xf2, 0xd3, 0x24, 0x88, 0x1b, 0xda, 0x09, 0x60, 0x20, 0x41, 0x9c, 0x71, 0xd2,
+	0xa4, 0xa3, 0xa8, 0xb5, 0x44, 0x98, 0xd2, 0xca, 0xdc, 0x25, 0x12, 0x77, 0xe9, 0x52, 0x26, 0x6f,
+	0x90, 0x36, 0x4d, 0x1e, 0x21, 0xbd, 0x4b, 0x97, 0xae, 0x88, 0x58, 0x6e, 0x02, 0x56, 0x7e, 0x84,
+	0x80, 0xbb, 0x14, 0x29, 0x52, 0x2b, 0x20, 0x0d, 0x39, 0xf3, 0x7d, 0xf3, 0xcd, 0x2c, 0x67, 0x86,
+	0x58, 0xb4, 0x33, 0x3e, 0xed, 0x77, 0x7c, 0xda, 0x3f, 0xf3, 0x87, 0xb4, 0x47, 0xfc, 0x0e, 0xe3,
+	0x0e, 0x67, 0xf2, 0xd9, 0x1e, 0x07, 0x94, 0x53, 0xac, 0x0b, 0xe7, 0xbf, 0x7b, 0x7d, 0x8f, 0x0f,
+	0xc2, 0x6e, 0xdb, 0xa5, 0xc3, 0x4e, 0x9f, 0xf6, 0x69, 0x47, 0xb0, 0xdd, 0xf0, 0x44, 0x78, 0xc2,
+	0x11, 0x96, 0x54, 0xb5, 0x7e, 0x68, 0xa8, 0x0e, 0x84, 0x85, 0x3e, 0xc7, 0x8f, 0x51, 0x83, 0x85,
+	0xc3, 0xa1, 0x13, 0x9c, 0x1b, 0xda, 0x8e, 0xb6, 0xfb, 0xd7, 0x83, 0xb5, 0xb6, 0xcc, 0x7f, 0x2c,
+	0x51, 0x7b, 0xfd, 0x22, 0xb2, 0x2a, 0x71, 0x64, 0x4d, 0xc3, 0x60, 0x6a, 0x24, 0xd2, 0xb3, 0x90,
+	0x04, 0x1e, 0x09, 0x8c, 0x6a, 0x41, 0xfa, 0x5a, 0xa2, 0xb9, 0x34, 0x0d, 0x83, 0xa9, 0x81, 0xf7,
+	0x50, 0xd3, 0x1b, 0xf5, 0x09, 0xe3, 0x24, 0x30, 0x6a, 0x42, 0xbb, 0x9e, 0x6a, 0x0f, 0x53, 0xd8,
+	0xde, 0x48, 0xc5, 0x59, 0x20, 0x64, 0x56, 0xeb, 0xeb, 0x12, 0x6a, 0xa4, 0xe7, 0xc3, 0x6f, 0xd1,
+	0x76, 0xf7, 0x9c, 0x13, 0x76, 0x14, 0x50, 0x97, 0x30, 0x46, 0x7a, 0x47, 0x24, 0x38, 0x26, 0x2e,
+	0x1d, 0xf5, 0xc4, 0x07, 0xd5, 0xec, 0xff, 0xe3, 0xc8, 0x5a, 0x14, 0x02, 0x8b, 0x88, 0x24, 0xad,
+	0xef, 0x8d, 0x94, 0x69, 0xab, 0x79, 0xda, 0x05, 0x21, 0xb0, 0x88, 0xc0, 0x87, 0x68, 0x93, 0x53,
+	0xee, 0xf8, 0x76, 0xa1, 0xac, 0xe8, 0x41, 0xcd, 0xde, 0x8e, 0x23, 0x4b, 0x45, 0x83, 0x0a, 0xcc,
+	0x52, 0xbd, 0x28, 0x94, 0x32, 0x96, 0x4a, 0xa9, 0x8a, 0x34, 0xa8, 0x40, 0xbc, 0x8b, 0x9a, 0xe4,
+	0x03, 0x71, 0xdf, 0x78, 0x43, 0x62, 0xe8, 0x3b, 0xda, 0xae, 0x66, 0xaf, 0x24, 0x9d, 0x9f, 0x62,
+	0x90, 0x59, 0xf8, 0x0e, 0x5a, 0x3e, 0x0b, 0x49, 0x48, 0x44, 0x68, 0x5d, 0x84, 0xae, 0xc6, 0x91,
+	0x95, 0x83, 0x90, 0x9b, 0xb8, 0x8d, 0x10, 0x0b, 0xbb, 0x72, 0xe6, 0xcc, 0x68, 0x88, 0x83, 0xad,
+	0xc5, 0x91, 0x35, 0x83, 0xc2, 0x8c, 0x5d, 0x6c, 0xce, 0x33, 0xc6, 0xbd, 0xa1, 0xc3, 0x49, 0xcf,
+	0x68, 0xaa, 0x9a, 0x93, 0xd1, 0xa0, 0x02, 0x5b, 0x4f, 0x51, 0x23, 0xdd, 0x42, 0x7c, 0x1f, 0xe9,
+	0x8c, 0xd3, 0x80, 0xa4, 0xfb, 0xbd, 0x32, 0xdd, 0xef, 0x04, 0xb3, 0x57, 0xd3, 0x2d, 0x93, 0x21,
+	0x20, 0x5f, 0xad, 0xef, 0x55, 0xd4, 0x9c, 0x2e, 0x22, 0x7e, 0x84, 0x56, 0x44, 0x05, 0x20, 0x8e,
+	0x3b, 0x20, 0x72, 0xab, 0x74, 0x7b, 0x23, 0x8e, 0xac, 0x02, 0x0e, 0x05, 0x0f, 0x3f, 0x47, 0x58,
+	0xf8, 0xfb, 0x83, 0x70, 0x74, 0xca, 0x5e, 0x3a, 0x5c, 0x68, 0xe5, 0xea, 0xfc, 0x13, 0x47, 0x96,
+	0x82, 0x05, 0x05, 0x96, 0x55, 0xb7, 0x85, 0xcf, 0xd2, 0x4d, 0xc9, 0xab, 0xa7, 0x38, 0x14, 0x3c,
+	0xfc, 0x04, 0xad, 0xe5, 0x73, 0x3e, 0x26, 0x23, 0x9e, 0xae, 0x05, 0x8e, 0x23, 0xab, 0xc4, 0x40,
+	0xc9, 0xcf, 0xfb, 0xa5, 0xff, 0x71, 0xbf, 0x3e, 0x57, 0x91, 0x2e, 0xf8, 0xac, 0xb0, 0xfc, 0x08,
+	0x20, 0x27, 0x86, 0x56, 0x2a, 0x9c, 0x31, 0x50, 0xf2, 0xf1, 0x2b, 0xb4, 0x35, 0x83, 0x1c, 0xd0,
+	0xf7, 0x23, 0x9f, 0x3a, 0xbd, 0xac, 0x6b, 0xff, 0xc6, 0x91, 0xa5, 0x0e, 0x00, 0x35, 0x9c, 0xcc,
+	0xc0, 0x2d, 0x60, 0x62, 0x6b, 0x6b, 0xf9, 0x0c, 0xe6, 0x59, 0x50, 0x60, 0x49, 0x47, 0x04, 0x6a,
+	0x2c, 0x15, 0x3a, 0x22, 0xea, 0xe5, 0x1d, 0x11, 0x21, 0x20, 0x5f, 0xad, 0x4f, 0x35, 0xa4, 0x0b,
+	0x3e, 0xe9, 0xc8, 0x80, 0x38, 0x3d, 0x19, 0x9c, 0x2c, 0xe9, 0xec, 0x28, 0x8a, 0x0c, 0x94, 0xfc,
+	0x82, 0x56, 0x0c, 0xc8, 0xd0, 0x15, 0x5a, 0xc1, 0x40, 0xc9, 0xc7, 0xfb, 0xe8, 0xef, 0x1e, 0x71,
+	0xe9, 0x70, 0x1c, 0x88, 0x7f, 0x5c, 0x96, 0xae, 0x0b, 0xf9, 0x56, 0x1c, 0x59, 0xf3, 0x24, 0xcc,
+	0x43, 0xe5, 0x24, 0xf2, 0x0c, 0x0d, 0x75, 0x12, 0x79, 0x8c, 0x79, 0x08, 0xef, 0xa1, 0xf5, 0xf2,
+	0x39, 0xe4, 0x2f, 0xbd, 0x19, 0x47, 0x56, 0x99, 0x82, 0x32, 0x90, 0xc8, 0xc5, 0x78, 0x0f, 0xc2,
+	0xb1, 0xef, 0xb9, 0x4e, 0x22, 0x5f, 0xce, 0xe5, 0x25, 0x0a, 0xca, 0x80, 0xdd, 0xbd, 0xbc, 0x36,
+	0x2b, 0x57, 0xd7, 0x66, 0xe5, 0xf6, 0xda, 0xd4, 0x3e, 0x4e, 0x4c, 0xed, 0xdb, 0xc4, 0xd4, 0x2e,
+	0x26, 0xa6, 0x76, 0x39, 0x31, 0xb5, 0x9f, 0x13, 0x53, 0xfb, 0x35, 0x31, 0x2b, 0xb7, 0x13, 0x53,
+	0xfb, 0x72, 0x63, 0x56, 0x2e, 0x6f, 0xcc, 0xca, 0xd5, 0x8d, 0x59, 0x79, 0x77, 0x77, 0xf6, 0x42,
+	0x0d, 0x9c, 0x13, 0x67, 0xe4, 0x74, 0x7c, 0x7a, 0xea, 0x75, 0x54, 0x37, 0x72, 0xb7, 0x2e, 0xae,
+	0xd5, 0x87, 0xbf, 0x07, 0x00, 0xd4, 0x21, 0xd9, 0x8f, 0xb0, 0x07, 0x00, 0x00,
 }
 
 func (this *Result) Equal(that interface{}) bool {
@@ -601,6 +612,9 @@ func (this *Summary) Equal(that interface{}) bool {
 	if this.Subqueries != that1.Subqueries {
 		return false
 	}
+	if this.TotalBytesEstimated != that1.TotalBytesEstimated {
+		return false
+	}
 	return true
 }
 func (this *Querier) Equal(that interface{}) bool {
@@ -751,7 +765,7 @@ func (this *Summary) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 11)
+	s := make([]string, 0, 12)
 	s = append(s, "&stats.Summary{")
 	s = append(s, "BytesProcessedPerSecond: "+fmt.Sprintf("%#v", this.BytesProcessedPerSecond)+",\n")
 	s = append(s, "LinesProcessedPerSecond: "+fmt.Sprintf("%#v", this.LinesProcessedPerSecond)+",\n")
@@ -760,6 +774,7 @@ func (this *Summary) GoString() string {
 	s = append(s, "ExecTime: "+fmt.Sprintf("%#v", this.ExecTime)+",\n")
 	s = append(s, "QueueTime: "+fmt.Sprintf("%#v", this.QueueTime)+",\n")
 	s = append(s, "Subqueries: "+fmt.Sprintf("%#v", this.Subqueries)+",\n")
+	s = append(s, "TotalBytesEstimated: "+fmt.Sprintf("%#v", this.TotalBytesEstimated)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -896,6 +911,11 @@ func (m *Summary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.TotalBytesEstimated != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.TotalBytesEstimated))
+		i--
+		dAtA[i] = 0x40
+	}
 	if m.Subqueries != 0 {
 		i = encodeVarintStats(dAtA, i, uint64(m.Subqueries))
 		i--
@@ -1176,6 +1196,9 @@ func (m *Summary) Size() (n int) {
 	if m.Subqueries != 0 {
 		n += 1 + sovStats(uint64(m.Subqueries))
 	}
+	if m.TotalBytesEstimated != 0 {
+		n += 1 + sovStats(uint64(m.TotalBytesEstimated))
+	}
 	return n
 }
 
@@ -1290,6 +1313,7 @@ func (this *Summary) String() string {
 		`ExecTime:` + fmt.Sprintf("%v", this.ExecTime) + `,`,
 		`QueueTime:` + fmt.Sprintf("%v", this.QueueTime) + `,`,
 		`Subqueries:` + fmt.Sprintf("%v", this.Subqueries) + `,`,
+		`TotalBytesEstimated:` + fmt.Sprintf("%v", this.TotalBytesEstimated) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1652,6 +1676,25 @@ func (m *Summary) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalBytesEstimated", wireType)
+			}
+			m.TotalBytesEstimated = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalBytesEstimated |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStats(dAtA[iNdEx:])
@@ -17,6 +17,10 @@ type Config struct {
 	DownstreamURL     string `yaml:"downstream_url"`
 
 	TailProxyURL string `yaml:"tail_proxy_url"`
+
+	CompactorAddress string `yaml:"compactor_address"`
+
+	AdminQueriesAPIEnabled bool `yaml:"admin_queries_api_enabled"`
 }
 
 // RegisterFlags adds the flags required to config this to the given FlagSet.
@@ -29,4 +33,8 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.StringVar(&cfg.DownstreamURL, "frontend.downstream-url", "", "URL of downstream Prometheus.")
 
 	f.StringVar(&cfg.TailProxyURL, "frontend.tail-proxy-url", "", "URL of querier for tail proxy.")
+
+	f.StringVar(&cfg.CompactorAddress, "frontend.compactor-address", "", "HTTP address of the compactor to load the results cache generation numbers of the tenants from. Cached results are invalidated when the delete requests of a tenant change.")
+
+	f.BoolVar(&cfg.AdminQueriesAPIEnabled, "frontend.admin-queries-api-enabled", false, "Enable the unauthenticated /frontend/queries endpoint listing and cancelling the queries of all tenants in progress in this query frontend. It must only be reachable by operators.")
 }
@@ -0,0 +1,223 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"go.uber.org/atomic"
+
+	"github.com/grafana/dskit/tenant"
+
+	util_log "github.com/grafana/loki/pkg/util/log"
+)
+
+type activeQueryContextKey int
+
+const activeQueryKey activeQueryContextKey = 0
+
+// ActiveQuery is a query in progress in the query frontend.
+type ActiveQuery struct {
+	ID        string
+	TenantID  string
+	Path      string
+	Query     string
+	StartTime time.Time
+
+	// Number of subqueries (splits and shards) sent to the queriers, and number of them still in progress.
+	subqueries            atomic.Int64
+	outstandingSubqueries atomic.Int64
+	bytesProcessed        atomic.Int64
+
+	cancelled atomic.Bool
+	cancel    context.CancelFunc
+}
+
+// ActiveQueryFromContext returns the active query the context belongs to, or nil if it isn't tracked.
+func ActiveQueryFromContext(ctx context.Context) *ActiveQuery {
+	q, _ := ctx.Value(activeQueryKey).(*ActiveQuery)
+	return q
+}
+
+// AddBytesProcessed adds the bytes processed by a subquery to the query. Safe if q is nil.
+func (q *ActiveQuery) AddBytesProcessed(bytes int64) {
+	if q == nil {
+		return
+	}
+	q.bytesProcessed.Add(bytes)
+}
+
+func (q *ActiveQuery) subqueryStarted() {
+	if q == nil {
+		return
+	}
+	q.subqueries.Inc()
+	q.outstandingSubqueries.Inc()
+}
+
+func (q *ActiveQuery) subqueryDone() {
+	if q == nil {
+		return
+	}
+	q.outstandingSubqueries.Dec()
+}
+
+// Cancelled returns true if the query has been cancelled with the active queries API.
+func (q *ActiveQuery) Cancelled() bool {
+	return q.cancelled.Load()
+}
+
+type activeQueryDesc struct {
+	ID                    string    `json:"id"`
+	TenantID              string    `json:"tenant"`
+	Path                  string    `json:"path"`
+	Query                 string    `json:"query"`
+	StartTime             time.Time `json:"startTime"`
+	Subqueries            int64     `json:"subqueries"`
+	OutstandingSubqueries int64     `json:"outstandingSubqueries"`
+	BytesProcessed        int64     `json:"bytesProcessed"`
+}
+
+func (q *ActiveQuery) desc() activeQueryDesc {
+	return activeQueryDesc{
+		ID:                    q.ID,
+		TenantID:              q.TenantID,
+		Path:                  q.Path,
+		Query:                 q.Query,
+		StartTime:             q.StartTime,
+		Subqueries:            q.subqueries.Load(),
+		OutstandingSubqueries: q.outstandingSubqueries.Load(),
+		BytesProcessed:        q.bytesProcessed.Load(),
+	}
+}
+
+// ActiveQueries tracks the queries in progress in the query frontend, so that they can be listed and cancelled.
+// Cancelling a query cancels its context, which cancels all its subqueries, either queued or already
+// scheduled on queriers.
+type ActiveQueries struct {
+	mtx     sync.RWMutex
+	queries map[string]*ActiveQuery
+
+	lastID atomic.Uint64
+}
+
+// NewActiveQueries creates a new ActiveQueries.
+func NewActiveQueries() *ActiveQueries {
+	a := &ActiveQueries{
+		queries: map[string]*ActiveQuery{},
+	}
+	// Randomize to avoid reusing the IDs of queries listed before a restart.
+	a.lastID.Store(rand.Uint64())
+	return a
+}
+
+// track registers a query. It returns the context of the query, which is cancelled when the query is cancelled,
+// and a function to call once the query is done.
+func (a *ActiveQueries) track(ctx context.Context, tenantID, path, query string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	q := &ActiveQuery{
+		ID:        strconv.FormatUint(a.lastID.Inc(), 10),
+		TenantID:  tenantID,
+		Path:      path,
+		Query:     query,
+		StartTime: time.Now(),
+		cancel:    cancel,
+	}
+
+	a.mtx.Lock()
+	a.queries[q.ID] = q
+	a.mtx.Unlock()
+
+	return context.WithValue(ctx, activeQueryKey, q), func() {
+		a.mtx.Lock()
+		delete(a.queries, q.ID)
+		a.mtx.Unlock()
+		cancel()
+	}
+}
+
+// list returns the queries of the tenant, or of all tenants if tenantID is empty, oldest first.
+func (a *ActiveQueries) list(tenantID string) []activeQueryDesc {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	queries := make([]activeQueryDesc, 0, len(a.queries))
+	for _, q := range a.queries {
+		if tenantID != "" && q.TenantID != tenantID {
+			continue
+		}
+		queries = append(queries, q.desc())
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].StartTime.Before(queries[j].StartTime)
+	})
+	return queries
+}
+
+// cancel cancels the query with the given ID, if it belongs to the tenant or if tenantID is empty.
+// It returns false if there is no such query.
+func (a *ActiveQueries) cancel(tenantID, id string) bool {
+	a.mtx.RLock()
+	q, ok := a.queries[id]
+	a.mtx.RUnlock()
+
+	if !ok || (tenantID != "" && q.TenantID != tenantID) {
+		return false
+	}
+	q.cancelled.Store(true)
+	q.cancel()
+	return true
+}
+
+// TenantHandler serves the active queries API for the tenant of the request: GET lists its queries, and
+// DELETE cancels the query given by the id parameter.
+func (a *ActiveQueries) TenantHandler(w http.ResponseWriter, r *http.Request) {
+	tenantIDs, err := tenant.TenantIDs(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.serveHTTP(w, r, tenant.JoinTenantIDs(tenantIDs))
+}
+
+// AdminHandler serves the active queries API for all tenants.
+func (a *ActiveQueries) AdminHandler(w http.ResponseWriter, r *http.Request) {
+	a.serveHTTP(w, r, "")
+}
+
+func (a *ActiveQueries) serveHTTP(w http.ResponseWriter, r *http.Request, tenantID string) {
+	switch r.Method {
+	case http.MethodGet:
+		resp := struct {
+			Queries []activeQueryDesc `json:"queries"`
+		}{
+			Queries: a.list(tenantID),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			level.Error(util_log.Logger).Log("msg", "error marshalling response", "err", err)
+			http.Error(w, fmt.Sprintf("Error marshalling response: %v", err), http.StatusInternalServerError)
+		}
+	case http.MethodDelete:
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, "missing the id of the query to cancel", http.StatusBadRequest)
+			return
+		}
+		if !a.cancel(tenantID, id) {
+			http.Error(w, "could not find an active query with the given id", http.StatusNotFound)
+			return
+		}
+		level.Info(util_log.WithContext(r.Context(), util_log.Logger)).Log("msg", "query cancelled", "id", id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,181 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+)
+
+type activeQueriesResponse struct {
+	Queries []activeQueryDesc `json:"queries"`
+}
+
+func listActiveQueries(t *testing.T, h http.HandlerFunc, tenantID string) []activeQueryDesc {
+	r := httptest.NewRequest(http.MethodGet, "/loki/api/v1/queries", nil)
+	if tenantID != "" {
+		r = r.WithContext(user.InjectOrgID(r.Context(), tenantID))
+	}
+	w := httptest.NewRecorder()
+	h(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp activeQueriesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Queries
+}
+
+func cancelActiveQuery(h http.HandlerFunc, tenantID, id string) int {
+	r := httptest.NewRequest(http.MethodDelete, "/loki/api/v1/queries?id="+id, nil)
+	if tenantID != "" {
+		r = r.WithContext(user.InjectOrgID(r.Context(), tenantID))
+	}
+	w := httptest.NewRecorder()
+	h(w, r)
+	return w.Code
+}
+
+func TestActiveQueries_ListAndCancel(t *testing.T) {
+	a := NewActiveQueries()
+
+	ctx1, done1 := a.track(context.Background(), "tenant-a", "/loki/api/v1/query_range", `{app="foo"}`)
+	defer done1()
+	ctx2, done2 := a.track(context.Background(), "tenant-b", "/loki/api/v1/query", `count_over_time({app="bar"}[1m])`)
+	defer done2()
+
+	q1 := ActiveQueryFromContext(ctx1)
+	q1.subqueryStarted()
+	q1.subqueryStarted()
+	q1.subqueryDone()
+	q1.AddBytesProcessed(42)
+
+	// Tenants only see their own queries.
+	queries := listActiveQueries(t, a.TenantHandler, "tenant-a")
+	require.Len(t, queries, 1)
+	require.Equal(t, q1.ID, queries[0].ID)
+	require.Equal(t, "tenant-a", queries[0].TenantID)
+	require.Equal(t, `{app="foo"}`, queries[0].Query)
+	require.Equal(t, int64(2), queries[0].Subqueries)
+	require.Equal(t, int64(1), queries[0].OutstandingSubqueries)
+	require.Equal(t, int64(42), queries[0].BytesProcessed)
+
+	// Admins see all queries.
+	require.Len(t, listActiveQueries(t, a.AdminHandler, ""), 2)
+
+	// Tenants can't cancel the queries of other tenants.
+	q2 := ActiveQueryFromContext(ctx2)
+	require.Equal(t, http.StatusNotFound, cancelActiveQuery(a.TenantHandler, "tenant-a", q2.ID))
+	require.NoError(t, ctx2.Err())
+
+	require.Equal(t, http.StatusNoContent, cancelActiveQuery(a.TenantHandler, "tenant-a", q1.ID))
+	require.Equal(t, context.Canceled, ctx1.Err())
+	require.True(t, q1.Cancelled())
+
+	require.Equal(t, http.StatusNoContent, cancelActiveQuery(a.AdminHandler, "", q2.ID))
+	require.Equal(t, context.Canceled, ctx2.Err())
+
+	// Queries are removed once done.
+	done1()
+	done2()
+	require.Len(t, listActiveQueries(t, a.AdminHandler, ""), 0)
+	require.Equal(t, http.StatusNotFound, cancelActiveQuery(a.AdminHandler, "", q1.ID))
+}
+
+type blockingGrpcRoundTripper struct {
+	started chan struct{}
+}
+
+func (b blockingGrpcRoundTripper) RoundTripGRPC(ctx context.Context, _ *httpgrpc.HTTPRequest) (*httpgrpc.HTTPResponse, error) {
+	b.started <- struct{}{}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestHandler_CancelActiveQuery(t *testing.T) {
+	a := NewActiveQueries()
+	rt := blockingGrpcRoundTripper{started: make(chan struct{}, 2)}
+
+	// Split the query into two subqueries.
+	adapter := AdaptGrpcRoundTripperToHTTPRoundTripper(rt)
+	split := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		errs := make(chan error, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				req := r.Clone(r.Context())
+				req.Body = http.NoBody
+				_, err := adapter.RoundTrip(req)
+				errs <- err
+			}()
+		}
+		err := <-errs
+		<-errs
+		return nil, err
+	})
+	handler := NewHandler(HandlerConfig{MaxBodySize: 1024}, split, a, log.NewNopLogger(), nil)
+
+	form := url.Values{"query": {`{app="foo"} |= "bar"`}}
+	r := httptest.NewRequest(http.MethodPost, "/loki/api/v1/query_range", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r = r.WithContext(user.InjectOrgID(r.Context(), "tenant-a"))
+	w := httptest.NewRecorder()
+
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		handler.ServeHTTP(w, r)
+	}()
+	<-rt.started
+	<-rt.started
+
+	queries := listActiveQueries(t, a.TenantHandler, "tenant-a")
+	require.Len(t, queries, 1)
+	require.Equal(t, `{app="foo"} |= "bar"`, queries[0].Query)
+	require.Equal(t, int64(2), queries[0].OutstandingSubqueries)
+
+	require.Equal(t, http.StatusNoContent, cancelActiveQuery(a.TenantHandler, "tenant-a", queries[0].ID))
+	select {
+	case <-served:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the query has not been cancelled")
+	}
+	require.Equal(t, StatusClientClosedRequest, w.Code)
+	require.Len(t, listActiveQueries(t, a.TenantHandler, "tenant-a"), 0)
+}
+
+type countingGrpcRoundTripper struct{}
+
+func (countingGrpcRoundTripper) RoundTripGRPC(ctx context.Context, _ *httpgrpc.HTTPRequest) (*httpgrpc.HTTPResponse, error) {
+	return &httpgrpc.HTTPResponse{Code: http.StatusOK}, nil
+}
+
+func TestGrpcRoundTripperAdapter_CountsOnlyQuerySubqueries(t *testing.T) {
+	a := NewActiveQueries()
+	ctx, done := a.track(user.InjectOrgID(context.Background(), "tenant-a"), "tenant-a", "/loki/api/v1/query_range", `{app="foo"}`)
+	defer done()
+	adapter := AdaptGrpcRoundTripperToHTTPRoundTripper(countingGrpcRoundTripper{})
+
+	for _, path := range []string{"/loki/api/v1/query_range", "/loki/api/v1/index/stats", "/loki/api/v1/query_range"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx)
+		_, err := adapter.RoundTrip(req)
+		require.NoError(t, err)
+	}
+
+	q := ActiveQueryFromContext(ctx)
+	require.Equal(t, int64(2), q.desc().Subqueries)
+	require.Equal(t, int64(0), q.desc().OutstandingSubqueries)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
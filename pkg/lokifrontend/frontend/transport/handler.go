@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -37,6 +38,7 @@ var (
 	errCanceled              = httpgrpc.Errorf(StatusClientClosedRequest, context.Canceled.Error())
 	errDeadlineExceeded      = httpgrpc.Errorf(http.StatusGatewayTimeout, context.DeadlineExceeded.Error())
 	errRequestEntityTooLarge = httpgrpc.Errorf(http.StatusRequestEntityTooLarge, "http: request body too large")
+	errQueryCancelled        = httpgrpc.Errorf(StatusClientClosedRequest, "the query has been cancelled by an operator")
 )
 
 // Config for a Handler.
@@ -55,9 +57,10 @@ func (cfg *HandlerConfig) RegisterFlags(f *flag.FlagSet) {
 // Handler accepts queries and forwards them to RoundTripper. It can log slow queries,
 // but all other logic is inside the RoundTripper.
 type Handler struct {
-	cfg          HandlerConfig
-	log          log.Logger
-	roundTripper http.RoundTripper
+	cfg           HandlerConfig
+	log           log.Logger
+	roundTripper  http.RoundTripper
+	activeQueries *ActiveQueries
 
 	// Metrics.
 	querySeconds *prometheus.CounterVec
@@ -66,12 +69,13 @@ type Handler struct {
 	activeUsers  *util.ActiveUsersCleanupService
 }
 
-// NewHandler creates a new frontend handler.
-func NewHandler(cfg HandlerConfig, roundTripper http.RoundTripper, log log.Logger, reg prometheus.Registerer) http.Handler {
+// NewHandler creates a new frontend handler. Queries are tracked in activeQueries, if not nil.
+func NewHandler(cfg HandlerConfig, roundTripper http.RoundTripper, activeQueries *ActiveQueries, log log.Logger, reg prometheus.Registerer) http.Handler {
 	h := &Handler{
-		cfg:          cfg,
-		log:          log,
-		roundTripper: roundTripper,
+		cfg:           cfg,
+		log:           log,
+		roundTripper:  roundTripper,
+		activeQueries: activeQueries,
 	}
 
 	if cfg.QueryStatsEnabled {
@@ -125,11 +129,32 @@ func (f *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	r.Body = http.MaxBytesReader(w, r.Body, f.cfg.MaxBodySize)
 	r.Body = ioutil.NopCloser(io.TeeReader(r.Body, &buf))
 
+	var activeQuery *ActiveQuery
+	if f.activeQueries != nil {
+		tenantIDs, err := tenant.TenantIDs(r.Context())
+		if err != nil {
+			writeError(w, httpgrpc.Errorf(http.StatusBadRequest, err.Error()))
+			return
+		}
+		query, err := requestQuery(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		ctx, done := f.activeQueries.track(r.Context(), tenant.JoinTenantIDs(tenantIDs), r.URL.Path, query)
+		defer done()
+		r = r.WithContext(ctx)
+		activeQuery = ActiveQueryFromContext(ctx)
+	}
+
 	startTime := time.Now()
 	resp, err := f.roundTripper.RoundTrip(r)
 	queryResponseTime := time.Since(startTime)
 
 	if err != nil {
+		if activeQuery != nil && activeQuery.Cancelled() {
+			err = errQueryCancelled
+		}
 		writeError(w, err)
 		return
 	}
@@ -219,6 +244,24 @@ func (f *Handler) parseRequestQueryString(r *http.Request, bodyBuf bytes.Buffer)
 	return r.Form
 }
 
+// requestQuery returns the query parameter of the request. The body of form requests is read, and replaced
+// so that it can still be read by the round tripper.
+func requestQuery(r *http.Request) (string, error) {
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); r.Method == http.MethodPost && err == nil && mediaType == "application/x-www-form-urlencoded" {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return "", err
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		values, err := url.ParseQuery(string(body))
+		if err == nil && values.Get("query") != "" {
+			return values.Get("query"), nil
+		}
+	}
+	return r.URL.Query().Get("query"), nil
+}
+
 func formatQueryString(queryString url.Values) (fields []interface{}) {
 	for k, v := range queryString {
 		fields = append(fields, fmt.Sprintf("param_%s", k), strings.Join(v, ","))
@@ -11,6 +11,8 @@ import (
 	"github.com/weaveworks/common/httpgrpc/server"
 )
 
+const indexStatsPath = "/loki/api/v1/index/stats"
+
 // GrpcRoundTripper is similar to http.RoundTripper, but works with HTTP requests converted to protobuf messages.
 type GrpcRoundTripper interface {
 	RoundTripGRPC(context.Context, *httpgrpc.HTTPRequest) (*httpgrpc.HTTPResponse, error)
@@ -40,6 +42,14 @@ func (a *grpcRoundTripperAdapter) RoundTrip(r *http.Request) (*http.Response, er
 		return nil, err
 	}
 
+	// Each query request sent to the frontend is a subquery of the active query, if any. The index stats
+	// requests estimating the bytes read by the query are not.
+	if r.URL.Path != indexStatsPath {
+		activeQuery := ActiveQueryFromContext(r.Context())
+		activeQuery.subqueryStarted()
+		defer activeQuery.subqueryDone()
+	}
+
 	resp, err := a.roundTripper.RoundTripGRPC(r.Context(), req)
 	if err != nil {
 		return nil, err
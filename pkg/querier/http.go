@@ -16,9 +16,11 @@ import (
 
 	"github.com/grafana/loki/pkg/loghttp"
 	loghttp_legacy "github.com/grafana/loki/pkg/loghttp/legacy"
+	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
 	"github.com/grafana/loki/pkg/logql/syntax"
 	"github.com/grafana/loki/pkg/logqlmodel"
+	"github.com/grafana/loki/pkg/querier/queryrange"
 	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/util/marshal"
 	marshal_legacy "github.com/grafana/loki/pkg/util/marshal/legacy"
@@ -67,7 +69,7 @@ func (q *QuerierAPI) RangeQueryHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := q.validateEntriesLimits(ctx, request.Query, request.Limit); err != nil {
+	if err := q.validateEntriesLimits(ctx, request.Query, request.Limit, request.Shards); err != nil {
 		serverutil.WriteError(err, w)
 		return
 	}
@@ -88,7 +90,7 @@ func (q *QuerierAPI) RangeQueryHandler(w http.ResponseWriter, r *http.Request) {
 		serverutil.WriteError(err, w)
 		return
 	}
-	if err := marshal.WriteQueryResponseJSON(result, w); err != nil {
+	if err := writeQueryResponse(result, w); err != nil {
 		serverutil.WriteError(err, w)
 		return
 	}
@@ -106,7 +108,7 @@ func (q *QuerierAPI) InstantQueryHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := q.validateEntriesLimits(ctx, request.Query, request.Limit); err != nil {
+	if err := q.validateEntriesLimits(ctx, request.Query, request.Limit, request.Shards); err != nil {
 		serverutil.WriteError(err, w)
 		return
 	}
@@ -128,12 +130,26 @@ func (q *QuerierAPI) InstantQueryHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := marshal.WriteQueryResponseJSON(result, w); err != nil {
+	if err := writeQueryResponse(result, w); err != nil {
 		serverutil.WriteError(err, w)
 		return
 	}
 }
 
+// writeQueryResponse writes the result of a query. Sketches, only returned to
+// the frontend for sharded quantile_over_time and approx_topk queries, cannot
+// be represented in JSON and are encoded with protobuf instead.
+func writeQueryResponse(result logqlmodel.Result, w http.ResponseWriter) error {
+	switch data := result.Data.(type) {
+	case logql.QuantileSketchMatrix:
+		return queryrange.WriteQuantileSketchResponse(w, data, result.Statistics)
+	case logql.CountMinSketchVector:
+		return queryrange.WriteCountMinSketchResponse(w, data, result.Statistics)
+	default:
+		return marshal.WriteQueryResponseJSON(result, w)
+	}
+}
+
 // LogQueryHandler is a http.HandlerFunc for log only queries.
 func (q *QuerierAPI) LogQueryHandler(w http.ResponseWriter, r *http.Request) {
 	// Enforce the query timeout while querying backends
@@ -163,7 +179,7 @@ func (q *QuerierAPI) LogQueryHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := q.validateEntriesLimits(ctx, request.Query, request.Limit); err != nil {
+	if err := q.validateEntriesLimits(ctx, request.Query, request.Limit, nil); err != nil {
 		serverutil.WriteError(err, w)
 		return
 	}
@@ -236,12 +252,13 @@ func (q *QuerierAPI) TailHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tenantID, err := tenant.TenantID(r.Context())
+	tenantIDs, err := tenant.TenantIDs(r.Context())
 	if err != nil {
 		level.Warn(logger).Log("msg", "error getting tenant id", "err", err)
 		serverutil.WriteError(httpgrpc.Errorf(http.StatusBadRequest, err.Error()), w)
 		return
 	}
+	tenantID := tenant.JoinTenantIDs(tenantIDs)
 
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -363,6 +380,50 @@ func (q *QuerierAPI) SeriesHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// IndexStatsHandler queries the index for the data statistics related to a query
+func (q *QuerierAPI) IndexStatsHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := loghttp.ParseIndexStatsQuery(r)
+	if err != nil {
+		serverutil.WriteError(httpgrpc.Errorf(http.StatusBadRequest, err.Error()), w)
+		return
+	}
+
+	resp, err := q.querier.IndexStats(r.Context(), req)
+	if err != nil {
+		serverutil.WriteError(err, w)
+		return
+	}
+
+	err = marshal.WriteIndexStatsResponseJSON(resp, w)
+	if err != nil {
+		serverutil.WriteError(err, w)
+		return
+	}
+}
+
+// VolumeHandler queries the index for the volume of the streams matching a query, aggregated by series or target labels
+func (q *QuerierAPI) VolumeHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := loghttp.ParseVolumeQuery(r)
+	if err != nil {
+		serverutil.WriteError(httpgrpc.Errorf(http.StatusBadRequest, err.Error()), w)
+		return
+	}
+
+	resp, err := q.querier.Volume(r.Context(), req)
+	if err != nil {
+		serverutil.WriteError(err, w)
+		return
+	}
+	// Only keep the largest volumes once the volumes of all the sources are summed.
+	resp.Volumes = logproto.TopVolumes(resp.Volumes, req.Limit)
+
+	err = marshal.WriteVolumeResponseJSON(resp, w)
+	if err != nil {
+		serverutil.WriteError(err, w)
+		return
+	}
+}
+
 // parseRegexQuery parses regex and query querystring from httpRequest and returns the combined LogQL query.
 // This is used only to keep regexp query string support until it gets fully deprecated.
 func parseRegexQuery(httpRequest *http.Request) (string, error) {
@@ -382,13 +443,18 @@ func parseRegexQuery(httpRequest *http.Request) (string, error) {
 	return query, nil
 }
 
-func (q *QuerierAPI) validateEntriesLimits(ctx context.Context, query string, limit uint32) error {
+func (q *QuerierAPI) validateEntriesLimits(ctx context.Context, query string, limit uint32, shards []string) error {
 	tenantIDs, err := tenant.TenantIDs(ctx)
 	if err != nil {
 		return httpgrpc.Errorf(http.StatusBadRequest, err.Error())
 	}
 
-	expr, err := syntax.ParseExpr(query)
+	parse := syntax.ParseExpr
+	if len(shards) > 0 {
+		// sharded queries are downstream queries of the query frontend.
+		parse = syntax.ParseDownstreamExpr
+	}
+	expr, err := parse(query)
 	if err != nil {
 		return err
 	}
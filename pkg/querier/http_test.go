@@ -3,6 +3,7 @@ package querier
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/go-kit/log"
@@ -23,8 +24,6 @@ func TestTailHandler(t *testing.T) {
 	api := NewQuerierAPI(mockQuerierConfig(), nil, limits, log.NewNopLogger())
 
 	req, err := http.NewRequest("GET", "/", nil)
-	ctx := user.InjectOrgID(req.Context(), "1|2")
-	req = req.WithContext(ctx)
 	require.NoError(t, err)
 
 	rr := httptest.NewRecorder()
@@ -32,5 +31,44 @@ func TestTailHandler(t *testing.T) {
 
 	handler.ServeHTTP(rr, req)
 	require.Equal(t, http.StatusBadRequest, rr.Code)
-	require.Equal(t, "multiple org IDs present\n", rr.Body.String())
+	require.Equal(t, "no org id\n", rr.Body.String())
+
+	// Multiple tenants are tailed by the multi-tenant querier, the request is
+	// only rejected here because it is not a websocket handshake.
+	req = req.WithContext(user.InjectOrgID(req.Context(), "1|2"))
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.NotContains(t, rr.Body.String(), "multiple org IDs present")
+}
+
+func TestQueryHandlers_RejectDownstreamOperations(t *testing.T) {
+	defaultLimits := defaultLimitsTestConfig()
+	limits, err := validation.NewOverrides(defaultLimits, nil)
+	require.NoError(t, err)
+
+	api := NewQuerierAPI(mockQuerierConfig(), nil, limits, log.NewNopLogger())
+
+	for _, query := range []string{
+		`quantile_sketch_over_time({app="foo"} | unwrap latency [5m])`,
+		`count_min_sketch(10, sum by (client_ip) (count_over_time({app="foo"} | json [5m])))`,
+	} {
+		for path, handler := range map[string]http.HandlerFunc{
+			"/loki/api/v1/query_range": api.RangeQueryHandler,
+			"/loki/api/v1/query":       api.InstantQueryHandler,
+		} {
+			t.Run(path+" "+query, func(t *testing.T) {
+				params := url.Values{"query": []string{query}, "start": []string{"0"}, "end": []string{"3600000000000"}}
+				req, err := http.NewRequest("GET", path+"?"+params.Encode(), nil)
+				require.NoError(t, err)
+				req = req.WithContext(user.InjectOrgID(req.Context(), "1"))
+
+				// the operations are only accepted in the sharded downstream queries of the frontend.
+				rr := httptest.NewRecorder()
+				handler.ServeHTTP(rr, req)
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.NotEqual(t, "application/vnd.google.protobuf", rr.Header().Get("Content-Type"))
+			})
+		}
+	}
 }
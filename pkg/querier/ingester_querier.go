@@ -147,6 +147,38 @@ func (q *IngesterQuerier) Label(ctx context.Context, req *logproto.LabelRequest)
 	return results, nil
 }
 
+func (q *IngesterQuerier) Stats(ctx context.Context, req *logproto.IndexStatsRequest) ([]*logproto.IndexStatsResponse, error) {
+	resps, err := q.forAllIngesters(ctx, func(client logproto.QuerierClient) (interface{}, error) {
+		return client.GetStats(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*logproto.IndexStatsResponse, 0, len(resps))
+	for _, resp := range resps {
+		results = append(results, resp.response.(*logproto.IndexStatsResponse))
+	}
+
+	return results, nil
+}
+
+func (q *IngesterQuerier) Volume(ctx context.Context, req *logproto.VolumeRequest) ([]*logproto.VolumeResponse, error) {
+	resps, err := q.forAllIngesters(ctx, func(client logproto.QuerierClient) (interface{}, error) {
+		return client.GetVolume(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*logproto.VolumeResponse, 0, len(resps))
+	for _, resp := range resps {
+		results = append(results, resp.response.(*logproto.VolumeResponse))
+	}
+
+	return results, nil
+}
+
 func (q *IngesterQuerier) Tail(ctx context.Context, req *logproto.TailRequest) (map[string]logproto.Querier_TailClient, error) {
 	resps, err := q.forAllIngesters(ctx, func(client logproto.QuerierClient) (interface{}, error) {
 		return client.Tail(ctx, req)
@@ -2,9 +2,12 @@ package querier
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/user"
 
 	"github.com/grafana/dskit/tenant"
@@ -13,6 +16,7 @@ import (
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
 	"github.com/grafana/loki/pkg/logql/syntax"
+	util_log "github.com/grafana/loki/pkg/util/log"
 )
 
 const (
@@ -101,23 +105,50 @@ func (q *MultiTenantQuerier) Label(ctx context.Context, req *logproto.LabelReque
 		return nil, err
 	}
 
-	if req.Values && req.Name == defaultTenantLabel {
-		return &logproto.LabelResponse{Values: tenantIDs}, nil
+	if len(tenantIDs) == 1 && !(req.Values && req.Name == defaultTenantLabel) {
+		return q.Querier.Label(ctx, req)
 	}
 
-	if len(tenantIDs) == 1 {
-		return q.Querier.Label(ctx, req)
+	matchedTenants := sliceToSet(tenantIDs)
+	if req.Query != "" {
+		matchers, err := syntax.ParseMatchers(req.Query)
+		if err != nil {
+			return nil, err
+		}
+		var filteredMatchers []*labels.Matcher
+		matchedTenants, filteredMatchers = filterValuesByMatchers(defaultTenantLabel, tenantIDs, matchers...)
+
+		// Copy the request so the tenant matchers are not forwarded to the single tenant querier.
+		updatedReq := *req
+		updatedReq.Query = ""
+		if len(filteredMatchers) > 0 {
+			updatedReq.Query = (&syntax.MatchersExpr{Mts: filteredMatchers}).String()
+		}
+		req = &updatedReq
 	}
 
-	responses := make([]*logproto.LabelResponse, len(tenantIDs))
-	for i, id := range tenantIDs {
+	if req.Values && req.Name == defaultTenantLabel {
+		values := make([]string, 0, len(matchedTenants))
+		for _, id := range tenantIDs {
+			if _, ok := matchedTenants[id]; ok {
+				values = append(values, id)
+			}
+		}
+		return &logproto.LabelResponse{Values: values}, nil
+	}
+
+	responses := make([]*logproto.LabelResponse, 0, len(matchedTenants))
+	for _, id := range tenantIDs {
+		if _, ok := matchedTenants[id]; !ok {
+			continue
+		}
 		singleContext := user.InjectOrgID(ctx, id)
 		resp, err := q.Querier.Label(singleContext, req)
 		if err != nil {
 			return nil, err
 		}
 
-		responses[i] = resp
+		responses = append(responses, resp)
 	}
 
 	// Append tenant ID label name if label names are requested.
@@ -158,6 +189,122 @@ func (q *MultiTenantQuerier) Series(ctx context.Context, req *logproto.SeriesReq
 	return logproto.MergeSeriesResponses(responses)
 }
 
+func (q *MultiTenantQuerier) IndexStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tenantIDs) == 1 {
+		return q.Querier.IndexStats(ctx, req)
+	}
+
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	matchedTenants, filteredMatchers := filterValuesByMatchers(defaultTenantLabel, tenantIDs, matchers...)
+
+	// Copy the request so the tenant matchers are not forwarded to the single tenant querier.
+	updatedReq := *req
+	updatedReq.Matchers = (&syntax.MatchersExpr{Mts: filteredMatchers}).String()
+
+	responses := make([]*logproto.IndexStatsResponse, 0, len(matchedTenants))
+	for _, id := range tenantIDs {
+		if _, ok := matchedTenants[id]; !ok {
+			continue
+		}
+		singleContext := user.InjectOrgID(ctx, id)
+		resp, err := q.Querier.IndexStats(singleContext, &updatedReq)
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, resp)
+	}
+
+	return logproto.MergeIndexStatsResponses(responses)
+}
+
+func (q *MultiTenantQuerier) Volume(ctx context.Context, req *logproto.VolumeRequest) (*logproto.VolumeResponse, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tenantIDs) == 1 {
+		return q.Querier.Volume(ctx, req)
+	}
+
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	matchedTenants, filteredMatchers := filterValuesByMatchers(defaultTenantLabel, tenantIDs, matchers...)
+
+	// Copy the request so the tenant matchers are not forwarded to the single tenant querier.
+	updatedReq := *req
+	updatedReq.Matchers = (&syntax.MatchersExpr{Mts: filteredMatchers}).String()
+
+	responses := make([]*logproto.VolumeResponse, 0, len(matchedTenants))
+	for _, id := range tenantIDs {
+		if _, ok := matchedTenants[id]; !ok {
+			continue
+		}
+		singleContext := user.InjectOrgID(ctx, id)
+		resp, err := q.Querier.Volume(singleContext, &updatedReq)
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, resp)
+	}
+
+	return logproto.MergeVolumeResponses(responses)
+}
+
+// Tail tails the logs of every tenant matched by the selector. Each tenant is
+// tailed by its own tailer, so the concurrent tail requests limit applies per
+// tenant.
+func (q *MultiTenantQuerier) Tail(ctx context.Context, req *logproto.TailRequest) (*Tailer, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tenantIDs) == 1 {
+		return q.Querier.Tail(ctx, req)
+	}
+
+	selector, err := syntax.ParseLogSelector(req.Query, true)
+	if err != nil {
+		return nil, err
+	}
+	matchedTenants, filteredMatchers := filterValuesByMatchers(defaultTenantLabel, tenantIDs, selector.Matchers()...)
+	if len(matchedTenants) == 0 {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, "the tail selector does not match any tenant")
+	}
+
+	// Copy the request so the tenant matchers are not forwarded to the single tenant querier.
+	updatedReq := *req
+	updatedReq.Query = replaceMatchers(selector, filteredMatchers).String()
+
+	tailers := make(map[string]*Tailer, len(matchedTenants))
+	for id := range matchedTenants {
+		singleContext := user.InjectOrgID(ctx, id)
+		tailer, err := q.Querier.Tail(singleContext, &updatedReq)
+		if err != nil {
+			if closeErr := closeTailers(tailers); closeErr != nil {
+				level.Error(util_log.Logger).Log("msg", "Error closing Tailer", "err", closeErr)
+			}
+			return nil, err
+		}
+
+		tailers[id] = tailer
+	}
+	return newMultiTenantTailer(tailers), nil
+}
+
 // removeTenantSelector filters the given tenant IDs based on any tenant ID filter the in passed selector.
 func removeTenantSelector(params logql.SelectSampleParams, tenantIDs []string) (map[string]struct{}, syntax.Expr, error) {
 	expr, err := params.Expr()
@@ -2,6 +2,7 @@ package querier
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -276,12 +277,13 @@ func TestMultiTenantQuerier_Label(t *testing.T) {
 	start := time.Unix(0, 0)
 	end := time.Unix(10, 0)
 
-	mockLabelRequest := func(name string) *logproto.LabelRequest {
+	mockLabelRequest := func(name, query string) *logproto.LabelRequest {
 		return &logproto.LabelRequest{
 			Name:   name,
 			Values: name != "",
 			Start:  &start,
 			End:    &end,
+			Query:  query,
 		}
 	}
 
@@ -290,6 +292,7 @@ func TestMultiTenantQuerier_Label(t *testing.T) {
 	for _, tc := range []struct {
 		desc           string
 		name           string
+		query          string
 		orgID          string
 		expectedLabels []string
 	}{
@@ -329,6 +332,20 @@ func TestMultiTenantQuerier_Label(t *testing.T) {
 			orgID:          "1",
 			expectedLabels: []string{"test"},
 		},
+		{
+			desc:           "defaultTenantLabel label request filtered by query",
+			name:           defaultTenantLabel,
+			query:          `{__tenant_id__="2", app="foo"}`,
+			orgID:          "1|2",
+			expectedLabels: []string{"2"},
+		},
+		{
+			desc:           "label request filtered by query matching no tenant",
+			name:           "test",
+			query:          `{__tenant_id__="3"}`,
+			orgID:          "1|2",
+			expectedLabels: nil,
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			querier := newQuerierMock()
@@ -336,7 +353,7 @@ func TestMultiTenantQuerier_Label(t *testing.T) {
 			multiTenantQuerier := NewMultiTenantQuerier(querier, log.NewNopLogger())
 			ctx := user.InjectOrgID(context.Background(), tc.orgID)
 
-			resp, err := multiTenantQuerier.Label(ctx, mockLabelRequest(tc.name))
+			resp, err := multiTenantQuerier.Label(ctx, mockLabelRequest(tc.name, tc.query))
 			require.NoError(t, err)
 			require.Equal(t, tc.expectedLabels, resp.GetValues())
 		})
@@ -407,6 +424,105 @@ func TestMultiTenantQuerierSeries(t *testing.T) {
 	}
 }
 
+func TestMultiTenantQuerier_Tail(t *testing.T) {
+	tenant.WithDefaultResolver(tenant.NewMultiResolver())
+
+	for _, tc := range []struct {
+		desc          string
+		orgID         string
+		query         string
+		expQuery      string
+		expLabels     []string
+		expTenants    []string
+		failingTenant string
+	}{
+		{
+			desc:       "two tenants",
+			orgID:      "1|2",
+			query:      `{type="test"}`,
+			expQuery:   `{type="test"}`,
+			expLabels:  []string{`{__tenant_id__="1", type="test"}`, `{__tenant_id__="2", type="test"}`},
+			expTenants: []string{"1", "2"},
+		},
+		{
+			desc:       "two tenants with selector",
+			orgID:      "1|2",
+			query:      `{type="test", __tenant_id__="2"} |= "line"`,
+			expQuery:   `{type="test"} |= "line"`,
+			expLabels:  []string{`{__tenant_id__="2", type="test"}`},
+			expTenants: []string{"2"},
+		},
+		{
+			desc:       "one tenant",
+			orgID:      "1",
+			query:      `{type="test"}`,
+			expQuery:   `{type="test"}`,
+			expLabels:  []string{`{type="test"}`},
+			expTenants: []string{"1"},
+		},
+		{
+			desc:          "limit reached for one tenant",
+			orgID:         "1|2",
+			query:         `{type="test"}`,
+			failingTenant: "2",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var tailers []*Tailer
+			querier := newQuerierMock()
+			querier.On("Tail", mock.Anything, mock.Anything).Return(func() *Tailer { return nil }, nil)
+			querier.ExpectedCalls[0].RunFn = func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				id, err := user.ExtractOrgID(ctx)
+				require.NoError(t, err)
+
+				call := querier.ExpectedCalls[0]
+				if id == tc.failingTenant {
+					call.ReturnArguments = mock.Arguments{func() *Tailer { return nil }, errors.New("max concurrent tail requests limit exceeded")}
+					return
+				}
+				tailer := newTailer(0, nil, mockStreamIterator(1, 1), func([]string) (map[string]logproto.Querier_TailClient, error) {
+					return map[string]logproto.Querier_TailClient{}, nil
+				}, timeout, throttle)
+				tailers = append(tailers, tailer)
+				call.ReturnArguments = mock.Arguments{func() *Tailer { return tailer }, nil}
+			}
+
+			multiTenantQuerier := NewMultiTenantQuerier(querier, log.NewNopLogger())
+			ctx := user.InjectOrgID(context.Background(), tc.orgID)
+
+			tailer, err := multiTenantQuerier.Tail(ctx, &logproto.TailRequest{Query: tc.query, Limit: 10})
+			if tc.failingTenant != "" {
+				require.Error(t, err)
+				// The tailers of the other tenants are closed.
+				for _, tailer := range tailers {
+					require.True(t, tailer.stopped)
+				}
+				return
+			}
+			require.NoError(t, err)
+			defer tailer.close()
+
+			var tenants []string
+			for _, call := range querier.Calls {
+				id, err := user.ExtractOrgID(call.Arguments.Get(0).(context.Context))
+				require.NoError(t, err)
+				tenants = append(tenants, id)
+				require.Equal(t, tc.expQuery, call.Arguments.Get(1).(*logproto.TailRequest).Query)
+			}
+			require.ElementsMatch(t, tc.expTenants, tenants)
+
+			responses, err := readFromTailer(tailer, len(tc.expLabels))
+			require.NoError(t, err)
+			var labels []string
+			for _, s := range flattenStreamsFromResponses(responses) {
+				labels = append(labels, s.Labels)
+			}
+			require.ElementsMatch(t, tc.expLabels, labels)
+		})
+	}
+}
+
 func mockSeriesRequest() *logproto.SeriesRequest {
 	return &logproto.SeriesRequest{
 		Start: time.Unix(0, 0),
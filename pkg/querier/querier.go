@@ -11,6 +11,7 @@ import (
 	"github.com/go-kit/log/level"
 	"github.com/pkg/errors"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/weaveworks/common/httpgrpc"
 	"google.golang.org/grpc/health/grpc_health_v1"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/grafana/loki/pkg/loghttp"
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
+	"github.com/grafana/loki/pkg/logql/syntax"
 	"github.com/grafana/loki/pkg/storage"
 	listutil "github.com/grafana/loki/pkg/util"
 	"github.com/grafana/loki/pkg/util/spanlogger"
@@ -81,6 +83,8 @@ type Querier interface {
 	Label(ctx context.Context, req *logproto.LabelRequest) (*logproto.LabelResponse, error)
 	Series(ctx context.Context, req *logproto.SeriesRequest) (*logproto.SeriesResponse, error)
 	Tail(ctx context.Context, req *logproto.TailRequest) (*Tailer, error)
+	IndexStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error)
+	Volume(ctx context.Context, req *logproto.VolumeRequest) (*logproto.VolumeResponse, error)
 }
 
 // SingleTenantQuerier handles single tenant queries.
@@ -323,6 +327,14 @@ func (q *SingleTenantQuerier) Label(ctx context.Context, req *logproto.LabelRequ
 		return nil, err
 	}
 
+	var matchers []*labels.Matcher
+	if req.Query != "" {
+		matchers, err = syntax.ParseMatchers(req.Query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Enforce the query timeout while querying backends
 	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(q.cfg.QueryTimeout))
 	defer cancel()
@@ -339,7 +351,7 @@ func (q *SingleTenantQuerier) Label(ctx context.Context, req *logproto.LabelRequ
 	if !q.cfg.QueryIngesterOnly {
 		from, through := model.TimeFromUnixNano(req.Start.UnixNano()), model.TimeFromUnixNano(req.End.UnixNano())
 		if req.Values {
-			storeValues, err = q.store.LabelValuesForMetricName(ctx, userID, from, through, "logs", req.Name)
+			storeValues, err = q.store.LabelValuesForMetricName(ctx, userID, from, through, "logs", req.Name, matchers...)
 			if err != nil {
 				return nil, err
 			}
@@ -357,6 +369,91 @@ func (q *SingleTenantQuerier) Label(ctx context.Context, req *logproto.LabelRequ
 	}, nil
 }
 
+// IndexStats returns the number of streams, chunks, entries and bytes matching the selector,
+// combining the in-memory streams of the ingesters with the index store.
+func (q *SingleTenantQuerier) IndexStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := validateQueryTimeRangeLimits(ctx, userID, q.limits, req.From.Time(), req.Through.Time())
+	if err != nil {
+		return nil, err
+	}
+	req.From, req.Through = model.TimeFromUnixNano(start.UnixNano()), model.TimeFromUnixNano(end.UnixNano())
+
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Enforce the query timeout while querying backends
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(q.cfg.QueryTimeout))
+	defer cancel()
+
+	var responses []*logproto.IndexStatsResponse
+	if !q.cfg.QueryStoreOnly {
+		responses, err = q.ingesterQuerier.Stats(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !q.cfg.QueryIngesterOnly {
+		storeStats, err := q.store.Stats(ctx, userID, req.From, req.Through, matchers...)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, storeStats)
+	}
+
+	return logproto.MergeIndexStatsResponses(responses)
+}
+
+// Volume returns the volumes of the streams matching the selector, aggregated by series or target labels,
+// combining the in-memory streams of the ingesters with the index store.
+// All the volumes are returned, largest first: they are limited by the API handler.
+func (q *SingleTenantQuerier) Volume(ctx context.Context, req *logproto.VolumeRequest) (*logproto.VolumeResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := validateQueryTimeRangeLimits(ctx, userID, q.limits, req.From.Time(), req.Through.Time())
+	if err != nil {
+		return nil, err
+	}
+	req.From, req.Through = model.TimeFromUnixNano(start.UnixNano()), model.TimeFromUnixNano(end.UnixNano())
+
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Enforce the query timeout while querying backends
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(q.cfg.QueryTimeout))
+	defer cancel()
+
+	var responses []*logproto.VolumeResponse
+	if !q.cfg.QueryStoreOnly {
+		responses, err = q.ingesterQuerier.Volume(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !q.cfg.QueryIngesterOnly {
+		storeVolumes, err := q.store.Volume(ctx, userID, req.From, req.Through, req.Limit, req.TargetLabels, matchers...)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, storeVolumes)
+	}
+
+	return logproto.MergeVolumeResponses(responses)
+}
+
 // Check implements the grpc healthcheck
 func (*SingleTenantQuerier) Check(_ context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
 	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
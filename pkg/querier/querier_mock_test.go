@@ -68,6 +68,16 @@ func (c *querierClientMock) Series(ctx context.Context, in *logproto.SeriesReque
 	return res.(*logproto.SeriesResponse), args.Error(1)
 }
 
+func (c *querierClientMock) GetStats(ctx context.Context, in *logproto.IndexStatsRequest, opts ...grpc.CallOption) (*logproto.IndexStatsResponse, error) {
+	args := c.Called(ctx, in, opts)
+	return args.Get(0).(*logproto.IndexStatsResponse), args.Error(1)
+}
+
+func (c *querierClientMock) GetVolume(ctx context.Context, in *logproto.VolumeRequest, opts ...grpc.CallOption) (*logproto.VolumeResponse, error) {
+	args := c.Called(ctx, in, opts)
+	return args.Get(0).(*logproto.VolumeResponse), args.Error(1)
+}
+
 func (c *querierClientMock) TailersCount(ctx context.Context, in *logproto.TailersCountRequest, opts ...grpc.CallOption) (*logproto.TailersCountResponse, error) {
 	args := c.Called(ctx, in, opts)
 	return args.Get(0).(*logproto.TailersCountResponse), args.Error(1)
@@ -299,6 +309,22 @@ func (s *storeMock) LabelNamesForMetricName(ctx context.Context, userID string,
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (s *storeMock) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	args := s.Called(ctx, userID, from, through, matchers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*logproto.IndexStatsResponse), args.Error(1)
+}
+
+func (s *storeMock) Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error) {
+	args := s.Called(ctx, userID, from, through, limit, targetLabels, matchers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*logproto.VolumeResponse), args.Error(1)
+}
+
 func (s *storeMock) GetChunkFetcher(_ model.Time) *fetcher.Fetcher {
 	panic("don't call me please")
 }
@@ -479,5 +505,16 @@ func (q *querierMock) Series(ctx context.Context, req *logproto.SeriesRequest) (
 }
 
 func (q *querierMock) Tail(ctx context.Context, req *logproto.TailRequest) (*Tailer, error) {
-	return nil, errors.New("querierMock.Tail() has not been mocked")
+	args := q.Called(ctx, req)
+	return args.Get(0).(func() *Tailer)(), args.Error(1)
+}
+
+func (q *querierMock) IndexStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	args := q.Called(ctx, req)
+	return args.Get(0).(*logproto.IndexStatsResponse), args.Error(1)
+}
+
+func (q *querierMock) Volume(ctx context.Context, req *logproto.VolumeRequest) (*logproto.VolumeResponse, error) {
+	args := q.Called(ctx, req)
+	return args.Get(0).(*logproto.VolumeResponse), args.Error(1)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/dskit/ring"
 	ring_client "github.com/grafana/dskit/ring/client"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -80,6 +81,96 @@ func TestQuerier_Label_QueryTimeoutConfigFlag(t *testing.T) {
 	store.AssertExpectations(t)
 }
 
+func TestQuerier_IndexStats(t *testing.T) {
+	through := model.Now()
+	from := through.Add(-time.Hour)
+
+	request := logproto.IndexStatsRequest{
+		From:     from,
+		Through:  through,
+		Matchers: `{app="foo"}`,
+	}
+
+	ingesterClient := newQuerierClientMock()
+	ingesterClient.On("GetStats", mock.Anything, &request, mock.Anything).Return(&logproto.IndexStatsResponse{Streams: 1, Chunks: 1, Bytes: 10, Entries: 2}, nil)
+
+	store := newStoreMock()
+	store.On("Stats", mock.Anything, "test", from, through, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "app", "foo")}).
+		Return(&logproto.IndexStatsResponse{Streams: 2, Chunks: 4, Bytes: 100, Entries: 20}, nil)
+
+	limits, err := validation.NewOverrides(defaultLimitsTestConfig(), nil)
+	require.NoError(t, err)
+
+	q, err := newQuerier(
+		mockQuerierConfig(),
+		mockIngesterClientConfig(),
+		newIngesterClientMockFactory(ingesterClient),
+		mockReadRingWithOneActiveIngester(),
+		&mockDeleteGettter{},
+		store, limits)
+	require.NoError(t, err)
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+	resp, err := q.IndexStats(ctx, &request)
+	require.NoError(t, err)
+	require.Equal(t, &logproto.IndexStatsResponse{Streams: 3, Chunks: 5, Bytes: 110, Entries: 22}, resp)
+
+	store.AssertExpectations(t)
+}
+
+func TestQuerier_Volume(t *testing.T) {
+	through := model.Now()
+	from := through.Add(-time.Hour)
+
+	request := logproto.VolumeRequest{
+		From:         from,
+		Through:      through,
+		Matchers:     `{app="foo"}`,
+		Limit:        2,
+		TargetLabels: []string{"namespace"},
+	}
+
+	ingesterClient := newQuerierClientMock()
+	ingesterClient.On("GetVolume", mock.Anything, &request, mock.Anything).Return(&logproto.VolumeResponse{
+		Volumes: []logproto.Volume{{Name: `{namespace="a"}`, Bytes: 10, Entries: 1}, {Name: `{namespace="b"}`, Bytes: 5, Entries: 1}},
+		Limit:   2,
+	}, nil)
+
+	store := newStoreMock()
+	store.On("Volume", mock.Anything, "test", from, through, int32(2), []string{"namespace"}, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "app", "foo")}).
+		Return(&logproto.VolumeResponse{
+			Volumes: []logproto.Volume{{Name: `{namespace="c"}`, Bytes: 8, Entries: 4}, {Name: `{namespace="b"}`, Bytes: 4, Entries: 2}},
+			Limit:   2,
+		}, nil)
+
+	limits, err := validation.NewOverrides(defaultLimitsTestConfig(), nil)
+	require.NoError(t, err)
+
+	q, err := newQuerier(
+		mockQuerierConfig(),
+		mockIngesterClientConfig(),
+		newIngesterClientMockFactory(ingesterClient),
+		mockReadRingWithOneActiveIngester(),
+		&mockDeleteGettter{},
+		store, limits)
+	require.NoError(t, err)
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+	resp, err := q.Volume(ctx, &request)
+	require.NoError(t, err)
+	// all the volumes are returned, largest first: the limit is applied by the API handler.
+	require.Equal(t, &logproto.VolumeResponse{
+		Volumes: []logproto.Volume{
+			{Name: `{namespace="a"}`, Bytes: 10, Entries: 1},
+			{Name: `{namespace="b"}`, Bytes: 9, Entries: 3},
+			{Name: `{namespace="c"}`, Bytes: 8, Entries: 4},
+		},
+		Limit: 2,
+	}, resp)
+
+	store.AssertExpectations(t)
+}
+
 func TestQuerier_Tail_QueryTimeoutConfigFlag(t *testing.T) {
 	request := logproto.TailRequest{
 		Query:    "{type=\"test\"}",
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
 	"sort"
@@ -182,13 +183,10 @@ func (r *LokiLabelNamesRequest) WithStartEnd(s int64, e int64) queryrangebase.Re
 
 func (r *LokiLabelNamesRequest) WithQuery(query string) queryrangebase.Request {
 	new := *r
+	new.Query = query
 	return &new
 }
 
-func (r *LokiLabelNamesRequest) GetQuery() string {
-	return ""
-}
-
 func (r *LokiLabelNamesRequest) GetStep() int64 {
 	return 0
 }
@@ -197,11 +195,84 @@ func (r *LokiLabelNamesRequest) LogToSpan(sp opentracing.Span) {
 	sp.LogFields(
 		otlog.String("start", timestamp.Time(r.GetStart()).String()),
 		otlog.String("end", timestamp.Time(r.GetEnd()).String()),
+		otlog.String("query", r.GetQuery()),
 	)
 }
 
 func (*LokiLabelNamesRequest) GetCachingOptions() (res queryrangebase.CachingOptions) { return }
 
+func (r *LokiIndexStatsRequest) GetEnd() int64 {
+	return r.EndTs.UnixNano() / (int64(time.Millisecond) / int64(time.Nanosecond))
+}
+
+func (r *LokiIndexStatsRequest) GetStart() int64 {
+	return r.StartTs.UnixNano() / (int64(time.Millisecond) / int64(time.Nanosecond))
+}
+
+func (r *LokiIndexStatsRequest) WithStartEnd(s int64, e int64) queryrangebase.Request {
+	new := *r
+	new.StartTs = time.Unix(0, s*int64(time.Millisecond))
+	new.EndTs = time.Unix(0, e*int64(time.Millisecond))
+	return &new
+}
+
+func (r *LokiIndexStatsRequest) WithQuery(query string) queryrangebase.Request {
+	new := *r
+	new.Query = query
+	return &new
+}
+
+func (r *LokiIndexStatsRequest) GetStep() int64 {
+	return 0
+}
+
+func (r *LokiIndexStatsRequest) LogToSpan(sp opentracing.Span) {
+	sp.LogFields(
+		otlog.String("query", r.GetQuery()),
+		otlog.String("start", timestamp.Time(r.GetStart()).String()),
+		otlog.String("end", timestamp.Time(r.GetEnd()).String()),
+	)
+}
+
+func (*LokiIndexStatsRequest) GetCachingOptions() (res queryrangebase.CachingOptions) { return }
+
+func (r *LokiVolumeRequest) GetEnd() int64 {
+	return r.EndTs.UnixNano() / (int64(time.Millisecond) / int64(time.Nanosecond))
+}
+
+func (r *LokiVolumeRequest) GetStart() int64 {
+	return r.StartTs.UnixNano() / (int64(time.Millisecond) / int64(time.Nanosecond))
+}
+
+func (r *LokiVolumeRequest) WithStartEnd(s int64, e int64) queryrangebase.Request {
+	new := *r
+	new.StartTs = time.Unix(0, s*int64(time.Millisecond))
+	new.EndTs = time.Unix(0, e*int64(time.Millisecond))
+	return &new
+}
+
+func (r *LokiVolumeRequest) WithQuery(query string) queryrangebase.Request {
+	new := *r
+	new.Query = query
+	return &new
+}
+
+func (r *LokiVolumeRequest) GetStep() int64 {
+	return 0
+}
+
+func (r *LokiVolumeRequest) LogToSpan(sp opentracing.Span) {
+	sp.LogFields(
+		otlog.String("query", r.GetQuery()),
+		otlog.String("start", timestamp.Time(r.GetStart()).String()),
+		otlog.String("end", timestamp.Time(r.GetEnd()).String()),
+		otlog.Int32("limit", r.GetLimit()),
+		otlog.String("target labels", strings.Join(r.GetTargetLabels(), ",")),
+	)
+}
+
+func (*LokiVolumeRequest) GetCachingOptions() (res queryrangebase.CachingOptions) { return }
+
 func (Codec) DecodeRequest(_ context.Context, r *http.Request, forwardHeaders []string) (queryrangebase.Request, error) {
 	if err := r.ParseForm(); err != nil {
 		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
@@ -258,6 +329,29 @@ func (Codec) DecodeRequest(_ context.Context, r *http.Request, forwardHeaders []
 			StartTs: *req.Start,
 			EndTs:   *req.End,
 			Path:    r.URL.Path,
+			Query:   req.Query,
+		}, nil
+	case IndexStatsOp:
+		req, err := loghttp.ParseIndexStatsQuery(r)
+		if err != nil {
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+		}
+		return &LokiIndexStatsRequest{
+			StartTs: req.From.Time().UTC(),
+			EndTs:   req.Through.Time().UTC(),
+			Query:   req.Matchers,
+		}, nil
+	case VolumeOp:
+		req, err := loghttp.ParseVolumeQuery(r)
+		if err != nil {
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+		}
+		return &LokiVolumeRequest{
+			StartTs:      req.From.Time().UTC(),
+			EndTs:        req.Through.Time().UTC(),
+			Query:        req.Matchers,
+			Limit:        req.Limit,
+			TargetLabels: req.TargetLabels,
 		}, nil
 	default:
 		return nil, httpgrpc.Errorf(http.StatusBadRequest, fmt.Sprintf("unknown request path: %s", r.URL.Path))
@@ -270,6 +364,9 @@ func (Codec) EncodeRequest(ctx context.Context, r queryrangebase.Request) (*http
 	if queryTags != "" {
 		header.Set(string(httpreq.QueryTagsHTTPHeader), queryTags)
 	}
+	if actorPath := getActorPath(ctx); actorPath != "" {
+		header.Set(string(httpreq.LokiActorPathHTTPHeader), actorPath)
+	}
 
 	switch request := r.(type) {
 	case *LokiRequest:
@@ -329,9 +426,18 @@ func (Codec) EncodeRequest(ctx context.Context, r queryrangebase.Request) (*http
 			"start": []string{fmt.Sprintf("%d", request.StartTs.UnixNano())},
 			"end":   []string{fmt.Sprintf("%d", request.EndTs.UnixNano())},
 		}
+		if request.Query != "" {
+			params["query"] = []string{request.Query}
+		}
+
+		path := "/loki/api/v1/labels"
+		if strings.HasSuffix(request.Path, "/values") {
+			// label values requests carry the label name in the path.
+			path = strings.Replace(request.Path, "/api/prom/label/", "/loki/api/v1/label/", 1)
+		}
 
 		u := &url.URL{
-			Path:     "/loki/api/v1/labels",
+			Path:     path,
 			RawQuery: params.Encode(),
 		}
 		req := &http.Request{
@@ -365,6 +471,46 @@ func (Codec) EncodeRequest(ctx context.Context, r queryrangebase.Request) (*http
 			Header:     header,
 		}
 
+		return req.WithContext(ctx), nil
+	case *LokiIndexStatsRequest:
+		params := url.Values{
+			"start": []string{fmt.Sprintf("%d", request.StartTs.UnixNano())},
+			"end":   []string{fmt.Sprintf("%d", request.EndTs.UnixNano())},
+			"query": []string{request.GetQuery()},
+		}
+		u := &url.URL{
+			Path:     "/loki/api/v1/index/stats",
+			RawQuery: params.Encode(),
+		}
+		req := &http.Request{
+			Method:     "GET",
+			RequestURI: u.String(), // This is what the httpgrpc code looks at.
+			URL:        u,
+			Body:       http.NoBody,
+			Header:     header,
+		}
+		return req.WithContext(ctx), nil
+	case *LokiVolumeRequest:
+		params := url.Values{
+			"start": []string{fmt.Sprintf("%d", request.StartTs.UnixNano())},
+			"end":   []string{fmt.Sprintf("%d", request.EndTs.UnixNano())},
+			"query": []string{request.GetQuery()},
+			"limit": []string{fmt.Sprintf("%d", request.Limit)},
+		}
+		if len(request.TargetLabels) > 0 {
+			params["targetLabels"] = []string{strings.Join(request.TargetLabels, ",")}
+		}
+		u := &url.URL{
+			Path:     "/loki/api/v1/index/volume",
+			RawQuery: params.Encode(),
+		}
+		req := &http.Request{
+			Method:     "GET",
+			RequestURI: u.String(), // This is what the httpgrpc code looks at.
+			URL:        u,
+			Body:       http.NoBody,
+			Header:     header,
+		}
 		return req.WithContext(ctx), nil
 	default:
 		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "invalid request format")
@@ -392,6 +538,14 @@ func (Codec) DecodeResponse(ctx context.Context, r *http.Response, req queryrang
 		}
 	}
 
+	if mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && mediaType == ProtobufContentType {
+		resp, err := decodeProtobufResponse(buf, params["proto"], r.Header)
+		if err != nil {
+			return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error decoding response: %v", err)
+		}
+		return resp, nil
+	}
+
 	switch req := req.(type) {
 	case *LokiSeriesRequest:
 		var resp loghttp.SeriesResponse
@@ -424,6 +578,24 @@ func (Codec) DecodeResponse(ctx context.Context, r *http.Response, req queryrang
 			Data:    resp.Data,
 			Headers: httpResponseHeadersToPromResponseHeaders(r.Header),
 		}, nil
+	case *LokiIndexStatsRequest:
+		var resp logproto.IndexStatsResponse
+		if err := json.Unmarshal(buf, &resp); err != nil {
+			return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error decoding response: %v", err)
+		}
+		return &LokiIndexStatsResponse{
+			Response: &resp,
+			Headers:  httpResponseHeadersToPromResponseHeaders(r.Header),
+		}, nil
+	case *LokiVolumeRequest:
+		var resp logproto.VolumeResponse
+		if err := json.Unmarshal(buf, &resp); err != nil {
+			return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error decoding response: %v", err)
+		}
+		return &LokiVolumeResponse{
+			Response: &resp,
+			Headers:  httpResponseHeadersToPromResponseHeaders(r.Header),
+		}, nil
 	default:
 		var resp loghttp.QueryResponse
 		if err := resp.UnmarshalJSON(buf); err != nil {
@@ -492,6 +664,7 @@ func (Codec) EncodeResponse(ctx context.Context, res queryrangebase.Response) (*
 	sp, _ := opentracing.StartSpanFromContext(ctx, "codec.EncodeResponse")
 	defer sp.Finish()
 	var buf bytes.Buffer
+	contentType := "application/json"
 
 	switch response := res.(type) {
 	case *LokiPromResponse:
@@ -536,6 +709,21 @@ func (Codec) EncodeResponse(ctx context.Context, res queryrangebase.Response) (*
 				return nil, err
 			}
 		}
+	case *LokiIndexStatsResponse:
+		if err := marshal.WriteIndexStatsResponseJSON(response.Response, &buf); err != nil {
+			return nil, err
+		}
+	case *LokiVolumeResponse:
+		if err := marshal.WriteVolumeResponseJSON(response.Response, &buf); err != nil {
+			return nil, err
+		}
+	case *QuantileSketchResponse, *CountMinSketchResponse:
+		b, err := response.(protobufResponse).Marshal()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+		contentType = protobufContentType(response.(protobufResponse))
 	default:
 		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "invalid response format")
 	}
@@ -544,7 +732,7 @@ func (Codec) EncodeResponse(ctx context.Context, res queryrangebase.Response) (*
 
 	resp := http.Response{
 		Header: http.Header{
-			"Content-Type": []string{"application/json"},
+			"Content-Type": []string{contentType},
 		},
 		Body:       ioutil.NopCloser(&buf),
 		StatusCode: http.StatusOK,
@@ -552,8 +740,6 @@ func (Codec) EncodeResponse(ctx context.Context, res queryrangebase.Response) (*
 	return &resp, nil
 }
 
-// NOTE: When we would start caching response from non-metric queries we would have to consider cache gen headers as well in
-// MergeResponse implementation for Loki codecs same as it is done in Cortex at https://github.com/cortexproject/cortex/blob/21bad57b346c730d684d6d0205efef133422ab28/pkg/querier/queryrange/query_range.go#L170
 func (Codec) MergeResponse(responses ...queryrangebase.Response) (queryrangebase.Response, error) {
 	if len(responses) == 0 {
 		return nil, errors.New("merging responses requires at least one response")
@@ -620,6 +806,30 @@ func (Codec) MergeResponse(responses ...queryrangebase.Response) (queryrangebase
 			Version: labelNameRes.Version,
 			Data:    names,
 		}, nil
+	case *LokiIndexStatsResponse:
+		stats := make([]*logproto.IndexStatsResponse, 0, len(responses))
+		for _, res := range responses {
+			stats = append(stats, res.(*LokiIndexStatsResponse).Response)
+		}
+		merged, err := logproto.MergeIndexStatsResponses(stats)
+		if err != nil {
+			return nil, err
+		}
+		return &LokiIndexStatsResponse{
+			Response: merged,
+		}, nil
+	case *LokiVolumeResponse:
+		volumes := make([]*logproto.VolumeResponse, 0, len(responses))
+		for _, res := range responses {
+			volumes = append(volumes, res.(*LokiVolumeResponse).Response)
+		}
+		merged, err := logproto.MergeVolumeResponses(volumes)
+		if err != nil {
+			return nil, err
+		}
+		return &LokiVolumeResponse{
+			Response: merged,
+		}, nil
 	default:
 		return nil, errors.New("unknown response in merging responses")
 	}
@@ -856,6 +1066,11 @@ func getQueryTags(ctx context.Context) string {
 	return v
 }
 
+func getActorPath(ctx context.Context) string {
+	v, _ := ctx.Value(httpreq.LokiActorPathHTTPHeader).(string) // it's ok to be empty
+	return v
+}
+
 func NewEmptyResponse(r queryrangebase.Request) (queryrangebase.Response, error) {
 	switch req := r.(type) {
 	case *LokiSeriesRequest:
@@ -868,6 +1083,17 @@ func NewEmptyResponse(r queryrangebase.Request) (queryrangebase.Response, error)
 			Status:  loghttp.QueryStatusSuccess,
 			Version: uint32(loghttp.GetVersion(req.Path)),
 		}, nil
+	case *LokiIndexStatsRequest:
+		return &LokiIndexStatsResponse{
+			Response: &logproto.IndexStatsResponse{},
+		}, nil
+	case *LokiVolumeRequest:
+		return &LokiVolumeResponse{
+			Response: &logproto.VolumeResponse{
+				Volumes: []logproto.Volume{},
+				Limit:   req.Limit,
+			},
+		}, nil
 	case *LokiInstantRequest:
 		// instant queries in the frontend are always metrics queries.
 		return &LokiPromResponse{
@@ -911,12 +1137,27 @@ func mergeLokiResponse(responses ...queryrangebase.Response) *LokiResponse {
 		lokiRes       = responses[0].(*LokiResponse)
 		mergedStats   stats.Result
 		lokiResponses = make([]*LokiResponse, 0, len(responses))
+		// we need to pass on all the headers for results cache gen numbers.
+		resultsCacheGenNumberHeaderValues []string
 	)
 
 	for _, res := range responses {
 		lokiResult := res.(*LokiResponse)
 		mergedStats.Merge(lokiResult.Statistics)
 		lokiResponses = append(lokiResponses, lokiResult)
+		for _, h := range lokiResult.Headers {
+			if h.Name == queryrangebase.ResultsCacheGenNumberHeaderName {
+				resultsCacheGenNumberHeaderValues = append(resultsCacheGenNumberHeaderValues, h.Values...)
+			}
+		}
+	}
+
+	var headers []queryrangebase.PrometheusResponseHeader
+	if len(resultsCacheGenNumberHeaderValues) != 0 {
+		headers = []queryrangebase.PrometheusResponseHeader{{
+			Name:   queryrangebase.ResultsCacheGenNumberHeaderName,
+			Values: resultsCacheGenNumberHeaderValues,
+		}}
 	}
 
 	return &LokiResponse{
@@ -931,5 +1172,6 @@ func mergeLokiResponse(responses ...queryrangebase.Response) *LokiResponse {
 			ResultType: loghttp.ResultTypeStream,
 			Result:     mergeOrderedNonOverlappingStreams(lokiResponses, lokiRes.Limit, lokiRes.Direction),
 		},
+		Headers: headers,
 	}
 }
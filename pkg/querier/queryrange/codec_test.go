@@ -79,6 +79,33 @@ func Test_codec_DecodeRequest(t *testing.T) {
 			StartTs: start,
 			EndTs:   end,
 		}, false},
+		{"label values", func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet,
+				fmt.Sprintf(`/label/foo/values?start=%d&end=%d&query={foo="bar"}`, start.UnixNano(), end.UnixNano()), nil)
+		}, &LokiLabelNamesRequest{
+			Path:    "/label/foo/values",
+			StartTs: start,
+			EndTs:   end,
+			Query:   `{foo="bar"}`,
+		}, false},
+		{"index stats", func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet,
+				fmt.Sprintf(`/loki/api/v1/index/stats?start=%d&end=%d&query={foo="bar"}`, start.UnixNano(), end.UnixNano()), nil)
+		}, &LokiIndexStatsRequest{
+			StartTs: start.Truncate(time.Millisecond),
+			EndTs:   end.Truncate(time.Millisecond),
+			Query:   `{foo="bar"}`,
+		}, false},
+		{"volume", func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet,
+				fmt.Sprintf(`/loki/api/v1/index/volume?start=%d&end=%d&query={foo="bar"}&limit=10&targetLabels=app,namespace`, start.UnixNano(), end.UnixNano()), nil)
+		}, &LokiVolumeRequest{
+			StartTs:      start.Truncate(time.Millisecond),
+			EndTs:        end.Truncate(time.Millisecond),
+			Query:        `{foo="bar"}`,
+			Limit:        10,
+			TargetLabels: []string{"app", "namespace"},
+		}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -306,6 +333,92 @@ func Test_codec_labels_EncodeRequest(t *testing.T) {
 	require.Equal(t, "/loki/api/v1/labels", req.(*LokiLabelNamesRequest).Path)
 }
 
+func Test_codec_label_values_EncodeRequest(t *testing.T) {
+	ctx := context.Background()
+	for _, path := range []string{"/loki/api/v1/label/foo/values", "/api/prom/label/foo/values"} {
+		toEncode := &LokiLabelNamesRequest{
+			Path:    path,
+			StartTs: start,
+			EndTs:   end,
+			Query:   `{foo="bar"}`,
+		}
+		got, err := LokiCodec.EncodeRequest(ctx, toEncode)
+		require.NoError(t, err)
+		require.Equal(t, "/loki/api/v1/label/foo/values", got.URL.Path)
+		require.Equal(t, fmt.Sprintf("%d", start.UnixNano()), got.URL.Query().Get("start"))
+		require.Equal(t, fmt.Sprintf("%d", end.UnixNano()), got.URL.Query().Get("end"))
+		require.Equal(t, `{foo="bar"}`, got.URL.Query().Get("query"))
+
+		// testing a full roundtrip
+		req, err := LokiCodec.DecodeRequest(context.TODO(), got, nil)
+		require.NoError(t, err)
+		require.Equal(t, toEncode.StartTs, req.(*LokiLabelNamesRequest).StartTs)
+		require.Equal(t, toEncode.EndTs, req.(*LokiLabelNamesRequest).EndTs)
+		require.Equal(t, toEncode.Query, req.(*LokiLabelNamesRequest).Query)
+		require.Equal(t, "/loki/api/v1/label/foo/values", req.(*LokiLabelNamesRequest).Path)
+	}
+}
+
+func Test_codec_index_stats_EncodeRequest(t *testing.T) {
+	from, through := start.Truncate(time.Millisecond), end.Truncate(time.Millisecond)
+	toEncode := &LokiIndexStatsRequest{
+		StartTs: from,
+		EndTs:   through,
+		Query:   `{job="foo"}`,
+	}
+	got, err := LokiCodec.EncodeRequest(context.Background(), toEncode)
+	require.Nil(t, err)
+	require.Equal(t, "/loki/api/v1/index/stats", got.URL.Path)
+	require.Equal(t, fmt.Sprintf("%d", from.UnixNano()), got.URL.Query().Get("start"))
+	require.Equal(t, fmt.Sprintf("%d", through.UnixNano()), got.URL.Query().Get("end"))
+	require.Equal(t, `{job="foo"}`, got.URL.Query().Get("query"))
+
+	// testing a full roundtrip
+	req, err := LokiCodec.DecodeRequest(context.TODO(), got, nil)
+	require.NoError(t, err)
+	require.Equal(t, toEncode.StartTs.UnixNano(), req.(*LokiIndexStatsRequest).StartTs.UnixNano())
+	require.Equal(t, toEncode.EndTs.UnixNano(), req.(*LokiIndexStatsRequest).EndTs.UnixNano())
+	require.Equal(t, toEncode.Query, req.(*LokiIndexStatsRequest).Query)
+}
+
+func Test_codec_volume_EncodeRequest(t *testing.T) {
+	from, through := start.Truncate(time.Millisecond), end.Truncate(time.Millisecond)
+	toEncode := &LokiVolumeRequest{
+		StartTs:      from,
+		EndTs:        through,
+		Query:        `{job="foo"}`,
+		Limit:        20,
+		TargetLabels: []string{"app", "namespace"},
+	}
+	got, err := LokiCodec.EncodeRequest(context.Background(), toEncode)
+	require.Nil(t, err)
+	require.Equal(t, "/loki/api/v1/index/volume", got.URL.Path)
+	require.Equal(t, `{job="foo"}`, got.URL.Query().Get("query"))
+	require.Equal(t, "20", got.URL.Query().Get("limit"))
+	require.Equal(t, "app,namespace", got.URL.Query().Get("targetLabels"))
+
+	// testing a full roundtrip
+	req, err := LokiCodec.DecodeRequest(context.TODO(), got, nil)
+	require.NoError(t, err)
+	require.Equal(t, toEncode.StartTs.UnixNano(), req.(*LokiVolumeRequest).StartTs.UnixNano())
+	require.Equal(t, toEncode.EndTs.UnixNano(), req.(*LokiVolumeRequest).EndTs.UnixNano())
+	require.Equal(t, toEncode.Query, req.(*LokiVolumeRequest).Query)
+	require.Equal(t, toEncode.Limit, req.(*LokiVolumeRequest).Limit)
+	require.Equal(t, toEncode.TargetLabels, req.(*LokiVolumeRequest).TargetLabels)
+}
+
+func Test_codec_index_stats_MergeResponse(t *testing.T) {
+	resps := []queryrangebase.Response{
+		&LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4}},
+		&LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{Streams: 10, Chunks: 20, Bytes: 30, Entries: 40}},
+	}
+	got, err := LokiCodec.MergeResponse(resps...)
+	require.NoError(t, err)
+	require.Equal(t, &LokiIndexStatsResponse{
+		Response: &logproto.IndexStatsResponse{Streams: 11, Chunks: 22, Bytes: 33, Entries: 44},
+	}, got)
+}
+
 func Test_codec_EncodeResponse(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -913,6 +1026,7 @@ var (
 			"linesProcessedPerSecond": 23,
 			"queueTime": 21,
 			"subqueries": 1,
+			"totalBytesEstimated": 0,
 			"totalBytesProcessed": 24,
 			"totalLinesProcessed": 25
 		}
@@ -0,0 +1,54 @@
+package queryrange
+
+import (
+	"net/http"
+
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql"
+	"github.com/grafana/loki/pkg/logql/sketch"
+	"github.com/grafana/loki/pkg/logqlmodel/stats"
+)
+
+// WriteCountMinSketchResponse writes the protobuf encoded result of a count_min_sketch query.
+func WriteCountMinSketchResponse(w http.ResponseWriter, v logql.CountMinSketchVector, statistics stats.Result) error {
+	return writeProtobufResponse(w, &CountMinSketchResponse{
+		Data:       CountMinSketchVectorFromLogQL(v),
+		Statistics: statistics,
+	})
+}
+
+// CountMinSketchVectorFromLogQL converts a logql.CountMinSketchVector to its protobuf representation.
+func CountMinSketchVectorFromLogQL(v logql.CountMinSketchVector) CountMinSketchVector {
+	metrics := make([]CountMinSketchMetric, 0, len(v.Metrics))
+	for _, m := range v.Metrics {
+		metrics = append(metrics, CountMinSketchMetric{Labels: logproto.FromLabelsToLabelAdapters(m)})
+	}
+	return CountMinSketchVector{
+		TimestampMs: v.T,
+		Sketch: CountMinSketch{
+			Depth:    v.Sketch.Depth(),
+			Width:    v.Sketch.Width(),
+			Counters: v.Sketch.Counters(),
+		},
+		Metrics: metrics,
+	}
+}
+
+// ToLogQL converts the protobuf representation of a count-min sketch back to a logql.CountMinSketchVector.
+func (v CountMinSketchVector) ToLogQL() (logql.CountMinSketchVector, error) {
+	s, err := sketch.NewCountMinSketchFromCounters(v.Sketch.Depth, v.Sketch.Width, v.Sketch.Counters)
+	if err != nil {
+		return logql.CountMinSketchVector{}, err
+	}
+	metrics := make([]labels.Labels, 0, len(v.Metrics))
+	for _, m := range v.Metrics {
+		metrics = append(metrics, logproto.FromLabelAdaptersToLabels(m.Labels))
+	}
+	return logql.CountMinSketchVector{
+		T:       v.TimestampMs,
+		Sketch:  s,
+		Metrics: metrics,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package queryrange
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/loki/pkg/logql"
+	"github.com/grafana/loki/pkg/logql/sketch"
+	"github.com/grafana/loki/pkg/logqlmodel/stats"
+)
+
+func countMinSketchVector(t *testing.T) logql.CountMinSketchVector {
+	t.Helper()
+	s, err := sketch.NewCountMinSketch(sketch.DefaultCountMinSketchDepth, sketch.DefaultCountMinSketchWidth)
+	require.NoError(t, err)
+	metrics := []labels.Labels{
+		{{Name: "client_ip", Value: "10.0.0.1"}},
+		{{Name: "client_ip", Value: "10.0.0.2"}},
+	}
+	s.Add(metrics[0].String(), 10)
+	s.Add(metrics[1].String(), 5)
+	s.Add(labels.Labels{{Name: "client_ip", Value: "10.0.0.3"}}.String(), 1)
+	return logql.CountMinSketchVector{T: 1000, Sketch: s, Metrics: metrics}
+}
+
+func Test_codec_CountMinSketchResponse(t *testing.T) {
+	v := countMinSketchVector(t)
+	resp := &CountMinSketchResponse{
+		Data:       CountMinSketchVectorFromLogQL(v),
+		Statistics: statsResult,
+	}
+
+	httpResp, err := LokiCodec.EncodeResponse(context.Background(), resp)
+	require.NoError(t, err)
+	require.Equal(t, `application/vnd.google.protobuf; proto=queryrange.CountMinSketchResponse`, httpResp.Header.Get("Content-Type"))
+
+	decoded, err := LokiCodec.DecodeResponse(context.Background(), httpResp, &LokiInstantRequest{})
+	require.NoError(t, err)
+	require.Equal(t, resp.Data, decoded.(*CountMinSketchResponse).Data)
+	require.Equal(t, resp.Statistics, decoded.(*CountMinSketchResponse).Statistics)
+
+	result, err := ResponseToResult(decoded)
+	require.NoError(t, err)
+	require.Equal(t, v.TopK(2), result.Data.(logql.CountMinSketchVector).TopK(2))
+}
+
+func TestWriteCountMinSketchResponse(t *testing.T) {
+	v := countMinSketchVector(t)
+	rec := httptest.NewRecorder()
+	require.NoError(t, WriteCountMinSketchResponse(rec, v, stats.Result{}))
+
+	decoded, err := LokiCodec.DecodeResponse(context.Background(), &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     rec.Header(),
+		Body:       ioutil.NopCloser(rec.Body),
+	}, &LokiInstantRequest{})
+	require.NoError(t, err)
+
+	actual, err := decoded.(*CountMinSketchResponse).Data.ToLogQL()
+	require.NoError(t, err)
+	require.Equal(t, v.Metrics, actual.Metrics)
+	require.Equal(t, float64(10), actual.Sketch.Count(v.Metrics[0].String()))
+}
@@ -225,6 +225,26 @@ func ResponseToResult(resp queryrangebase.Response) (logqlmodel.Result, error) {
 			Data:       sampleStreamToMatrix(r.Response.Data.Result),
 		}, nil
 
+	case *QuantileSketchResponse:
+		m, err := r.Data.ToLogQL()
+		if err != nil {
+			return logqlmodel.Result{}, err
+		}
+		return logqlmodel.Result{
+			Statistics: r.Statistics,
+			Data:       m,
+		}, nil
+
+	case *CountMinSketchResponse:
+		v, err := r.Data.ToLogQL()
+		if err != nil {
+			return logqlmodel.Result{}, err
+		}
+		return logqlmodel.Result{
+			Statistics: r.Statistics,
+			Data:       v,
+		}, nil
+
 	default:
 		return logqlmodel.Result{}, fmt.Errorf("cannot decode (%T)", resp)
 	}
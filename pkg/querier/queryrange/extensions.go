@@ -27,6 +27,20 @@ func (m *LokiSeriesResponse) GetHeaders() []*queryrangebase.PrometheusResponseHe
 	return nil
 }
 
+func (m *LokiIndexStatsResponse) GetHeaders() []*queryrangebase.PrometheusResponseHeader {
+	if m != nil {
+		return convertPrometheusResponseHeadersToPointers(m.Headers)
+	}
+	return nil
+}
+
+func (m *LokiVolumeResponse) GetHeaders() []*queryrangebase.PrometheusResponseHeader {
+	if m != nil {
+		return convertPrometheusResponseHeadersToPointers(m.Headers)
+	}
+	return nil
+}
+
 func (m *LokiPromResponse) GetHeaders() []*queryrangebase.PrometheusResponseHeader {
 	if m != nil {
 		return m.Response.GetHeaders()
@@ -41,6 +55,20 @@ func (m *LokiResponse) GetHeaders() []*queryrangebase.PrometheusResponseHeader {
 	return nil
 }
 
+func (m *QuantileSketchResponse) GetHeaders() []*queryrangebase.PrometheusResponseHeader {
+	if m != nil {
+		return convertPrometheusResponseHeadersToPointers(m.Headers)
+	}
+	return nil
+}
+
+func (m *CountMinSketchResponse) GetHeaders() []*queryrangebase.PrometheusResponseHeader {
+	if m != nil {
+		return convertPrometheusResponseHeadersToPointers(m.Headers)
+	}
+	return nil
+}
+
 func convertPrometheusResponseHeadersToPointers(h []queryrangebase.PrometheusResponseHeader) []*queryrangebase.PrometheusResponseHeader {
 	if h == nil {
 		return nil
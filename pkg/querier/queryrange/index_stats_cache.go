@@ -0,0 +1,122 @@
+package queryrange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/grafana/dskit/tenant"
+	"github.com/prometheus/common/model"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/grafana/loki/pkg/querier/queryrange/queryrangebase"
+	"github.com/grafana/loki/pkg/storage/chunk/cache"
+	"github.com/grafana/loki/pkg/util/validation"
+)
+
+// NewIndexStatsCache creates a new index stats cache middleware.
+// Index stats can neither be narrowed down to a sub time range nor merged with the stats of an overlapping time
+// range without counting the same chunks twice, so a cached response is only used for the exact same time range.
+// Since requests are split by day, this still caches every full day of a query.
+func NewIndexStatsCache(logger log.Logger, limits Limits, c cache.Cache, shouldCache queryrangebase.ShouldCacheFn) queryrangebase.Middleware {
+	return queryrangebase.MiddlewareFunc(func(next queryrangebase.Handler) queryrangebase.Handler {
+		return &indexStatsCache{
+			next:        next,
+			limits:      limits,
+			cache:       c,
+			logger:      logger,
+			shouldCache: shouldCache,
+		}
+	})
+}
+
+type indexStatsCache struct {
+	next        queryrangebase.Handler
+	limits      Limits
+	cache       cache.Cache
+	shouldCache queryrangebase.ShouldCacheFn
+
+	logger log.Logger
+}
+
+func (i *indexStatsCache) Do(ctx context.Context, req queryrangebase.Request) (queryrangebase.Response, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	}
+
+	if i.shouldCache != nil && !i.shouldCache(req) {
+		return i.next.Do(ctx, req)
+	}
+
+	// Recent stats still change as chunks are flushed.
+	maxCacheFreshness := validation.MaxDurationPerTenant(tenantIDs, i.limits.MaxCacheFreshness)
+	maxCacheTime := int64(model.Now().Add(-maxCacheFreshness))
+	if req.GetEnd() > maxCacheTime {
+		return i.next.Do(ctx, req)
+	}
+
+	// generate the cache key based on query, tenant and the exact time range.
+	cacheKey := fmt.Sprintf("indexstats:%s:%s:%d:%d", tenant.JoinTenantIDs(tenantIDs), req.GetQuery(), req.GetStart(), req.GetEnd())
+
+	_, buff, _, err := i.cache.Fetch(ctx, []string{cache.HashKey(cacheKey)})
+	if err != nil {
+		level.Warn(i.logger).Log("msg", "error fetching cache", "err", err, "cacheKey", cacheKey)
+		return i.next.Do(ctx, req)
+	}
+
+	if len(buff) == 1 {
+		var cached queryrangebase.CachedResponse
+		if err := proto.Unmarshal(buff[0], &cached); err != nil {
+			level.Warn(i.logger).Log("msg", "error unmarshalling response from cache", "err", err)
+			return i.next.Do(ctx, req)
+		}
+		// Different keys can share the same hash.
+		if cached.Key == cacheKey && len(cached.Extents) == 1 {
+			var resp LokiIndexStatsResponse
+			if err := types.UnmarshalAny(cached.Extents[0].Response, &resp); err == nil {
+				return &resp, nil
+			}
+			level.Warn(i.logger).Log("msg", "error unmarshalling response from cache", "err", err)
+		}
+	}
+
+	resp, err := i.next.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	statsResp, ok := resp.(*LokiIndexStatsResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+	i.store(ctx, cacheKey, req, statsResp)
+	return resp, nil
+}
+
+// store caches the response of the request. Headers are not cached.
+func (i *indexStatsCache) store(ctx context.Context, cacheKey string, req queryrangebase.Request, resp *LokiIndexStatsResponse) {
+	any, err := types.MarshalAny(&LokiIndexStatsResponse{Response: resp.Response})
+	if err != nil {
+		level.Warn(i.logger).Log("msg", "error marshalling response", "err", err)
+		return
+	}
+	data, err := proto.Marshal(&queryrangebase.CachedResponse{
+		Key: cacheKey,
+		Extents: []queryrangebase.Extent{{
+			Start:    req.GetStart(),
+			End:      req.GetEnd(),
+			Response: any,
+		}},
+	})
+	if err != nil {
+		level.Warn(i.logger).Log("msg", "error marshalling response", "err", err)
+		return
+	}
+	if err := i.cache.Store(ctx, []string{cache.HashKey(cacheKey)}, [][]byte{data}); err != nil {
+		level.Warn(i.logger).Log("msg", "error storing cache", "err", err)
+	}
+}
@@ -0,0 +1,105 @@
+package queryrange
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/querier/queryrange/queryrangebase"
+	"github.com/grafana/loki/pkg/storage/chunk/cache"
+)
+
+func Test_IndexStatsCache(t *testing.T) {
+	var (
+		ctx   = user.InjectOrgID(context.Background(), "foo")
+		calls int
+		h     = NewIndexStatsCache(
+			log.NewNopLogger(),
+			fakeLimits{},
+			cache.NewMockCache(),
+			nil,
+		).Wrap(queryrangebase.HandlerFunc(func(_ context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+			calls++
+			// the stats are proportional to the length of the time range.
+			hours := uint64((r.GetEnd() - r.GetStart()) / time.Hour.Milliseconds())
+			return &LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{
+				Streams: 1,
+				Chunks:  hours,
+				Bytes:   hours * 1024,
+				Entries: hours * 10,
+			}}, nil
+		}))
+	)
+
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := &LokiIndexStatsRequest{
+		Query:   `{app="foo"}`,
+		StartTs: day,
+		EndTs:   day.Add(24 * time.Hour),
+	}
+	expected := &LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{Streams: 1, Chunks: 24, Bytes: 24 * 1024, Entries: 240}}
+
+	resp, err := h.Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, expected, resp)
+	require.Equal(t, 1, calls)
+
+	// the same time range is served from the cache.
+	resp, err = h.Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, expected, resp)
+	require.Equal(t, 1, calls)
+
+	// a sub range of a cached time range is not served from the cache, as the whole day would be counted.
+	subReq := &LokiIndexStatsRequest{
+		Query:   `{app="foo"}`,
+		StartTs: day,
+		EndTs:   day.Add(time.Hour),
+	}
+	resp, err = h.Do(ctx, subReq)
+	require.NoError(t, err)
+	require.Equal(t, &LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{Streams: 1, Chunks: 1, Bytes: 1024, Entries: 10}}, resp)
+	require.Equal(t, 2, calls)
+
+	// a different query is not served from the cache.
+	resp, err = h.Do(ctx, req.WithQuery(`{app="bar"}`))
+	require.NoError(t, err)
+	require.Equal(t, expected, resp)
+	require.Equal(t, 3, calls)
+}
+
+func Test_IndexStatsCacheMaxCacheFreshness(t *testing.T) {
+	var (
+		ctx   = user.InjectOrgID(context.Background(), "foo")
+		calls int
+		h     = NewIndexStatsCache(
+			log.NewNopLogger(),
+			fakeLimits{},
+			cache.NewMockCache(),
+			nil,
+		).Wrap(queryrangebase.HandlerFunc(func(_ context.Context, _ queryrangebase.Request) (queryrangebase.Response, error) {
+			calls++
+			return &LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{Streams: uint64(calls)}}, nil
+		}))
+	)
+
+	// the end of the request is within the max cache freshness.
+	now := time.Now()
+	req := &LokiIndexStatsRequest{
+		Query:   `{app="foo"}`,
+		StartTs: now.Add(-time.Hour),
+		EndTs:   now,
+	}
+
+	resp, err := h.Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), resp.(*LokiIndexStatsResponse).Response.Streams)
+	resp, err = h.Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), resp.(*LokiIndexStatsResponse).Response.Streams)
+}
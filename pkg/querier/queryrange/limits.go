@@ -7,20 +7,27 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize"
+	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/user"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/grafana/dskit/tenant"
 
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/logql"
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/lokifrontend/frontend/transport"
 	"github.com/grafana/loki/pkg/querier/queryrange/queryrangebase"
 	"github.com/grafana/loki/pkg/util"
+	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/util/spanlogger"
 	"github.com/grafana/loki/pkg/util/validation"
+	limits_validation "github.com/grafana/loki/pkg/validation"
 )
 
 const (
@@ -39,6 +46,10 @@ type Limits interface {
 	MaxQuerySeries(string) int
 	MaxEntriesLimitPerQuery(string) int
 	MinShardingLookback(string) time.Duration
+	QuantileOverTimeSharding(string) bool
+	MaxQueryBytesRead(string) int
+	MaxQuerierBytesRead(string) int
+	BlockedQueries(string) []limits_validation.BlockedQuery
 }
 
 type limits struct {
@@ -139,6 +150,168 @@ func (l limitsMiddleware) Do(ctx context.Context, r queryrangebase.Request) (que
 	return l.next.Do(ctx, r)
 }
 
+type querySizeLimiter struct {
+	logger       log.Logger
+	next         queryrangebase.Handler
+	statsHandler queryrangebase.Handler
+	maxBytes     func(string) int
+	errTmpl      string
+	// recordEstimate sets the estimate in the statistics of the response.
+	recordEstimate bool
+
+	// shardingConfs are the configs the following query sharding middleware shards the requests with, if any.
+	// The estimate of sharded requests is divided by their number of shards.
+	shardingConfs   ShardingConfigs
+	shardingMetrics *logql.ShardingMetrics
+	limits          Limits
+}
+
+// NewQuerySizeLimiterMiddleware creates a new Middleware that rejects queries estimated to read more bytes than
+// the max_query_bytes_read limit. The estimate is computed from the index stats of the stream selectors of the
+// query, fetched with the statsHandler, and is added to the statistics of the response of accepted queries.
+func NewQuerySizeLimiterMiddleware(logger log.Logger, limits Limits, statsHandler queryrangebase.Handler) queryrangebase.Middleware {
+	return queryrangebase.MiddlewareFunc(func(next queryrangebase.Handler) queryrangebase.Handler {
+		return &querySizeLimiter{
+			logger:         logger,
+			next:           next,
+			statsHandler:   statsHandler,
+			maxBytes:       limits.MaxQueryBytesRead,
+			errTmpl:        validation.ErrQueryTooManyBytes,
+			recordEstimate: true,
+		}
+	})
+}
+
+// NewQuerierSizeLimiterMiddleware creates a new Middleware that rejects queries for which a subquery is estimated
+// to read more bytes than the max_querier_bytes_read limit. It must be placed after the split by interval and,
+// when the queries are sharded, right before the query sharding middleware using shardingConfs, which is nil otherwise.
+func NewQuerierSizeLimiterMiddleware(logger log.Logger, limits Limits, statsHandler queryrangebase.Handler, shardingConfs ShardingConfigs) queryrangebase.Middleware {
+	// The shard mapper is only used to know whether requests are sharded, its metrics are not registered.
+	shardingMetrics := logql.NewShardingMetrics(nil)
+	return queryrangebase.MiddlewareFunc(func(next queryrangebase.Handler) queryrangebase.Handler {
+		return &querySizeLimiter{
+			logger:          logger,
+			next:            next,
+			statsHandler:    statsHandler,
+			maxBytes:        limits.MaxQuerierBytesRead,
+			errTmpl:         validation.ErrQuerierTooManyBytes,
+			shardingConfs:   shardingConfs,
+			shardingMetrics: shardingMetrics,
+			limits:          limits,
+		}
+	})
+}
+
+func (q *querySizeLimiter) Do(ctx context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	}
+
+	maxBytes := validation.SmallestPositiveNonZeroIntPerTenant(tenantIDs, q.maxBytes)
+	if maxBytes == 0 {
+		return q.next.Do(ctx, r)
+	}
+
+	bytes, err := q.estimateBytesRead(ctx, r)
+	if err != nil {
+		// Don't fail the query when the index stats are not available.
+		level.Warn(util_log.WithContext(ctx, q.logger)).Log("msg", "failed to estimate the bytes read by the query", "query", r.GetQuery(), "err", err)
+		return q.next.Do(ctx, r)
+	}
+
+	if q.shardingConfs != nil {
+		// Each shard reads about the same share of the bytes of the request.
+		bytes /= uint64(shardFactor(ctx, q.shardingConfs, q.limits, q.shardingMetrics, time.Now(), r))
+	}
+
+	if bytes > uint64(maxBytes) {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, q.errTmpl, humanize.Bytes(bytes), humanize.Bytes(uint64(maxBytes)))
+	}
+
+	resp, err := q.next.Do(ctx, r)
+	if err != nil || !q.recordEstimate {
+		return resp, err
+	}
+	switch res := resp.(type) {
+	case *LokiResponse:
+		res.Statistics.Summary.TotalBytesEstimated = int64(bytes)
+	case *LokiPromResponse:
+		res.Statistics.Summary.TotalBytesEstimated = int64(bytes)
+	}
+	return resp, nil
+}
+
+// estimateBytesRead sums the bytes of the index stats of each stream selector of the query. The time range of
+// the stats includes the lookback of the range aggregations and subqueries of the query.
+func (q *querySizeLimiter) estimateBytesRead(ctx context.Context, r queryrangebase.Request) (uint64, error) {
+	expr, err := syntax.ParseExpr(r.GetQuery())
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		selectors        []string
+		seen             = map[string]struct{}{}
+		rangeLookback    time.Duration
+		subqueryLookback time.Duration
+	)
+	expr.Walk(func(e interface{}) {
+		switch e := e.(type) {
+		case *syntax.MatchersExpr:
+			selector := e.String()
+			if _, ok := seen[selector]; !ok {
+				seen[selector] = struct{}{}
+				selectors = append(selectors, selector)
+			}
+		case *syntax.LogRange:
+			if lookback := e.Interval + e.Offset; lookback > rangeLookback {
+				rangeLookback = lookback
+			}
+		case *syntax.SubqueryExpr:
+			if lookback := e.Range + e.Offset; lookback > subqueryLookback {
+				subqueryLookback = lookback
+			}
+		}
+	})
+
+	start := timestamp.Time(r.GetStart()).Add(-rangeLookback - subqueryLookback)
+	end := timestamp.Time(r.GetEnd())
+
+	g, gctx := errgroup.WithContext(ctx)
+	bytes := make([]uint64, len(selectors))
+	for i, selector := range selectors {
+		i, selector := i, selector
+		g.Go(func() error {
+			resp, err := q.statsHandler.Do(gctx, &LokiIndexStatsRequest{
+				StartTs: start,
+				EndTs:   end,
+				Query:   selector,
+			})
+			if err != nil {
+				return err
+			}
+			statsResp, ok := resp.(*LokiIndexStatsResponse)
+			if !ok {
+				return fmt.Errorf("unexpected index stats response type: %T", resp)
+			}
+			if statsResp.Response != nil {
+				bytes[i] = statsResp.Response.Bytes
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return 0, err
+	}
+
+	var total uint64
+	for _, b := range bytes {
+		total += b
+	}
+	return total, nil
+}
+
 type seriesLimiter struct {
 	hashes map[uint64]struct{}
 	rw     sync.RWMutex
@@ -323,5 +496,17 @@ func (rt limitedRoundTripper) do(ctx context.Context, r queryrangebase.Request)
 	}
 	defer func() { _ = response.Body.Close() }()
 
-	return rt.codec.DecodeResponse(ctx, response, r)
+	resp, err := rt.codec.DecodeResponse(ctx, response, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Report the progress of the query to the active queries of the frontend.
+	switch res := resp.(type) {
+	case *LokiResponse:
+		transport.ActiveQueryFromContext(ctx).AddBytesProcessed(res.Statistics.Summary.TotalBytesProcessed)
+	case *LokiPromResponse:
+		transport.ActiveQueryFromContext(ctx).AddBytesProcessed(res.Statistics.Summary.TotalBytesProcessed)
+	}
+	return resp, nil
 }
@@ -8,18 +8,29 @@ import (
 	"testing"
 	"time"
 
+	"github.com/grafana/dskit/flagext"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql"
 	"github.com/stretchr/testify/require"
 	"github.com/weaveworks/common/user"
 	"go.uber.org/atomic"
 
+	"github.com/grafana/loki/pkg/chunkenc"
+	"github.com/grafana/loki/pkg/ingester/client"
+	"github.com/grafana/loki/pkg/loghttp"
 	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/syntax"
 	"github.com/grafana/loki/pkg/logqlmodel"
 	"github.com/grafana/loki/pkg/querier/queryrange/queryrangebase"
+	"github.com/grafana/loki/pkg/storage"
+	"github.com/grafana/loki/pkg/storage/chunk"
+	"github.com/grafana/loki/pkg/storage/chunk/client/testutils"
 	"github.com/grafana/loki/pkg/storage/config"
+	"github.com/grafana/loki/pkg/storage/stores/series/index"
 	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/util/marshal"
+	"github.com/grafana/loki/pkg/validation"
 )
 
 func TestLimits(t *testing.T) {
@@ -52,7 +63,7 @@ func Test_seriesLimiter(t *testing.T) {
 	cfg.CacheResults = false
 	// split in 7 with 2 in // max.
 	l := WithSplitByLimits(fakeLimits{maxSeries: 1, maxQueryParallelism: 2}, time.Hour)
-	tpw, stopper, err := NewTripperware(cfg, util_log.Logger, l, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(cfg, util_log.Logger, l, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -237,7 +248,7 @@ func Test_MaxQueryLookBack(t *testing.T) {
 	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{
 		maxQueryLookback:    1 * time.Hour,
 		maxQueryParallelism: 1,
-	}, config.SchemaConfig{}, nil)
+	}, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -282,3 +293,240 @@ func Test_GenerateCacheKey_NoDivideZero(t *testing.T) {
 		l.GenerateCacheKey("foo", r),
 	)
 }
+
+func Test_QuerySizeLimiter(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		query      string
+		limit      int
+		statsBytes uint64
+		statsErr   error
+
+		expectedErr      bool
+		expectedStats    int
+		expectedLookback time.Duration
+		expectedEstimate int64
+	}{
+		{
+			desc:  "disabled",
+			query: `{app="foo"} |= "foo"`,
+		},
+		{
+			desc:             "under the limit",
+			query:            `{app="foo"} |= "foo"`,
+			limit:            100,
+			statsBytes:       100,
+			expectedStats:    1,
+			expectedEstimate: 100,
+		},
+		{
+			desc:          "over the limit",
+			query:         `{app="foo"} |= "foo"`,
+			limit:         100,
+			statsBytes:    101,
+			expectedErr:   true,
+			expectedStats: 1,
+		},
+		{
+			desc:             "range and duplicate selectors",
+			query:            `sum(rate({app="foo"} |= "foo" [5m] offset 1m)) / sum(rate({app="foo"}[1m])) + sum(rate({app="bar"}[1m]))`,
+			limit:            100,
+			statsBytes:       50,
+			expectedStats:    2,
+			expectedLookback: 6 * time.Minute,
+			expectedEstimate: 100,
+		},
+		{
+			desc:             "subquery",
+			query:            `max_over_time(sum(rate({app="foo"}[1m]))[10m:1m])`,
+			limit:            100,
+			statsBytes:       50,
+			expectedStats:    1,
+			expectedLookback: 11 * time.Minute,
+			expectedEstimate: 50,
+		},
+		{
+			desc:          "stats error",
+			query:         `{app="foo"} |= "foo"`,
+			limit:         100,
+			statsErr:      fmt.Errorf("stats not available"),
+			expectedStats: 1,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := &LokiRequest{
+				Query:   tc.query,
+				StartTs: testTime.Add(-time.Hour),
+				EndTs:   testTime,
+			}
+
+			var (
+				mtx        sync.Mutex
+				statsCalls int
+			)
+			statsHandler := queryrangebase.HandlerFunc(func(_ context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				statsCalls++
+				require.Equal(t, req.StartTs.Add(-tc.expectedLookback).UnixMilli(), r.(*LokiIndexStatsRequest).StartTs.UnixMilli())
+				require.Equal(t, req.EndTs.UnixMilli(), r.(*LokiIndexStatsRequest).EndTs.UnixMilli())
+				if tc.statsErr != nil {
+					return nil, tc.statsErr
+				}
+				return &LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{Bytes: tc.statsBytes}}, nil
+			})
+			next := queryrangebase.HandlerFunc(func(context.Context, queryrangebase.Request) (queryrangebase.Response, error) {
+				return &LokiResponse{Status: loghttp.QueryStatusSuccess}, nil
+			})
+
+			ctx := user.InjectOrgID(context.Background(), "1")
+			limits := fakeLimits{maxQueryBytesRead: tc.limit}
+			resp, err := NewQuerySizeLimiterMiddleware(util_log.Logger, limits, statsHandler).Wrap(next).Do(ctx, req)
+			require.Equal(t, tc.expectedStats, statsCalls)
+			if tc.expectedErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "estimated: 101 B, limit: 100 B")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedEstimate, resp.(*LokiResponse).Statistics.Summary.TotalBytesEstimated)
+		})
+	}
+}
+
+func Test_QuerySizeLimiterWithStoreStats(t *testing.T) {
+	schemaCfg := testutils.SchemaConfig("", "v11", 0)
+	schemaCfg.Configs[0].IndexType = "inmemory"
+	schemaCfg.Configs[0].ObjectType = "inmemory"
+	require.NoError(t, schemaCfg.Validate())
+
+	testutils.ResetMockStorage()
+	var tbmConfig index.TableManagerConfig
+	flagext.DefaultValues(&tbmConfig)
+	tm, err := index.NewTableManager(tbmConfig, schemaCfg, 12*time.Hour, testutils.NewMockStorage(), nil, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, tm.SyncTables(context.Background()))
+
+	overrides, err := validation.NewOverrides(validation.Limits{}, nil)
+	require.NoError(t, err)
+	var storeCfg config.ChunkStoreConfig
+	flagext.DefaultValues(&storeCfg)
+	store, err := storage.NewStore(storage.Config{MaxChunkBatchSize: 1}, storeCfg, schemaCfg, overrides, storage.NewClientMetrics(), nil, util_log.Logger)
+	require.NoError(t, err)
+	defer store.Stop()
+
+	// flush a chunk of the queried stream to the store.
+	lbs := labels.Labels{{Name: labels.MetricName, Value: "logs"}, {Name: "app", Value: "foo"}}
+	memChk := chunkenc.NewMemChunk(chunkenc.EncSnappy, chunkenc.UnorderedHeadBlockFmt, 256*1024, 0)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, memChk.Append(&logproto.Entry{
+			Timestamp: testTime.Add(-time.Hour).Add(time.Duration(i) * time.Second),
+			Line:      fmt.Sprintf("line %d", i),
+		}))
+	}
+	require.NoError(t, memChk.Close())
+	chk := chunk.NewChunk("1", client.Fingerprint(lbs), lbs, chunkenc.NewFacade(memChk, 0, 0), model.TimeFromUnixNano(testTime.Add(-time.Hour).UnixNano()), model.TimeFromUnixNano(testTime.UnixNano()))
+	require.NoError(t, chk.Encode())
+	ctx := user.InjectOrgID(context.Background(), "1")
+	require.NoError(t, store.Put(ctx, []chunk.Chunk{chk}))
+	storedBytes := memChk.UncompressedSize()
+
+	// the index stats are computed by the store, as they are by the queriers.
+	statsHandler := queryrangebase.HandlerFunc(func(ctx context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
+		matchers, err := syntax.ParseMatchers(r.GetQuery())
+		if err != nil {
+			return nil, err
+		}
+		stats, err := store.Stats(ctx, "1", model.TimeFromUnixNano(r.GetStart()*1e6), model.TimeFromUnixNano(r.GetEnd()*1e6), matchers...)
+		if err != nil {
+			return nil, err
+		}
+		return &LokiIndexStatsResponse{Response: stats}, nil
+	})
+	next := queryrangebase.HandlerFunc(func(context.Context, queryrangebase.Request) (queryrangebase.Response, error) {
+		return &LokiResponse{Status: loghttp.QueryStatusSuccess}, nil
+	})
+	req := &LokiRequest{
+		Query:   `{app="foo"} |= "line"`,
+		StartTs: testTime.Add(-2 * time.Hour),
+		EndTs:   testTime,
+	}
+
+	resp, err := NewQuerySizeLimiterMiddleware(util_log.Logger, fakeLimits{maxQueryBytesRead: storedBytes}, statsHandler).Wrap(next).Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, int64(storedBytes), resp.(*LokiResponse).Statistics.Summary.TotalBytesEstimated)
+
+	_, err = NewQuerySizeLimiterMiddleware(util_log.Logger, fakeLimits{maxQueryBytesRead: storedBytes - 1}, statsHandler).Wrap(next).Do(ctx, req)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the query would read too many bytes")
+
+	_, err = NewQuerierSizeLimiterMiddleware(util_log.Logger, fakeLimits{maxQuerierBytesRead: storedBytes - 1}, statsHandler, nil).Wrap(next).Do(ctx, req)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "a subquery of the query would read too many bytes")
+}
+
+func Test_QuerierSizeLimiter(t *testing.T) {
+	statsHandler := queryrangebase.HandlerFunc(func(context.Context, queryrangebase.Request) (queryrangebase.Response, error) {
+		return &LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{Bytes: 100}}, nil
+	})
+	next := queryrangebase.HandlerFunc(func(context.Context, queryrangebase.Request) (queryrangebase.Response, error) {
+		return &LokiResponse{Status: loghttp.QueryStatusSuccess}, nil
+	})
+	req := &LokiRequest{
+		Query:   `{app="foo"} |= "foo"`,
+		StartTs: testTime.Add(-time.Hour),
+		EndTs:   testTime,
+	}
+	ctx := user.InjectOrgID(context.Background(), "1")
+
+	// The estimate is not recorded for subqueries.
+	resp, err := NewQuerierSizeLimiterMiddleware(util_log.Logger, fakeLimits{maxQuerierBytesRead: 100}, statsHandler, nil).Wrap(next).Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), resp.(*LokiResponse).Statistics.Summary.TotalBytesEstimated)
+
+	_, err = NewQuerierSizeLimiterMiddleware(util_log.Logger, fakeLimits{maxQuerierBytesRead: 99}, statsHandler, nil).Wrap(next).Do(ctx, req)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "a subquery of the query would read too many bytes")
+}
+
+func Test_QuerierSizeLimiterSharded(t *testing.T) {
+	statsHandler := queryrangebase.HandlerFunc(func(context.Context, queryrangebase.Request) (queryrangebase.Response, error) {
+		return &LokiIndexStatsResponse{Response: &logproto.IndexStatsResponse{Bytes: 100}}, nil
+	})
+	next := queryrangebase.HandlerFunc(func(context.Context, queryrangebase.Request) (queryrangebase.Response, error) {
+		return &LokiPromResponse{Response: &queryrangebase.PrometheusResponse{Status: loghttp.QueryStatusSuccess}}, nil
+	})
+	confs := ShardingConfigs{{RowShards: 4}}
+	ctx := user.InjectOrgID(context.Background(), "1")
+
+	for _, tc := range []struct {
+		name     string
+		query    string
+		limits   fakeLimits
+		maxBytes int
+	}{
+		// Each of the 4 shards reads a quarter of the bytes.
+		{name: "sharded", query: `sum(rate({app="foo"}[1m]))`, maxBytes: 25},
+		{name: "not shardable", query: `quantile_over_time(0.99, {app="foo"} | unwrap latency [1m])`, maxBytes: 100},
+		{name: "quantile sharding enabled", query: `quantile_over_time(0.99, {app="foo"} | unwrap latency [1m])`, limits: fakeLimits{quantileSharding: true}, maxBytes: 25},
+		{name: "within the sharding lookback", query: `sum(rate({app="foo"}[1m]))`, limits: fakeLimits{minShardingLookback: 2 * time.Hour}, maxBytes: 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			end := time.Now()
+			req := &LokiRequest{
+				Query:   tc.query,
+				StartTs: end.Add(-time.Hour),
+				EndTs:   end,
+			}
+
+			tc.limits.maxQuerierBytesRead = tc.maxBytes
+			_, err := NewQuerierSizeLimiterMiddleware(util_log.Logger, tc.limits, statsHandler, confs).Wrap(next).Do(ctx, req)
+			require.NoError(t, err)
+
+			tc.limits.maxQuerierBytesRead = tc.maxBytes - 1
+			_, err = NewQuerierSizeLimiterMiddleware(util_log.Logger, tc.limits, statsHandler, confs).Wrap(next).Do(ctx, req)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "a subquery of the query would read too many bytes")
+		})
+	}
+}
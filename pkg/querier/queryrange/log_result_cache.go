@@ -9,6 +9,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/common/model"
@@ -46,31 +47,38 @@ func NewLogResultCacheMetrics(registerer prometheus.Registerer) *LogResultCacheM
 }
 
 // NewLogResultCache creates a new log result cache middleware.
-// Currently it only caches empty filter queries, this is because those are usually easily and freely cacheable.
-// Log hits are difficult to handle because of the limit query parameter and the size of the response.
-// In the future it could be extended to cache non-empty query results.
+// It caches the responses of split log queries, one cache entry per query, split interval, direction and limit.
+// Each entry holds the response of a single contiguous time range within the split interval, which is extended
+// when a request of the same split interval is not fully covered by it.
+// Since a response holds at most limit entries, a cached response can only be used for a smaller time range
+// if it's complete for that range.
 // see https://docs.google.com/document/d/1_mACOpxdWZ5K0cIedaja5gzMbv-m0lUVazqZd2O4mEU/edit
-func NewLogResultCache(logger log.Logger, limits Limits, cache cache.Cache, shouldCache queryrangebase.ShouldCacheFn, metrics *LogResultCacheMetrics) queryrangebase.Middleware {
+func NewLogResultCache(logger log.Logger, limits Limits, c cache.Cache, shouldCache queryrangebase.ShouldCacheFn, cacheGenNumberLoader queryrangebase.CacheGenNumberLoader, metrics *LogResultCacheMetrics) queryrangebase.Middleware {
 	if metrics == nil {
 		metrics = NewLogResultCacheMetrics(nil)
 	}
+	if cacheGenNumberLoader != nil {
+		c = cache.NewCacheGenNumMiddleware(c)
+	}
 	return queryrangebase.MiddlewareFunc(func(next queryrangebase.Handler) queryrangebase.Handler {
 		return &logResultCache{
-			next:        next,
-			limits:      limits,
-			cache:       cache,
-			logger:      logger,
-			shouldCache: shouldCache,
-			metrics:     metrics,
+			next:                 next,
+			limits:               limits,
+			cache:                c,
+			logger:               logger,
+			shouldCache:          shouldCache,
+			cacheGenNumberLoader: cacheGenNumberLoader,
+			metrics:              metrics,
 		}
 	})
 }
 
 type logResultCache struct {
-	next        queryrangebase.Handler
-	limits      Limits
-	cache       cache.Cache
-	shouldCache queryrangebase.ShouldCacheFn
+	next                 queryrangebase.Handler
+	limits               Limits
+	cache                cache.Cache
+	shouldCache          queryrangebase.ShouldCacheFn
+	cacheGenNumberLoader queryrangebase.CacheGenNumberLoader
 
 	metrics *LogResultCacheMetrics
 	logger  log.Logger
@@ -86,6 +94,10 @@ func (l *logResultCache) Do(ctx context.Context, req queryrangebase.Request) (qu
 		return l.next.Do(ctx, req)
 	}
 
+	if l.cacheGenNumberLoader != nil {
+		ctx = cache.InjectCacheGenNumber(ctx, l.cacheGenNumberLoader.GetResultsCacheGenNumber(ctx, tenantIDs))
+	}
+
 	maxCacheFreshness := validation.MaxDurationPerTenant(tenantIDs, l.limits.MaxCacheFreshness)
 	maxCacheTime := int64(model.Now().Add(-maxCacheFreshness))
 	if req.GetEnd() > maxCacheTime {
@@ -104,8 +116,8 @@ func (l *logResultCache) Do(ctx context.Context, req queryrangebase.Request) (qu
 	}
 	// The first subquery might not be aligned.
 	alignedStart := time.Unix(0, lokiReq.GetStartTs().UnixNano()-(lokiReq.GetStartTs().UnixNano()%interval.Nanoseconds()))
-	// generate the cache key based on query, tenant and start time.
-	cacheKey := fmt.Sprintf("log:%s:%s:%d:%d", tenant.JoinTenantIDs(tenantIDs), req.GetQuery(), interval.Nanoseconds(), alignedStart.UnixNano()/(interval.Nanoseconds()))
+	// generate the cache key based on query, tenant, start time, direction and limit.
+	cacheKey := fmt.Sprintf("log:%s:%s:%d:%d:%d:%d", tenant.JoinTenantIDs(tenantIDs), req.GetQuery(), interval.Nanoseconds(), alignedStart.UnixNano()/(interval.Nanoseconds()), lokiReq.Direction, lokiReq.Limit)
 
 	_, buff, _, err := l.cache.Fetch(ctx, []string{cache.HashKey(cacheKey)})
 	if err != nil {
@@ -124,13 +136,23 @@ func (l *logResultCache) Do(ctx context.Context, req queryrangebase.Request) (qu
 	}
 
 	// cache hit
-	var cachedRequest LokiRequest
-	err = proto.Unmarshal(buff[0], &cachedRequest)
+	var cached queryrangebase.CachedResponse
+	err = proto.Unmarshal(buff[0], &cached)
+	if err != nil {
+		level.Warn(l.logger).Log("msg", "error unmarshalling response from cache", "err", err)
+		return l.next.Do(ctx, req)
+	}
+	// Different keys can share the same hash.
+	if cached.Key != cacheKey || len(cached.Extents) != 1 {
+		return l.handleMiss(ctx, cacheKey, lokiReq)
+	}
+	extent := cached.Extents[0]
+	cachedResp, err := extentToLokiResponse(extent)
 	if err != nil {
-		level.Warn(l.logger).Log("msg", "error unmarshalling request from cache", "err", err)
+		level.Warn(l.logger).Log("msg", "error unmarshalling response from cache", "err", err)
 		return l.next.Do(ctx, req)
 	}
-	return l.handleHit(ctx, cacheKey, &cachedRequest, lokiReq)
+	return l.handleHit(ctx, cacheKey, time.Unix(0, extent.Start), time.Unix(0, extent.End), cachedResp, lokiReq)
 }
 
 func (l *logResultCache) handleMiss(ctx context.Context, cacheKey string, req *LokiRequest) (queryrangebase.Response, error) {
@@ -144,51 +166,43 @@ func (l *logResultCache) handleMiss(ctx context.Context, cacheKey string, req *L
 	if !ok {
 		return nil, fmt.Errorf("unexpected response type %T", resp)
 	}
-	// At the moment we only cache empty results
-	if !isEmpty(lokiRes) {
-		return resp, nil
-	}
-	data, err := proto.Marshal(req)
-	if err != nil {
-		level.Warn(l.logger).Log("msg", "error marshalling request", "err", err)
-		return resp, nil
-	}
-	// cache the result
-	err = l.cache.Store(ctx, []string{cache.HashKey(cacheKey)}, [][]byte{data})
-	if err != nil {
-		level.Warn(l.logger).Log("msg", "error storing cache", "err", err)
+	if l.shouldCacheResponse(ctx, lokiRes) {
+		l.store(ctx, cacheKey, req.GetStartTs(), req.GetEndTs(), lokiRes)
 	}
 	return resp, nil
 }
 
-func (l *logResultCache) handleHit(ctx context.Context, cacheKey string, cachedRequest *LokiRequest, lokiReq *LokiRequest) (queryrangebase.Response, error) {
-	l.metrics.CacheHit.Inc()
-	// we start with an empty response
-	result := emptyResponse(cachedRequest)
-	// if the request is the same and cover the whole time range,
-	// we can just return the cached result.
-	if !lokiReq.GetStartTs().After(cachedRequest.GetStartTs()) && lokiReq.GetStartTs().Equal(cachedRequest.GetStartTs()) &&
-		!lokiReq.GetEndTs().Before(cachedRequest.GetEndTs()) && lokiReq.GetEndTs().Equal(cachedRequest.GetEndTs()) {
+func (l *logResultCache) handleHit(ctx context.Context, cacheKey string, cachedStart, cachedEnd time.Time, cachedResp *LokiResponse, lokiReq *LokiRequest) (queryrangebase.Response, error) {
+	start, end := lokiReq.GetStartTs(), lokiReq.GetEndTs()
+
+	// if the request is within the cached time range, we can use the cached response if it's complete for the request.
+	if !start.Before(cachedStart) && !end.After(cachedEnd) {
+		result, ok := extractLokiResponse(cachedResp, cachedStart, cachedEnd, start, end)
+		if !ok {
+			// the cached response has been truncated by the limit and might miss entries of the request.
+			l.metrics.CacheMiss.Inc()
+			return l.next.Do(ctx, lokiReq)
+		}
+		l.metrics.CacheHit.Inc()
 		return result, nil
 	}
+
+	l.metrics.CacheHit.Inc()
 	// we could be missing data at the start and the end.
 	// so we're going to fetch what is missing.
 	var (
-		startRequest, endRequest *LokiRequest
-		startResp, endResp       *LokiResponse
-		updateCache              bool
-		ok                       bool
+		startResp, endResp *LokiResponse
 	)
-	g, ctx := errgroup.WithContext(ctx)
+	g, gctx := errgroup.WithContext(ctx)
 
 	// if we're missing data at the start, start fetching from the start to the cached start.
-	if lokiReq.GetStartTs().Before(cachedRequest.GetStartTs()) {
+	if start.Before(cachedStart) {
 		g.Go(func() error {
-			startRequest = lokiReq.WithStartEndTime(lokiReq.GetStartTs(), cachedRequest.GetStartTs())
-			resp, err := l.next.Do(ctx, startRequest)
+			resp, err := l.next.Do(gctx, lokiReq.WithStartEndTime(start, cachedStart))
 			if err != nil {
 				return err
 			}
+			var ok bool
 			startResp, ok = resp.(*LokiResponse)
 			if !ok {
 				return fmt.Errorf("unexpected response type %T", resp)
@@ -198,13 +212,13 @@ func (l *logResultCache) handleHit(ctx context.Context, cacheKey string, cachedR
 	}
 
 	// if we're missing data at the end, start fetching from the cached end to the end.
-	if lokiReq.GetEndTs().After(cachedRequest.GetEndTs()) {
+	if end.After(cachedEnd) {
 		g.Go(func() error {
-			endRequest = lokiReq.WithStartEndTime(cachedRequest.GetEndTs(), lokiReq.GetEndTs())
-			resp, err := l.next.Do(ctx, endRequest)
+			resp, err := l.next.Do(gctx, lokiReq.WithStartEndTime(cachedEnd, end))
 			if err != nil {
 				return err
 			}
+			var ok bool
 			endResp, ok = resp.(*LokiResponse)
 			if !ok {
 				return fmt.Errorf("unexpected response type %T", resp)
@@ -217,52 +231,178 @@ func (l *logResultCache) handleHit(ctx context.Context, cacheKey string, cachedR
 		return nil, err
 	}
 
-	// if we have data at the start, we need to merge it with the cached data if it's empty and update the cache.
-	// If it's not empty only merge the response.
-	if startResp != nil {
-		if isEmpty(startResp) {
-			cachedRequest = cachedRequest.WithStartEndTime(startRequest.GetStartTs(), cachedRequest.GetEndTs())
-			updateCache = true
-		} else {
-			if startResp.Status != loghttp.QueryStatusSuccess {
-				return startResp, nil
+	// The responses are merged in the order of the query direction, since merging stops once the limit is reached.
+	responses := make([]queryrangebase.Response, 0, 3)
+	shouldCache := true
+	for _, resp := range []*LokiResponse{startResp, cachedResp, endResp} {
+		if resp == nil {
+			continue
+		}
+		if resp.Status != loghttp.QueryStatusSuccess {
+			return resp, nil
+		}
+		if resp != cachedResp && !l.shouldCacheResponse(ctx, resp) {
+			shouldCache = false
+		}
+		responses = append(responses, resp)
+	}
+	if lokiReq.Direction == logproto.BACKWARD {
+		for i, j := 0, len(responses)-1; i < j; i, j = i+1, j-1 {
+			responses[i], responses[j] = responses[j], responses[i]
+		}
+	}
+	merged := mergeLokiResponse(responses...)
+
+	// The merged response holds the first entries, according to the limit, of the union of the time ranges,
+	// which is the response of the whole extended time range.
+	extendedStart, extendedEnd := cachedStart, cachedEnd
+	if start.Before(extendedStart) {
+		extendedStart = start
+	}
+	if end.After(extendedEnd) {
+		extendedEnd = end
+	}
+	if shouldCache {
+		l.store(ctx, cacheKey, extendedStart, extendedEnd, merged)
+	}
+
+	if start.Equal(extendedStart) && end.Equal(extendedEnd) {
+		return merged, nil
+	}
+	// the request doesn't cover the whole cached time range, e.g. if it's before it.
+	result, ok := extractLokiResponse(merged, extendedStart, extendedEnd, start, end)
+	if !ok {
+		return l.next.Do(ctx, lokiReq)
+	}
+	return result, nil
+}
+
+// shouldCacheResponse returns true if the response is successful and computed with the current results cache gen number.
+// Queriers don't have to report the gen number they computed the response with, so the response is only cached if the
+// gen number didn't change while it was computed.
+func (l *logResultCache) shouldCacheResponse(ctx context.Context, resp *LokiResponse) bool {
+	if resp.Status != loghttp.QueryStatusSuccess {
+		return false
+	}
+	if l.cacheGenNumberLoader == nil {
+		return true
+	}
+
+	genNumber := cache.ExtractCacheGenNumber(ctx)
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil || l.cacheGenNumberLoader.GetResultsCacheGenNumber(ctx, tenantIDs) != genNumber {
+		return false
+	}
+	for _, h := range resp.Headers {
+		if h.Name != queryrangebase.ResultsCacheGenNumberHeaderName {
+			continue
+		}
+		for _, v := range h.Values {
+			if v != genNumber {
+				level.Debug(l.logger).Log("msg", "inconsistency in results cache gen numbers, not caching the response", "genFromResponse", v, "genFromStore", genNumber)
+				return false
 			}
-			result = mergeLokiResponse(startResp, result)
 		}
 	}
+	return true
+}
+
+// store caches the response of the given time range. Statistics and headers are not cached.
+func (l *logResultCache) store(ctx context.Context, cacheKey string, start, end time.Time, resp *LokiResponse) {
+	cachedResp := *resp
+	cachedResp.Statistics = stats.Result{}
+	cachedResp.Headers = nil
+
+	any, err := types.MarshalAny(&cachedResp)
+	if err != nil {
+		level.Warn(l.logger).Log("msg", "error marshalling response", "err", err)
+		return
+	}
+	data, err := proto.Marshal(&queryrangebase.CachedResponse{
+		Key: cacheKey,
+		Extents: []queryrangebase.Extent{{
+			Start:    start.UnixNano(),
+			End:      end.UnixNano(),
+			Response: any,
+		}},
+	})
+	if err != nil {
+		level.Warn(l.logger).Log("msg", "error marshalling response", "err", err)
+		return
+	}
+	// cache the result
+	err = l.cache.Store(ctx, []string{cache.HashKey(cacheKey)}, [][]byte{data})
+	if err != nil {
+		level.Warn(l.logger).Log("msg", "error storing cache", "err", err)
+	}
+}
+
+// extentToLokiResponse returns the response of a cached extent. Extents of log results are in nanoseconds.
+func extentToLokiResponse(extent queryrangebase.Extent) (*LokiResponse, error) {
+	var resp LokiResponse
+	if err := types.UnmarshalAny(extent.Response, &resp); err != nil {
+		return nil, err
+	}
+	// empty results are unmarshalled as nil, which would be encoded as null.
+	if resp.Data.Result == nil {
+		resp.Data.Result = []logproto.Stream{}
+	}
+	return &resp, nil
+}
+
+// extractLokiResponse returns the entries of the response of [respStart, respEnd) which are in [start, end).
+// It returns false if the response has been truncated by the limit and might miss some entries of [start, end):
+// a backward response has all the entries after its oldest one, and a forward response all the entries before its
+// newest one.
+func extractLokiResponse(resp *LokiResponse, respStart, respEnd, start, end time.Time) (*LokiResponse, bool) {
+	if start.Equal(respStart) && end.Equal(respEnd) {
+		return resp, true
+	}
 
-	// if we have data at the end, we need to merge it with the cached data if it's empty and update the cache.
-	// If it's not empty only merge the response.
-	if endResp != nil {
-		if isEmpty(endResp) {
-			cachedRequest = cachedRequest.WithStartEndTime(cachedRequest.GetStartTs(), endRequest.GetEndTs())
-			updateCache = true
-		} else {
-			if endResp.Status != loghttp.QueryStatusSuccess {
-				return endResp, nil
+	var (
+		total            int
+		oldest, newest   time.Time
+		extractedStreams = make([]logproto.Stream, 0, len(resp.Data.Result))
+	)
+	for _, stream := range resp.Data.Result {
+		var entries []logproto.Entry
+		for _, e := range stream.Entries {
+			if total == 0 || e.Timestamp.Before(oldest) {
+				oldest = e.Timestamp
+			}
+			if total == 0 || e.Timestamp.After(newest) {
+				newest = e.Timestamp
+			}
+			total++
+			if !e.Timestamp.Before(start) && e.Timestamp.Before(end) {
+				entries = append(entries, e)
 			}
-			result = mergeLokiResponse(endResp, result)
+		}
+		if len(entries) > 0 {
+			extractedStreams = append(extractedStreams, logproto.Stream{Labels: stream.Labels, Entries: entries})
 		}
 	}
 
-	// we need to update the cache since we fetched more either at the end or the start and it was empty.
-	if updateCache {
-		data, err := proto.Marshal(cachedRequest)
-		if err != nil {
-			level.Warn(l.logger).Log("msg", "error marshalling request", "err", err)
-			return result, err
+	if total > 0 && total >= int(resp.Limit) {
+		if resp.Direction == logproto.BACKWARD && !start.After(oldest) {
+			return nil, false
 		}
-		// cache the result
-		err = l.cache.Store(ctx, []string{cache.HashKey(cacheKey)}, [][]byte{data})
-		if err != nil {
-			level.Warn(l.logger).Log("msg", "error storing cache", "err", err)
+		if resp.Direction == logproto.FORWARD && end.After(newest) {
+			return nil, false
 		}
 	}
-	return result, nil
-}
 
-func isEmpty(lokiRes *LokiResponse) bool {
-	return lokiRes.Status == loghttp.QueryStatusSuccess && len(lokiRes.Data.Result) == 0
+	return &LokiResponse{
+		Status:     loghttp.QueryStatusSuccess,
+		Direction:  resp.Direction,
+		Limit:      resp.Limit,
+		Version:    resp.Version,
+		Statistics: stats.Result{},
+		Data: LokiData{
+			ResultType: loghttp.ResultTypeStream,
+			Result:     extractedStreams,
+		},
+	}, true
 }
 
 func emptyResponse(lokiReq *LokiRequest) *LokiResponse {
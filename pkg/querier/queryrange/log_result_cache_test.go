@@ -3,6 +3,7 @@ package queryrange
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ func Test_LogResultCacheSameRange(t *testing.T) {
 			cache.NewMockCache(),
 			nil,
 			nil,
+			nil,
 		)
 	)
 
@@ -69,6 +71,7 @@ func Test_LogResultCacheSameRangeNonEmpty(t *testing.T) {
 			cache.NewMockCache(),
 			nil,
 			nil,
+			nil,
 		)
 	)
 
@@ -84,12 +87,6 @@ func Test_LogResultCacheSameRangeNonEmpty(t *testing.T) {
 				Response: nonEmptyResponse(req, 1),
 			},
 		},
-		{
-			RequestResponse: queryrangebase.RequestResponse{
-				Request:  req,
-				Response: nonEmptyResponse(req, 2),
-			},
-		},
 	})
 
 	h := lrc.Wrap(fake)
@@ -99,7 +96,7 @@ func Test_LogResultCacheSameRangeNonEmpty(t *testing.T) {
 	require.Equal(t, nonEmptyResponse(req, 1), resp)
 	resp, err = h.Do(ctx, req)
 	require.NoError(t, err)
-	require.Equal(t, nonEmptyResponse(req, 2), resp)
+	require.Equal(t, nonEmptyResponse(req, 1), resp)
 
 	fake.AssertExpectations(t)
 }
@@ -115,6 +112,7 @@ func Test_LogResultCacheSmallerRange(t *testing.T) {
 			cache.NewMockCache(),
 			nil,
 			nil,
+			nil,
 		)
 	)
 
@@ -161,6 +159,7 @@ func Test_LogResultCacheDifferentRange(t *testing.T) {
 			cache.NewMockCache(),
 			nil,
 			nil,
+			nil,
 		)
 	)
 
@@ -214,7 +213,17 @@ func Test_LogResultCacheDifferentRange(t *testing.T) {
 	require.Equal(t, emptyResponse(req1), resp)
 	resp, err = h.Do(ctx, req2)
 	require.NoError(t, err)
-	require.Equal(t, emptyResponse(req2), resp)
+	require.Equal(t, mergeLokiResponse(
+		emptyResponse(&LokiRequest{
+			StartTs: time.Unix(0, time.Minute.Nanoseconds()),
+			EndTs:   time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
+		}),
+		emptyResponse(req1),
+		emptyResponse(&LokiRequest{
+			StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
+			EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
+		}),
+	), resp)
 
 	fake.AssertExpectations(t)
 }
@@ -230,15 +239,18 @@ func Test_LogResultCacheDifferentRangeNonEmpty(t *testing.T) {
 			cache.NewMockCache(),
 			nil,
 			nil,
+			nil,
 		)
 	)
 
 	req1 := &LokiRequest{
+		Limit:   10,
 		StartTs: time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
 		EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
 	}
 
 	req2 := &LokiRequest{
+		Limit:   10,
 		StartTs: time.Unix(0, time.Minute.Nanoseconds()),
 		EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
 	}
@@ -253,10 +265,12 @@ func Test_LogResultCacheDifferentRangeNonEmpty(t *testing.T) {
 		{
 			RequestResponse: queryrangebase.RequestResponse{
 				Request: &LokiRequest{
+					Limit:   10,
 					StartTs: time.Unix(0, time.Minute.Nanoseconds()),
 					EndTs:   time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
 				},
 				Response: nonEmptyResponse(&LokiRequest{
+					Limit:   10,
 					StartTs: time.Unix(0, time.Minute.Nanoseconds()),
 					EndTs:   time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
 				}, 1),
@@ -265,10 +279,12 @@ func Test_LogResultCacheDifferentRangeNonEmpty(t *testing.T) {
 		{
 			RequestResponse: queryrangebase.RequestResponse{
 				Request: &LokiRequest{
+					Limit:   10,
 					StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
 					EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
 				},
 				Response: nonEmptyResponse(&LokiRequest{
+					Limit:   10,
 					StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
 					EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
 				}, 2),
@@ -283,16 +299,26 @@ func Test_LogResultCacheDifferentRangeNonEmpty(t *testing.T) {
 	require.Equal(t, emptyResponse(req1), resp)
 	resp, err = h.Do(ctx, req2)
 	require.NoError(t, err)
-	require.Equal(t, mergeLokiResponse(
-		nonEmptyResponse(&LokiRequest{
-			StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
-			EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
-		}, 2),
+	expected := mergeLokiResponse(
 		nonEmptyResponse(&LokiRequest{
+			Limit:   10,
 			StartTs: time.Unix(0, time.Minute.Nanoseconds()),
 			EndTs:   time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
 		}, 1),
-	), resp)
+		emptyResponse(req1),
+		nonEmptyResponse(&LokiRequest{
+			Limit:   10,
+			StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
+			EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
+		}, 2),
+	)
+	require.Equal(t, expected, resp)
+
+	// the whole range is now cached.
+	resp, err = h.Do(ctx, req2)
+	require.NoError(t, err)
+	expected.Statistics = stats.Result{}
+	require.Equal(t, expected, resp)
 
 	fake.AssertExpectations(t)
 }
@@ -308,15 +334,18 @@ func Test_LogResultCacheDifferentRangeNonEmptyAndEmpty(t *testing.T) {
 			cache.NewMockCache(),
 			nil,
 			nil,
+			nil,
 		)
 	)
 
 	req1 := &LokiRequest{
+		Limit:   10,
 		StartTs: time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
 		EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
 	}
 
 	req2 := &LokiRequest{
+		Limit:   10,
 		StartTs: time.Unix(0, time.Minute.Nanoseconds()),
 		EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
 	}
@@ -331,10 +360,12 @@ func Test_LogResultCacheDifferentRangeNonEmptyAndEmpty(t *testing.T) {
 		{
 			RequestResponse: queryrangebase.RequestResponse{
 				Request: &LokiRequest{
+					Limit:   10,
 					StartTs: time.Unix(0, time.Minute.Nanoseconds()),
 					EndTs:   time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
 				},
 				Response: emptyResponse(&LokiRequest{
+					Limit:   10,
 					StartTs: time.Unix(0, time.Minute.Nanoseconds()),
 					EndTs:   time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
 				}),
@@ -343,23 +374,12 @@ func Test_LogResultCacheDifferentRangeNonEmptyAndEmpty(t *testing.T) {
 		{
 			RequestResponse: queryrangebase.RequestResponse{
 				Request: &LokiRequest{
+					Limit:   10,
 					StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
 					EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
 				},
 				Response: nonEmptyResponse(&LokiRequest{
-					StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
-					EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
-				}, 2),
-			},
-		},
-		// we call it twice
-		{
-			RequestResponse: queryrangebase.RequestResponse{
-				Request: &LokiRequest{
-					StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
-					EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
-				},
-				Response: nonEmptyResponse(&LokiRequest{
+					Limit:   10,
 					StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
 					EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
 				}, 2),
@@ -374,22 +394,403 @@ func Test_LogResultCacheDifferentRangeNonEmptyAndEmpty(t *testing.T) {
 	require.Equal(t, emptyResponse(req1), resp)
 	resp, err = h.Do(ctx, req2)
 	require.NoError(t, err)
-	require.Equal(t, mergeLokiResponse(
-		emptyResponse(req1),
-		nonEmptyResponse(&LokiRequest{
+	expected := mergeLokiResponse(
+		emptyResponse(&LokiRequest{
+			Limit:   10,
 			StartTs: time.Unix(0, time.Minute.Nanoseconds()),
 			EndTs:   time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
-		}, 1),
-	), resp)
-	resp, err = h.Do(ctx, req2)
-	require.NoError(t, err)
-	require.Equal(t, mergeLokiResponse(
+		}),
 		emptyResponse(req1),
 		nonEmptyResponse(&LokiRequest{
-			StartTs: time.Unix(0, time.Minute.Nanoseconds()),
-			EndTs:   time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
-		}, 1),
-	), resp)
+			Limit:   10,
+			StartTs: time.Unix(0, 2*time.Minute.Nanoseconds()-30*time.Second.Nanoseconds()),
+			EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
+		}, 2),
+	)
+	require.Equal(t, expected, resp)
+
+	// the whole range is now cached.
+	resp, err = h.Do(ctx, req2)
+	require.NoError(t, err)
+	expected.Statistics = stats.Result{}
+	require.Equal(t, expected, resp)
+	fake.AssertExpectations(t)
+}
+
+func Test_LogResultCacheSmallerRangeLimited(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		direction logproto.Direction
+		// entries of the cached response, which has reached the limit.
+		entries []time.Duration
+		req     *LokiRequest
+		// expected entries if the cached response can be used, otherwise the request is sent downstream.
+		expected []time.Duration
+		cached   bool
+	}{
+		{
+			name:      "backward after the oldest entry",
+			direction: logproto.BACKWARD,
+			entries:   []time.Duration{time.Minute + 50*time.Second, time.Minute + 40*time.Second},
+			req:       &LokiRequest{StartTs: time.Unix(0, int64(time.Minute+45*time.Second)), EndTs: time.Unix(0, int64(2*time.Minute))},
+			expected:  []time.Duration{time.Minute + 50*time.Second},
+			cached:    true,
+		},
+		{
+			name:      "backward before the oldest entry",
+			direction: logproto.BACKWARD,
+			entries:   []time.Duration{time.Minute + 50*time.Second, time.Minute + 40*time.Second},
+			req:       &LokiRequest{StartTs: time.Unix(0, int64(time.Minute+30*time.Second)), EndTs: time.Unix(0, int64(time.Minute+45*time.Second))},
+		},
+		{
+			name:      "forward before the newest entry",
+			direction: logproto.FORWARD,
+			entries:   []time.Duration{time.Minute + 10*time.Second, time.Minute + 20*time.Second},
+			req:       &LokiRequest{StartTs: time.Unix(0, int64(time.Minute)), EndTs: time.Unix(0, int64(time.Minute+15*time.Second))},
+			expected:  []time.Duration{time.Minute + 10*time.Second},
+			cached:    true,
+		},
+		{
+			name:      "forward after the newest entry",
+			direction: logproto.FORWARD,
+			entries:   []time.Duration{time.Minute + 10*time.Second, time.Minute + 20*time.Second},
+			req:       &LokiRequest{StartTs: time.Unix(0, int64(time.Minute+15*time.Second)), EndTs: time.Unix(0, int64(time.Minute+30*time.Second))},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				ctx = user.InjectOrgID(context.Background(), "foo")
+				lrc = NewLogResultCache(
+					log.NewNopLogger(),
+					fakeLimits{
+						splits: map[string]time.Duration{"foo": time.Minute},
+					},
+					cache.NewMockCache(),
+					nil,
+					nil,
+					nil,
+				)
+			)
+
+			req := &LokiRequest{
+				StartTs:   time.Unix(0, time.Minute.Nanoseconds()),
+				EndTs:     time.Unix(0, 2*time.Minute.Nanoseconds()),
+				Limit:     2,
+				Direction: tc.direction,
+			}
+			tc.req.Limit = req.Limit
+			tc.req.Direction = req.Direction
+
+			responses := []mockResponse{
+				{
+					RequestResponse: queryrangebase.RequestResponse{
+						Request:  req,
+						Response: responseWithEntries(req, tc.entries...),
+					},
+				},
+			}
+			if !tc.cached {
+				responses = append(responses, mockResponse{
+					RequestResponse: queryrangebase.RequestResponse{
+						Request:  tc.req,
+						Response: responseWithEntries(tc.req),
+					},
+				})
+			}
+			fake := newFakeResponse(responses)
+
+			h := lrc.Wrap(fake)
+
+			resp, err := h.Do(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, responseWithEntries(req, tc.entries...), resp)
+			resp, err = h.Do(ctx, tc.req)
+			require.NoError(t, err)
+			require.Equal(t, responseWithEntries(tc.req, tc.expected...), resp)
+
+			fake.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_LogResultCacheDifferentRangeLimited(t *testing.T) {
+	var (
+		ctx = user.InjectOrgID(context.Background(), "foo")
+		lrc = NewLogResultCache(
+			log.NewNopLogger(),
+			fakeLimits{
+				splits: map[string]time.Duration{"foo": time.Minute},
+			},
+			cache.NewMockCache(),
+			nil,
+			nil,
+			nil,
+		)
+	)
+
+	req1 := &LokiRequest{
+		StartTs:   time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
+		EndTs:     time.Unix(0, 2*time.Minute.Nanoseconds()),
+		Limit:     2,
+		Direction: logproto.BACKWARD,
+	}
+	req2 := &LokiRequest{
+		StartTs:   time.Unix(0, time.Minute.Nanoseconds()),
+		EndTs:     time.Unix(0, 2*time.Minute.Nanoseconds()),
+		Limit:     2,
+		Direction: logproto.BACKWARD,
+	}
+	missing := &LokiRequest{
+		StartTs:   time.Unix(0, time.Minute.Nanoseconds()),
+		EndTs:     time.Unix(0, time.Minute.Nanoseconds()+30*time.Second.Nanoseconds()),
+		Limit:     2,
+		Direction: logproto.BACKWARD,
+	}
+
+	fake := newFakeResponse([]mockResponse{
+		{
+			RequestResponse: queryrangebase.RequestResponse{
+				Request:  req1,
+				Response: responseWithEntries(req1, time.Minute+50*time.Second),
+			},
+		},
+		{
+			RequestResponse: queryrangebase.RequestResponse{
+				Request:  missing,
+				Response: responseWithEntries(missing, time.Minute+20*time.Second, time.Minute+10*time.Second),
+			},
+		},
+	})
+
+	h := lrc.Wrap(fake)
+
+	_, err := h.Do(ctx, req1)
+	require.NoError(t, err)
+
+	// Only the newest entries are kept.
+	resp, err := h.Do(ctx, req2)
+	require.NoError(t, err)
+	require.Equal(t, []logproto.Stream{
+		{
+			Labels: `{foo="bar"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: time.Unix(0, int64(time.Minute+50*time.Second)), Line: "line"},
+				{Timestamp: time.Unix(0, int64(time.Minute+20*time.Second)), Line: "line"},
+			},
+		},
+	}, resp.(*LokiResponse).Data.Result)
+
+	// The extended range is cached and can be used for a request after the oldest entry.
+	resp, err = h.Do(ctx, &LokiRequest{
+		StartTs:   time.Unix(0, time.Minute.Nanoseconds()+25*time.Second.Nanoseconds()),
+		EndTs:     time.Unix(0, 2*time.Minute.Nanoseconds()),
+		Limit:     2,
+		Direction: logproto.BACKWARD,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []logproto.Stream{
+		{
+			Labels:  `{foo="bar"}`,
+			Entries: []logproto.Entry{{Timestamp: time.Unix(0, int64(time.Minute+50*time.Second)), Line: "line"}},
+		},
+	}, resp.(*LokiResponse).Data.Result)
+
+	fake.AssertExpectations(t)
+}
+
+func Test_LogResultCacheMaxCacheFreshness(t *testing.T) {
+	var (
+		ctx = user.InjectOrgID(context.Background(), "foo")
+		lrc = NewLogResultCache(
+			log.NewNopLogger(),
+			fakeLimits{
+				splits: map[string]time.Duration{"foo": time.Minute},
+			},
+			cache.NewMockCache(),
+			nil,
+			nil,
+			nil,
+		)
+	)
+
+	// the end of the request is within the max cache freshness.
+	now := time.Now()
+	req := &LokiRequest{
+		StartTs: now.Add(-time.Minute),
+		EndTs:   now,
+		Limit:   10,
+	}
+
+	fake := newFakeResponse([]mockResponse{
+		{
+			RequestResponse: queryrangebase.RequestResponse{
+				Request:  req,
+				Response: nonEmptyResponse(req, 1),
+			},
+		},
+		{
+			RequestResponse: queryrangebase.RequestResponse{
+				Request:  req,
+				Response: nonEmptyResponse(req, 2),
+			},
+		},
+	})
+
+	h := lrc.Wrap(fake)
+
+	resp, err := h.Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, nonEmptyResponse(req, 1), resp)
+	resp, err = h.Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, nonEmptyResponse(req, 2), resp)
+
+	fake.AssertExpectations(t)
+}
+
+type fakeCacheGenNumberLoader string
+
+func (l fakeCacheGenNumberLoader) GetResultsCacheGenNumber(_ context.Context, _ []string) string {
+	return string(l)
+}
+
+func Test_LogResultCacheGenNumber(t *testing.T) {
+	var (
+		ctx = user.InjectOrgID(context.Background(), "foo")
+		c   = cache.NewMockCache()
+	)
+
+	req := &LokiRequest{
+		StartTs: time.Unix(0, time.Minute.Nanoseconds()),
+		EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
+		Limit:   10,
+	}
+	withGenNumber := func(resp *LokiResponse, gen string) *LokiResponse {
+		resp.Headers = []queryrangebase.PrometheusResponseHeader{
+			{Name: queryrangebase.ResultsCacheGenNumberHeaderName, Values: []string{gen}},
+		}
+		return resp
+	}
+
+	fake := newFakeResponse([]mockResponse{
+		// computed before a deletion, not cached.
+		{
+			RequestResponse: queryrangebase.RequestResponse{
+				Request:  req,
+				Response: withGenNumber(nonEmptyResponse(req, 1), "1"),
+			},
+		},
+		{
+			RequestResponse: queryrangebase.RequestResponse{
+				Request:  req,
+				Response: withGenNumber(nonEmptyResponse(req, 2), "2"),
+			},
+		},
+		// after another deletion, the cached response can't be used.
+		{
+			RequestResponse: queryrangebase.RequestResponse{
+				Request:  req,
+				Response: withGenNumber(nonEmptyResponse(req, 3), "3"),
+			},
+		},
+	})
+
+	newHandler := func(gen string) queryrangebase.Handler {
+		return NewLogResultCache(
+			log.NewNopLogger(),
+			fakeLimits{
+				splits: map[string]time.Duration{"foo": time.Minute},
+			},
+			c,
+			nil,
+			fakeCacheGenNumberLoader(gen),
+			nil,
+		).Wrap(fake)
+	}
+
+	resp, err := newHandler("2").Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "1", resp.(*LokiResponse).Data.Result[0].Entries[0].Line)
+
+	resp, err = newHandler("2").Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "2", resp.(*LokiResponse).Data.Result[0].Entries[0].Line)
+
+	resp, err = newHandler("2").Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "2", resp.(*LokiResponse).Data.Result[0].Entries[0].Line)
+
+	resp, err = newHandler("3").Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "3", resp.(*LokiResponse).Data.Result[0].Entries[0].Line)
+
+	fake.AssertExpectations(t)
+}
+
+type cacheGenNumberLoaderFunc func(tenantIDs []string) string
+
+func (f cacheGenNumberLoaderFunc) GetResultsCacheGenNumber(_ context.Context, tenantIDs []string) string {
+	return f(tenantIDs)
+}
+
+func Test_LogResultCacheGenNumberWithoutHeader(t *testing.T) {
+	var (
+		ctx = user.InjectOrgID(context.Background(), "foo")
+		c   = cache.NewMockCache()
+	)
+
+	req := &LokiRequest{
+		StartTs: time.Unix(0, time.Minute.Nanoseconds()),
+		EndTs:   time.Unix(0, 2*time.Minute.Nanoseconds()),
+		Limit:   10,
+	}
+
+	fake := newFakeResponse([]mockResponse{
+		// the gen number changes while the response is computed, not cached.
+		{
+			RequestResponse: queryrangebase.RequestResponse{
+				Request:  req,
+				Response: nonEmptyResponse(req, 1),
+			},
+		},
+		// queriers don't report the gen number, cached.
+		{
+			RequestResponse: queryrangebase.RequestResponse{
+				Request:  req,
+				Response: nonEmptyResponse(req, 2),
+			},
+		},
+	})
+
+	newHandler := func(loader queryrangebase.CacheGenNumberLoader) queryrangebase.Handler {
+		return NewLogResultCache(
+			log.NewNopLogger(),
+			fakeLimits{
+				splits: map[string]time.Duration{"foo": time.Minute},
+			},
+			c,
+			nil,
+			loader,
+			nil,
+		).Wrap(fake)
+	}
+
+	gen := 0
+	resp, err := newHandler(cacheGenNumberLoaderFunc(func(_ []string) string {
+		gen++
+		return strconv.Itoa(gen)
+	})).Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "1", resp.(*LokiResponse).Data.Result[0].Entries[0].Line)
+
+	resp, err = newHandler(fakeCacheGenNumberLoader("2")).Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "2", resp.(*LokiResponse).Data.Result[0].Entries[0].Line)
+
+	resp, err = newHandler(fakeCacheGenNumberLoader("2")).Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "2", resp.(*LokiResponse).Data.Result[0].Entries[0].Line)
+
 	fake.AssertExpectations(t)
 }
 
@@ -450,3 +851,16 @@ func nonEmptyResponse(lokiReq *LokiRequest, i int) *LokiResponse {
 		},
 	}
 }
+
+func responseWithEntries(lokiReq *LokiRequest, entries ...time.Duration) *LokiResponse {
+	resp := emptyResponse(lokiReq)
+	if len(entries) == 0 {
+		return resp
+	}
+	stream := logproto.Stream{Labels: `{foo="bar"}`}
+	for _, e := range entries {
+		stream.Entries = append(stream.Entries, logproto.Entry{Timestamp: time.Unix(0, int64(e)), Line: "line"})
+	}
+	resp.Data.Result = []logproto.Stream{stream}
+	return resp
+}
@@ -53,6 +53,7 @@ var emptyStats = `"stats": {
 		"linesProcessedPerSecond": 0,
 		"queueTime": 0,
 		"subqueries": 0,
+		"totalBytesEstimated": 0,
 		"totalBytesProcessed":0,
 		"totalLinesProcessed":0
 	}
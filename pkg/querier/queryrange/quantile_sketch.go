@@ -0,0 +1,121 @@
+package queryrange
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql"
+	"github.com/grafana/loki/pkg/logql/sketch"
+	"github.com/grafana/loki/pkg/logqlmodel/stats"
+	"github.com/grafana/loki/pkg/querier/queryrange/queryrangebase"
+)
+
+// ProtobufContentType is the content type of the responses encoded with protobuf
+// instead of JSON, used for results JSON cannot represent such as quantile sketches.
+// The name of the message is given by the proto parameter of the content type.
+const ProtobufContentType = "application/vnd.google.protobuf"
+
+// protobufContentType returns the content type of a response encoding the given message.
+func protobufContentType(m proto.Message) string {
+	return mime.FormatMediaType(ProtobufContentType, map[string]string{"proto": proto.MessageName(m)})
+}
+
+// WriteQuantileSketchResponse writes the protobuf encoded result of a quantile_sketch_over_time query.
+func WriteQuantileSketchResponse(w http.ResponseWriter, m logql.QuantileSketchMatrix, statistics stats.Result) error {
+	return writeProtobufResponse(w, &QuantileSketchResponse{
+		Data:       QuantileSketchMatrixFromLogQL(m),
+		Statistics: statistics,
+	})
+}
+
+// protobufResponse is a response encoded with protobuf by the querier.
+type protobufResponse interface {
+	queryrangebase.Response
+	Marshal() ([]byte, error)
+}
+
+func writeProtobufResponse(w http.ResponseWriter, resp protobufResponse) error {
+	buf, err := resp.Marshal()
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", protobufContentType(resp))
+	_, err = w.Write(buf)
+	return err
+}
+
+// decodeProtobufResponse decodes a response encoded by writeProtobufResponse,
+// given the name of its message.
+func decodeProtobufResponse(buf []byte, name string, header http.Header) (queryrangebase.Response, error) {
+	switch name {
+	case proto.MessageName(&QuantileSketchResponse{}):
+		var resp QuantileSketchResponse
+		if err := resp.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		resp.Headers = httpResponseHeadersToPromResponseHeaders(header)
+		return &resp, nil
+	case proto.MessageName(&CountMinSketchResponse{}):
+		var resp CountMinSketchResponse
+		if err := resp.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		resp.Headers = httpResponseHeadersToPromResponseHeaders(header)
+		return &resp, nil
+	default:
+		return nil, fmt.Errorf("unsupported protobuf response type %q", name)
+	}
+}
+
+// QuantileSketchMatrixFromLogQL converts a logql.QuantileSketchMatrix to its protobuf representation.
+func QuantileSketchMatrixFromLogQL(m logql.QuantileSketchMatrix) QuantileSketchMatrix {
+	series := make([]QuantileSketchSeries, 0, len(m))
+	for _, s := range m {
+		samples := make([]QuantileSketchSample, 0, len(s.Points))
+		for _, p := range s.Points {
+			samples = append(samples, QuantileSketchSample{
+				TimestampMs: p.T,
+				Sketch: DDSketch{
+					RelativeAccuracy: p.Sketch.RelativeAccuracy(),
+					ZeroCount:        p.Sketch.ZeroCount(),
+					Positive:         DDSketchStore{Offset: p.Sketch.Positive().Offset, Counts: p.Sketch.Positive().Counts},
+					Negative:         DDSketchStore{Offset: p.Sketch.Negative().Offset, Counts: p.Sketch.Negative().Counts},
+				},
+			})
+		}
+		series = append(series, QuantileSketchSeries{
+			Labels:  logproto.FromLabelsToLabelAdapters(s.Metric),
+			Samples: samples,
+		})
+	}
+	return QuantileSketchMatrix{Series: series}
+}
+
+// ToLogQL converts the protobuf representation of quantile sketches back to a logql.QuantileSketchMatrix.
+func (m QuantileSketchMatrix) ToLogQL() (logql.QuantileSketchMatrix, error) {
+	result := make(logql.QuantileSketchMatrix, 0, len(m.Series))
+	for _, s := range m.Series {
+		points := make([]logql.QuantileSketchPoint, 0, len(s.Samples))
+		for _, sample := range s.Samples {
+			sk, err := sketch.NewDDSketchFromBins(
+				sample.Sketch.RelativeAccuracy,
+				sample.Sketch.ZeroCount,
+				sketch.Store{Offset: sample.Sketch.Positive.Offset, Counts: sample.Sketch.Positive.Counts},
+				sketch.Store{Offset: sample.Sketch.Negative.Offset, Counts: sample.Sketch.Negative.Counts},
+			)
+			if err != nil {
+				return nil, err
+			}
+			points = append(points, logql.QuantileSketchPoint{T: sample.TimestampMs, Sketch: sk})
+		}
+		result = append(result, logql.QuantileSketchSeries{
+			Metric: logproto.FromLabelAdaptersToLabels(s.Labels),
+			Points: points,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package queryrange
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/loki/pkg/logql"
+	"github.com/grafana/loki/pkg/logql/sketch"
+	"github.com/grafana/loki/pkg/logqlmodel/stats"
+	"github.com/grafana/loki/pkg/querier/queryrange/queryrangebase"
+	"github.com/grafana/loki/pkg/storage/config"
+)
+
+func quantileSketchMatrix(t *testing.T, values ...float64) logql.QuantileSketchMatrix {
+	t.Helper()
+	s, err := sketch.NewDDSketch(sketch.DefaultRelativeAccuracy)
+	require.NoError(t, err)
+	for _, v := range values {
+		s.Add(v)
+	}
+	return logql.QuantileSketchMatrix{
+		{
+			Metric: labels.Labels{{Name: "foo", Value: "bar"}},
+			Points: []logql.QuantileSketchPoint{{T: 1000, Sketch: s}},
+		},
+	}
+}
+
+func Test_codec_QuantileSketchResponse(t *testing.T) {
+	m := quantileSketchMatrix(t, -1, 0, 1, 2, 3, 100)
+	resp := &QuantileSketchResponse{
+		Data:       QuantileSketchMatrixFromLogQL(m),
+		Statistics: statsResult,
+	}
+
+	httpResp, err := LokiCodec.EncodeResponse(context.Background(), resp)
+	require.NoError(t, err)
+	require.Equal(t, `application/vnd.google.protobuf; proto=queryrange.QuantileSketchResponse`, httpResp.Header.Get("Content-Type"))
+
+	decoded, err := LokiCodec.DecodeResponse(context.Background(), httpResp, &LokiRequest{})
+	require.NoError(t, err)
+	require.Equal(t, resp.Data, decoded.(*QuantileSketchResponse).Data)
+	require.Equal(t, resp.Statistics, decoded.(*QuantileSketchResponse).Statistics)
+
+	result, err := ResponseToResult(decoded)
+	require.NoError(t, err)
+	require.Equal(t, m.Quantile(0.5), result.Data.(logql.QuantileSketchMatrix).Quantile(0.5))
+}
+
+func TestWriteQuantileSketchResponse(t *testing.T) {
+	m := quantileSketchMatrix(t, 1, 2, 3)
+	rec := httptest.NewRecorder()
+	require.NoError(t, WriteQuantileSketchResponse(rec, m, stats.Result{}))
+
+	decoded, err := LokiCodec.DecodeResponse(context.Background(), &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     rec.Header(),
+		Body:       ioutil.NopCloser(rec.Body),
+	}, &LokiRequest{})
+	require.NoError(t, err)
+
+	actual, err := decoded.(*QuantileSketchResponse).Data.ToLogQL()
+	require.NoError(t, err)
+	require.Equal(t, m.Quantile(0.99), actual.Quantile(0.99))
+}
+
+func Test_astMapperware_QuantileOverTime(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		quantileSharding bool
+		expectedQueries  int
+	}{
+		{name: "disabled", quantileSharding: false, expectedQueries: 1},
+		{name: "enabled", quantileSharding: true, expectedQueries: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				lock    sync.Mutex
+				queries []string
+			)
+			handler := queryrangebase.HandlerFunc(func(ctx context.Context, req queryrangebase.Request) (queryrangebase.Response, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				queries = append(queries, req.GetQuery())
+				if strings.Contains(req.GetQuery(), "quantile_sketch_over_time") {
+					return &QuantileSketchResponse{Data: QuantileSketchMatrixFromLogQL(quantileSketchMatrix(t, 1, 2, 3))}, nil
+				}
+				return &LokiPromResponse{Response: &queryrangebase.PrometheusResponse{}}, nil
+			})
+
+			mware := newASTMapperware(
+				ShardingConfigs{
+					config.PeriodConfig{
+						RowShards: 2,
+					},
+				},
+				handler,
+				log.NewNopLogger(),
+				nilShardingMetrics,
+				fakeLimits{maxSeries: math.MaxInt32, maxQueryParallelism: 1, quantileSharding: tc.quantileSharding},
+			)
+
+			ctx := user.InjectOrgID(context.Background(), "1")
+			req := &LokiRequest{
+				Query:   `quantile_over_time(0.5, {foo="bar"} | unwrap latency [1m])`,
+				StartTs: time.Unix(0, 0),
+				EndTs:   time.Unix(1, 0),
+				Step:    1000,
+				Path:    "/loki/api/v1/query_range",
+			}
+			_, err := mware.Do(ctx, req)
+			require.NoError(t, err)
+			require.Len(t, queries, tc.expectedQueries)
+			if tc.quantileSharding {
+				for _, q := range queries {
+					require.Contains(t, q, "quantile_sketch_over_time")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package queryrange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/grafana/dskit/tenant"
+
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/util"
+	util_log "github.com/grafana/loki/pkg/util/log"
+	"github.com/grafana/loki/pkg/util/validation"
+	limits_validation "github.com/grafana/loki/pkg/validation"
+)
+
+type QueryBlockerMetrics struct {
+	blocked *prometheus.CounterVec
+}
+
+func NewQueryBlockerMetrics(registerer prometheus.Registerer) *QueryBlockerMetrics {
+	return &QueryBlockerMetrics{
+		blocked: promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "loki",
+			Name:      "query_frontend_blocked_queries_total",
+			Help:      "Total number of queries rejected by the blocked queries rules of their tenant.",
+		}, []string{"tenant", "type"}),
+	}
+}
+
+// queryBlocker rejects the queries matching the blocked queries rules of their tenant,
+// before they are split, sharded and scheduled on queriers.
+type queryBlocker struct {
+	logger  log.Logger
+	limits  Limits
+	metrics *QueryBlockerMetrics
+}
+
+func newQueryBlocker(logger log.Logger, limits Limits, metrics *QueryBlockerMetrics) queryBlocker {
+	return queryBlocker{
+		logger:  logger,
+		limits:  limits,
+		metrics: metrics,
+	}
+}
+
+// check returns an error if the query is blocked for any of the tenants of the context.
+func (b queryBlocker) check(ctx context.Context, query string, expr syntax.Expr) error {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	}
+
+	queryType := limits_validation.BlockedQueryTypeLog
+	if _, ok := expr.(syntax.SampleExpr); ok {
+		queryType = limits_validation.BlockedQueryTypeMetric
+	}
+	hash := util.HashedQuery(query)
+
+	for _, tenantID := range tenantIDs {
+		for _, rule := range b.limits.BlockedQueries(tenantID) {
+			if !blockedQueryMatches(rule, query, expr, hash, queryType) {
+				continue
+			}
+			b.metrics.blocked.WithLabelValues(tenantID, queryType).Inc()
+			level.Warn(util_log.WithContext(ctx, b.logger)).Log(
+				"msg", "query blocked",
+				"tenant", tenantID,
+				"query", query,
+				"query_hash", hash,
+				"rule", describeBlockedQuery(rule),
+			)
+			return httpgrpc.Errorf(http.StatusBadRequest, validation.ErrQueryBlocked, hash, describeBlockedQuery(rule))
+		}
+	}
+	return nil
+}
+
+func blockedQueryMatches(rule limits_validation.BlockedQuery, query string, expr syntax.Expr, hash uint32, queryType string) bool {
+	if len(rule.Types) > 0 {
+		found := false
+		for _, t := range rule.Types {
+			if t == queryType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if rule.Hash != 0 && rule.Hash == hash {
+		return true
+	}
+	if rule.Pattern == "" {
+		return false
+	}
+	if rule.Regex {
+		return rule.Regexp != nil && rule.Regexp.MatchString(query)
+	}
+	// Also compare with the formatted query, so that the rule doesn't depend on the whitespaces of the query.
+	pattern := strings.TrimSpace(rule.Pattern)
+	return pattern == strings.TrimSpace(query) || pattern == expr.String()
+}
+
+func describeBlockedQuery(rule limits_validation.BlockedQuery) string {
+	switch {
+	case rule.Pattern == "":
+		return fmt.Sprintf("hash %d", rule.Hash)
+	case rule.Regex:
+		return fmt.Sprintf("regex %q", rule.Pattern)
+	default:
+		return fmt.Sprintf("pattern %q", rule.Pattern)
+	}
+}
@@ -0,0 +1,198 @@
+package queryrange
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+	"gopkg.in/yaml.v2"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/syntax"
+	"github.com/grafana/loki/pkg/storage/config"
+	"github.com/grafana/loki/pkg/util"
+	util_log "github.com/grafana/loki/pkg/util/log"
+	"github.com/grafana/loki/pkg/validation"
+)
+
+func blockedQueries(t *testing.T, rules string) []validation.BlockedQuery {
+	var l validation.Limits
+	require.NoError(t, yaml.Unmarshal([]byte(rules), &l))
+	require.NoError(t, l.Validate())
+	return l.BlockedQueries
+}
+
+func Test_QueryBlocker(t *testing.T) {
+	metricQuery := `sum(rate({app="foo"} |= "bar" [1m]))`
+	logQuery := `{app="foo"} |= "bar"`
+
+	for _, tc := range []struct {
+		name    string
+		rules   string
+		query   string
+		blocked bool
+	}{
+		{
+			name:  "no rules",
+			query: metricQuery,
+		},
+		{
+			name: "exact pattern",
+			rules: `
+blocked_queries:
+  - pattern: '{app="foo"} |= "bar"'
+`,
+			query:   logQuery,
+			blocked: true,
+		},
+		{
+			name: "exact pattern ignores whitespaces",
+			rules: `
+blocked_queries:
+  - pattern: 'sum(rate({app="foo"} |= "bar"[1m]))'
+`,
+			query:   metricQuery,
+			blocked: true,
+		},
+		{
+			name: "exact pattern does not match other queries",
+			rules: `
+blocked_queries:
+  - pattern: '{app="foo"}'
+`,
+			query: logQuery,
+		},
+		{
+			name: "regex",
+			rules: `
+blocked_queries:
+  - pattern: '.*app="foo".*'
+    regex: true
+`,
+			query:   metricQuery,
+			blocked: true,
+		},
+		{
+			name: "hash",
+			rules: `
+blocked_queries:
+  - hash: ` + strconv.FormatUint(uint64(util.HashedQuery(logQuery)), 10) + `
+`,
+			query:   logQuery,
+			blocked: true,
+		},
+		{
+			name: "restricted to log queries",
+			rules: `
+blocked_queries:
+  - pattern: '.*app="foo".*'
+    regex: true
+    types: log
+`,
+			query: metricQuery,
+		},
+		{
+			name: "restricted to metric queries",
+			rules: `
+blocked_queries:
+  - pattern: '.*app="foo".*'
+    regex: true
+    types: metric
+`,
+			query:   metricQuery,
+			blocked: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			metrics := NewQueryBlockerMetrics(nil)
+			blocker := newQueryBlocker(util_log.Logger, fakeLimits{blockedQueries: blockedQueries(t, tc.rules)}, metrics)
+
+			expr, err := syntax.ParseExpr(tc.query)
+			require.NoError(t, err)
+
+			err = blocker.check(user.InjectOrgID(context.Background(), "1"), tc.query, expr)
+			if !tc.blocked {
+				require.NoError(t, err)
+				require.Equal(t, 0, testutil.CollectAndCount(metrics.blocked))
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "the query has been blocked")
+			require.Equal(t, 1, testutil.CollectAndCount(metrics.blocked))
+		})
+	}
+}
+
+func Test_BlockedQueriesValidation(t *testing.T) {
+	for _, rules := range []string{
+		`
+blocked_queries:
+  - regex: true
+`,
+		`
+blocked_queries:
+  - pattern: '(foo'
+    regex: true
+`,
+		`
+blocked_queries:
+  - pattern: '{app="foo"}'
+    types: sample
+`,
+	} {
+		var l validation.Limits
+		require.NoError(t, yaml.Unmarshal([]byte(rules), &l))
+		require.Error(t, l.Validate())
+	}
+}
+
+func TestBlockedQueriesTripperware(t *testing.T) {
+	limits := fakeLimits{
+		maxQueryParallelism: 1,
+		blockedQueries: blockedQueries(t, `
+blocked_queries:
+  - pattern: '{app="foo"} |= "bar"'
+`),
+	}
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, limits, config.SchemaConfig{}, nil, nil)
+	if stopper != nil {
+		defer stopper.Stop()
+	}
+	require.NoError(t, err)
+	rt, err := newfakeRoundTripper()
+	require.NoError(t, err)
+	defer rt.Close()
+
+	count, h := counter()
+	rt.setHandler(h)
+
+	lreq := &LokiRequest{
+		Query:     `{app="foo"} |= "bar"`,
+		Limit:     1000,
+		StartTs:   testTime.Add(-6 * time.Hour),
+		EndTs:     testTime,
+		Direction: logproto.FORWARD,
+		Path:      "/loki/api/v1/query_range",
+	}
+
+	ctx := user.InjectOrgID(context.Background(), "1")
+	req, err := LokiCodec.EncodeRequest(ctx, lreq)
+	require.NoError(t, err)
+
+	req = req.WithContext(ctx)
+	err = user.InjectOrgIDIntoHTTPRequest(ctx, req)
+	require.NoError(t, err)
+
+	_, err = tpw(rt).RoundTrip(req)
+	resp, ok := httpgrpc.HTTPResponseFromError(err)
+	require.True(t, ok)
+	require.Equal(t, int32(http.StatusBadRequest), resp.Code)
+	require.Contains(t, string(resp.Body), `matching rule: pattern "{app=\"foo\"} |= \"bar\""`)
+	require.Equal(t, 0, *count)
+}
@@ -20,14 +20,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/timestamp"
-	"github.com/prometheus/prometheus/promql"
-	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/uber/jaeger-client-go"
 	"github.com/weaveworks/common/httpgrpc"
 
 	"github.com/grafana/dskit/tenant"
 
 	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/logql/syntax"
 	"github.com/grafana/loki/pkg/storage/chunk/cache"
 	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/util/spanlogger"
@@ -43,7 +42,7 @@ var (
 )
 
 type CacheGenNumberLoader interface {
-	GetResultsCacheGenNumber(tenantIDs []string) string
+	GetResultsCacheGenNumber(ctx context.Context, tenantIDs []string) string
 }
 
 // ResultsCacheConfig is the config for the results cache.
@@ -189,7 +188,7 @@ func (s resultsCache) Do(ctx context.Context, r Request) (Response, error) {
 	}
 
 	if s.cacheGenNumberLoader != nil {
-		ctx = cache.InjectCacheGenNumber(ctx, s.cacheGenNumberLoader.GetResultsCacheGenNumber(tenantIDs))
+		ctx = cache.InjectCacheGenNumber(ctx, s.cacheGenNumberLoader.GetResultsCacheGenNumber(ctx, tenantIDs))
 	}
 
 	var (
@@ -258,8 +257,6 @@ func (s resultsCache) shouldCacheResponse(ctx context.Context, req Request, r Re
 	return true
 }
 
-var errAtModifierAfterEnd = errors.New("at modifier after end")
-
 // isAtModifierCachable returns true if the @ modifier result
 // is safe to cache.
 func (s resultsCache) isAtModifierCachable(r Request, maxCacheTime int64) bool {
@@ -270,10 +267,10 @@ func (s resultsCache) isAtModifierCachable(r Request, maxCacheTime int64) bool {
 	//      playing with old data, we could cache empty result if we look
 	//      beyond query end.
 	query := r.GetQuery()
-	if !strings.Contains(query, "@") {
+	if !strings.Contains(query, syntax.OpAt) {
 		return true
 	}
-	expr, err := parser.ParseExpr(query)
+	expr, err := syntax.ParseExpr(query)
 	if err != nil {
 		// We are being pessimistic in such cases.
 		level.Warn(s.logger).Log("msg", "failed to parse query, considering @ modifier as not cachable", "query", query, "err", err)
@@ -281,30 +278,17 @@ func (s resultsCache) isAtModifierCachable(r Request, maxCacheTime int64) bool {
 	}
 
 	// This resolves the start() and end() used with the @ modifier.
-	expr = promql.PreprocessExpr(expr, timestamp.Time(r.GetStart()), timestamp.Time(r.GetEnd()))
+	syntax.ResolveAtModifiers(expr, timestamp.Time(r.GetStart()), timestamp.Time(r.GetEnd()))
 
 	end := r.GetEnd()
 	atModCachable := true
-	parser.Inspect(expr, func(n parser.Node, _ []parser.Node) error {
-		switch e := n.(type) {
-		case *parser.VectorSelector:
-			if e.Timestamp != nil && (*e.Timestamp > end || *e.Timestamp > maxCacheTime) {
-				atModCachable = false
-				return errAtModifierAfterEnd
-			}
-		case *parser.MatrixSelector:
-			ts := e.VectorSelector.(*parser.VectorSelector).Timestamp
-			if ts != nil && (*ts > end || *ts > maxCacheTime) {
+	expr.Walk(func(e interface{}) {
+		if r, ok := e.(*syntax.LogRange); ok && r.At != nil {
+			ts := timestamp.FromTime(r.At.Timestamp)
+			if ts > end || ts > maxCacheTime {
 				atModCachable = false
-				return errAtModifierAfterEnd
-			}
-		case *parser.SubqueryExpr:
-			if e.Timestamp != nil && (*e.Timestamp > end || *e.Timestamp > maxCacheTime) {
-				atModCachable = false
-				return errAtModifierAfterEnd
 			}
 		}
-		return nil
 	})
 
 	return atModCachable
@@ -529,7 +513,12 @@ func (s resultsCache) partition(req Request, extents []Extent) ([]Request, []Res
 }
 
 func (s resultsCache) filterRecentExtents(req Request, maxCacheFreshness time.Duration, extents []Extent) ([]Extent, error) {
-	maxCacheTime := (int64(model.Now().Add(-maxCacheFreshness)) / req.GetStep()) * req.GetStep()
+	// Requests without a step (e.g. index stats) are aligned to the millisecond.
+	step := req.GetStep()
+	if step <= 0 {
+		step = 1
+	}
+	maxCacheTime := (int64(model.Now().Add(-maxCacheFreshness)) / step) * step
 	for i := range extents {
 		// Never cache data for the latest freshness period.
 		if extents[i].End > maxCacheTime {
@@ -273,117 +273,55 @@ func TestShouldCache(t *testing.T) {
 			cacheGenNumberToInject: "1",
 			expected:               false,
 		},
-		// @ modifier on vector selectors.
+		// @ modifier on log ranges.
 		{
-			name:     "@ modifier on vector selector, before end, before maxCacheTime",
-			request:  &PrometheusRequest{Query: "metric @ 123", End: 125000},
+			name:     "@ modifier on log range, before end, before maxCacheTime",
+			request:  &PrometheusRequest{Query: `rate({app="foo"}[5m] @ 123)`, End: 125000},
 			input:    Response(&PrometheusResponse{}),
 			expected: true,
 		},
 		{
-			name:     "@ modifier on vector selector, after end, before maxCacheTime",
-			request:  &PrometheusRequest{Query: "metric @ 127", End: 125000},
+			name:     "@ modifier on log range, after end, before maxCacheTime",
+			request:  &PrometheusRequest{Query: `rate({app="foo"}[5m] @ 127)`, End: 125000},
 			input:    Response(&PrometheusResponse{}),
 			expected: false,
 		},
 		{
-			name:     "@ modifier on vector selector, before end, after maxCacheTime",
-			request:  &PrometheusRequest{Query: "metric @ 151", End: 200000},
+			name:     "@ modifier on log range, before end, after maxCacheTime",
+			request:  &PrometheusRequest{Query: `rate({app="foo"}[5m] @ 151)`, End: 200000},
 			input:    Response(&PrometheusResponse{}),
 			expected: false,
 		},
 		{
-			name:     "@ modifier on vector selector, after end, after maxCacheTime",
-			request:  &PrometheusRequest{Query: "metric @ 151", End: 125000},
+			name:     "@ modifier on log range, after end, after maxCacheTime",
+			request:  &PrometheusRequest{Query: `rate({app="foo"}[5m] @ 151)`, End: 125000},
 			input:    Response(&PrometheusResponse{}),
 			expected: false,
 		},
 		{
-			name:     "@ modifier on vector selector with start() before maxCacheTime",
-			request:  &PrometheusRequest{Query: "metric @ start()", Start: 100000, End: 200000},
+			name:     "@ modifier on log range with start() before maxCacheTime",
+			request:  &PrometheusRequest{Query: `rate({app="foo"}[5m] @ start())`, Start: 100000, End: 200000},
 			input:    Response(&PrometheusResponse{}),
 			expected: true,
 		},
 		{
-			name:     "@ modifier on vector selector with end() after maxCacheTime",
-			request:  &PrometheusRequest{Query: "metric @ end()", Start: 100000, End: 200000},
+			name:     "@ modifier on log range with end() after maxCacheTime",
+			request:  &PrometheusRequest{Query: `rate({app="foo"}[5m] @ end())`, Start: 100000, End: 200000},
 			input:    Response(&PrometheusResponse{}),
 			expected: false,
 		},
-		// @ modifier on matrix selectors.
 		{
-			name:     "@ modifier on matrix selector, before end, before maxCacheTime",
-			request:  &PrometheusRequest{Query: "rate(metric[5m] @ 123)", End: 125000},
-			input:    Response(&PrometheusResponse{}),
-			expected: true,
-		},
-		{
-			name:     "@ modifier on matrix selector, after end, before maxCacheTime",
-			request:  &PrometheusRequest{Query: "rate(metric[5m] @ 127)", End: 125000},
-			input:    Response(&PrometheusResponse{}),
-			expected: false,
-		},
-		{
-			name:     "@ modifier on matrix selector, before end, after maxCacheTime",
-			request:  &PrometheusRequest{Query: "rate(metric[5m] @ 151)", End: 200000},
-			input:    Response(&PrometheusResponse{}),
-			expected: false,
-		},
-		{
-			name:     "@ modifier on matrix selector, after end, after maxCacheTime",
-			request:  &PrometheusRequest{Query: "rate(metric[5m] @ 151)", End: 125000},
-			input:    Response(&PrometheusResponse{}),
-			expected: false,
-		},
-		{
-			name:     "@ modifier on matrix selector with start() before maxCacheTime",
-			request:  &PrometheusRequest{Query: "rate(metric[5m] @ start())", Start: 100000, End: 200000},
-			input:    Response(&PrometheusResponse{}),
-			expected: true,
-		},
-		{
-			name:     "@ modifier on matrix selector with end() after maxCacheTime",
-			request:  &PrometheusRequest{Query: "rate(metric[5m] @ end())", Start: 100000, End: 200000},
+			name:     "@ modifier on nested log range, after end, before maxCacheTime",
+			request:  &PrometheusRequest{Query: `sum(rate({app="foo"}[5m])) / sum(rate({app="foo"}[5m] offset 1m @ 127))`, End: 125000},
 			input:    Response(&PrometheusResponse{}),
 			expected: false,
 		},
-		// @ modifier on subqueries.
 		{
-			name:     "@ modifier on subqueries, before end, before maxCacheTime",
-			request:  &PrometheusRequest{Query: "sum_over_time(rate(metric[1m])[10m:1m] @ 123)", End: 125000},
+			name:     "@ in a line filter",
+			request:  &PrometheusRequest{Query: `rate({app="foo"} |= "user@example.com" [5m])`, End: 125000},
 			input:    Response(&PrometheusResponse{}),
 			expected: true,
 		},
-		{
-			name:     "@ modifier on subqueries, after end, before maxCacheTime",
-			request:  &PrometheusRequest{Query: "sum_over_time(rate(metric[1m])[10m:1m] @ 127)", End: 125000},
-			input:    Response(&PrometheusResponse{}),
-			expected: false,
-		},
-		{
-			name:     "@ modifier on subqueries, before end, after maxCacheTime",
-			request:  &PrometheusRequest{Query: "sum_over_time(rate(metric[1m])[10m:1m] @ 151)", End: 200000},
-			input:    Response(&PrometheusResponse{}),
-			expected: false,
-		},
-		{
-			name:     "@ modifier on subqueries, after end, after maxCacheTime",
-			request:  &PrometheusRequest{Query: "sum_over_time(rate(metric[1m])[10m:1m] @ 151)", End: 125000},
-			input:    Response(&PrometheusResponse{}),
-			expected: false,
-		},
-		{
-			name:     "@ modifier on subqueries with start() before maxCacheTime",
-			request:  &PrometheusRequest{Query: "sum_over_time(rate(metric[1m])[10m:1m] @ start())", Start: 100000, End: 200000},
-			input:    Response(&PrometheusResponse{}),
-			expected: true,
-		},
-		{
-			name:     "@ modifier on subqueries with end() after maxCacheTime",
-			request:  &PrometheusRequest{Query: "sum_over_time(rate(metric[1m])[10m:1m] @ end())", Start: 100000, End: 200000},
-			input:    Response(&PrometheusResponse{}),
-			expected: false,
-		},
 	} {
 		{
 			t.Run(tc.name, func(t *testing.T) {
@@ -1044,6 +982,6 @@ func newMockCacheGenNumberLoader() CacheGenNumberLoader {
 	return mockCacheGenNumberLoader{}
 }
 
-func (mockCacheGenNumberLoader) GetResultsCacheGenNumber(tenantIDs []string) string {
+func (mockCacheGenNumberLoader) GetResultsCacheGenNumber(ctx context.Context, tenantIDs []string) string {
 	return ""
 }
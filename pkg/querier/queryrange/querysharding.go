@@ -69,7 +69,7 @@ func newASTMapperware(
 	next queryrangebase.Handler,
 	logger log.Logger,
 	metrics *logql.ShardingMetrics,
-	limits logql.Limits,
+	limits Limits,
 ) *astMapperware {
 	return &astMapperware{
 		confs:   confs,
@@ -77,7 +77,23 @@ func newASTMapperware(
 		next:    next,
 		ng:      logql.NewDownstreamEngine(logql.EngineOpts{}, DownstreamHandler{next}, metrics, limits, logger),
 		metrics: metrics,
+		limits:  limits,
+	}
+}
+
+// shardQuantileOverTime returns whether all tenants of the request opted in for
+// sharding quantile_over_time queries, whose results are then approximate.
+func shardQuantileOverTime(ctx context.Context, limits Limits) bool {
+	tenants, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return false
 	}
+	for _, t := range tenants {
+		if !limits.QuantileOverTimeSharding(t) {
+			return false
+		}
+	}
+	return len(tenants) > 0
 }
 
 type astMapperware struct {
@@ -86,6 +102,7 @@ type astMapperware struct {
 	next    queryrangebase.Handler
 	ng      *logql.DownstreamEngine
 	metrics *logql.ShardingMetrics
+	limits  Limits
 }
 
 func (ast *astMapperware) Do(ctx context.Context, r queryrangebase.Request) (queryrangebase.Response, error) {
@@ -97,7 +114,7 @@ func (ast *astMapperware) Do(ctx context.Context, r queryrangebase.Request) (que
 		return ast.next.Do(ctx, r)
 	}
 
-	mapper, err := logql.NewShardMapper(int(conf.RowShards), ast.metrics)
+	mapper, err := logql.NewShardMapper(int(conf.RowShards), ast.metrics, shardQuantileOverTime(ctx, ast.limits))
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +226,34 @@ func (splitter *shardSplitter) Do(ctx context.Context, r queryrangebase.Request)
 	return splitter.next.Do(ctx, r)
 }
 
+// shardFactor returns the number of shards the query sharding middleware splits the request into,
+// or 1 when it doesn't shard the request.
+func shardFactor(ctx context.Context, confs ShardingConfigs, limits Limits, metrics *logql.ShardingMetrics, now time.Time, r queryrangebase.Request) int {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return 1
+	}
+	// Like the shardSplitter, requests within the sharding lookback are not sharded.
+	minShardingLookback := validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, limits.MinShardingLookback)
+	if minShardingLookback != 0 && !util.TimeFromMillis(r.GetEnd()).Before(now.Add(-minShardingLookback)) {
+		return 1
+	}
+
+	conf, err := confs.GetConf(r)
+	if err != nil {
+		return 1
+	}
+	mapper, err := logql.NewShardMapper(int(conf.RowShards), metrics, shardQuantileOverTime(ctx, limits))
+	if err != nil {
+		return 1
+	}
+	noop, _, err := mapper.Parse(r.GetQuery())
+	if err != nil || noop {
+		return 1
+	}
+	return int(conf.RowShards)
+}
+
 func hasShards(confs ShardingConfigs) bool {
 	for _, conf := range confs {
 		if conf.RowShards > 0 {
@@ -42,6 +42,7 @@ func NewTripperware(
 	log log.Logger,
 	limits Limits,
 	schema config.SchemaConfig,
+	cacheGenNumLoader queryrangebase.CacheGenNumberLoader,
 	registerer prometheus.Registerer,
 ) (queryrangebase.Tripperware, Stopper, error) {
 	metrics := NewMetrics(registerer)
@@ -60,15 +61,23 @@ func NewTripperware(
 		}
 	}
 
+	indexStatsTripperware, err := NewIndexStatsTripperware(cfg, log, limits, LokiCodec, c, metrics)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	metricsTripperware, err := NewMetricTripperware(cfg, log, limits, schema, LokiCodec, c,
-		PrometheusExtractor{}, metrics, registerer)
+		PrometheusExtractor{}, metrics, registerer, indexStatsTripperware)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// NOTE: When we would start caching response from non-metric queries we would have to consider cache gen headers as well in
-	// MergeResponse implementation for Loki codecs same as it is done in Cortex at https://github.com/cortexproject/cortex/blob/21bad57b346c730d684d6d0205efef133422ab28/pkg/querier/queryrange/query_range.go#L170
-	logFilterTripperware, err := NewLogFilterTripperware(cfg, log, limits, schema, LokiCodec, c, metrics)
+	limitedTripperware, err := NewLimitedTripperware(cfg, log, limits, LokiCodec, c, cacheGenNumLoader, metrics, indexStatsTripperware)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logFilterTripperware, err := NewLogFilterTripperware(cfg, log, limits, schema, LokiCodec, c, cacheGenNumLoader, metrics, indexStatsTripperware)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -83,35 +92,50 @@ func NewTripperware(
 		return nil, nil, err
 	}
 
-	instantMetricTripperware, err := NewInstantMetricTripperware(cfg, log, limits, schema, LokiCodec, metrics)
+	instantMetricTripperware, err := NewInstantMetricTripperware(cfg, log, limits, schema, LokiCodec, metrics, indexStatsTripperware)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	volumeTripperware, err := NewVolumeTripperware(cfg, log, limits, LokiCodec, metrics)
 	if err != nil {
 		return nil, nil, err
 	}
+
+	blocker := newQueryBlocker(log, limits, metrics.QueryBlockerMetrics)
 	return func(next http.RoundTripper) http.RoundTripper {
 		metricRT := metricsTripperware(next)
+		limitedRT := limitedTripperware(next)
 		logFilterRT := logFilterTripperware(next)
 		seriesRT := seriesTripperware(next)
 		labelsRT := labelsTripperware(next)
 		instantRT := instantMetricTripperware(next)
-		return newRoundTripper(next, logFilterRT, metricRT, seriesRT, labelsRT, instantRT, limits)
+		indexStatsRT := indexStatsTripperware(next)
+		volumeRT := volumeTripperware(next)
+		return newRoundTripper(next, limitedRT, logFilterRT, metricRT, seriesRT, labelsRT, instantRT, indexStatsRT, volumeRT, limits, blocker)
 	}, c, nil
 }
 
 type roundTripper struct {
-	next, log, metric, series, labels, instantMetric http.RoundTripper
+	next, limited, log, metric, series, labels, instantMetric, indexStats, volume http.RoundTripper
 
-	limits Limits
+	limits  Limits
+	blocker queryBlocker
 }
 
 // newRoundTripper creates a new queryrange roundtripper
-func newRoundTripper(next, log, metric, series, labels, instantMetric http.RoundTripper, limits Limits) roundTripper {
+func newRoundTripper(next, limited, log, metric, series, labels, instantMetric, indexStats, volume http.RoundTripper, limits Limits, blocker queryBlocker) roundTripper {
 	return roundTripper{
+		blocker:       blocker,
+		limited:       limited,
 		log:           log,
 		limits:        limits,
 		metric:        metric,
 		series:        series,
 		labels:        labels,
 		instantMetric: instantMetric,
+		indexStats:    indexStats,
+		volume:        volume,
 		next:          next,
 	}
 }
@@ -132,6 +156,9 @@ func (r roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 		if err != nil {
 			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
 		}
+		if err := r.blocker.check(req.Context(), rangeQuery.Query, expr); err != nil {
+			return nil, err
+		}
 		switch e := expr.(type) {
 		case syntax.SampleExpr:
 			return r.metric.RoundTrip(req)
@@ -145,7 +172,7 @@ func (r roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 			}
 			// Only filter expressions are query sharded
 			if !expr.HasFilter() {
-				return r.next.RoundTrip(req)
+				return r.limited.RoundTrip(req)
 			}
 			return r.log.RoundTrip(req)
 
@@ -173,12 +200,27 @@ func (r roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 		if err != nil {
 			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
 		}
+		if err := r.blocker.check(req.Context(), instantQuery.Query, expr); err != nil {
+			return nil, err
+		}
 		switch expr.(type) {
 		case syntax.SampleExpr:
 			return r.instantMetric.RoundTrip(req)
 		default:
 			return r.next.RoundTrip(req)
 		}
+	case IndexStatsOp:
+		_, err := loghttp.ParseIndexStatsQuery(req)
+		if err != nil {
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+		}
+		return r.indexStats.RoundTrip(req)
+	case VolumeOp:
+		_, err := loghttp.ParseVolumeQuery(req)
+		if err != nil {
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+		}
+		return r.volume.RoundTrip(req)
 	default:
 		return r.next.RoundTrip(req)
 	}
@@ -223,6 +265,8 @@ const (
 	QueryRangeOp   = "query_range"
 	SeriesOp       = "series"
 	LabelNamesOp   = "labels"
+	IndexStatsOp   = "index_stats"
+	VolumeOp       = "volume"
 )
 
 func getOperation(path string) string {
@@ -231,15 +275,73 @@ func getOperation(path string) string {
 		return QueryRangeOp
 	case strings.HasSuffix(path, "/series"):
 		return SeriesOp
-	case strings.HasSuffix(path, "/labels") || strings.HasSuffix(path, "/label"):
+	case strings.HasSuffix(path, "/labels") || strings.HasSuffix(path, "/label") || strings.HasSuffix(path, "/values"):
 		return LabelNamesOp
 	case strings.HasSuffix(path, "/v1/query"):
 		return InstantQueryOp
+	case path == "/loki/api/v1/index/stats":
+		return IndexStatsOp
+	case path == "/loki/api/v1/index/volume":
+		return VolumeOp
 	default:
 		return ""
 	}
 }
 
+// NewLimitedTripperware creates a new frontend tripperware responsible for handling log requests without filter.
+// They are split and cached like the other log requests, but not sharded, as every shard could return up to limit entries.
+func NewLimitedTripperware(
+	cfg Config,
+	log log.Logger,
+	limits Limits,
+	codec queryrangebase.Codec,
+	c cache.Cache,
+	cacheGenNumLoader queryrangebase.CacheGenNumberLoader,
+	metrics *Metrics,
+	indexStatsTripperware queryrangebase.Tripperware,
+) (queryrangebase.Tripperware, error) {
+	return func(next http.RoundTripper) http.RoundTripper {
+		statsHandler := queryrangebase.NewRoundTripperHandler(indexStatsTripperware(next), codec)
+
+		queryRangeMiddleware := []queryrangebase.Middleware{
+			StatsCollectorMiddleware(),
+			NewLimitsMiddleware(limits),
+			NewQuerySizeLimiterMiddleware(log, limits, statsHandler),
+			queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
+			SplitByIntervalMiddleware(limits, codec, splitByTime, metrics.SplitByMetrics),
+		}
+
+		if cfg.CacheResults {
+			queryCacheMiddleware := NewLogResultCache(
+				log,
+				limits,
+				c,
+				func(r queryrangebase.Request) bool {
+					return !r.GetCachingOptions().Disabled
+				},
+				cacheGenNumLoader,
+				metrics.LogResultCacheMetrics,
+			)
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("log_results_cache", metrics.InstrumentMiddlewareMetrics),
+				queryCacheMiddleware,
+			)
+		}
+
+		queryRangeMiddleware = append(queryRangeMiddleware, NewQuerierSizeLimiterMiddleware(log, limits, statsHandler, nil))
+
+		if cfg.MaxRetries > 0 {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware, queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+				queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+			)
+		}
+
+		return NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
+	}, nil
+}
+
 // NewLogFilterTripperware creates a new frontend tripperware responsible for handling log requests with regex.
 func NewLogFilterTripperware(
 	cfg Config,
@@ -248,56 +350,65 @@ func NewLogFilterTripperware(
 	schema config.SchemaConfig,
 	codec queryrangebase.Codec,
 	c cache.Cache,
+	cacheGenNumLoader queryrangebase.CacheGenNumberLoader,
 	metrics *Metrics,
+	indexStatsTripperware queryrangebase.Tripperware,
 ) (queryrangebase.Tripperware, error) {
-	queryRangeMiddleware := []queryrangebase.Middleware{
-		StatsCollectorMiddleware(),
-		NewLimitsMiddleware(limits),
-		queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
-		SplitByIntervalMiddleware(limits, codec, splitByTime, metrics.SplitByMetrics),
-	}
-
-	if cfg.CacheResults {
-		queryCacheMiddleware := NewLogResultCache(
-			log,
-			limits,
-			c,
-			func(r queryrangebase.Request) bool {
-				return !r.GetCachingOptions().Disabled
-			},
-			metrics.LogResultCacheMetrics,
-		)
-		queryRangeMiddleware = append(
-			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("log_results_cache", metrics.InstrumentMiddlewareMetrics),
-			queryCacheMiddleware,
-		)
-	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		statsHandler := queryrangebase.NewRoundTripperHandler(indexStatsTripperware(next), codec)
+
+		queryRangeMiddleware := []queryrangebase.Middleware{
+			StatsCollectorMiddleware(),
+			NewLimitsMiddleware(limits),
+			NewQuerySizeLimiterMiddleware(log, limits, statsHandler),
+			queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
+			SplitByIntervalMiddleware(limits, codec, splitByTime, metrics.SplitByMetrics),
+		}
 
-	if cfg.ShardedQueries {
-		queryRangeMiddleware = append(queryRangeMiddleware,
-			NewQueryShardMiddleware(
+		if cfg.CacheResults {
+			queryCacheMiddleware := NewLogResultCache(
 				log,
-				schema.Configs,
-				metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
-				metrics.ShardingMetrics,
 				limits,
-			),
-		)
-	}
+				c,
+				func(r queryrangebase.Request) bool {
+					return !r.GetCachingOptions().Disabled
+				},
+				cacheGenNumLoader,
+				metrics.LogResultCacheMetrics,
+			)
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("log_results_cache", metrics.InstrumentMiddlewareMetrics),
+				queryCacheMiddleware,
+			)
+		}
 
-	if cfg.MaxRetries > 0 {
-		queryRangeMiddleware = append(
-			queryRangeMiddleware, queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
-			queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
-		)
-	}
+		var shardingConfs ShardingConfigs
+		if cfg.ShardedQueries {
+			shardingConfs = schema.Configs
+		}
+		queryRangeMiddleware = append(queryRangeMiddleware, NewQuerierSizeLimiterMiddleware(log, limits, statsHandler, shardingConfs))
+
+		if cfg.ShardedQueries {
+			queryRangeMiddleware = append(queryRangeMiddleware,
+				NewQueryShardMiddleware(
+					log,
+					schema.Configs,
+					metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
+					metrics.ShardingMetrics,
+					limits,
+				),
+			)
+		}
 
-	return func(next http.RoundTripper) http.RoundTripper {
-		if len(queryRangeMiddleware) > 0 {
-			return NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
+		if cfg.MaxRetries > 0 {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware, queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+				queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+			)
 		}
-		return next
+
+		return NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
 	}, nil
 }
 
@@ -379,35 +490,105 @@ func NewLabelsTripperware(
 	}, nil
 }
 
-// NewMetricTripperware creates a new frontend tripperware responsible for handling metric queries
-func NewMetricTripperware(
+// NewIndexStatsTripperware creates a new frontend tripperware responsible for handling index stats requests.
+func NewIndexStatsTripperware(
 	cfg Config,
 	log log.Logger,
 	limits Limits,
-	schema config.SchemaConfig,
 	codec queryrangebase.Codec,
 	c cache.Cache,
-	extractor queryrangebase.Extractor,
 	metrics *Metrics,
-	registerer prometheus.Registerer,
 ) (queryrangebase.Tripperware, error) {
-	queryRangeMiddleware := []queryrangebase.Middleware{StatsCollectorMiddleware(), NewLimitsMiddleware(limits)}
-	if cfg.AlignQueriesWithStep {
+	// Force a 24 hours split by for index stats, like the other index-only APIs.
+	// Each day of a query is then cached on its own.
+	limits = WithSplitByLimits(limits, 24*time.Hour)
+
+	queryRangeMiddleware := []queryrangebase.Middleware{
+		NewLimitsMiddleware(limits),
+		queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
+		SplitByIntervalMiddleware(limits, codec, splitByTime, metrics.SplitByMetrics),
+	}
+
+	if cfg.CacheResults {
 		queryRangeMiddleware = append(
 			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("step_align", metrics.InstrumentMiddlewareMetrics),
-			queryrangebase.StepAlignMiddleware,
+			queryrangebase.InstrumentMiddleware("index_stats_results_cache", metrics.InstrumentMiddlewareMetrics),
+			NewIndexStatsCache(
+				log,
+				limits,
+				c,
+				func(r queryrangebase.Request) bool {
+					return !r.GetCachingOptions().Disabled
+				},
+			),
+		)
+	}
+
+	if cfg.MaxRetries > 0 {
+		queryRangeMiddleware = append(queryRangeMiddleware,
+			queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+			queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
 		)
 	}
 
-	queryRangeMiddleware = append(
-		queryRangeMiddleware,
+	return func(next http.RoundTripper) http.RoundTripper {
+		if len(queryRangeMiddleware) > 0 {
+			// Do not forward any request header.
+			return queryrangebase.NewRoundTripper(next, codec, nil, queryRangeMiddleware...)
+		}
+		return next
+	}, nil
+}
+
+// NewVolumeTripperware creates a new frontend tripperware responsible for handling volume requests.
+func NewVolumeTripperware(
+	cfg Config,
+	log log.Logger,
+	limits Limits,
+	codec queryrangebase.Codec,
+	metrics *Metrics,
+) (queryrangebase.Tripperware, error) {
+	queryRangeMiddleware := []queryrangebase.Middleware{
+		NewLimitsMiddleware(limits),
+		NewVolumeLimitMiddleware(),
 		queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
-		SplitByIntervalMiddleware(limits, codec, splitMetricByTime, metrics.SplitByMetrics),
-	)
+		// Force a 24 hours split by for volume, like the other index-only APIs.
+		SplitByIntervalMiddleware(WithSplitByLimits(limits, 24*time.Hour), codec, splitByTime, metrics.SplitByMetrics),
+	}
+
+	if cfg.MaxRetries > 0 {
+		queryRangeMiddleware = append(queryRangeMiddleware,
+			queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+			queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+		)
+	}
 
+	return func(next http.RoundTripper) http.RoundTripper {
+		if len(queryRangeMiddleware) > 0 {
+			// Do not forward any request header.
+			return queryrangebase.NewRoundTripper(next, codec, nil, queryRangeMiddleware...)
+		}
+		return next
+	}, nil
+}
+
+// NewMetricTripperware creates a new frontend tripperware responsible for handling metric queries
+func NewMetricTripperware(
+	cfg Config,
+	log log.Logger,
+	limits Limits,
+	schema config.SchemaConfig,
+	codec queryrangebase.Codec,
+	c cache.Cache,
+	extractor queryrangebase.Extractor,
+	metrics *Metrics,
+	registerer prometheus.Registerer,
+	indexStatsTripperware queryrangebase.Tripperware,
+) (queryrangebase.Tripperware, error) {
+	var queryCacheMiddleware queryrangebase.Middleware
 	if cfg.CacheResults {
-		queryCacheMiddleware, err := queryrangebase.NewResultsCacheMiddleware(
+		var err error
+		queryCacheMiddleware, err = queryrangebase.NewResultsCacheMiddleware(
 			log,
 			c,
 			cacheKeyLimits{limits},
@@ -423,45 +604,72 @@ func NewMetricTripperware(
 		if err != nil {
 			return nil, err
 		}
-		queryRangeMiddleware = append(
-			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("results_cache", metrics.InstrumentMiddlewareMetrics),
-			queryCacheMiddleware,
-		)
 	}
 
-	if cfg.ShardedQueries {
-		queryRangeMiddleware = append(queryRangeMiddleware,
-			NewQueryShardMiddleware(
-				log,
-				schema.Configs,
-				metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
-				metrics.ShardingMetrics,
-				limits,
-			),
-		)
-	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		statsHandler := queryrangebase.NewRoundTripperHandler(indexStatsTripperware(next), codec)
+
+		queryRangeMiddleware := []queryrangebase.Middleware{
+			StatsCollectorMiddleware(),
+			NewLimitsMiddleware(limits),
+			NewQuerySizeLimiterMiddleware(log, limits, statsHandler),
+		}
+		if cfg.AlignQueriesWithStep {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("step_align", metrics.InstrumentMiddlewareMetrics),
+				queryrangebase.StepAlignMiddleware,
+			)
+		}
 
-	if cfg.MaxRetries > 0 {
 		queryRangeMiddleware = append(
 			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
-			queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+			queryrangebase.InstrumentMiddleware("split_by_interval", metrics.InstrumentMiddlewareMetrics),
+			SplitByIntervalMiddleware(limits, codec, splitMetricByTime, metrics.SplitByMetrics),
 		)
-	}
 
-	return func(next http.RoundTripper) http.RoundTripper {
-		// Finally, if the user selected any query range middleware, stitch it in.
-		if len(queryRangeMiddleware) > 0 {
-			rt := NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
-			return queryrangebase.RoundTripFunc(func(r *http.Request) (*http.Response, error) {
-				if !strings.HasSuffix(r.URL.Path, "/query_range") {
-					return next.RoundTrip(r)
-				}
-				return rt.RoundTrip(r)
-			})
+		if queryCacheMiddleware != nil {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("results_cache", metrics.InstrumentMiddlewareMetrics),
+				queryCacheMiddleware,
+			)
 		}
-		return next
+
+		var shardingConfs ShardingConfigs
+		if cfg.ShardedQueries {
+			shardingConfs = schema.Configs
+		}
+		queryRangeMiddleware = append(queryRangeMiddleware, NewQuerierSizeLimiterMiddleware(log, limits, statsHandler, shardingConfs))
+
+		if cfg.ShardedQueries {
+			queryRangeMiddleware = append(queryRangeMiddleware,
+				NewQueryShardMiddleware(
+					log,
+					schema.Configs,
+					metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
+					metrics.ShardingMetrics,
+					limits,
+				),
+			)
+		}
+
+		if cfg.MaxRetries > 0 {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+				queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+			)
+		}
+
+		// Finally, stitch the query range middlewares in.
+		rt := NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
+		return queryrangebase.RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if !strings.HasSuffix(r.URL.Path, "/query_range") {
+				return next.RoundTrip(r)
+			}
+			return rt.RoundTrip(r)
+		})
 	}, nil
 }
 
@@ -473,34 +681,39 @@ func NewInstantMetricTripperware(
 	schema config.SchemaConfig,
 	codec queryrangebase.Codec,
 	metrics *Metrics,
+	indexStatsTripperware queryrangebase.Tripperware,
 ) (queryrangebase.Tripperware, error) {
-	queryRangeMiddleware := []queryrangebase.Middleware{StatsCollectorMiddleware(), NewLimitsMiddleware(limits)}
+	return func(next http.RoundTripper) http.RoundTripper {
+		statsHandler := queryrangebase.NewRoundTripperHandler(indexStatsTripperware(next), codec)
 
-	if cfg.ShardedQueries {
-		queryRangeMiddleware = append(queryRangeMiddleware,
-			NewSplitByRangeMiddleware(log, limits, nil),
-			NewQueryShardMiddleware(
-				log,
-				schema.Configs,
-				metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
-				metrics.ShardingMetrics,
-				limits,
-			),
-		)
-	}
+		queryRangeMiddleware := []queryrangebase.Middleware{
+			StatsCollectorMiddleware(),
+			NewLimitsMiddleware(limits),
+			NewQuerySizeLimiterMiddleware(log, limits, statsHandler),
+		}
 
-	if cfg.MaxRetries > 0 {
-		queryRangeMiddleware = append(
-			queryRangeMiddleware,
-			queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
-			queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
-		)
-	}
+		if cfg.ShardedQueries {
+			queryRangeMiddleware = append(queryRangeMiddleware,
+				NewSplitByRangeMiddleware(log, limits, nil),
+				NewQuerierSizeLimiterMiddleware(log, limits, statsHandler, schema.Configs),
+				NewQueryShardMiddleware(
+					log,
+					schema.Configs,
+					metrics.InstrumentMiddlewareMetrics, // instrumentation is included in the sharding middleware
+					metrics.ShardingMetrics,
+					limits,
+				),
+			)
+		}
 
-	return func(next http.RoundTripper) http.RoundTripper {
-		if len(queryRangeMiddleware) > 0 {
-			return NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
+		if cfg.MaxRetries > 0 {
+			queryRangeMiddleware = append(
+				queryRangeMiddleware,
+				queryrangebase.InstrumentMiddleware("retry", metrics.InstrumentMiddlewareMetrics),
+				queryrangebase.NewRetryMiddleware(log, cfg.MaxRetries, metrics.RetryMiddlewareMetrics),
+			)
 		}
-		return next
+
+		return NewLimitedRoundTripper(next, codec, limits, queryRangeMiddleware...)
 	}, nil
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/grafana/loki/pkg/storage/config"
 	util_log "github.com/grafana/loki/pkg/util/log"
 	"github.com/grafana/loki/pkg/util/marshal"
+	"github.com/grafana/loki/pkg/validation"
 )
 
 var (
@@ -109,7 +110,7 @@ var (
 // those tests are mostly for testing the glue between all component and make sure they activate correctly.
 func TestMetricsTripperware(t *testing.T) {
 	l := WithSplitByLimits(fakeLimits{maxSeries: math.MaxInt32, maxQueryParallelism: 1}, 4*time.Hour)
-	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, l, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, l, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -172,7 +173,7 @@ func TestMetricsTripperware(t *testing.T) {
 }
 
 func TestLogFilterTripperware(t *testing.T) {
-	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryParallelism: 1}, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryParallelism: 1}, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -221,7 +222,7 @@ func TestLogFilterTripperware(t *testing.T) {
 func TestInstantQueryTripperware(t *testing.T) {
 	testShardingConfig := testConfig
 	testShardingConfig.ShardedQueries = true
-	tpw, stopper, err := NewTripperware(testShardingConfig, util_log.Logger, fakeLimits{maxQueryParallelism: 1}, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(testShardingConfig, util_log.Logger, fakeLimits{maxQueryParallelism: 1}, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -257,7 +258,7 @@ func TestInstantQueryTripperware(t *testing.T) {
 }
 
 func TestSeriesTripperware(t *testing.T) {
-	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryLength: 48 * time.Hour, maxQueryParallelism: 1}, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryLength: 48 * time.Hour, maxQueryParallelism: 1}, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -298,7 +299,7 @@ func TestSeriesTripperware(t *testing.T) {
 }
 
 func TestLabelsTripperware(t *testing.T) {
-	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryLength: 48 * time.Hour, maxQueryParallelism: 1}, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryLength: 48 * time.Hour, maxQueryParallelism: 1}, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -343,8 +344,134 @@ func TestLabelsTripperware(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestIndexStatsTripperware(t *testing.T) {
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryLength: 48 * time.Hour, maxQueryParallelism: 1}, config.SchemaConfig{}, nil, nil)
+	if stopper != nil {
+		defer stopper.Stop()
+	}
+	require.NoError(t, err)
+	rt, err := newfakeRoundTripper()
+	require.NoError(t, err)
+	defer rt.Close()
+
+	lreq := &LokiIndexStatsRequest{
+		StartTs: testTime.Add(-25 * time.Hour), // bigger than the limit
+		EndTs:   testTime,
+		Query:   `{foo="bar"}`,
+	}
+
+	ctx := user.InjectOrgID(context.Background(), "1")
+	req, err := LokiCodec.EncodeRequest(ctx, lreq)
+	require.NoError(t, err)
+
+	req = req.WithContext(ctx)
+	err = user.InjectOrgIDIntoHTTPRequest(ctx, req)
+	require.NoError(t, err)
+
+	response := logproto.IndexStatsResponse{
+		Streams: 100,
+		Chunks:  200,
+		Bytes:   300,
+		Entries: 400,
+	}
+	handler := newFakeHandler(
+		// we expect 2 calls.
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, marshal.WriteIndexStatsResponseJSON(&response, w))
+		}),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, marshal.WriteIndexStatsResponseJSON(&response, w))
+		}),
+	)
+	rt.setHandler(handler)
+	resp, err := tpw(rt).RoundTrip(req)
+	// verify 2 calls have been made to downstream.
+	require.Equal(t, 2, handler.count)
+	require.NoError(t, err)
+	statsResp, err := LokiCodec.DecodeResponse(ctx, resp, lreq)
+	require.NoError(t, err)
+	res, ok := statsResp.(*LokiIndexStatsResponse)
+	require.Equal(t, true, ok)
+	require.Equal(t, response.Streams*2, res.Response.Streams)
+	require.Equal(t, response.Chunks*2, res.Response.Chunks)
+	require.Equal(t, response.Bytes*2, res.Response.Bytes)
+	require.Equal(t, response.Entries*2, res.Response.Entries)
+
+	// Re-run the same query so it gets served from cache.
+	handler.count = 0
+	resp, err = tpw(rt).RoundTrip(req)
+	require.NoError(t, err)
+	require.Equal(t, 0, handler.count)
+	statsResp, err = LokiCodec.DecodeResponse(ctx, resp, lreq)
+	require.NoError(t, err)
+	res, ok = statsResp.(*LokiIndexStatsResponse)
+	require.Equal(t, true, ok)
+	require.Equal(t, response.Streams*2, res.Response.Streams)
+}
+
+func TestVolumeTripperware(t *testing.T) {
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryLength: 48 * time.Hour, maxQueryParallelism: 1}, config.SchemaConfig{}, nil, nil)
+	if stopper != nil {
+		defer stopper.Stop()
+	}
+	require.NoError(t, err)
+	rt, err := newfakeRoundTripper()
+	require.NoError(t, err)
+	defer rt.Close()
+
+	lreq := &LokiVolumeRequest{
+		StartTs:      testTime.Add(-25 * time.Hour), // bigger than the limit
+		EndTs:        testTime,
+		Query:        `{foo="bar"}`,
+		Limit:        2,
+		TargetLabels: []string{"app"},
+	}
+
+	ctx := user.InjectOrgID(context.Background(), "1")
+	req, err := LokiCodec.EncodeRequest(ctx, lreq)
+	require.NoError(t, err)
+
+	req = req.WithContext(ctx)
+	err = user.InjectOrgIDIntoHTTPRequest(ctx, req)
+	require.NoError(t, err)
+
+	// {app="b"} isn't among the 2 largest volumes of any split, but it's the largest one once summed.
+	handler := newFakeHandler(
+		// we expect 2 calls.
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "app", r.URL.Query().Get("targetLabels"))
+			require.Equal(t, strconv.Itoa(logproto.VolumeNoLimit), r.URL.Query().Get("limit"))
+			require.NoError(t, marshal.WriteVolumeResponseJSON(&logproto.VolumeResponse{
+				Volumes: []logproto.Volume{{Name: `{app="a"}`, Bytes: 10, Entries: 1}, {Name: `{app="d"}`, Bytes: 9, Entries: 1}, {Name: `{app="b"}`, Bytes: 8, Entries: 1}},
+				Limit:   logproto.VolumeNoLimit,
+			}, w))
+		}),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, strconv.Itoa(logproto.VolumeNoLimit), r.URL.Query().Get("limit"))
+			require.NoError(t, marshal.WriteVolumeResponseJSON(&logproto.VolumeResponse{
+				Volumes: []logproto.Volume{{Name: `{app="c"}`, Bytes: 12, Entries: 1}, {Name: `{app="e"}`, Bytes: 11, Entries: 1}, {Name: `{app="b"}`, Bytes: 8, Entries: 1}},
+				Limit:   logproto.VolumeNoLimit,
+			}, w))
+		}),
+	)
+	rt.setHandler(handler)
+	resp, err := tpw(rt).RoundTrip(req)
+	// verify 2 calls have been made to downstream.
+	require.Equal(t, 2, handler.count)
+	require.NoError(t, err)
+	volumeResp, err := LokiCodec.DecodeResponse(ctx, resp, lreq)
+	require.NoError(t, err)
+	res, ok := volumeResp.(*LokiVolumeResponse)
+	require.Equal(t, true, ok)
+	require.Equal(t, &logproto.VolumeResponse{
+		Volumes: []logproto.Volume{{Name: `{app="b"}`, Bytes: 16, Entries: 2}, {Name: `{app="c"}`, Bytes: 12, Entries: 1}},
+		Limit:   2,
+	}, res.Response)
+}
+
 func TestLogNoRegex(t *testing.T) {
-	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{}, config.SchemaConfig{}, nil)
+	l := WithSplitByLimits(fakeLimits{maxQueryParallelism: 1}, 4*time.Hour)
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, l, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -354,7 +481,7 @@ func TestLogNoRegex(t *testing.T) {
 	defer rt.Close()
 
 	lreq := &LokiRequest{
-		Query:     `{app="foo"}`, // no regex so it should go to the querier
+		Query:     `{app="foo"}`, // no regex so it is split but not sharded
 		Limit:     1000,
 		StartTs:   testTime.Add(-6 * time.Hour),
 		EndTs:     testTime,
@@ -372,13 +499,25 @@ func TestLogNoRegex(t *testing.T) {
 
 	count, h := promqlResult(streams)
 	rt.setHandler(h)
-	_, err = tpw(rt).RoundTrip(req)
-	require.Equal(t, 1, *count)
+	resp, err := tpw(rt).RoundTrip(req)
+	require.Equal(t, 2, *count)
+	require.NoError(t, err)
+	lokiResponse, err := LokiCodec.DecodeResponse(ctx, resp, lreq)
 	require.NoError(t, err)
+
+	// the same query is served from the results cache.
+	count, h = promqlResult(streams)
+	rt.setHandler(h)
+	cacheResp, err := tpw(rt).RoundTrip(req)
+	require.Equal(t, 0, *count)
+	require.NoError(t, err)
+	lokiCacheResponse, err := LokiCodec.DecodeResponse(ctx, cacheResp, lreq)
+	require.NoError(t, err)
+	require.Equal(t, lokiResponse.(*LokiResponse).Data, lokiCacheResponse.(*LokiResponse).Data)
 }
 
 func TestUnhandledPath(t *testing.T) {
-	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{}, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{}, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -388,7 +527,7 @@ func TestUnhandledPath(t *testing.T) {
 	defer rt.Close()
 
 	ctx := user.InjectOrgID(context.Background(), "1")
-	req, err := http.NewRequest(http.MethodGet, "/loki/api/v1/labels/foo/values", nil)
+	req, err := http.NewRequest(http.MethodGet, "/loki/api/v1/rules", nil)
 	require.NoError(t, err)
 	req = req.WithContext(ctx)
 	err = user.InjectOrgIDIntoHTTPRequest(ctx, req)
@@ -403,7 +542,7 @@ func TestUnhandledPath(t *testing.T) {
 
 func TestRegexpParamsSupport(t *testing.T) {
 	l := WithSplitByLimits(fakeLimits{maxSeries: 1, maxQueryParallelism: 2}, 4*time.Hour)
-	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, l, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, l, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -461,6 +600,10 @@ func TestPostQueries(t *testing.T) {
 			t.Error("unexpected default roundtripper called")
 			return nil, nil
 		}),
+		queryrangebase.RoundTripFunc(func(*http.Request) (*http.Response, error) {
+			t.Error("unexpected limited roundtripper called")
+			return nil, nil
+		}),
 		queryrangebase.RoundTripFunc(func(*http.Request) (*http.Response, error) {
 			return nil, nil
 		}),
@@ -480,13 +623,22 @@ func TestPostQueries(t *testing.T) {
 			t.Error("unexpected instant roundtripper called")
 			return nil, nil
 		}),
+		queryrangebase.RoundTripFunc(func(*http.Request) (*http.Response, error) {
+			t.Error("unexpected index stats roundtripper called")
+			return nil, nil
+		}),
+		queryrangebase.RoundTripFunc(func(*http.Request) (*http.Response, error) {
+			t.Error("unexpected volume roundtripper called")
+			return nil, nil
+		}),
 		fakeLimits{},
+		newQueryBlocker(util_log.Logger, fakeLimits{}, NewQueryBlockerMetrics(nil)),
 	).RoundTrip(req)
 	require.NoError(t, err)
 }
 
 func TestEntriesLimitsTripperware(t *testing.T) {
-	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxEntriesLimitPerQuery: 5000}, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxEntriesLimitPerQuery: 5000}, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -496,7 +648,7 @@ func TestEntriesLimitsTripperware(t *testing.T) {
 	defer rt.Close()
 
 	lreq := &LokiRequest{
-		Query:     `{app="foo"}`, // no regex so it should go to the querier
+		Query:     `{app="foo"}`,
 		Limit:     10000,
 		StartTs:   testTime.Add(-6 * time.Hour),
 		EndTs:     testTime,
@@ -517,7 +669,7 @@ func TestEntriesLimitsTripperware(t *testing.T) {
 }
 
 func TestEntriesLimitWithZeroTripperware(t *testing.T) {
-	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{}, config.SchemaConfig{}, nil)
+	tpw, stopper, err := NewTripperware(testConfig, util_log.Logger, fakeLimits{maxQueryParallelism: 1}, config.SchemaConfig{}, nil, nil)
 	if stopper != nil {
 		defer stopper.Stop()
 	}
@@ -527,7 +679,7 @@ func TestEntriesLimitWithZeroTripperware(t *testing.T) {
 	defer rt.Close()
 
 	lreq := &LokiRequest{
-		Query:     `{app="foo"}`, // no regex so it should go to the querier
+		Query:     `{app="foo"}`,
 		Limit:     10000,
 		StartTs:   testTime.Add(-6 * time.Hour),
 		EndTs:     testTime,
@@ -543,6 +695,8 @@ func TestEntriesLimitWithZeroTripperware(t *testing.T) {
 	err = user.InjectOrgIDIntoHTTPRequest(ctx, req)
 	require.NoError(t, err)
 
+	_, h := promqlResult(streams)
+	rt.setHandler(h)
 	_, err = tpw(rt).RoundTrip(req)
 	require.NoError(t, err)
 }
@@ -555,6 +709,10 @@ type fakeLimits struct {
 	maxSeries               int
 	splits                  map[string]time.Duration
 	minShardingLookback     time.Duration
+	quantileSharding        bool
+	maxQueryBytesRead       int
+	maxQuerierBytesRead     int
+	blockedQueries          []validation.BlockedQuery
 }
 
 func (f fakeLimits) QuerySplitDuration(key string) time.Duration {
@@ -595,6 +753,22 @@ func (f fakeLimits) MinShardingLookback(string) time.Duration {
 	return f.minShardingLookback
 }
 
+func (f fakeLimits) QuantileOverTimeSharding(string) bool {
+	return f.quantileSharding
+}
+
+func (f fakeLimits) MaxQueryBytesRead(string) int {
+	return f.maxQueryBytesRead
+}
+
+func (f fakeLimits) MaxQuerierBytesRead(string) int {
+	return f.maxQuerierBytesRead
+}
+
+func (f fakeLimits) BlockedQueries(string) []validation.BlockedQuery {
+	return f.blockedQueries
+}
+
 func counter() (*int, http.Handler) {
 	count := 0
 	var lock sync.Mutex
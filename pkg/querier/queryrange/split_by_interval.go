@@ -3,6 +3,7 @@ package queryrange
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/opentracing/opentracing-go"
@@ -202,8 +203,8 @@ func (h *splitByInterval) Do(ctx context.Context, r queryrangebase.Request) (que
 				intervals[i], intervals[j] = intervals[j], intervals[i]
 			}
 		}
-	case *LokiSeriesRequest, *LokiLabelNamesRequest:
-		// Set this to 0 since this is not used in Series/Labels Request.
+	case *LokiSeriesRequest, *LokiLabelNamesRequest, *LokiIndexStatsRequest, *LokiVolumeRequest:
+		// Set this to 0 since this is not used in Series/Labels/IndexStats/Volume Request.
 		limit = 0
 	default:
 		return nil, httpgrpc.Errorf(http.StatusBadRequest, "unknown request type")
@@ -259,6 +260,25 @@ func splitByTime(req queryrangebase.Request, interval time.Duration) ([]queryran
 				Path:    r.Path,
 				StartTs: start,
 				EndTs:   end,
+				Query:   r.Query,
+			})
+		})
+	case *LokiIndexStatsRequest:
+		forInterval(interval, r.StartTs, r.EndTs, true, func(start, end time.Time) {
+			reqs = append(reqs, &LokiIndexStatsRequest{
+				StartTs: start,
+				EndTs:   end,
+				Query:   r.Query,
+			})
+		})
+	case *LokiVolumeRequest:
+		forInterval(interval, r.StartTs, r.EndTs, true, func(start, end time.Time) {
+			reqs = append(reqs, &LokiVolumeRequest{
+				StartTs:      start,
+				EndTs:        end,
+				Query:        r.Query,
+				Limit:        r.Limit,
+				TargetLabels: r.TargetLabels,
 			})
 		})
 	default:
@@ -296,18 +316,30 @@ func forInterval(interval time.Duration, start, end time.Time, endTimeInclusive
 }
 
 // maxRangeVectorDuration returns the maximum range vector duration within a LogQL query.
+// The duration of a subquery is its range plus the maximum range vector duration of its inner query.
 func maxRangeVectorDuration(q string) (time.Duration, error) {
 	expr, err := syntax.ParseSampleExpr(q)
 	if err != nil {
 		return 0, err
 	}
+	return maxRangeVectorDurationOf(expr), nil
+}
+
+func maxRangeVectorDurationOf(expr syntax.Expr) time.Duration {
 	var max time.Duration
 	expr.Walk(func(e interface{}) {
-		if r, ok := e.(*syntax.LogRange); ok && r.Interval > max {
-			max = r.Interval
+		switch r := e.(type) {
+		case *syntax.LogRange:
+			if r.Interval > max {
+				max = r.Interval
+			}
+		case *syntax.SubqueryExpr:
+			if d := r.Range + maxRangeVectorDurationOf(r.Left); d > max {
+				max = d
+			}
 		}
 	})
-	return max, nil
+	return max
 }
 
 // reduceSplitIntervalForRangeVector reduces the split interval for a range query based on the duration of the range vector.
@@ -333,6 +365,14 @@ func splitMetricByTime(r queryrangebase.Request, interval time.Duration) ([]quer
 
 	lokiReq := r.(*LokiRequest)
 
+	// start() and end() of @ modifiers are the start and end of the whole
+	// query, not of the split queries.
+	query, err := resolveAtModifiers(lokiReq)
+	if err != nil {
+		return nil, err
+	}
+	lokiReq = lokiReq.WithQuery(query).(*LokiRequest)
+
 	// step align start and end time of the query. Start time is rounded down and end time is rounded up.
 	stepNs := r.GetStep() * 1e6
 	startNs := lokiReq.StartTs.UnixNano()
@@ -384,6 +424,20 @@ func splitMetricByTime(r queryrangebase.Request, interval time.Duration) ([]quer
 	return reqs, nil
 }
 
+// resolveAtModifiers returns the query of a request with the start() and end()
+// @ modifiers replaced by the start and end of the request.
+func resolveAtModifiers(r *LokiRequest) (string, error) {
+	if !strings.Contains(r.Query, syntax.OpAt) {
+		return r.Query, nil
+	}
+	expr, err := syntax.ParseExpr(r.Query)
+	if err != nil {
+		return "", err
+	}
+	syntax.ResolveAtModifiers(expr, r.StartTs, r.EndTs)
+	return expr.String(), nil
+}
+
 // Round up to the step before the next interval boundary.
 func nextIntervalBoundary(t time.Time, step int64, interval time.Duration) time.Time {
 	stepNs := step * 1e6
@@ -64,6 +64,33 @@ func Test_splitQuery(t *testing.T) {
 		}
 	}
 
+	buildLokiLabelValuesRequest := func(start, end time.Time) queryrangebase.Request {
+		return &LokiLabelNamesRequest{
+			StartTs: start,
+			EndTs:   end,
+			Path:    "/label/foo/values",
+			Query:   `{foo="bar"}`,
+		}
+	}
+
+	buildLokiIndexStatsRequest := func(start, end time.Time) queryrangebase.Request {
+		return &LokiIndexStatsRequest{
+			StartTs: start,
+			EndTs:   end,
+			Query:   `{foo="bar"}`,
+		}
+	}
+
+	buildLokiVolumeRequest := func(start, end time.Time) queryrangebase.Request {
+		return &LokiVolumeRequest{
+			StartTs:      start,
+			EndTs:        end,
+			Query:        `{foo="bar"}`,
+			Limit:        10,
+			TargetLabels: []string{"app"},
+		}
+	}
+
 	type interval struct {
 		start, end time.Time
 	}
@@ -87,6 +114,18 @@ func Test_splitQuery(t *testing.T) {
 			buildLokiLabelNamesRequest,
 			true,
 		},
+		"LokiLabelValuesRequest": {
+			buildLokiLabelValuesRequest,
+			true,
+		},
+		"LokiIndexStatsRequest": {
+			buildLokiIndexStatsRequest,
+			true,
+		},
+		"LokiVolumeRequest": {
+			buildLokiVolumeRequest,
+			true,
+		},
 	} {
 		expectedSplitGap := time.Duration(0)
 		if tc.endTimeInclusive {
@@ -544,6 +583,54 @@ func Test_splitMetricQuery(t *testing.T) {
 			},
 			interval: 1 * time.Hour,
 		},
+		// the duration of a subquery includes the range of its inner query
+		{
+			input: &LokiRequest{
+				StartTs: time.Unix(2*3600, 0),
+				EndTs:   time.Unix(3*3*3600, 0),
+				Step:    15 * seconds,
+				Query:   `max_over_time(rate({app="foo"}[1h])[5h:1m])`,
+			},
+			expected: []queryrangebase.Request{
+				&LokiRequest{
+					StartTs: time.Unix(2*3600, 0),
+					EndTs:   time.Unix((6*3600)-15, 0),
+					Step:    15 * seconds,
+					Query:   `max_over_time(rate({app="foo"}[1h])[5h:1m])`,
+				},
+				&LokiRequest{
+					StartTs: time.Unix(6*3600, 0),
+					EndTs:   time.Unix(3*3*3600, 0),
+					Step:    15 * seconds,
+					Query:   `max_over_time(rate({app="foo"}[1h])[5h:1m])`,
+				},
+			},
+			interval: 1 * time.Hour,
+		},
+		// start() and end() of @ modifiers are resolved before splitting
+		{
+			input: &LokiRequest{
+				StartTs: time.Unix(2*3600, 0),
+				EndTs:   time.Unix(6*3600, 0),
+				Step:    15 * seconds,
+				Query:   `rate({app="foo"}[1m] @ start()) / rate({app="foo"}[1m] @ end())`,
+			},
+			expected: []queryrangebase.Request{
+				&LokiRequest{
+					StartTs: time.Unix(2*3600, 0),
+					EndTs:   time.Unix((4*3600)-15, 0),
+					Step:    15 * seconds,
+					Query:   `(rate({app="foo"}[1m] @ 7200) / rate({app="foo"}[1m] @ 21600))`,
+				},
+				&LokiRequest{
+					StartTs: time.Unix(4*3600, 0),
+					EndTs:   time.Unix(6*3600, 0),
+					Step:    15 * seconds,
+					Query:   `(rate({app="foo"}[1m] @ 7200) / rate({app="foo"}[1m] @ 21600))`,
+				},
+			},
+			interval: 2 * time.Hour,
+		},
 		// range vector too large we don't want to split it
 		{
 			input: &LokiRequest{
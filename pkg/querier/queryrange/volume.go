@@ -0,0 +1,44 @@
+package queryrange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/querier/queryrange/queryrangebase"
+)
+
+// NewVolumeLimitMiddleware asks the downstream handlers for all the volumes and only keeps the largest ones,
+// according to the limit of the request, once they are merged.
+// Limiting the volumes of every split request would drop the volumes just missing the largest ones of a split,
+// although they could be among the largest ones once summed.
+func NewVolumeLimitMiddleware() queryrangebase.Middleware {
+	return queryrangebase.MiddlewareFunc(func(next queryrangebase.Handler) queryrangebase.Handler {
+		return queryrangebase.HandlerFunc(func(ctx context.Context, req queryrangebase.Request) (queryrangebase.Response, error) {
+			volumeReq, ok := req.(*LokiVolumeRequest)
+			if !ok {
+				return next.Do(ctx, req)
+			}
+
+			unlimitedReq := *volumeReq
+			unlimitedReq.Limit = logproto.VolumeNoLimit
+			resp, err := next.Do(ctx, &unlimitedReq)
+			if err != nil {
+				return nil, err
+			}
+			volumeResp, ok := resp.(*LokiVolumeResponse)
+			if !ok {
+				return nil, fmt.Errorf("unexpected response type %T", resp)
+			}
+
+			volumes := logproto.TopVolumes(volumeResp.Response.Volumes, volumeReq.Limit)
+			return &LokiVolumeResponse{
+				Response: &logproto.VolumeResponse{
+					Volumes: volumes,
+					Limit:   volumeReq.Limit,
+				},
+				Headers: volumeResp.Headers,
+			}, nil
+		})
+	})
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/go-kit/log/level"
 	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/model/labels"
+	tsdb_errors "github.com/prometheus/prometheus/tsdb/errors"
 
 	"github.com/grafana/loki/pkg/iter"
 	loghttp "github.com/grafana/loki/pkg/loghttp/legacy"
@@ -55,6 +57,12 @@ type Tailer struct {
 	// if we are not seeing any response from ingester,
 	// how long do we want to wait by going into sleep
 	waitEntryThrottle time.Duration
+
+	// tenantTailers are the tailers of each tenant of a multi-tenant tail request,
+	// whose responses are merged by this tailer. It has no streams of its own.
+	tenantTailers map[string]*Tailer
+	quit          chan struct{}
+	quitOnce      sync.Once
 }
 
 func (t *Tailer) readTailClients() {
@@ -247,9 +255,21 @@ func (t *Tailer) close() error {
 	defer t.streamMtx.Unlock()
 
 	t.stopped = true
+	if t.tenantTailers != nil {
+		t.quitOnce.Do(func() { close(t.quit) })
+		return closeTailers(t.tenantTailers)
+	}
 	return t.openStreamIterator.Close()
 }
 
+func closeTailers(tailers map[string]*Tailer) error {
+	errs := tsdb_errors.NewMulti()
+	for _, tailer := range tailers {
+		errs.Add(tailer.close())
+	}
+	return errs.Err()
+}
+
 func (t *Tailer) isResponseChanBlocked() bool {
 	// Thread-safety: len() and cap() on a channel are thread-safe. The cap() doesn't
 	// change over the time, while len() does.
@@ -288,6 +308,58 @@ func newTailer(
 	return &t
 }
 
+// newMultiTenantTailer returns a tailer merging the responses of the tailers of
+// each tenant, with the tenant ID label added to their streams.
+func newMultiTenantTailer(tenantTailers map[string]*Tailer) *Tailer {
+	t := Tailer{
+		responseChan:  make(chan *loghttp.TailResponse, maxBufferedTailResponses),
+		closeErrChan:  make(chan error),
+		tenantTailers: tenantTailers,
+		quit:          make(chan struct{}),
+	}
+
+	for id, tailer := range tenantTailers {
+		go t.forwardTenantResponses(id, tailer)
+	}
+	return &t
+}
+
+// forwardTenantResponses sends the responses of a tenant's tailer through the
+// response channel. The tenant's tailer drops entries itself while the
+// response channel is blocked.
+func (t *Tailer) forwardTenantResponses(tenantID string, tailer *Tailer) {
+	r := relabel{
+		tenantID: tenantID,
+		cache:    map[string]labels.Labels{},
+	}
+
+	for {
+		select {
+		case resp := <-tailer.getResponseChan():
+			for i := range resp.Streams {
+				resp.Streams[i].Labels = r.relabel(resp.Streams[i].Labels)
+			}
+			for i := range resp.DroppedEntries {
+				resp.DroppedEntries[i].Labels = r.relabel(resp.DroppedEntries[i].Labels)
+			}
+			select {
+			case t.responseChan <- resp:
+			case <-t.quit:
+				return
+			}
+		case err := <-tailer.getCloseErrorChan():
+			// The tail request ends as soon as the tail of any tenant does.
+			select {
+			case t.closeErrChan <- fmt.Errorf("tenant %s: %w", tenantID, err):
+			case <-t.quit:
+			}
+			return
+		case <-t.quit:
+			return
+		}
+	}
+}
+
 func dropEntry(droppedEntries []loghttp.DroppedEntry, timestamp time.Time, labels string) []loghttp.DroppedEntry {
 	if len(droppedEntries) >= maxDroppedEntriesPerTailResponse {
 		return droppedEntries
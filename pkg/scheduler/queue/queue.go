@@ -57,15 +57,21 @@ type RequestQueue struct {
 	stopped bool
 
 	queueLength       *prometheus.GaugeVec   // Per user and reason.
+	subQueueLength    *prometheus.GaugeVec   // Per user and actor, the first level of the sub-queues.
 	discardedRequests *prometheus.CounterVec // Per user.
+
+	// subQueues are the first level sub-queues of each user with a subQueueLength series.
+	subQueues map[string]map[string]struct{}
 }
 
-func NewRequestQueue(maxOutstandingPerTenant int, forgetDelay time.Duration, queueLength *prometheus.GaugeVec, discardedRequests *prometheus.CounterVec) *RequestQueue {
+func NewRequestQueue(maxOutstandingPerTenant int, forgetDelay time.Duration, queueLength, subQueueLength *prometheus.GaugeVec, discardedRequests *prometheus.CounterVec) *RequestQueue {
 	q := &RequestQueue{
 		queues:                  newUserQueues(maxOutstandingPerTenant, forgetDelay),
 		connectedQuerierWorkers: atomic.NewInt32(0),
 		queueLength:             queueLength,
+		subQueueLength:          subQueueLength,
 		discardedRequests:       discardedRequests,
+		subQueues:               map[string]map[string]struct{}{},
 	}
 
 	q.cond = contextCond{Cond: sync.NewCond(&q.mtx)}
@@ -78,8 +84,13 @@ func NewRequestQueue(maxOutstandingPerTenant int, forgetDelay time.Duration, que
 // this user use (zero or negative = all queriers). It is passed to each EnqueueRequest, because it can change
 // between calls.
 //
+// The path splits the queue of the user into sub-queues, e.g. per actor and then per query type. Requests of the
+// user are dequeued in a round robin fashion between the sub-queues at each level of the path. An empty path
+// puts the request directly in the queue of the user. The length of the first level sub-queues is exposed per
+// user and actor.
+//
 // If request is successfully enqueued, successFn is called with the lock held, before any querier can receive the request.
-func (q *RequestQueue) EnqueueRequest(userID string, req Request, maxQueriers int, successFn func()) error {
+func (q *RequestQueue) EnqueueRequest(userID string, path []string, req Request, maxQueriers int, successFn func()) error {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
 
@@ -93,19 +104,26 @@ func (q *RequestQueue) EnqueueRequest(userID string, req Request, maxQueriers in
 		return errors.New("no queue found")
 	}
 
-	select {
-	case queue <- req:
-		q.queueLength.WithLabelValues(userID).Inc()
-		q.cond.Broadcast()
-		// Call this function while holding a lock. This guarantees that no querier can fetch the request before function returns.
-		if successFn != nil {
-			successFn()
-		}
-		return nil
-	default:
+	if queue.length >= q.queues.maxUserQueueSize {
 		q.discardedRequests.WithLabelValues(userID).Inc()
 		return ErrTooManyRequests
 	}
+
+	queue.enqueue(path, req)
+	q.queueLength.WithLabelValues(userID).Inc()
+	if len(path) > 0 {
+		q.subQueueLength.WithLabelValues(userID, path[0]).Inc()
+		if q.subQueues[userID] == nil {
+			q.subQueues[userID] = map[string]struct{}{}
+		}
+		q.subQueues[userID][path[0]] = struct{}{}
+	}
+	q.cond.Broadcast()
+	// Call this function while holding a lock. This guarantees that no querier can fetch the request before function returns.
+	if successFn != nil {
+		successFn()
+	}
+	return nil
 }
 
 // GetNextRequestForQuerier find next user queue and takes the next request off of it. Will block if there are no requests.
@@ -141,12 +159,19 @@ FindQueue:
 
 		// Pick next request from the queue.
 		for {
-			request := <-queue
-			if len(queue) == 0 {
+			request, subQueue := queue.dequeue()
+			if queue.length == 0 {
 				q.queues.deleteQueue(userID)
 			}
 
 			q.queueLength.WithLabelValues(userID).Dec()
+			if subQueue != "" {
+				if queue.hasChild(subQueue) {
+					q.subQueueLength.WithLabelValues(userID, subQueue).Dec()
+				} else {
+					q.deleteSubQueueMetric(userID, subQueue)
+				}
+			}
 
 			// Tell close() we've processed a request.
 			q.cond.Broadcast()
@@ -161,6 +186,24 @@ FindQueue:
 	goto FindQueue
 }
 
+func (q *RequestQueue) deleteSubQueueMetric(userID, subQueue string) {
+	q.subQueueLength.DeleteLabelValues(userID, subQueue)
+	delete(q.subQueues[userID], subQueue)
+	if len(q.subQueues[userID]) == 0 {
+		delete(q.subQueues, userID)
+	}
+}
+
+// CleanupInactiveUserMetrics deletes the sub-queue length series of an inactive user.
+func (q *RequestQueue) CleanupInactiveUserMetrics(userID string) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	for subQueue := range q.subQueues[userID] {
+		q.deleteSubQueueMetric(userID, subQueue)
+	}
+}
+
 func (q *RequestQueue) forgetDisconnectedQueriers(_ context.Context) error {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
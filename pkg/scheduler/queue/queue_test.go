@@ -10,6 +10,7 @@ import (
 
 	"github.com/grafana/dskit/services"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +25,7 @@ func BenchmarkGetNextRequest(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		queue := NewRequestQueue(maxOutstandingPerTenant, 0,
 			prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}),
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user", "actor"}),
 			prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"user"}),
 		)
 		queues = append(queues, queue)
@@ -36,7 +38,7 @@ func BenchmarkGetNextRequest(b *testing.B) {
 			for j := 0; j < numTenants; j++ {
 				userID := strconv.Itoa(j)
 
-				err := queue.EnqueueRequest(userID, "request", 0, nil)
+				err := queue.EnqueueRequest(userID, nil, "request", 0, nil)
 				if err != nil {
 					b.Fatal(err)
 				}
@@ -81,6 +83,7 @@ func BenchmarkQueueRequest(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		q := NewRequestQueue(maxOutstandingPerTenant, 0,
 			prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}),
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user", "actor"}),
 			prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"user"}),
 		)
 
@@ -100,7 +103,7 @@ func BenchmarkQueueRequest(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		for i := 0; i < maxOutstandingPerTenant; i++ {
 			for j := 0; j < numTenants; j++ {
-				err := queues[n].EnqueueRequest(users[j], requests[j], 0, nil)
+				err := queues[n].EnqueueRequest(users[j], nil, requests[j], 0, nil)
 				if err != nil {
 					b.Fatal(err)
 				}
@@ -114,6 +117,7 @@ func TestRequestQueue_GetNextRequestForQuerier_ShouldGetRequestAfterReshardingBe
 
 	queue := NewRequestQueue(1, forgetDelay,
 		prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}),
+		prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user", "actor"}),
 		prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"user"}))
 
 	// Start the queue service.
@@ -141,7 +145,7 @@ func TestRequestQueue_GetNextRequestForQuerier_ShouldGetRequestAfterReshardingBe
 
 	// Enqueue a request from an user which would be assigned to querier-1.
 	// NOTE: "user-1" hash falls in the querier-1 shard.
-	require.NoError(t, queue.EnqueueRequest("user-1", "request", 1, nil))
+	require.NoError(t, queue.EnqueueRequest("user-1", nil, "request", 1, nil))
 
 	startTime := time.Now()
 	querier2wg.Wait()
@@ -151,6 +155,72 @@ func TestRequestQueue_GetNextRequestForQuerier_ShouldGetRequestAfterReshardingBe
 	assert.GreaterOrEqual(t, waitTime.Milliseconds(), forgetDelay.Milliseconds())
 }
 
+func TestRequestQueue_SubQueuesFairness(t *testing.T) {
+	queueLength := prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"})
+	subQueueLength := prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user", "actor"})
+	queue := NewRequestQueue(100, 0, queueLength, subQueueLength,
+		prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"user"}))
+	queue.RegisterQuerierConnection("querier-1")
+
+	// An analyst sends a long query split into many subqueries, and then a dashboard sends a few queries.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, queue.EnqueueRequest("user-1", []string{"analyst", "query_range"}, "analyst", 0, nil))
+	}
+	require.NoError(t, queue.EnqueueRequest("user-1", []string{"dashboard", "query_range"}, "dashboard", 0, nil))
+	require.NoError(t, queue.EnqueueRequest("user-1", []string{"dashboard", "labels"}, "dashboard", 0, nil))
+
+	require.Equal(t, 12.0, testutil.ToFloat64(queueLength.WithLabelValues("user-1")))
+	require.Equal(t, 10.0, testutil.ToFloat64(subQueueLength.WithLabelValues("user-1", "analyst")))
+	require.Equal(t, 2.0, testutil.ToFloat64(subQueueLength.WithLabelValues("user-1", "dashboard")))
+
+	// The dashboard queries are not starved by the ones of the analyst.
+	var actual []Request
+	idx := FirstUser()
+	for i := 0; i < 4; i++ {
+		req, nidx, err := queue.GetNextRequestForQuerier(context.Background(), idx, "querier-1")
+		require.NoError(t, err)
+		actual = append(actual, req)
+		idx = nidx
+	}
+	require.Equal(t, []Request{"analyst", "dashboard", "analyst", "dashboard"}, actual)
+
+	// Metrics of empty sub-queues are removed.
+	require.Equal(t, 8.0, testutil.ToFloat64(queueLength.WithLabelValues("user-1")))
+	require.Equal(t, 8.0, testutil.ToFloat64(subQueueLength.WithLabelValues("user-1", "analyst")))
+	require.False(t, subQueueLength.DeleteLabelValues("user-1", "dashboard"))
+}
+
+func TestRequestQueue_CleanupInactiveUserMetrics(t *testing.T) {
+	subQueueLength := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "sub_queue_length"}, []string{"user", "actor"})
+	queue := NewRequestQueue(100, 0,
+		prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}),
+		subQueueLength,
+		prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"user"}))
+
+	require.NoError(t, queue.EnqueueRequest("user-1", []string{"analyst", "query_range"}, "analyst", 0, nil))
+	require.NoError(t, queue.EnqueueRequest("user-1", []string{"dashboard", "query_range"}, "dashboard", 0, nil))
+	require.NoError(t, queue.EnqueueRequest("user-2", []string{"dashboard", "query_range"}, "dashboard", 0, nil))
+	require.Equal(t, 3, testutil.CollectAndCount(subQueueLength))
+
+	queue.CleanupInactiveUserMetrics("user-1")
+	require.Equal(t, 1, testutil.CollectAndCount(subQueueLength))
+	require.Equal(t, 1.0, testutil.ToFloat64(subQueueLength.WithLabelValues("user-2", "dashboard")))
+}
+
+func TestRequestQueue_TooManyRequestsWithSubQueues(t *testing.T) {
+	discardedRequests := prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"user"})
+	queue := NewRequestQueue(2, 0,
+		prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}),
+		prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user", "actor"}),
+		discardedRequests)
+
+	// The limit of outstanding requests is for the whole queue of the user.
+	require.NoError(t, queue.EnqueueRequest("user-1", []string{"a"}, "request", 0, nil))
+	require.NoError(t, queue.EnqueueRequest("user-1", []string{"b"}, "request", 0, nil))
+	require.Equal(t, ErrTooManyRequests, queue.EnqueueRequest("user-1", []string{"c"}, "request", 0, nil))
+	require.Equal(t, 1.0, testutil.ToFloat64(discardedRequests.WithLabelValues("user-1")))
+}
+
 func TestContextCond(t *testing.T) {
 	t.Run("wait until broadcast", func(t *testing.T) {
 		t.Parallel()
@@ -0,0 +1,91 @@
+package queue
+
+// treeQueue is a FIFO queue of requests which can be split into sub-queues, identified by a path.
+// Requests are dequeued in a round robin fashion between the requests of the queue itself and each of its
+// sub-queues, so that a sub-queue with many requests can't starve the others.
+type treeQueue struct {
+	name string
+
+	// Requests enqueued with an empty path.
+	requests []Request
+
+	children map[string]*treeQueue
+	// Names of the sub-queues in round robin order.
+	order []string
+	// Next position of the round robin. 0 is for the requests of the queue itself,
+	// and i > 0 is for the sub-queue order[i-1].
+	next int
+
+	// Number of requests in the queue, including its sub-queues.
+	length int
+}
+
+func newTreeQueue(name string) *treeQueue {
+	return &treeQueue{name: name}
+}
+
+// enqueue adds the request to the sub-queue at the given path, creating the sub-queues if needed.
+func (q *treeQueue) enqueue(path []string, req Request) {
+	q.length++
+	if len(path) == 0 {
+		q.requests = append(q.requests, req)
+		return
+	}
+
+	child, ok := q.children[path[0]]
+	if !ok {
+		if q.children == nil {
+			q.children = map[string]*treeQueue{}
+		}
+		child = newTreeQueue(path[0])
+		q.children[path[0]] = child
+		q.order = append(q.order, path[0])
+	}
+	child.enqueue(path[1:], req)
+}
+
+// dequeue removes the next request of the queue. It returns nil if the queue is empty, otherwise it also returns
+// the name of the sub-queue the request was taken from, or "" if the request was enqueued with an empty path.
+// Empty sub-queues are removed.
+func (q *treeQueue) dequeue() (Request, string) {
+	if q.length == 0 {
+		return nil, ""
+	}
+
+	for {
+		pos := q.next
+		q.next = (q.next + 1) % (len(q.order) + 1)
+
+		if pos == 0 {
+			if len(q.requests) == 0 {
+				continue
+			}
+			req := q.requests[0]
+			q.requests[0] = nil
+			q.requests = q.requests[1:]
+			q.length--
+			return req, ""
+		}
+
+		child := q.children[q.order[pos-1]]
+		req, _ := child.dequeue()
+		q.length--
+
+		if child.length == 0 {
+			delete(q.children, child.name)
+			q.order = append(q.order[:pos-1], q.order[pos:]...)
+			// The next sub-queue has taken the position of the removed one.
+			q.next = pos
+			if q.next > len(q.order) {
+				q.next = 0
+			}
+		}
+		return req, child.name
+	}
+}
+
+// hasChild returns true if the queue has a sub-queue with the given name.
+func (q *treeQueue) hasChild(name string) bool {
+	_, ok := q.children[name]
+	return ok
+}
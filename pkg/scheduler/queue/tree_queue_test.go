@@ -0,0 +1,89 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTreeQueue_RoundRobin(t *testing.T) {
+	q := newTreeQueue("root")
+
+	// A single actor with many requests.
+	for i := 0; i < 4; i++ {
+		q.enqueue([]string{"analyst", "query_range"}, "analyst")
+	}
+	q.enqueue([]string{"dashboard-1", "query_range"}, "dashboard-1")
+	q.enqueue([]string{"dashboard-2", "series"}, "dashboard-2")
+	q.enqueue(nil, "no-path")
+	require.Equal(t, 7, q.length)
+
+	type dequeued struct {
+		req      Request
+		subQueue string
+	}
+	var actual []dequeued
+	for q.length > 0 {
+		req, subQueue := q.dequeue()
+		actual = append(actual, dequeued{req, subQueue})
+	}
+
+	require.Equal(t, []dequeued{
+		{"no-path", ""},
+		{"analyst", "analyst"},
+		{"dashboard-1", "dashboard-1"},
+		{"dashboard-2", "dashboard-2"},
+		{"analyst", "analyst"},
+		{"analyst", "analyst"},
+		{"analyst", "analyst"},
+	}, actual)
+
+	// Empty sub-queues are removed.
+	require.False(t, q.hasChild("analyst"))
+	require.Empty(t, q.children)
+	require.Empty(t, q.order)
+
+	req, subQueue := q.dequeue()
+	require.Nil(t, req)
+	require.Equal(t, "", subQueue)
+}
+
+func TestTreeQueue_NestedRoundRobin(t *testing.T) {
+	q := newTreeQueue("root")
+
+	for i := 0; i < 3; i++ {
+		q.enqueue([]string{"dashboard", "query_range"}, "query_range")
+	}
+	q.enqueue([]string{"dashboard", "labels"}, "labels")
+
+	var actual []Request
+	for q.length > 0 {
+		req, subQueue := q.dequeue()
+		require.Equal(t, "dashboard", subQueue)
+		actual = append(actual, req)
+	}
+
+	// Query types of the same actor are dequeued in a round robin fashion too.
+	require.Equal(t, []Request{"query_range", "labels", "query_range", "query_range"}, actual)
+}
+
+func TestTreeQueue_EnqueueWhileDequeuing(t *testing.T) {
+	q := newTreeQueue("root")
+
+	q.enqueue([]string{"a"}, "a-1")
+	q.enqueue([]string{"b"}, "b-1")
+
+	req, _ := q.dequeue()
+	require.Equal(t, "a-1", req)
+
+	// "a" has been removed, so it's added back at the end of the round robin.
+	q.enqueue([]string{"a"}, "a-2")
+	q.enqueue([]string{"c"}, "c-1")
+
+	var actual []Request
+	for q.length > 0 {
+		req, _ := q.dequeue()
+		actual = append(actual, req)
+	}
+	require.Equal(t, []Request{"b-1", "a-2", "c-1"}, actual)
+}
@@ -371,6 +371,39 @@ func (s *store) Series(ctx context.Context, req logql.SelectLogParams) ([]logpro
 	return result, nil
 }
 
+// Volume returns the bytes and entries of the logs streams matching the matchers, aggregated by series or target labels.
+func (s *store) Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error) {
+	matchers, err := withMetricNameMatcher(matchers)
+	if err != nil {
+		return nil, err
+	}
+	return s.Store.Volume(ctx, userID, from, through, limit, targetLabels, matchers...)
+}
+
+// Stats returns the number of streams, chunks, entries and bytes of the logs streams matching the matchers.
+func (s *store) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	matchers, err := withMetricNameMatcher(matchers)
+	if err != nil {
+		return nil, err
+	}
+	return s.Store.Stats(ctx, userID, from, through, matchers...)
+}
+
+// withMetricNameMatcher prepends the metric name matcher of the logs to the matchers, unless they already have it,
+// like the requests forwarded by the index gateway clients, without mutating the caller's slice.
+func withMetricNameMatcher(matchers []*labels.Matcher) ([]*labels.Matcher, error) {
+	for _, m := range matchers {
+		if m.Name == labels.MetricName {
+			return matchers, nil
+		}
+	}
+	nameLabelMatcher, err := labels.NewMatcher(labels.MatchEqual, labels.MetricName, "logs")
+	if err != nil {
+		return nil, err
+	}
+	return append([]*labels.Matcher{nameLabelMatcher}, matchers...), nil
+}
+
 // SelectLogs returns an iterator that will query the store for more chunks while iterating instead of fetching all chunks upfront
 // for that request.
 func (s *store) SelectLogs(ctx context.Context, req logql.SelectLogParams) (iter.EntryIterator, error) {
@@ -7,6 +7,7 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 
+	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/storage/chunk"
 	"github.com/grafana/loki/pkg/storage/chunk/fetcher"
 	"github.com/grafana/loki/pkg/util"
@@ -22,6 +23,10 @@ type Store interface {
 	GetSeries(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) ([]labels.Labels, error)
 	LabelValuesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string, labelName string, matchers ...*labels.Matcher) ([]string, error)
 	LabelNamesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string) ([]string, error)
+	// Stats returns the number of streams, chunks, entries and bytes matching the matchers.
+	Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error)
+	// Volume returns the largest volumes matching the matchers, aggregated by series or target labels.
+	Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error)
 	GetChunkFetcher(tm model.Time) *fetcher.Fetcher
 	SetChunkFilterer(chunkFilter chunk.RequestChunkFilterer)
 	Stop()
@@ -139,6 +144,45 @@ func (c compositeStore) LabelNamesForMetricName(ctx context.Context, userID stri
 	return result.Strings(), err
 }
 
+// Stats sums the stats of the stores for each period overlapping the time range.
+func (c compositeStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	var responses []*logproto.IndexStatsResponse
+	err := c.forStores(ctx, from, through, func(innerCtx context.Context, from, through model.Time, store Store) error {
+		stats, err := store.Stats(innerCtx, userID, from, through, matchers...)
+		if err != nil {
+			return err
+		}
+		responses = append(responses, stats)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logproto.MergeIndexStatsResponses(responses)
+}
+
+// Volume merges the volumes of the stores for each period overlapping the time range.
+func (c compositeStore) Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error) {
+	var responses []*logproto.VolumeResponse
+	err := c.forStores(ctx, from, through, func(innerCtx context.Context, from, through model.Time, store Store) error {
+		volumes, err := store.Volume(innerCtx, userID, from, through, limit, targetLabels, matchers...)
+		if err != nil {
+			return err
+		}
+		responses = append(responses, volumes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, err := logproto.MergeVolumeResponses(responses)
+	if err != nil {
+		return nil, err
+	}
+	res.Limit = limit
+	return res, nil
+}
+
 func (c compositeStore) GetChunkRefs(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) ([][]chunk.Chunk, []*fetcher.Fetcher, error) {
 	chunkIDs := [][]chunk.Chunk{}
 	fetchers := []*fetcher.Fetcher{}
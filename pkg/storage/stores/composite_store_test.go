@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/weaveworks/common/test"
 
+	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/storage/chunk"
 	"github.com/grafana/loki/pkg/storage/chunk/fetcher"
 )
@@ -43,6 +44,10 @@ func (m mockStore) LabelNamesForMetricName(ctx context.Context, userID string, f
 	return nil, nil
 }
 
+func (m mockStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	return nil, nil
+}
+
 func (m mockStore) GetChunkFetcher(tm model.Time) *fetcher.Fetcher {
 	return nil
 }
@@ -233,6 +238,55 @@ func TestCompositeStoreLabels(t *testing.T) {
 	}
 }
 
+func (m mockStore) Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error) {
+	return nil, nil
+}
+
+type mockStoreStats struct {
+	mockStore
+	stats *logproto.IndexStatsResponse
+}
+
+func (m mockStoreStats) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	return m.stats, nil
+}
+
+func TestCompositeStoreStats(t *testing.T) {
+	t.Parallel()
+
+	cs := compositeStore{
+		stores: []compositeStoreEntry{
+			{model.TimeFromUnix(0), mockStore(1)},
+			{model.TimeFromUnix(20), mockStoreStats{mockStore(1), &logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4}}},
+			{model.TimeFromUnix(40), mockStoreStats{mockStore(1), &logproto.IndexStatsResponse{Streams: 2, Chunks: 3, Bytes: 4, Entries: 5}}},
+		},
+	}
+
+	for i, tc := range []struct {
+		from, through int64
+		want          *logproto.IndexStatsResponse
+	}{
+		{
+			0, 10,
+			&logproto.IndexStatsResponse{},
+		},
+		{
+			0, 30,
+			&logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 3, Entries: 4},
+		},
+		{
+			0, 40,
+			&logproto.IndexStatsResponse{Streams: 3, Chunks: 5, Bytes: 7, Entries: 9},
+		},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			have, err := cs.Stats(context.Background(), "", model.TimeFromUnix(tc.from), model.TimeFromUnix(tc.through))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, have)
+		})
+	}
+}
+
 type mockStoreGetChunkFetcher struct {
 	mockStore
 	chunkFetcher *fetcher.Fetcher
@@ -285,3 +339,53 @@ func TestCompositeStore_GetChunkFetcher(t *testing.T) {
 		})
 	}
 }
+
+type mockStoreVolume struct {
+	mockStore
+	volumes *logproto.VolumeResponse
+}
+
+func (m mockStoreVolume) Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error) {
+	return m.volumes, nil
+}
+
+func TestCompositeStoreVolume(t *testing.T) {
+	t.Parallel()
+
+	cs := compositeStore{
+		stores: []compositeStoreEntry{
+			{model.TimeFromUnix(0), mockStore(1)},
+			{model.TimeFromUnix(20), mockStoreVolume{mockStore(1), &logproto.VolumeResponse{
+				Volumes: []logproto.Volume{{Name: `{app="foo"}`, Bytes: 10, Entries: 1}},
+			}}},
+			{model.TimeFromUnix(40), mockStoreVolume{mockStore(1), &logproto.VolumeResponse{
+				Volumes: []logproto.Volume{{Name: `{app="foo"}`, Bytes: 20, Entries: 2}, {Name: `{app="bar"}`, Bytes: 5, Entries: 1}},
+			}}},
+		},
+	}
+
+	for i, tc := range []struct {
+		from, through int64
+		limit         int32
+		want          *logproto.VolumeResponse
+	}{
+		{
+			0, 10, 10,
+			&logproto.VolumeResponse{Volumes: []logproto.Volume{}, Limit: 10},
+		},
+		{
+			0, 30, 10,
+			&logproto.VolumeResponse{Volumes: []logproto.Volume{{Name: `{app="foo"}`, Bytes: 10, Entries: 1}}, Limit: 10},
+		},
+		{
+			0, 40, 10,
+			&logproto.VolumeResponse{Volumes: []logproto.Volume{{Name: `{app="foo"}`, Bytes: 30, Entries: 3}, {Name: `{app="bar"}`, Bytes: 5, Entries: 1}}, Limit: 10},
+		},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			have, err := cs.Volume(context.Background(), "", model.TimeFromUnix(tc.from), model.TimeFromUnix(tc.through), tc.limit, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, have)
+		})
+	}
+}
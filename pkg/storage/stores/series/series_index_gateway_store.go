@@ -23,6 +23,8 @@ type IndexGatewayClient interface {
 	GetChunkRef(ctx context.Context, in *indexgatewaypb.GetChunkRefRequest, opts ...grpc.CallOption) (*indexgatewaypb.GetChunkRefResponse, error)
 	LabelNamesForMetricName(ctx context.Context, in *indexgatewaypb.LabelNamesForMetricNameRequest, opts ...grpc.CallOption) (*indexgatewaypb.LabelResponse, error)
 	LabelValuesForMetricName(ctx context.Context, in *indexgatewaypb.LabelValuesForMetricNameRequest, opts ...grpc.CallOption) (*indexgatewaypb.LabelResponse, error)
+	GetStats(ctx context.Context, in *logproto.IndexStatsRequest, opts ...grpc.CallOption) (*logproto.IndexStatsResponse, error)
+	GetVolume(ctx context.Context, in *logproto.VolumeRequest, opts ...grpc.CallOption) (*logproto.VolumeResponse, error)
 }
 
 func NewIndexGatewayClientStore(client IndexGatewayClient, index *IndexStore) *IndexGatewayClientStore {
@@ -96,6 +98,40 @@ func (c *IndexGatewayClientStore) LabelValuesForMetricName(ctx context.Context,
 	return resp.Values, nil
 }
 
+func (c *IndexGatewayClientStore) Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error) {
+	resp, err := c.client.GetVolume(ctx, &logproto.VolumeRequest{
+		From:         from,
+		Through:      through,
+		Matchers:     (&syntax.MatchersExpr{Mts: matchers}).String(),
+		Limit:        limit,
+		TargetLabels: targetLabels,
+	})
+	if isUnimplementedCallError(err) {
+		// Handle communication with older index gateways gracefully, by falling back to the index store calls.
+		return c.IndexStore.Volume(ctx, userID, from, through, limit, targetLabels, matchers...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *IndexGatewayClientStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	resp, err := c.client.GetStats(ctx, &logproto.IndexStatsRequest{
+		From:     from,
+		Through:  through,
+		Matchers: (&syntax.MatchersExpr{Mts: matchers}).String(),
+	})
+	if isUnimplementedCallError(err) {
+		// Handle communication with older index gateways gracefully, by falling back to the index store calls.
+		return c.IndexStore.Stats(ctx, userID, from, through, matchers...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // isUnimplementedCallError tells if the GRPC error is a gRPC error with code Unimplemented.
 func isUnimplementedCallError(err error) bool {
 	if err == nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 
+	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/storage/chunk/client/testutils"
 	"github.com/grafana/loki/pkg/storage/config"
 	"github.com/grafana/loki/pkg/storage/stores/series/index"
@@ -27,6 +28,14 @@ func (fakeClient) GetChunkRef(ctx context.Context, in *indexgatewaypb.GetChunkRe
 	return &indexgatewaypb.GetChunkRefResponse{}, nil
 }
 
+func (fakeClient) GetStats(ctx context.Context, in *logproto.IndexStatsRequest, opts ...grpc.CallOption) (*logproto.IndexStatsResponse, error) {
+	return &logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 1024, Entries: 10}, nil
+}
+
+func (fakeClient) GetVolume(ctx context.Context, in *logproto.VolumeRequest, opts ...grpc.CallOption) (*logproto.VolumeResponse, error) {
+	return &logproto.VolumeResponse{Volumes: []logproto.Volume{{Name: `{app="foo"}`, Bytes: 1024, Entries: 10}}, Limit: in.Limit}, nil
+}
+
 func Test_IndexGatewayClient(t *testing.T) {
 	idx := IndexGatewayClientStore{
 		client: fakeClient{},
@@ -36,6 +45,14 @@ func Test_IndexGatewayClient(t *testing.T) {
 	}
 	_, err := idx.GetSeries(context.Background(), "foo", model.Earliest, model.Latest)
 	require.NoError(t, err)
+
+	stats, err := idx.Stats(context.Background(), "foo", model.Earliest, model.Latest, labels.MustNewMatcher(labels.MatchEqual, "__name__", "logs"))
+	require.NoError(t, err)
+	require.Equal(t, &logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Bytes: 1024, Entries: 10}, stats)
+
+	volumes, err := idx.Volume(context.Background(), "foo", model.Earliest, model.Latest, 10, nil, labels.MustNewMatcher(labels.MatchEqual, "__name__", "logs"))
+	require.NoError(t, err)
+	require.Equal(t, &logproto.VolumeResponse{Volumes: []logproto.Volume{{Name: `{app="foo"}`, Bytes: 1024, Entries: 10}}, Limit: 10}, volumes)
 }
 
 func Test_IndexGatewayClient_Fallback(t *testing.T) {
@@ -101,4 +118,10 @@ func Test_IndexGatewayClient_Fallback(t *testing.T) {
 
 	_, err = idx.GetSeries(context.Background(), "foo", model.Now(), model.Now().Add(1*time.Hour), labels.MustNewMatcher(labels.MatchEqual, "__name__", "logs"))
 	require.NoError(t, err)
+
+	_, err = idx.Stats(context.Background(), "foo", model.Now(), model.Now().Add(1*time.Hour), labels.MustNewMatcher(labels.MatchEqual, "__name__", "logs"))
+	require.NoError(t, err)
+
+	_, err = idx.Volume(context.Background(), "foo", model.Now(), model.Now().Add(1*time.Hour), 10, nil, labels.MustNewMatcher(labels.MatchEqual, "__name__", "logs"))
+	require.NoError(t, err)
 }
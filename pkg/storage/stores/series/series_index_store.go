@@ -120,6 +120,42 @@ func (c *IndexStore) GetChunkRefs(ctx context.Context, userID string, from, thro
 	return chunks, nil
 }
 
+// Volume returns the volumes of the series matching the matchers, aggregated by series or target labels.
+// The series index doesn't record the labels nor the size of the chunks, so the chunks are fetched to get them.
+// All the volumes are returned: they are only limited once merged with the volumes from the other sources.
+func (c *IndexStore) Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error) {
+	chunks, err := c.fetchMatchingChunks(ctx, userID, from, through, matchers)
+	if err != nil {
+		return nil, err
+	}
+	return volumesFromChunks(chunks, targetLabels, limit), nil
+}
+
+// Stats returns the number of streams, chunks, bytes and entries matching the matchers.
+// The series index doesn't record the size of the chunks, so the chunks are fetched to get their bytes and entries.
+func (c *IndexStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	chunks, err := c.fetchMatchingChunks(ctx, userID, from, through, matchers)
+	if err != nil {
+		return nil, err
+	}
+	return statsFromChunks(chunks), nil
+}
+
+// fetchMatchingChunks fetches all the chunks matching the matchers.
+func (c *IndexStore) fetchMatchingChunks(ctx context.Context, userID string, from, through model.Time, matchers []*labels.Matcher) ([]chunk.Chunk, error) {
+	refs, err := c.GetChunkRefs(ctx, userID, from, through, matchers...)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]chunk.Chunk, 0, len(refs))
+	keys := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		chunks = append(chunks, chunk.Chunk{ChunkRef: ref})
+		keys = append(keys, c.schemaCfg.ExternalKey(ref))
+	}
+	return c.fetchChunks(ctx, chunks, keys, matchers)
+}
+
 func (c *IndexStore) SetChunkFilterer(f chunk.RequestChunkFilterer) {
 	c.chunkFilterer = f
 }
@@ -146,18 +182,39 @@ func (c *IndexStore) GetSeries(ctx context.Context, userID string, from, through
 }
 
 func (c *IndexStore) chunksToSeries(ctx context.Context, in []logproto.ChunkRef, matchers []*labels.Matcher) ([]labels.Labels, error) {
+	chunks, err := c.fetchSeriesChunks(ctx, in, matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]labels.Labels, 0, len(chunks))
+	for _, chk := range chunks {
+		results = append(results, chk.Metric.WithoutLabels(labels.MetricName))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return labels.Compare(results[i], results[j]) < 0
+	})
+	return results, nil
+}
+
+// fetchSeriesChunks fetches one chunk per series of the chunk refs and returns the ones matching the matchers.
+func (c *IndexStore) fetchSeriesChunks(ctx context.Context, in []logproto.ChunkRef, matchers []*labels.Matcher) ([]chunk.Chunk, error) {
 	// download one per series and merge
 	// group chunks by series
 	chunksBySeries, keys := filterChunkRefsByUniqueFingerprint(c.schemaCfg, in)
+	return c.fetchChunks(ctx, chunksBySeries, keys, matchers)
+}
 
-	results := make([]labels.Labels, 0, len(chunksBySeries))
+// fetchChunks fetches the chunks in batches and returns the ones matching the matchers.
+func (c *IndexStore) fetchChunks(ctx context.Context, chunks []chunk.Chunk, keys []string, matchers []*labels.Matcher) ([]chunk.Chunk, error) {
+	results := make([]chunk.Chunk, 0, len(chunks))
 
 	// bound concurrency
-	groups := make([]chunkGroup, 0, len(chunksBySeries)/c.chunkBatchSize+1)
+	groups := make([]chunkGroup, 0, len(chunks)/c.chunkBatchSize+1)
 
 	split := c.chunkBatchSize
-	if len(chunksBySeries) < split {
-		split = len(chunksBySeries)
+	if len(chunks) < split {
+		split = len(chunks)
 	}
 
 	var chunkFilterer chunk.Filterer
@@ -166,23 +223,23 @@ func (c *IndexStore) chunksToSeries(ctx context.Context, in []logproto.ChunkRef,
 	}
 
 	for split > 0 {
-		groups = append(groups, chunkGroup{chunksBySeries[:split], keys[:split]})
-		chunksBySeries = chunksBySeries[split:]
+		groups = append(groups, chunkGroup{chunks[:split], keys[:split]})
+		chunks = chunks[split:]
 		keys = keys[split:]
-		if len(chunksBySeries) < split {
-			split = len(chunksBySeries)
+		if len(chunks) < split {
+			split = len(chunks)
 		}
 	}
 
 	for _, group := range groups {
 		sort.Sort(group)
-		chunks, err := c.fetcher.FetchChunks(ctx, group.chunks, group.keys)
+		fetched, err := c.fetcher.FetchChunks(ctx, group.chunks, group.keys)
 		if err != nil {
 			return nil, err
 		}
 
 	outer:
-		for _, chk := range chunks {
+		for _, chk := range fetched {
 			for _, matcher := range matchers {
 				if matcher.Name == astmapper.ShardLabel || matcher.Name == labels.MetricName {
 					continue
@@ -196,12 +253,9 @@ func (c *IndexStore) chunksToSeries(ctx context.Context, in []logproto.ChunkRef,
 				continue outer
 			}
 
-			results = append(results, chk.Metric.WithoutLabels(labels.MetricName))
+			results = append(results, chk)
 		}
 	}
-	sort.Slice(results, func(i, j int) bool {
-		return labels.Compare(results[i], results[j]) < 0
-	})
 	return results, nil
 }
 
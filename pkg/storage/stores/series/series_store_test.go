@@ -18,7 +18,9 @@ import (
 	"github.com/weaveworks/common/test"
 	"github.com/weaveworks/common/user"
 
+	"github.com/grafana/loki/pkg/chunkenc"
 	"github.com/grafana/loki/pkg/ingester/client"
+	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/storage"
 	"github.com/grafana/loki/pkg/storage/chunk"
 	"github.com/grafana/loki/pkg/storage/chunk/cache"
@@ -533,6 +535,162 @@ func Test_GetSeries(t *testing.T) {
 	}
 }
 
+func Test_Stats(t *testing.T) {
+	now := model.Now()
+	ch1lbs := labels.Labels{
+		{Name: labels.MetricName, Value: "logs"},
+		{Name: "app", Value: "foo"},
+		{Name: "env", Value: "prod"},
+	}
+	ch2lbs := labels.Labels{
+		{Name: labels.MetricName, Value: "logs"},
+		{Name: "app", Value: "bar"},
+		{Name: "env", Value: "prod"},
+	}
+	chunk1 := dummyLokiChunkFor(now, ch1lbs, 10)
+	chunk2 := dummyLokiChunkFor(now.Add(-time.Minute), ch1lbs, 5)
+	chunk3 := dummyLokiChunkFor(now, ch2lbs, 20)
+	uncompressedSize := func(chunks ...chunk.Chunk) (bytes uint64) {
+		for _, c := range chunks {
+			bytes += uint64(c.Data.(*chunkenc.Facade).LokiChunk().UncompressedSize())
+		}
+		return bytes
+	}
+
+	testCases := []struct {
+		query  string
+		expect *logproto.IndexStatsResponse
+	}{
+		{
+			`{env="prod"}`,
+			&logproto.IndexStatsResponse{Streams: 2, Chunks: 3, Entries: 35, Bytes: uncompressedSize(chunk1, chunk2, chunk3)},
+		},
+		{
+			`{app="foo"}`,
+			&logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Entries: 15, Bytes: uncompressedSize(chunk1, chunk2)},
+		},
+		{
+			`{app="bar"}`,
+			&logproto.IndexStatsResponse{Streams: 1, Chunks: 1, Entries: 20, Bytes: uncompressedSize(chunk3)},
+		},
+		{
+			`{env="prod", app!="bar"}`,
+			&logproto.IndexStatsResponse{Streams: 1, Chunks: 2, Entries: 15, Bytes: uncompressedSize(chunk1, chunk2)},
+		},
+		{
+			`{app="none"}`,
+			&logproto.IndexStatsResponse{},
+		},
+	}
+	for _, schema := range schemas {
+		for _, storeCase := range stores {
+			storeCfg := storeCase.configFn()
+
+			store, _ := newTestChunkStoreConfig(t, schema, storeCfg)
+			defer store.Stop()
+
+			if err := store.Put(ctx, []chunk.Chunk{chunk1, chunk2, chunk3}); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, tc := range testCases {
+				t.Run(fmt.Sprintf("%s / %s / %s", tc.query, schema, storeCase.name), func(t *testing.T) {
+					matchers, err := parser.ParseMetricSelector(tc.query)
+					require.NoError(t, err)
+
+					res, err := store.Stats(ctx, userID, now.Add(-2*time.Hour), now, matchers...)
+					require.NoError(t, err)
+					require.Equal(t, tc.expect, res)
+				})
+			}
+		}
+	}
+}
+
+func Test_Volume(t *testing.T) {
+	// the chunks have been flushed long ago, they are only in the store.
+	through := model.Now().Add(-72 * time.Hour)
+	ch1lbs := labels.Labels{
+		{Name: labels.MetricName, Value: "logs"},
+		{Name: "app", Value: "foo"},
+		{Name: "env", Value: "prod"},
+	}
+	ch2lbs := labels.Labels{
+		{Name: labels.MetricName, Value: "logs"},
+		{Name: "app", Value: "bar"},
+		{Name: "env", Value: "prod"},
+	}
+	chunk1 := dummyLokiChunkFor(through, ch1lbs, 10)
+	chunk2 := dummyLokiChunkFor(through.Add(-time.Minute), ch1lbs, 5)
+	chunk3 := dummyLokiChunkFor(through, ch2lbs, 20)
+	uncompressedSize := func(chunks ...chunk.Chunk) (bytes uint64) {
+		for _, c := range chunks {
+			bytes += uint64(c.Data.(*chunkenc.Facade).LokiChunk().UncompressedSize())
+		}
+		return bytes
+	}
+
+	testCases := []struct {
+		query        string
+		targetLabels []string
+		expect       []logproto.Volume
+	}{
+		{
+			`{env="prod"}`,
+			nil,
+			[]logproto.Volume{
+				{Name: `{app="bar", env="prod"}`, Bytes: uncompressedSize(chunk3), Entries: 20},
+				{Name: `{app="foo", env="prod"}`, Bytes: uncompressedSize(chunk1, chunk2), Entries: 15},
+			},
+		},
+		{
+			`{env="prod"}`,
+			[]string{"env"},
+			[]logproto.Volume{
+				{Name: `{env="prod"}`, Bytes: uncompressedSize(chunk1, chunk2, chunk3), Entries: 35},
+			},
+		},
+		{
+			`{app="foo"}`,
+			[]string{"app"},
+			[]logproto.Volume{
+				{Name: `{app="foo"}`, Bytes: uncompressedSize(chunk1, chunk2), Entries: 15},
+			},
+		},
+		{
+			`{app="none"}`,
+			nil,
+			[]logproto.Volume{},
+		},
+	}
+	for _, schema := range schemas {
+		for _, storeCase := range stores {
+			storeCfg := storeCase.configFn()
+
+			store, _ := newTestChunkStoreConfig(t, schema, storeCfg)
+			defer store.Stop()
+
+			if err := store.Put(ctx, []chunk.Chunk{chunk1, chunk2, chunk3}); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, tc := range testCases {
+				t.Run(fmt.Sprintf("%s / %v / %s / %s", tc.query, tc.targetLabels, schema, storeCase.name), func(t *testing.T) {
+					matchers, err := parser.ParseMetricSelector(tc.query)
+					require.NoError(t, err)
+
+					res, err := store.Volume(ctx, userID, through.Add(-2*time.Hour), through, 1, tc.targetLabels, matchers...)
+					require.NoError(t, err)
+					require.Equal(t, int32(1), res.Limit)
+					// all the volumes are returned largest first,
+					// the limit is only applied once the volumes of the store and the ingesters are merged.
+					require.Equal(t, tc.expect, res.Volumes)
+				})
+			}
+		}
+	}
+}
+
 func Test_GetSeriesShard(t *testing.T) {
 	now := model.Now()
 	ch1lbs := labels.Labels{
@@ -765,6 +923,38 @@ func dummyChunkFor(now model.Time, metric labels.Labels) chunk.Chunk {
 	return dummyChunkForEncoding(now, metric, 1)
 }
 
+// dummyLokiChunkFor returns an encoded chunk holding the given number of log lines.
+func dummyLokiChunkFor(now model.Time, metric labels.Labels, entries int) chunk.Chunk {
+	c := chunkenc.NewMemChunk(chunkenc.EncSnappy, chunkenc.UnorderedHeadBlockFmt, 256*1024, 0)
+	chunkStart := now.Add(-time.Hour)
+
+	for i := 0; i < entries; i++ {
+		if err := c.Append(&logproto.Entry{
+			Timestamp: chunkStart.Add(time.Duration(i) * time.Second).Time(),
+			Line:      fmt.Sprintf("line %d", i),
+		}); err != nil {
+			panic(err)
+		}
+	}
+	if err := c.Close(); err != nil {
+		panic(err)
+	}
+
+	chunk := chunk.NewChunk(
+		userID,
+		client.Fingerprint(metric),
+		metric,
+		chunkenc.NewFacade(c, 0, 0),
+		chunkStart,
+		now,
+	)
+	// Force checksum calculation.
+	if err := chunk.Encode(); err != nil {
+		panic(err)
+	}
+	return chunk
+}
+
 // BenchmarkLabels is a real example from Kubernetes' embedded cAdvisor metrics, lightly obfuscated
 var BenchmarkLabels = labels.Labels{
 	{Name: model.MetricNameLabel, Value: "container_cpu_usage_seconds_total"},
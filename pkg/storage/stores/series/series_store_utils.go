@@ -7,6 +7,7 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 
+	"github.com/grafana/loki/pkg/chunkenc"
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/grafana/loki/pkg/storage/chunk"
 	"github.com/grafana/loki/pkg/storage/config"
@@ -35,6 +36,59 @@ func filterChunkRefsByTime(from, through model.Time, chunks []logproto.ChunkRef)
 	return filtered
 }
 
+// chunkSize returns the uncompressed bytes and the entries of a chunk, or zero for chunks which aren't Loki chunks.
+func chunkSize(c chunk.Chunk) (bytes, entries uint64) {
+	facade, ok := c.Data.(*chunkenc.Facade)
+	if !ok || facade.LokiChunk() == nil {
+		return 0, 0
+	}
+	return uint64(facade.LokiChunk().UncompressedSize()), uint64(facade.LokiChunk().Size())
+}
+
+// statsFromChunks counts the unique streams, the chunks and their bytes and entries.
+func statsFromChunks(chunks []chunk.Chunk) *logproto.IndexStatsResponse {
+	res := &logproto.IndexStatsResponse{}
+	fingerprints := make(map[uint64]struct{})
+	for _, c := range chunks {
+		fingerprints[c.Fingerprint] = struct{}{}
+		bytes, entries := chunkSize(c)
+		res.Chunks++
+		res.Bytes += bytes
+		res.Entries += entries
+	}
+	res.Streams = uint64(len(fingerprints))
+	return res
+}
+
+// volumesFromChunks sums the bytes and entries of the chunks by the volume name of their series.
+// Chunks of series without any of the target labels are not counted.
+func volumesFromChunks(chunks []chunk.Chunk, targetLabels []string, limit int32) *logproto.VolumeResponse {
+	volumes := make(map[string]*logproto.Volume)
+	for _, c := range chunks {
+		name, ok := logproto.VolumeName(c.Metric.WithoutLabels(labels.MetricName), targetLabels)
+		if !ok {
+			continue
+		}
+		v, ok := volumes[name]
+		if !ok {
+			v = &logproto.Volume{Name: name}
+			volumes[name] = v
+		}
+		bytes, entries := chunkSize(c)
+		v.Bytes += bytes
+		v.Entries += entries
+	}
+
+	res := &logproto.VolumeResponse{
+		Volumes: make([]logproto.Volume, 0, len(volumes)),
+		Limit:   limit,
+	}
+	for _, v := range volumes {
+		res.Volumes = append(res.Volumes, *v)
+	}
+	return res
+}
+
 func labelNamesFromChunks(chunks []chunk.Chunk) []string {
 	var result util.UniqueStrings
 	for _, c := range chunks {
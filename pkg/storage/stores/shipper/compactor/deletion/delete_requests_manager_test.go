@@ -50,6 +50,10 @@ func (m mockDeleteRequestsStore) RemoveDeleteRequest(ctx context.Context, userID
 	panic("implement me")
 }
 
+func (m mockDeleteRequestsStore) GetCacheGenerationNumber(ctx context.Context, userID string) (string, error) {
+	panic("implement me")
+}
+
 func (m mockDeleteRequestsStore) Stop() {
 	panic("implement me")
 }
@@ -29,6 +29,7 @@ const (
 
 	deleteRequestID      indexType = "1"
 	deleteRequestDetails indexType = "2"
+	cacheGenNum          indexType = "3"
 
 	tempFileSuffix          = ".temp"
 	DeleteRequestsTableName = "delete_requests"
@@ -43,6 +44,7 @@ type DeleteRequestsStore interface {
 	UpdateStatus(ctx context.Context, userID, requestID string, newStatus DeleteRequestStatus) error
 	GetDeleteRequest(ctx context.Context, userID, requestID string) (*DeleteRequest, error)
 	RemoveDeleteRequest(ctx context.Context, userID, requestID string, createdAt, startTime, endTime model.Time) error
+	GetCacheGenerationNumber(ctx context.Context, userID string) (string, error)
 	Stop()
 }
 
@@ -106,6 +108,8 @@ func (ds *deleteRequestsStore) addDeleteRequest(ctx context.Context, userID stri
 	writeBatch.Add(DeleteRequestsTableName, fmt.Sprintf("%s:%s", deleteRequestDetails, userIDAndRequestID),
 		[]byte(rangeValue), []byte(query))
 
+	ds.updateCacheGen(userID, writeBatch)
+
 	err := ds.indexClient.BatchWrite(ctx, writeBatch)
 	if err != nil {
 		return nil, err
@@ -139,6 +143,9 @@ func (ds *deleteRequestsStore) UpdateStatus(ctx context.Context, userID, request
 	writeBatch := ds.indexClient.NewWriteBatch()
 	writeBatch.Add(DeleteRequestsTableName, string(deleteRequestID), []byte(userIDAndRequestID), []byte(newStatus))
 
+	// Processing a delete request removes logs from the store.
+	ds.updateCacheGen(userID, writeBatch)
+
 	return ds.indexClient.BatchWrite(ctx, writeBatch)
 }
 
@@ -234,9 +241,40 @@ func (ds *deleteRequestsStore) RemoveDeleteRequest(ctx context.Context, userID,
 	writeBatch.Delete(DeleteRequestsTableName, fmt.Sprintf("%s:%s", deleteRequestDetails, userIDAndRequestID),
 		[]byte(rangeValue))
 
+	ds.updateCacheGen(userID, writeBatch)
+
 	return ds.indexClient.BatchWrite(ctx, writeBatch)
 }
 
+// GetCacheGenerationNumber returns the results cache generation number of a user, which changes whenever a delete
+// request of the user is added, processed or removed. It is empty if the user never had a delete request.
+func (ds *deleteRequestsStore) GetCacheGenerationNumber(ctx context.Context, userID string) (string, error) {
+	query := index.Query{
+		TableName: DeleteRequestsTableName,
+		HashValue: fmt.Sprintf("%s:%s", cacheGenNum, userID),
+	}
+
+	var genNumber string
+	err := ds.indexClient.QueryPages(ctx, []index.Query{query}, func(query index.Query, batch index.ReadBatchResult) (shouldContinue bool) {
+		itr := batch.Iterator()
+		for itr.Next() {
+			genNumber = string(itr.Value())
+		}
+		return false
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return genNumber, nil
+}
+
+// updateCacheGen sets the results cache generation number of a user to the current time, so that query results
+// computed before a change of the delete requests of the user are not served from the results cache anymore.
+func (ds *deleteRequestsStore) updateCacheGen(userID string, writeBatch index.WriteBatch) {
+	writeBatch.Add(DeleteRequestsTableName, fmt.Sprintf("%s:%s", cacheGenNum, userID), []byte{}, []byte(strconv.FormatInt(time.Now().UnixNano(), 10)))
+}
+
 func parseDeleteRequestTimestamps(rangeValue []byte, deleteRequest DeleteRequest) (DeleteRequest, error) {
 	hexParts := strings.Split(string(rangeValue), ":")
 	if len(hexParts) != 3 {
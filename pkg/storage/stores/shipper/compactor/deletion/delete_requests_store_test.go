@@ -58,6 +58,11 @@ func TestDeleteRequestsStore(t *testing.T) {
 
 	defer testDeleteRequestsStore.Stop()
 
+	// no cache generation number without delete requests
+	cacheGenNumber, err := testDeleteRequestsStore.GetCacheGenerationNumber(context.Background(), user1)
+	require.NoError(t, err)
+	require.Empty(t, cacheGenNumber)
+
 	// add requests for both the users to the store
 	for i := 0; i < len(user1ExpectedRequests); i++ {
 		requestID, err := testDeleteRequestsStore.(*deleteRequestsStore).addDeleteRequest(
@@ -85,6 +90,14 @@ func TestDeleteRequestsStore(t *testing.T) {
 		require.NoError(t, user2ExpectedRequests[i].SetQuery(user2ExpectedRequests[i].Query))
 	}
 
+	// adding delete requests sets the cache generation number of the users
+	user1CacheGenNumber, err := testDeleteRequestsStore.GetCacheGenerationNumber(context.Background(), user1)
+	require.NoError(t, err)
+	require.NotEmpty(t, user1CacheGenNumber)
+	user2CacheGenNumber, err := testDeleteRequestsStore.GetCacheGenerationNumber(context.Background(), user2)
+	require.NoError(t, err)
+	require.NotEmpty(t, user2CacheGenNumber)
+
 	// get all requests with StatusReceived and see if they have expected values
 	deleteRequests, err := testDeleteRequestsStore.GetDeleteRequestsByStatus(context.Background(), StatusReceived)
 	require.NoError(t, err)
@@ -124,6 +137,12 @@ func TestDeleteRequestsStore(t *testing.T) {
 		require.NoError(t, testDeleteRequestsStore.UpdateStatus(context.Background(), request.UserID, request.RequestID, StatusProcessed))
 	}
 
+	// processing delete requests changes the cache generation number of the users
+	cacheGenNumber, err = testDeleteRequestsStore.GetCacheGenerationNumber(context.Background(), user1)
+	require.NoError(t, err)
+	require.NotEqual(t, user1CacheGenNumber, cacheGenNumber)
+	user1CacheGenNumber = cacheGenNumber
+
 	// see if requests in the store have right values
 	user1Requests, err = testDeleteRequestsStore.GetAllDeleteRequestsForUser(context.Background(), user1)
 	require.NoError(t, err)
@@ -150,6 +169,11 @@ func TestDeleteRequestsStore(t *testing.T) {
 		require.NoError(t, testDeleteRequestsStore.RemoveDeleteRequest(context.Background(), request.UserID, request.RequestID, request.CreatedAt, request.StartTime, request.EndTime))
 	}
 
+	// removing delete requests changes the cache generation number of the users
+	cacheGenNumber, err = testDeleteRequestsStore.GetCacheGenerationNumber(context.Background(), user1)
+	require.NoError(t, err)
+	require.NotEqual(t, user1CacheGenNumber, cacheGenNumber)
+
 	// see if the store has the right remaining requests
 	deleteRequests, err = testDeleteRequestsStore.GetDeleteRequestsByStatus(context.Background(), StatusReceived)
 	require.NoError(t, err)
@@ -36,4 +36,8 @@ func (d *noOpDeleteRequestsStore) RemoveDeleteRequest(ctx context.Context, userI
 	return nil
 }
 
+func (d *noOpDeleteRequestsStore) GetCacheGenerationNumber(ctx context.Context, userID string) (string, error) {
+	return "", nil
+}
+
 func (d *noOpDeleteRequestsStore) Stop() {}
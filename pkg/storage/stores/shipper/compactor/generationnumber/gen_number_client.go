@@ -0,0 +1,65 @@
+package generationnumber
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/weaveworks/common/user"
+)
+
+const (
+	cacheGenNumberPath = "/loki/api/v1/cache/generation_numbers"
+	maxResponseSize    = 1 << 20 // 1MB
+)
+
+// CompactorClient loads the results cache generation numbers of tenants from the compactor.
+type CompactorClient struct {
+	httpClient *http.Client
+	addr       string
+}
+
+// NewCompactorClient returns a client for the compactor at addr.
+func NewCompactorClient(addr string, httpClient *http.Client) (*CompactorClient, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = cacheGenNumberPath
+
+	return &CompactorClient{httpClient: httpClient, addr: u.String()}, nil
+}
+
+// GetCacheGenerationNumber returns the results cache generation number of a tenant.
+func (c *CompactorClient) GetCacheGenerationNumber(ctx context.Context, userID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.addr, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := user.InjectOrgIDIntoHTTPRequest(user.InjectOrgID(ctx, userID), req); err != nil {
+		return "", err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d loading the cache generation number: %s", resp.StatusCode, body)
+	}
+
+	var genNumber string
+	if err := json.Unmarshal(body, &genNumber); err != nil {
+		return "", err
+	}
+	return genNumber, nil
+}
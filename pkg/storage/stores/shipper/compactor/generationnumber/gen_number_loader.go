@@ -0,0 +1,138 @@
+package generationnumber
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/services"
+)
+
+const (
+	reloadInterval = time.Minute
+	// minRetryBackoff is the first delay before loading a generation number again after an error. It doubles
+	// on every consecutive error up to the reload interval.
+	minRetryBackoff = time.Second
+)
+
+// CacheGenClient returns the results cache generation number of a tenant.
+type CacheGenClient interface {
+	GetCacheGenerationNumber(ctx context.Context, userID string) (string, error)
+}
+
+// genNumber is the generation number of a tenant and when to load it next.
+type genNumber struct {
+	value string
+	// loaded is false until the generation number is loaded successfully.
+	loaded   bool
+	backoff  time.Duration
+	nextLoad time.Time
+}
+
+// GenNumberLoader keeps the results cache generation numbers of the tenants it was asked about. Only the first
+// request of a tenant loads its generation number, while running the loader reloads them in the background every
+// reloadInterval and retries the ones which failed to load with an exponential backoff.
+type GenNumberLoader struct {
+	services.Service
+
+	client CacheGenClient
+	logger log.Logger
+
+	numbersMtx sync.RWMutex
+	numbers    map[string]genNumber
+}
+
+// NewGenNumberLoader creates a GenNumberLoader loading the generation numbers with the given client.
+func NewGenNumberLoader(client CacheGenClient, logger log.Logger) *GenNumberLoader {
+	l := &GenNumberLoader{
+		client:  client,
+		logger:  logger,
+		numbers: map[string]genNumber{},
+	}
+
+	l.Service = services.NewTimerService(minRetryBackoff, nil, func(ctx context.Context) error {
+		l.reload(ctx, time.Now())
+		return nil
+	}, nil).WithName("cache generation number loader")
+	return l
+}
+
+// GetResultsCacheGenNumber returns the results cache generation number of the given tenants. Generation numbers
+// are timestamps, so the largest one changes whenever the generation number of any of the tenants changes.
+// When the generation number of a tenant couldn't be loaded yet, a number which matches no cached results is returned.
+func (l *GenNumberLoader) GetResultsCacheGenNumber(ctx context.Context, tenantIDs []string) string {
+	var max int64
+	for _, tenantID := range tenantIDs {
+		l.numbersMtx.RLock()
+		n, ok := l.numbers[tenantID]
+		l.numbersMtx.RUnlock()
+		if !ok {
+			n = l.load(ctx, tenantID, time.Now())
+		}
+		if !n.loaded {
+			return strconv.FormatInt(time.Now().UnixNano(), 10)
+		}
+		if n.value == "" {
+			continue
+		}
+
+		v, err := strconv.ParseInt(n.value, 10, 64)
+		if err != nil {
+			level.Error(l.logger).Log("msg", "error parsing cache generation number", "tenant", tenantID, "genNumber", n.value, "err", err)
+			return strconv.FormatInt(time.Now().UnixNano(), 10)
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	if max == 0 {
+		return ""
+	}
+	return strconv.FormatInt(max, 10)
+}
+
+// load loads the generation number of a tenant and schedules its next load. The last loaded generation number
+// of a tenant is kept when it can't be reloaded.
+func (l *GenNumberLoader) load(ctx context.Context, tenantID string, now time.Time) genNumber {
+	value, err := l.client.GetCacheGenerationNumber(ctx, tenantID)
+
+	l.numbersMtx.Lock()
+	defer l.numbersMtx.Unlock()
+
+	n := l.numbers[tenantID]
+	if err != nil {
+		level.Error(l.logger).Log("msg", "error loading cache generation number", "tenant", tenantID, "err", err)
+		n.backoff *= 2
+		if n.backoff < minRetryBackoff {
+			n.backoff = minRetryBackoff
+		}
+		if n.backoff > reloadInterval {
+			n.backoff = reloadInterval
+		}
+		n.nextLoad = now.Add(n.backoff)
+	} else {
+		n = genNumber{value: value, loaded: true, nextLoad: now.Add(reloadInterval)}
+	}
+	l.numbers[tenantID] = n
+	return n
+}
+
+// reload loads the generation numbers which are due.
+func (l *GenNumberLoader) reload(ctx context.Context, now time.Time) {
+	l.numbersMtx.RLock()
+	var tenantIDs []string
+	for tenantID, n := range l.numbers {
+		if !now.Before(n.nextLoad) {
+			tenantIDs = append(tenantIDs, tenantID)
+		}
+	}
+	l.numbersMtx.RUnlock()
+
+	for _, tenantID := range tenantIDs {
+		l.load(ctx, tenantID, now)
+	}
+}
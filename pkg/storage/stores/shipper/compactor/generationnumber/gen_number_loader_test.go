@@ -0,0 +1,106 @@
+package generationnumber
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCacheGenClient struct {
+	numbers map[string]string
+	err     error
+	calls   int
+}
+
+func (c *fakeCacheGenClient) GetCacheGenerationNumber(ctx context.Context, userID string) (string, error) {
+	c.calls++
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return c.numbers[userID], c.err
+}
+
+func TestGenNumberLoader(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeCacheGenClient{numbers: map[string]string{"tenant-a": "100", "tenant-b": "200"}}
+	l := NewGenNumberLoader(client, log.NewNopLogger())
+
+	require.Equal(t, "100", l.GetResultsCacheGenNumber(ctx, []string{"tenant-a"}))
+	require.Equal(t, "200", l.GetResultsCacheGenNumber(ctx, []string{"tenant-a", "tenant-b"}))
+	require.Equal(t, "", l.GetResultsCacheGenNumber(ctx, []string{"tenant-c"}))
+	require.Equal(t, 3, client.calls)
+
+	// loaded numbers only change once they are reloaded.
+	now := time.Now()
+	client.numbers["tenant-a"] = "300"
+	require.Equal(t, "100", l.GetResultsCacheGenNumber(ctx, []string{"tenant-a"}))
+	l.reload(ctx, now)
+	require.Equal(t, 3, client.calls)
+	l.reload(ctx, now.Add(reloadInterval))
+	require.Equal(t, 6, client.calls)
+	require.Equal(t, "300", l.GetResultsCacheGenNumber(ctx, []string{"tenant-a"}))
+	require.Equal(t, "300", l.GetResultsCacheGenNumber(ctx, []string{"tenant-a", "tenant-b"}))
+
+	// the last loaded numbers are kept when they can't be reloaded.
+	client.err = errors.New("compactor unavailable")
+	l.reload(ctx, now.Add(2*reloadInterval))
+	require.Equal(t, "300", l.GetResultsCacheGenNumber(ctx, []string{"tenant-a"}))
+}
+
+func TestGenNumberLoader_Errors(t *testing.T) {
+	client := &fakeCacheGenClient{numbers: map[string]string{"tenant-a": "100"}, err: errors.New("compactor unavailable")}
+	l := NewGenNumberLoader(client, log.NewNopLogger())
+
+	// numbers which can't be loaded don't match any previous number.
+	first := l.GetResultsCacheGenNumber(context.Background(), []string{"tenant-a"})
+	require.NotEqual(t, "", first)
+	require.NotEqual(t, first, l.GetResultsCacheGenNumber(context.Background(), []string{"tenant-a"}))
+	// failed numbers are only retried in the background, with an exponential backoff.
+	require.Equal(t, 1, client.calls)
+
+	now := time.Now()
+	l.reload(context.Background(), now.Add(minRetryBackoff))
+	require.Equal(t, 2, client.calls)
+	l.reload(context.Background(), now.Add(2*minRetryBackoff))
+	require.Equal(t, 2, client.calls)
+	l.reload(context.Background(), now.Add(3*minRetryBackoff))
+	require.Equal(t, 3, client.calls)
+
+	client.err = nil
+	l.reload(context.Background(), now.Add(reloadInterval))
+	require.Equal(t, "100", l.GetResultsCacheGenNumber(context.Background(), []string{"tenant-a"}))
+
+	// the context of the request is used to load the number of a new tenant.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NotEqual(t, "", l.GetResultsCacheGenNumber(ctx, []string{"tenant-b"}))
+}
+
+func TestCompactorClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, cacheGenNumberPath, r.URL.Path)
+		if r.Header.Get("X-Scope-OrgID") != "tenant-a" {
+			http.Error(w, "unknown tenant", http.StatusBadRequest)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode("100"))
+	}))
+	defer srv.Close()
+
+	client, err := NewCompactorClient(srv.URL, srv.Client())
+	require.NoError(t, err)
+
+	genNumber, err := client.GetCacheGenerationNumber(context.Background(), "tenant-a")
+	require.NoError(t, err)
+	require.Equal(t, "100", genNumber)
+
+	_, err = client.GetCacheGenerationNumber(context.Background(), "tenant-b")
+	require.Error(t, err)
+}
@@ -46,6 +46,8 @@ type IndexQuerier interface {
 	GetChunkRefs(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) ([][]chunk.Chunk, []*fetcher.Fetcher, error)
 	LabelValuesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string, labelName string, matchers ...*labels.Matcher) ([]string, error)
 	LabelNamesForMetricName(ctx context.Context, userID string, from, through model.Time, metricName string) ([]string, error)
+	Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error)
+	Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error)
 	Stop()
 }
 
@@ -346,6 +348,30 @@ func (g *Gateway) LabelValuesForMetricName(ctx context.Context, req *indexgatewa
 	}, nil
 }
 
+func (g *Gateway) GetStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	instanceID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	return g.indexQuerier.Stats(ctx, instanceID, req.From, req.Through, matchers...)
+}
+
+func (g *Gateway) GetVolume(ctx context.Context, req *logproto.VolumeRequest) (*logproto.VolumeResponse, error) {
+	instanceID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matchers, err := syntax.ParseMatchers(req.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	return g.indexQuerier.Volume(ctx, instanceID, req.From, req.Through, req.Limit, req.TargetLabels, matchers...)
+}
+
 // ServeHTTP serves the HTTP route /indexgateway/ring.
 func (g *Gateway) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	if g.cfg.Mode == RingMode {
@@ -506,52 +506,54 @@ func init() {
 }
 
 var fileDescriptor_33a7bd4603d312b2 = []byte{
-	// 705 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xcd, 0x6e, 0xd3, 0x4e,
-	0x10, 0xf7, 0x26, 0xe9, 0x47, 0xa6, 0xf9, 0x7f, 0xb0, 0x45, 0x10, 0x19, 0xba, 0x29, 0x46, 0xa2,
-	0x11, 0x12, 0x31, 0x2a, 0xbd, 0x21, 0x2e, 0x2d, 0x50, 0x55, 0x94, 0x0a, 0x16, 0xa8, 0xe0, 0x84,
-	0x9c, 0x74, 0x63, 0x87, 0xda, 0xd9, 0x74, 0x6d, 0x93, 0xf6, 0xc6, 0x0b, 0x20, 0x71, 0xe5, 0x0d,
-	0x78, 0x0a, 0xc4, 0xb1, 0xc7, 0x72, 0xab, 0x38, 0x54, 0x34, 0xbd, 0x70, 0xec, 0x23, 0xa0, 0x8c,
-	0x63, 0x3b, 0x1f, 0x6d, 0x91, 0x4a, 0x4f, 0xd9, 0xf9, 0xcd, 0x6f, 0x66, 0xe7, 0x37, 0xe3, 0xd9,
-	0xc0, 0xc3, 0xd6, 0xa6, 0x6d, 0xfa, 0x81, 0x54, 0x96, 0x2d, 0xf0, 0x57, 0xf8, 0xa6, 0xef, 0x34,
-	0x5a, 0x2d, 0xa1, 0xcc, 0x46, 0x73, 0x43, 0x6c, 0xdb, 0x56, 0x20, 0xda, 0xd6, 0xce, 0x80, 0xd1,
-	0xaa, 0x9a, 0xbd, 0x53, 0xa5, 0xa5, 0x64, 0x20, 0xe9, 0xbf, 0x83, 0x5e, 0xfd, 0x5a, 0x37, 0xab,
-	0x2b, 0x6d, 0xf4, 0x26, 0x87, 0x88, 0xac, 0xdf, 0xb1, 0x1b, 0x81, 0x13, 0x56, 0x2b, 0x35, 0xe9,
-	0x99, 0xb6, 0xb4, 0xa5, 0x89, 0x70, 0x35, 0xac, 0xa3, 0x15, 0x85, 0x74, 0x4f, 0x11, 0xdd, 0xf8,
-	0x98, 0x81, 0xd2, 0xaa, 0x55, 0x15, 0xee, 0xba, 0xe5, 0x86, 0xc2, 0x7f, 0x2c, 0xd5, 0x53, 0x11,
-	0xa8, 0x46, 0x6d, 0xcd, 0xf2, 0x04, 0x17, 0x5b, 0xa1, 0xf0, 0x03, 0x5a, 0x82, 0x29, 0x0f, 0xc1,
-	0xb7, 0x4d, 0xcb, 0x13, 0x45, 0x32, 0x4b, 0xca, 0x79, 0x0e, 0x5e, 0xc2, 0xa3, 0x33, 0x00, 0x6e,
-	0x37, 0x47, 0xe4, 0xcf, 0xa0, 0x3f, 0x8f, 0x08, 0xba, 0x97, 0x20, 0x57, 0x57, 0xd2, 0x2b, 0x66,
-	0x67, 0x49, 0x39, 0xbb, 0x68, 0xee, 0x1e, 0x94, 0xb4, 0x1f, 0x07, 0xa5, 0xb9, 0xbe, 0x42, 0x5b,
-	0x4a, 0x7a, 0x22, 0x70, 0x44, 0xe8, 0x9b, 0x35, 0xe9, 0x79, 0xb2, 0x69, 0x7a, 0x72, 0x43, 0xb8,
-	0x95, 0x97, 0x0d, 0x4f, 0x70, 0x0c, 0xa6, 0x2b, 0x30, 0x11, 0x38, 0x4a, 0x86, 0xb6, 0x53, 0xcc,
-	0x9d, 0x2f, 0x4f, 0x1c, 0x4f, 0x75, 0x98, 0xf4, 0xac, 0xa0, 0xe6, 0x08, 0xe5, 0x17, 0xc7, 0xb0,
-	0xd8, 0xc4, 0x36, 0xbe, 0x13, 0x60, 0xab, 0x71, 0xe5, 0xe7, 0x6c, 0x47, 0xac, 0x37, 0x73, 0x41,
-	0x7a, 0xb3, 0x7f, 0xa7, 0xd7, 0x98, 0x83, 0x7f, 0x50, 0x12, 0x17, 0x7e, 0x4b, 0x36, 0x7d, 0x41,
-	0xaf, 0xc0, 0xf8, 0x7b, 0x1c, 0x77, 0x91, 0xcc, 0x66, 0xcb, 0x79, 0xde, 0xb3, 0x8c, 0x6f, 0x04,
-	0xe8, 0xb2, 0x08, 0x96, 0x9c, 0xb0, 0xb9, 0xc9, 0x45, 0x3d, 0x16, 0x1c, 0xeb, 0x21, 0x17, 0xa4,
-	0x27, 0x73, 0x81, 0xf3, 0xcb, 0x0e, 0xcd, 0xef, 0x01, 0x4c, 0x0f, 0x28, 0xe8, 0x29, 0xbe, 0x05,
-	0x39, 0x25, 0xea, 0x91, 0xde, 0xa9, 0x79, 0x5a, 0x49, 0x96, 0x26, 0x61, 0xa2, 0xdf, 0x78, 0x03,
-	0xf4, 0x79, 0x28, 0xd4, 0xce, 0x4a, 0x77, 0xe3, 0x92, 0x68, 0x1d, 0x26, 0x11, 0x7d, 0x22, 0x76,
-	0x7a, 0xe3, 0x4e, 0x6c, 0x3a, 0x07, 0x39, 0x25, 0xdb, 0x7e, 0x31, 0x83, 0x99, 0xa7, 0x2b, 0x83,
-	0xbb, 0x5a, 0xe1, 0xb2, 0xcd, 0x91, 0x60, 0xdc, 0x87, 0x2c, 0x97, 0x6d, 0xca, 0x00, 0x94, 0xd5,
-	0xb4, 0x05, 0xee, 0x1b, 0x66, 0x2b, 0xf0, 0x3e, 0x84, 0x5e, 0x86, 0x31, 0x9c, 0x06, 0x76, 0xa9,
-	0xc0, 0x23, 0xc3, 0x58, 0x81, 0x4b, 0xfd, 0x75, 0x45, 0x73, 0x59, 0x80, 0x89, 0x2e, 0xd8, 0x10,
-	0xb1, 0x2e, 0x7d, 0xf8, 0x76, 0xa4, 0x63, 0x20, 0x8f, 0xa9, 0xc6, 0x57, 0x02, 0x90, 0xe2, 0xf4,
-	0x3a, 0xe4, 0x03, 0xab, 0xea, 0x8a, 0xb5, 0xf4, 0x5b, 0x4e, 0x81, 0xae, 0xd7, 0xb1, 0x7c, 0x67,
-	0x3d, 0xa9, 0x28, 0xcf, 0x53, 0x80, 0xde, 0x86, 0xff, 0xd3, 0xca, 0x9f, 0x29, 0x51, 0x6f, 0x6c,
-	0xe3, 0x40, 0x0a, 0x7c, 0x04, 0xa7, 0x65, 0xf8, 0x2f, 0xc5, 0x5e, 0x04, 0x96, 0x0a, 0x70, 0x8f,
-	0x0b, 0x7c, 0x18, 0xee, 0x76, 0x08, 0x45, 0x3f, 0xda, 0x0a, 0x2d, 0x17, 0x17, 0xb4, 0xc0, 0xfb,
-	0x90, 0xf9, 0xcf, 0x59, 0x28, 0xa0, 0x80, 0xe5, 0x48, 0x27, 0x7d, 0x05, 0x90, 0x36, 0x87, 0xde,
-	0x18, 0x6e, 0xc2, 0x48, 0xe3, 0x74, 0xe3, 0x2c, 0x4a, 0x34, 0xf3, 0xbb, 0x84, 0xbe, 0x86, 0xa9,
-	0xbe, 0x4f, 0x89, 0x8e, 0x04, 0x8d, 0x6e, 0x8a, 0x7e, 0xf3, 0x4c, 0x4e, 0x94, 0xd9, 0xd0, 0xe8,
-	0x3b, 0xb8, 0x7a, 0xca, 0x1b, 0x43, 0x2b, 0xc3, 0x19, 0xce, 0x7e, 0x8c, 0xf4, 0x99, 0x13, 0xf9,
-	0x7d, 0x77, 0xb9, 0x50, 0x3c, 0xed, 0x7d, 0xa7, 0xe6, 0x89, 0xc1, 0xa7, 0xff, 0x13, 0xfc, 0xf1,
-	0xb6, 0xc5, 0x85, 0xbd, 0x43, 0xa6, 0xed, 0x1f, 0x32, 0xed, 0xf8, 0x90, 0x91, 0x0f, 0x1d, 0x46,
-	0xbe, 0x74, 0x18, 0xd9, 0xed, 0x30, 0xb2, 0xd7, 0x61, 0xe4, 0x67, 0x87, 0x91, 0x5f, 0x1d, 0xa6,
-	0x1d, 0x77, 0x18, 0xf9, 0x74, 0xc4, 0xb4, 0xbd, 0x23, 0xa6, 0xed, 0x1f, 0x31, 0xad, 0x3a, 0x8e,
-	0xbb, 0x78, 0xef, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x20, 0x48, 0x2d, 0xb9, 0x2f, 0x07, 0x00,
-	0x00,
+	// 747 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xcd, 0x6e, 0xd3, 0x5a,
+	0x10, 0xf6, 0x49, 0xd2, 0x9f, 0x4c, 0x73, 0xff, 0x4e, 0xaf, 0x6e, 0x2d, 0xb7, 0x75, 0x7a, 0x7d,
+	0xa5, 0xdb, 0x08, 0x89, 0x18, 0x95, 0xee, 0x10, 0x12, 0x6a, 0x81, 0xa8, 0xa2, 0x54, 0x70, 0x0a,
+	0x15, 0xac, 0x90, 0x93, 0x4e, 0xec, 0x50, 0x3b, 0x27, 0xb5, 0x8f, 0x49, 0xbb, 0xe3, 0x05, 0x90,
+	0x78, 0x08, 0x16, 0x3c, 0x05, 0x62, 0xd9, 0x65, 0xd9, 0x55, 0x2c, 0x2a, 0x9a, 0x6e, 0x58, 0xf6,
+	0x11, 0x50, 0x8e, 0x63, 0x3b, 0x3f, 0x6d, 0x91, 0x4a, 0x57, 0x39, 0xf3, 0xcd, 0xcc, 0x77, 0xe6,
+	0x9b, 0xf1, 0x9c, 0xc0, 0xfd, 0xd6, 0x8e, 0x6d, 0x06, 0x82, 0xfb, 0x96, 0x8d, 0xf2, 0x17, 0x03,
+	0x33, 0x70, 0x1a, 0xad, 0x16, 0xfa, 0x66, 0xa3, 0xb9, 0x8d, 0x7b, 0xb6, 0x25, 0xb0, 0x6d, 0xed,
+	0x0f, 0x18, 0xad, 0xaa, 0xd9, 0x3b, 0x95, 0x5b, 0x3e, 0x17, 0x9c, 0xfe, 0x3e, 0xe8, 0xd5, 0x66,
+	0xbb, 0xac, 0x2e, 0xb7, 0xa5, 0x37, 0x39, 0x44, 0xc1, 0xda, 0x4d, 0xbb, 0x21, 0x9c, 0xb0, 0x5a,
+	0xae, 0x71, 0xcf, 0xb4, 0xb9, 0xcd, 0x4d, 0x09, 0x57, 0xc3, 0xba, 0xb4, 0xa2, 0x94, 0xee, 0x29,
+	0x0a, 0x37, 0xde, 0x65, 0xa0, 0xb8, 0x6e, 0x55, 0xd1, 0xdd, 0xb2, 0xdc, 0x10, 0x83, 0x87, 0xdc,
+	0x7f, 0x8c, 0xc2, 0x6f, 0xd4, 0x36, 0x2c, 0x0f, 0x19, 0xee, 0x86, 0x18, 0x08, 0x5a, 0x84, 0x29,
+	0x4f, 0x82, 0xaf, 0x9a, 0x96, 0x87, 0x2a, 0x59, 0x20, 0xa5, 0x3c, 0x03, 0x2f, 0x89, 0xa3, 0xf3,
+	0x00, 0x6e, 0x97, 0x23, 0xf2, 0x67, 0xa4, 0x3f, 0x2f, 0x11, 0xe9, 0x5e, 0x85, 0x5c, 0xdd, 0xe7,
+	0x9e, 0x9a, 0x5d, 0x20, 0xa5, 0xec, 0x8a, 0x79, 0x70, 0x5c, 0x54, 0xbe, 0x1e, 0x17, 0x17, 0xfb,
+	0x0a, 0x6d, 0xf9, 0xdc, 0x43, 0xe1, 0x60, 0x18, 0x98, 0x35, 0xee, 0x79, 0xbc, 0x69, 0x7a, 0x7c,
+	0x1b, 0xdd, 0xf2, 0xb3, 0x86, 0x87, 0x4c, 0x26, 0xd3, 0x35, 0x98, 0x10, 0x8e, 0xcf, 0x43, 0xdb,
+	0x51, 0x73, 0x57, 0xe3, 0x89, 0xf3, 0xa9, 0x06, 0x93, 0x9e, 0x25, 0x6a, 0x0e, 0xfa, 0x81, 0x3a,
+	0x26, 0x8b, 0x4d, 0x6c, 0xe3, 0x0b, 0x01, 0x7d, 0x3d, 0xae, 0xfc, 0x8a, 0xed, 0x88, 0xf5, 0x66,
+	0xae, 0x49, 0x6f, 0xf6, 0xd7, 0xf4, 0x1a, 0x8b, 0xf0, 0x9b, 0x94, 0xc4, 0x30, 0x68, 0xf1, 0x66,
+	0x80, 0xf4, 0x1f, 0x18, 0x7f, 0x23, 0xc7, 0xad, 0x92, 0x85, 0x6c, 0x29, 0xcf, 0x7a, 0x96, 0xf1,
+	0x99, 0x00, 0xad, 0xa0, 0x58, 0x75, 0xc2, 0xe6, 0x0e, 0xc3, 0x7a, 0x2c, 0x38, 0xd6, 0x43, 0xae,
+	0x49, 0x4f, 0xe6, 0x1a, 0xe7, 0x97, 0x1d, 0x9a, 0xdf, 0x5d, 0x98, 0x1e, 0x50, 0xd0, 0x53, 0xfc,
+	0x3f, 0xe4, 0x7c, 0xac, 0x47, 0x7a, 0xa7, 0x96, 0x68, 0x39, 0x59, 0x9a, 0x24, 0x52, 0xfa, 0x8d,
+	0x97, 0x40, 0x9f, 0x86, 0xe8, 0xef, 0xaf, 0x75, 0x37, 0x2e, 0xc9, 0xd6, 0x60, 0x52, 0xa2, 0x8f,
+	0x70, 0xbf, 0x37, 0xee, 0xc4, 0xa6, 0x8b, 0x90, 0xf3, 0x79, 0x3b, 0x50, 0x33, 0x92, 0x79, 0xba,
+	0x3c, 0xb8, 0xab, 0x65, 0xc6, 0xdb, 0x4c, 0x06, 0x18, 0x77, 0x20, 0xcb, 0x78, 0x9b, 0xea, 0x00,
+	0xbe, 0xd5, 0xb4, 0x51, 0xee, 0x9b, 0x64, 0x2b, 0xb0, 0x3e, 0x84, 0xfe, 0x0d, 0x63, 0x72, 0x1a,
+	0xb2, 0x4b, 0x05, 0x16, 0x19, 0xc6, 0x1a, 0xfc, 0xd5, 0x5f, 0x57, 0x34, 0x97, 0x65, 0x98, 0xe8,
+	0x82, 0x0d, 0x8c, 0x75, 0x69, 0xc3, 0xb7, 0xcb, 0x70, 0x99, 0xc8, 0xe2, 0x50, 0xe3, 0x13, 0x01,
+	0x48, 0x71, 0x3a, 0x07, 0x79, 0x61, 0x55, 0x5d, 0xdc, 0x48, 0xbf, 0xe5, 0x14, 0xe8, 0x7a, 0x1d,
+	0x2b, 0x70, 0xb6, 0x92, 0x8a, 0xf2, 0x2c, 0x05, 0xe8, 0x0d, 0xf8, 0x33, 0xad, 0xfc, 0x89, 0x8f,
+	0xf5, 0xc6, 0x9e, 0x1c, 0x48, 0x81, 0x8d, 0xe0, 0xb4, 0x04, 0x7f, 0xa4, 0xd8, 0xa6, 0xb0, 0x7c,
+	0x21, 0xf7, 0xb8, 0xc0, 0x86, 0xe1, 0x6e, 0x87, 0xa4, 0xe8, 0x07, 0xbb, 0xa1, 0xe5, 0xca, 0x05,
+	0x2d, 0xb0, 0x3e, 0x64, 0xe9, 0x43, 0x0e, 0x0a, 0x52, 0x40, 0x25, 0xd2, 0x49, 0x9f, 0x03, 0xa4,
+	0xcd, 0xa1, 0xff, 0x0e, 0x37, 0x61, 0xa4, 0x71, 0x9a, 0x71, 0x59, 0x48, 0x34, 0xf3, 0x5b, 0x84,
+	0xbe, 0x80, 0xa9, 0xbe, 0x4f, 0x89, 0x8e, 0x24, 0x8d, 0x6e, 0x8a, 0xf6, 0xdf, 0xa5, 0x31, 0x11,
+	0xb3, 0xa1, 0xd0, 0xd7, 0x30, 0x73, 0xc1, 0x1b, 0x43, 0xcb, 0xc3, 0x0c, 0x97, 0x3f, 0x46, 0xda,
+	0xfc, 0xb9, 0xf1, 0x7d, 0x77, 0xb9, 0xa0, 0x5e, 0xf4, 0xbe, 0x53, 0xf3, 0xdc, 0xe4, 0x8b, 0xff,
+	0x09, 0x7e, 0x7e, 0x5b, 0x05, 0x26, 0x2b, 0x28, 0x36, 0x85, 0x25, 0x02, 0x3a, 0x9b, 0x6e, 0x99,
+	0x6c, 0xad, 0x44, 0x63, 0xa6, 0xb9, 0xf3, 0x9d, 0x09, 0xd1, 0x3d, 0xc8, 0x57, 0x50, 0x6c, 0x71,
+	0x37, 0xf4, 0x90, 0xce, 0xa4, 0xc1, 0x11, 0x12, 0xb3, 0xa8, 0xa3, 0x8e, 0x98, 0x61, 0x65, 0xf9,
+	0xf0, 0x44, 0x57, 0x8e, 0x4e, 0x74, 0xe5, 0xec, 0x44, 0x27, 0x6f, 0x3b, 0x3a, 0xf9, 0xd8, 0xd1,
+	0xc9, 0x41, 0x47, 0x27, 0x87, 0x1d, 0x9d, 0x7c, 0xeb, 0xe8, 0xe4, 0x7b, 0x47, 0x57, 0xce, 0x3a,
+	0x3a, 0x79, 0x7f, 0xaa, 0x2b, 0x87, 0xa7, 0xba, 0x72, 0x74, 0xaa, 0x2b, 0xd5, 0x71, 0xc9, 0x76,
+	0xfb, 0xc7, 0x00, 0xa0, 0x6d, 0x50, 0xb1, 0xba, 0x07, 0x00, 0x00,
 }
 
 func (this *LabelValuesForMetricNameRequest) Equal(that interface{}) bool {
@@ -970,6 +972,9 @@ type IndexGatewayClient interface {
 	GetChunkRef(ctx context.Context, in *GetChunkRefRequest, opts ...grpc.CallOption) (*GetChunkRefResponse, error)
 	LabelNamesForMetricName(ctx context.Context, in *LabelNamesForMetricNameRequest, opts ...grpc.CallOption) (*LabelResponse, error)
 	LabelValuesForMetricName(ctx context.Context, in *LabelValuesForMetricNameRequest, opts ...grpc.CallOption) (*LabelResponse, error)
+	/// GetStats returns the number of streams, chunks, bytes and entries matching the provided label matchers
+	GetStats(ctx context.Context, in *logproto.IndexStatsRequest, opts ...grpc.CallOption) (*logproto.IndexStatsResponse, error)
+	GetVolume(ctx context.Context, in *logproto.VolumeRequest, opts ...grpc.CallOption) (*logproto.VolumeResponse, error)
 }
 
 type indexGatewayClient struct {
@@ -1039,6 +1044,24 @@ func (c *indexGatewayClient) LabelValuesForMetricName(ctx context.Context, in *L
 	return out, nil
 }
 
+func (c *indexGatewayClient) GetStats(ctx context.Context, in *logproto.IndexStatsRequest, opts ...grpc.CallOption) (*logproto.IndexStatsResponse, error) {
+	out := new(logproto.IndexStatsResponse)
+	err := c.cc.Invoke(ctx, "/indexgatewaypb.IndexGateway/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexGatewayClient) GetVolume(ctx context.Context, in *logproto.VolumeRequest, opts ...grpc.CallOption) (*logproto.VolumeResponse, error) {
+	out := new(logproto.VolumeResponse)
+	err := c.cc.Invoke(ctx, "/indexgatewaypb.IndexGateway/GetVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IndexGatewayServer is the server API for IndexGateway service.
 type IndexGatewayServer interface {
 	/// QueryIndex reads the indexes required for given query & sends back the batch of rows
@@ -1048,6 +1071,9 @@ type IndexGatewayServer interface {
 	GetChunkRef(context.Context, *GetChunkRefRequest) (*GetChunkRefResponse, error)
 	LabelNamesForMetricName(context.Context, *LabelNamesForMetricNameRequest) (*LabelResponse, error)
 	LabelValuesForMetricName(context.Context, *LabelValuesForMetricNameRequest) (*LabelResponse, error)
+	/// GetStats returns the number of streams, chunks, bytes and entries matching the provided label matchers
+	GetStats(context.Context, *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error)
+	GetVolume(context.Context, *logproto.VolumeRequest) (*logproto.VolumeResponse, error)
 }
 
 // UnimplementedIndexGatewayServer can be embedded to have forward compatible implementations.
@@ -1066,6 +1092,12 @@ func (*UnimplementedIndexGatewayServer) LabelNamesForMetricName(ctx context.Cont
 func (*UnimplementedIndexGatewayServer) LabelValuesForMetricName(ctx context.Context, req *LabelValuesForMetricNameRequest) (*LabelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LabelValuesForMetricName not implemented")
 }
+func (*UnimplementedIndexGatewayServer) GetStats(ctx context.Context, req *logproto.IndexStatsRequest) (*logproto.IndexStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (*UnimplementedIndexGatewayServer) GetVolume(ctx context.Context, req *logproto.VolumeRequest) (*logproto.VolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolume not implemented")
+}
 
 func RegisterIndexGatewayServer(s *grpc.Server, srv IndexGatewayServer) {
 	s.RegisterService(&_IndexGateway_serviceDesc, srv)
@@ -1146,6 +1178,42 @@ func _IndexGateway_LabelValuesForMetricName_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IndexGateway_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(logproto.IndexStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexGatewayServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexgatewaypb.IndexGateway/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexGatewayServer).GetStats(ctx, req.(*logproto.IndexStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexGateway_GetVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(logproto.VolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexGatewayServer).GetVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexgatewaypb.IndexGateway/GetVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexGatewayServer).GetVolume(ctx, req.(*logproto.VolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _IndexGateway_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "indexgatewaypb.IndexGateway",
 	HandlerType: (*IndexGatewayServer)(nil),
@@ -1162,6 +1230,14 @@ var _IndexGateway_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LabelValuesForMetricName",
 			Handler:    _IndexGateway_LabelValuesForMetricName_Handler,
 		},
+		{
+			MethodName: "GetStats",
+			Handler:    _IndexGateway_GetStats_Handler,
+		},
+		{
+			MethodName: "GetVolume",
+			Handler:    _IndexGateway_GetVolume_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
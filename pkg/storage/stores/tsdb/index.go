@@ -19,6 +19,10 @@ type ChunkRef struct {
 	Fingerprint model.Fingerprint
 	Start, End  model.Time
 	Checksum    uint32
+
+	// Bytes stored, rounded to nearest KB
+	KB      uint32
+	Entries uint32
 }
 
 // Compares by (Start, End)
@@ -210,6 +210,14 @@ func (m *mockChunkStore) LabelNamesForMetricName(ctx context.Context, userID str
 	return nil, nil
 }
 
+func (m *mockChunkStore) Volume(ctx context.Context, userID string, from, through model.Time, limit int32, targetLabels []string, matchers ...*labels.Matcher) (*logproto.VolumeResponse, error) {
+	return nil, nil
+}
+
+func (m *mockChunkStore) Stats(ctx context.Context, userID string, from, through model.Time, matchers ...*labels.Matcher) (*logproto.IndexStatsResponse, error) {
+	return nil, nil
+}
+
 func (m *mockChunkStore) SetChunkFilterer(f chunk.RequestChunkFilterer) {
 	m.f = f
 }
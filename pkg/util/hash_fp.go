@@ -1,6 +1,10 @@
 package util
 
-import "github.com/prometheus/common/model"
+import (
+	"hash/fnv"
+
+	"github.com/prometheus/common/model"
+)
 
 // HashFP simply moves entropy from the most significant 48 bits of the
 // fingerprint into the least significant 16 bits (by XORing) so that a simple
@@ -12,3 +16,11 @@ import "github.com/prometheus/common/model"
 func HashFP(fp model.Fingerprint) uint32 {
 	return uint32(fp ^ (fp >> 32) ^ (fp >> 16))
 }
+
+// HashedQuery returns a hash of the query text, logged with the query statistics so that
+// a query can be identified, for example to block it, without its full text.
+func HashedQuery(query string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(query))
+	return h.Sum32()
+}
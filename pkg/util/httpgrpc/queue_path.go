@@ -0,0 +1,36 @@
+package httpgrpc
+
+import (
+	"net/textproto"
+	"net/url"
+	"path"
+
+	weaveworks_httpgrpc "github.com/weaveworks/common/httpgrpc"
+
+	"github.com/grafana/loki/pkg/util/httpreq"
+)
+
+// NoActor is the actor of the requests without actor path in the queue of their tenant.
+const NoActor = "none"
+
+// QueuePath returns the path of the sub-queue of a request in the queue of its tenant:
+// the levels of the actor path of the request, or NoActor, followed by its query type, which is the last element
+// of its URL path (e.g. query_range, series or labels). The first level of the path is always the actor.
+func QueuePath(req *weaveworks_httpgrpc.HTTPRequest) []string {
+	var queuePath []string
+	key := textproto.CanonicalMIMEHeaderKey(string(httpreq.LokiActorPathHTTPHeader))
+	for _, h := range req.GetHeaders() {
+		if textproto.CanonicalMIMEHeaderKey(h.Key) == key && len(h.Values) > 0 {
+			queuePath = httpreq.ExtractActorPath(h.Values[0])
+			break
+		}
+	}
+	if len(queuePath) == 0 {
+		queuePath = []string{NoActor}
+	}
+
+	if u, err := url.Parse(req.GetUrl()); err == nil && u.Path != "" {
+		queuePath = append(queuePath, path.Base(u.Path))
+	}
+	return queuePath
+}
@@ -0,0 +1,39 @@
+package httpgrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	weaveworks_httpgrpc "github.com/weaveworks/common/httpgrpc"
+)
+
+func TestQueuePath(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		headers []*weaveworks_httpgrpc.Header
+		exp     []string
+	}{
+		{
+			desc:    "actor path",
+			headers: []*weaveworks_httpgrpc.Header{{Key: "X-Loki-Actor-Path", Values: []string{"dashboard-1|panel-2"}}},
+			exp:     []string{"dashboard-1", "panel-2", "query_range"},
+		},
+		{
+			desc: "no actor path",
+			exp:  []string{NoActor, "query_range"},
+		},
+		{
+			desc:    "empty actor path",
+			headers: []*weaveworks_httpgrpc.Header{{Key: "X-Loki-Actor-Path", Values: []string{"|#|"}}},
+			exp:     []string{NoActor, "query_range"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := &weaveworks_httpgrpc.HTTPRequest{
+				Url:     "/loki/api/v1/query_range?query=%7Bapp%3D%22foo%22%7D",
+				Headers: tc.headers,
+			}
+			require.Equal(t, tc.exp, QueuePath(req))
+		})
+	}
+}
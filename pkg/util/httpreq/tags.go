@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/weaveworks/common/middleware"
@@ -18,6 +19,21 @@ var (
 	safeQueryTags              = regexp.MustCompile("[^a-zA-Z0-9-=, ]+") // only alpha-numeric, ' ', ',', '=' and `-`
 
 	QueryQueueTimeHTTPHeader ctxKey = "X-Query-Queue-Time"
+
+	// LokiActorPathHTTPHeader is the actor of a query, e.g. the user or the dashboard which sent it.
+	// Its levels are separated by LokiActorPathDelimiter, e.g. "dashboard-1|panel-2".
+	// The query schedulers split the queue of each tenant into sub-queues by actor path.
+	LokiActorPathHTTPHeader ctxKey = "X-Loki-Actor-Path"
+	safeActorPathLevel             = regexp.MustCompile("[^a-zA-Z0-9-_.:@/ ]+") // only alpha-numeric, ' ', '-', '_', '.', ':', '@' and '/'
+)
+
+const (
+	LokiActorPathDelimiter = "|"
+
+	// The actor path is sent by clients and each of its levels creates a sub-queue, and a metric
+	// series for the first one, in the query schedulers, so its number of levels and their length are bounded.
+	maxActorPathLevels      = 4
+	maxActorPathLevelLength = 64
 )
 
 func ExtractQueryTagsMiddleware() middleware.Interface {
@@ -36,6 +52,37 @@ func ExtractQueryTagsMiddleware() middleware.Interface {
 	})
 }
 
+func ExtractActorPathMiddleware() middleware.Interface {
+	return middleware.Func(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if actorPath := req.Header.Get(string(LokiActorPathHTTPHeader)); actorPath != "" {
+				req = req.WithContext(context.WithValue(req.Context(), LokiActorPathHTTPHeader, actorPath))
+			}
+			next.ServeHTTP(w, req)
+		})
+	})
+}
+
+// ExtractActorPath returns the levels of an actor path, ignoring the empty ones. Unsafe characters are removed
+// from the levels, which are truncated to 64 characters, and only the first 4 levels are returned.
+func ExtractActorPath(actorPath string) []string {
+	var path []string
+	for _, level := range strings.Split(actorPath, LokiActorPathDelimiter) {
+		level = strings.TrimSpace(safeActorPathLevel.ReplaceAllString(level, ""))
+		if len(level) > maxActorPathLevelLength {
+			level = strings.TrimSpace(level[:maxActorPathLevelLength])
+		}
+		if level == "" {
+			continue
+		}
+		path = append(path, level)
+		if len(path) == maxActorPathLevels {
+			break
+		}
+	}
+	return path
+}
+
 func ExtractQueryMetricsMiddleware() middleware.Interface {
 	return middleware.Func(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -3,6 +3,7 @@ package httpreq
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -91,3 +92,89 @@ func TestQueryMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestActorPath(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		in   string
+		exp  interface{}
+		path []string
+	}{
+		{
+			desc: "single level",
+			in:   `dashboard-1`,
+			exp:  `dashboard-1`,
+			path: []string{"dashboard-1"},
+		},
+		{
+			desc: "multiple levels",
+			in:   `dashboard-1|panel-2`,
+			exp:  `dashboard-1|panel-2`,
+			path: []string{"dashboard-1", "panel-2"},
+		},
+		{
+			desc: "empty levels",
+			in:   `|dashboard-1|| panel-2 |`,
+			exp:  `|dashboard-1|| panel-2 |`,
+			path: []string{"dashboard-1", "panel-2"},
+		},
+		{
+			desc: "unsafe characters",
+			in:   "dashboard-1\n|{panel=\"2\"}|#",
+			exp:  "dashboard-1\n|{panel=\"2\"}|#",
+			path: []string{"dashboard-1", "panel2"},
+		},
+		{
+			desc: "bounded levels",
+			in:   `a|b|c|d|e|` + strings.Repeat("x", 100),
+			exp:  `a|b|c|d|e|` + strings.Repeat("x", 100),
+			path: []string{"a", "b", "c", "d"},
+		},
+		{
+			desc: "bounded level length",
+			in:   strings.Repeat("x", 100),
+			exp:  strings.Repeat("x", 100),
+			path: []string{strings.Repeat("x", 64)},
+		},
+		{
+			desc: "unsafe characters",
+			in:   "dashboard-1\n|{panel=\"2\"}|#",
+			exp:  "dashboard-1\n|{panel=\"2\"}|#",
+			path: []string{"dashboard-1", "panel2"},
+		},
+		{
+			desc: "bounded levels",
+			in:   `a|b|c|d|e|` + strings.Repeat("x", 100),
+			exp:  `a|b|c|d|e|` + strings.Repeat("x", 100),
+			path: []string{"a", "b", "c", "d"},
+		},
+		{
+			desc: "bounded level length",
+			in:   strings.Repeat("x", 100),
+			exp:  strings.Repeat("x", 100),
+			path: []string{strings.Repeat("x", 64)},
+		},
+		{
+			desc: "empty header",
+			in:   ``,
+			exp:  nil,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "http://testing.com", nil)
+			req.Header.Set(string(LokiActorPathHTTPHeader), tc.in)
+
+			w := httptest.NewRecorder()
+			checked := false
+			mware := ExtractActorPathMiddleware().Wrap(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				require.Equal(t, tc.exp, req.Context().Value(LokiActorPathHTTPHeader))
+				checked = true
+			}))
+
+			mware.ServeHTTP(w, req)
+
+			assert.True(t, checked)
+			assert.Equal(t, tc.path, ExtractActorPath(tc.in))
+		})
+	}
+}
@@ -84,6 +84,7 @@ var queryTests = []struct {
 					"linesProcessedPerSecond": 0,
 					"queueTime": 0,
 					"subqueries": 0,
+					"totalBytesEstimated": 0,
 					"totalBytesProcessed":0,
 					"totalLinesProcessed":0
 				}
@@ -80,9 +80,30 @@ func WriteSeriesResponseJSON(r logproto.SeriesResponse, w io.Writer) error {
 	return jsoniter.NewEncoder(w).Encode(adapter)
 }
 
+// WriteIndexStatsResponseJSON marshals a logproto.IndexStatsResponse to JSON and then
+// writes it to the provided io.Writer.
+func WriteIndexStatsResponseJSON(r *logproto.IndexStatsResponse, w io.Writer) error {
+	if r == nil {
+		r = &logproto.IndexStatsResponse{}
+	}
+	return jsoniter.NewEncoder(w).Encode(r)
+}
+
 // This struct exists primarily because we can't specify a repeated map in proto v3.
 // Otherwise, we'd use that + gogoproto.jsontag to avoid this layer of indirection
 type seriesResponseAdapter struct {
 	Status string              `json:"status"`
 	Data   []map[string]string `json:"data"`
 }
+
+// WriteVolumeResponseJSON marshals a logproto.VolumeResponse to JSON and then
+// writes it to the provided io.Writer.
+func WriteVolumeResponseJSON(r *logproto.VolumeResponse, w io.Writer) error {
+	if r == nil {
+		r = &logproto.VolumeResponse{}
+	}
+	if r.Volumes == nil {
+		r.Volumes = []logproto.Volume{}
+	}
+	return jsoniter.NewEncoder(w).Encode(r)
+}
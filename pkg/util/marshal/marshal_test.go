@@ -90,6 +90,7 @@ var queryTests = []struct {
 						"linesProcessedPerSecond": 0,
 						"queueTime": 0,
 						"subqueries": 0,
+						"totalBytesEstimated": 0,
 						"totalBytesProcessed":0,
 						"totalLinesProcessed":0
 					}
@@ -199,6 +200,7 @@ var queryTests = []struct {
 					"linesProcessedPerSecond": 0,
 					"queueTime": 0,
 					"subqueries": 0,
+					"totalBytesEstimated": 0,
 					"totalBytesProcessed":0,
 					"totalLinesProcessed":0
 				}
@@ -325,6 +327,7 @@ var queryTests = []struct {
 					"linesProcessedPerSecond": 0,
 					"queueTime": 0,
 					"subqueries": 0,
+					"totalBytesEstimated": 0,
 					"totalBytesProcessed":0,
 					"totalLinesProcessed":0
 				}
@@ -4,6 +4,14 @@ const (
 	// ErrQueryTooLong is used in chunk store, querier and query frontend.
 	ErrQueryTooLong = "the query time range exceeds the limit (query length: %s, limit: %s)"
 
+	// ErrQueryTooManyBytes and ErrQuerierTooManyBytes are used in the query frontend,
+	// with the bytes a query is estimated to read from the index stats.
+	ErrQueryTooManyBytes   = "the query would read too many bytes (estimated: %s, limit: %s); consider adding more specific stream selectors or reducing the time range of the query"
+	ErrQuerierTooManyBytes = "a subquery of the query would read too many bytes (estimated: %s, limit: %s); consider adding more specific stream selectors or reducing the time range of the query"
+
+	// ErrQueryBlocked is used in the query frontend when a query matches a blocked queries rule of the tenant.
+	ErrQueryBlocked = "the query has been blocked by your Loki operator (query hash: %d, matching rule: %s); please contact your Loki operator"
+
 	// RateLimited is one of the values for the reason to discard samples.
 	// Declared here to avoid duplication in ingester and distributor.
 	RateLimited = "rate_limited"
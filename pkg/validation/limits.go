@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
+	dskit_flagext "github.com/grafana/dskit/flagext"
 	"github.com/pkg/errors"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
@@ -38,6 +41,8 @@ const (
 
 	defaultPerStreamRateLimit  = 3 << 20 // 3MB
 	defaultPerStreamBurstLimit = 5 * defaultPerStreamRateLimit
+
+	defaultOTLPResourceAttributesAsLabels = "service.name,service.namespace,deployment.environment,cloud.region,cloud.availability_zone,k8s.cluster.name,k8s.namespace.name,k8s.container.name,container.name,k8s.deployment.name,k8s.statefulset.name,k8s.daemonset.name,k8s.cronjob.name,k8s.job.name"
 )
 
 // Limits describe all the limits for users; can be used to describe global default
@@ -59,6 +64,9 @@ type Limits struct {
 	MaxLineSize            flagext.ByteSize `yaml:"max_line_size" json:"max_line_size"`
 	MaxLineSizeTruncate    bool             `yaml:"max_line_size_truncate" json:"max_line_size_truncate"`
 
+	// OTLPResourceAttributesAsLabels lists the OTLP resource attributes promoted to stream labels.
+	OTLPResourceAttributesAsLabels []string `yaml:"otlp_resource_attributes_as_labels,omitempty" json:"otlp_resource_attributes_as_labels,omitempty"`
+
 	// Ingester enforced limits.
 	MaxLocalStreamsPerUser  int              `yaml:"max_streams_per_user" json:"max_streams_per_user"`
 	MaxGlobalStreamsPerUser int              `yaml:"max_global_streams_per_user" json:"max_global_streams_per_user"`
@@ -83,6 +91,13 @@ type Limits struct {
 	// Query frontend enforced limits. The default is actually parameterized by the queryrange config.
 	QuerySplitDuration  model.Duration `yaml:"split_queries_by_interval" json:"split_queries_by_interval"`
 	MinShardingLookback model.Duration `yaml:"min_sharding_lookback" json:"min_sharding_lookback"`
+	// QuantileOverTimeSharding shards quantile_over_time queries with sketches, which makes their results approximate.
+	QuantileOverTimeSharding bool `yaml:"quantile_over_time_sharding" json:"quantile_over_time_sharding"`
+	// Query frontend enforced limits on the bytes a query is estimated to read, based on the index stats.
+	MaxQueryBytesRead   flagext.ByteSize `yaml:"max_query_bytes_read" json:"max_query_bytes_read"`
+	MaxQuerierBytesRead flagext.ByteSize `yaml:"max_querier_bytes_read" json:"max_querier_bytes_read"`
+
+	BlockedQueries []BlockedQuery `yaml:"blocked_queries,omitempty" json:"blocked_queries,omitempty"`
 
 	// Ruler defaults and limits.
 	RulerEvaluationDelay        model.Duration `yaml:"ruler_evaluation_delay_duration" json:"ruler_evaluation_delay_duration"`
@@ -124,6 +139,22 @@ type StreamRetention struct {
 	Matchers []*labels.Matcher `yaml:"-" json:"-"` // populated during validation.
 }
 
+const (
+	BlockedQueryTypeMetric = "metric"
+	BlockedQueryTypeLog    = "log"
+)
+
+// BlockedQuery is a rule rejecting the queries of a tenant in the query frontend.
+// A query is blocked if its text matches Pattern, either exactly or as a regular expression if Regex is true,
+// or if its hash is Hash. Types restricts the rule to metric and/or log queries, all queries if empty.
+type BlockedQuery struct {
+	Pattern string                       `yaml:"pattern" json:"pattern"`
+	Regex   bool                         `yaml:"regex" json:"regex"`
+	Hash    uint32                       `yaml:"hash" json:"hash"`
+	Types   dskit_flagext.StringSliceCSV `yaml:"types" json:"types"`
+	Regexp  *regexp.Regexp               `yaml:"-" json:"-"` // populated during validation.
+}
+
 // RegisterFlags adds the flags required to config this to the given FlagSet
 func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.StringVar(&l.IngestionRateStrategy, "distributor.ingestion-rate-limit-strategy", "global", "Whether the ingestion rate limit should be applied individually to each distributor instance (local), or evenly shared across the cluster (global).")
@@ -131,6 +162,8 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.Float64Var(&l.IngestionBurstSizeMB, "distributor.ingestion-burst-size-mb", 6, "Per-user allowed ingestion burst size (in sample size). Units in MB.")
 	f.Var(&l.MaxLineSize, "distributor.max-line-size", "maximum line length allowed, i.e. 100mb. Default (0) means unlimited.")
 	f.BoolVar(&l.MaxLineSizeTruncate, "distributor.max-line-size-truncate", false, "Whether to truncate lines that exceed max_line_size")
+	l.OTLPResourceAttributesAsLabels = strings.Split(defaultOTLPResourceAttributesAsLabels, ",")
+	f.Var((*dskit_flagext.StringSliceCSV)(&l.OTLPResourceAttributesAsLabels), "distributor.otlp-resource-attributes-as-labels", "Comma-separated list of OTLP resource attributes to use as stream labels. Dots in attribute names are replaced by underscores. Resource attributes not listed are added to the log line.")
 	f.IntVar(&l.MaxLabelNameLength, "validation.max-length-label-name", 1024, "Maximum length accepted for label names")
 	f.IntVar(&l.MaxLabelValueLength, "validation.max-length-label-value", 2048, "Maximum length accepted for label value. This setting also applies to the metric name")
 	f.IntVar(&l.MaxLabelNamesPerSeries, "validation.max-label-names-per-series", 30, "Maximum number of label names per series.")
@@ -167,6 +200,10 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 
 	_ = l.MinShardingLookback.Set("0s")
 	f.Var(&l.MinShardingLookback, "frontend.min-sharding-lookback", "Limit the sharding time range.Queries with time range that fall between now and now minus the sharding lookback are not sharded. 0 to disable.")
+	f.BoolVar(&l.QuantileOverTimeSharding, "frontend.quantile-over-time-sharding", false, "Shard quantile_over_time queries by merging quantile sketches computed by each shard. Results become approximate, within 1% of the exact quantile.")
+
+	f.Var(&l.MaxQueryBytesRead, "frontend.max-query-bytes-read", "Max number of bytes a query can fetch. The estimate is based on the index stats of the query stream selectors. 0 to disable.")
+	f.Var(&l.MaxQuerierBytesRead, "frontend.max-querier-bytes-read", "Max number of bytes each subquery of a query, after it has been split by time and sharded, can fetch. The estimate is based on the index stats of the query stream selectors. 0 to disable.")
 
 	_ = l.MaxCacheFreshness.Set("1m")
 	f.Var(&l.MaxCacheFreshness, "frontend.max-cache-freshness", "Most recent allowed cacheable result per-tenant, to prevent caching very recent results that might still be in flux.")
@@ -226,6 +263,24 @@ func (l *Limits) Validate() error {
 			l.StreamRetention[i].Matchers = matchers
 		}
 	}
+
+	for i, rule := range l.BlockedQueries {
+		if rule.Pattern == "" && rule.Hash == 0 {
+			return fmt.Errorf("blocked query rule %d must have either a pattern or a hash", i)
+		}
+		if rule.Regex {
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return fmt.Errorf("invalid blocked query regex %q: %w", rule.Pattern, err)
+			}
+			l.BlockedQueries[i].Regexp = re
+		}
+		for _, t := range rule.Types {
+			if t != BlockedQueryTypeMetric && t != BlockedQueryTypeLog {
+				return fmt.Errorf("invalid blocked query type %q, must be %q or %q", t, BlockedQueryTypeMetric, BlockedQueryTypeLog)
+			}
+		}
+	}
 	return nil
 }
 
@@ -390,6 +445,11 @@ func (o *Overrides) MinShardingLookback(userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).MinShardingLookback)
 }
 
+// QuantileOverTimeSharding returns whether quantile_over_time queries of the tenant are sharded with approximate sketches.
+func (o *Overrides) QuantileOverTimeSharding(userID string) bool {
+	return o.getOverridesForUser(userID).QuantileOverTimeSharding
+}
+
 // QuerySplitDuration returns the tenant specific splitby interval applied in the query frontend.
 func (o *Overrides) QuerySplitDuration(userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).QuerySplitDuration)
@@ -410,6 +470,11 @@ func (o *Overrides) MaxLineSizeTruncate(userID string) bool {
 	return o.getOverridesForUser(userID).MaxLineSizeTruncate
 }
 
+// OTLPResourceAttributesAsLabels returns the OTLP resource attributes to use as stream labels.
+func (o *Overrides) OTLPResourceAttributesAsLabels(userID string) []string {
+	return o.getOverridesForUser(userID).OTLPResourceAttributesAsLabels
+}
+
 // MaxEntriesLimitPerQuery returns the limit to number of entries the querier should return per query.
 func (o *Overrides) MaxEntriesLimitPerQuery(userID string) int {
 	return o.getOverridesForUser(userID).MaxEntriesLimitPerQuery
@@ -419,6 +484,22 @@ func (o *Overrides) MaxCacheFreshness(userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).MaxCacheFreshness)
 }
 
+// MaxQueryBytesRead returns the maximum bytes a query is allowed to read, as estimated from the index.
+func (o *Overrides) MaxQueryBytesRead(userID string) int {
+	return o.getOverridesForUser(userID).MaxQueryBytesRead.Val()
+}
+
+// MaxQuerierBytesRead returns the maximum bytes a subquery of a split or sharded query is allowed to read,
+// as estimated from the index.
+func (o *Overrides) MaxQuerierBytesRead(userID string) int {
+	return o.getOverridesForUser(userID).MaxQuerierBytesRead.Val()
+}
+
+// BlockedQueries returns the rules of the queries the tenant isn't allowed to run.
+func (o *Overrides) BlockedQueries(userID string) []BlockedQuery {
+	return o.getOverridesForUser(userID).BlockedQueries
+}
+
 // MaxQueryLookback returns the max lookback period of queries.
 func (o *Overrides) MaxQueryLookback(userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).MaxQueryLookback)